@@ -12,6 +12,15 @@ import (
 
 func main() {
 	cfg := config.Load()
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := di.RunMigrate(cfg, os.Args[2:]); err != nil {
+			fmt.Println("migrate:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	aplicationContainer, err := di.NewContainer(cfg)
 	if err != nil {
 		fmt.Println("error while loading container: ", err)
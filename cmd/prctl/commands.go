@@ -0,0 +1,1134 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/generated"
+)
+
+type command func(a *app, args []string, stdin io.Reader, stderr io.Writer) error
+
+var commands = map[string]command{
+	"team":     teamCommand,
+	"user":     userCommand,
+	"pr":       prCommand,
+	"reviews":  reviewsCommand,
+	"sync":     syncCommand,
+	"import":   importCommand,
+	"export":   exportCommand,
+	"snapshot": snapshotCommand,
+	"away":     awayCommand,
+	"aliases":  aliasesCommand,
+	"skills":   skillsCommand,
+	"schedule": scheduleCommand,
+	"owners":   ownersCommand,
+	"rules":    rulesCommand,
+}
+
+const teamUsage = "team add -f FILE | team get TEAM_NAME | team limit TEAM_NAME N | " +
+	"team policy TEAM_NAME [-require-senior] [-senior-for-juniors]"
+
+func teamCommand(a *app, args []string, stdin io.Reader, stderr io.Writer) error {
+	if len(args) == 0 {
+		return usageError(stderr, teamUsage)
+	}
+
+	switch args[0] {
+	case "add":
+		flags := newFlagSet("team add", stderr)
+		file := flags.String("f", "", "JSON/YAML file with the team (\"-\" reads stdin)")
+		idempotencyKey := flags.String("idempotency-key", "", "Idempotency-Key header")
+		if _, err := parseArgs(flags, args[1:], 0); err != nil {
+			return err
+		}
+		if *file == "" {
+			return usageError(stderr, "team add -f FILE")
+		}
+
+		var team generated.Team
+		if err := readInput(*file, stdin, &team); err != nil {
+			return err
+		}
+
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.PostTeamAddWithResponse(ctx,
+			&generated.PostTeamAddParams{IdempotencyKey: optional(*idempotencyKey)}, team)
+		if err != nil {
+			return err
+		}
+		if resp.JSON201 == nil || resp.JSON201.Team == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.team(resp.JSON201.Team)
+
+	case "get":
+		if len(args) != 2 {
+			return usageError(stderr, "team get TEAM_NAME")
+		}
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.GetTeamGetWithResponse(ctx, &generated.GetTeamGetParams{TeamName: args[1]})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.team(resp.JSON200)
+
+	case "limit":
+		if len(args) != 3 {
+			return usageError(stderr, "team limit TEAM_NAME N")
+		}
+		limit, err := strconv.Atoi(args[2])
+		if err != nil {
+			return usageError(stderr, "team limit TEAM_NAME N")
+		}
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.PostTeamSetMaxOpenReviewsWithResponse(ctx,
+			generated.PostTeamSetMaxOpenReviewsJSONRequestBody{TeamName: args[1], MaxOpenReviews: limit})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil || resp.JSON200.Team == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.team(resp.JSON200.Team)
+
+	case "policy":
+		flags := newFlagSet("team policy", stderr)
+		requireSenior := flags.Bool("require-senior", false, "every PR needs a senior or lead reviewer")
+		seniorForJuniors := flags.Bool("senior-for-juniors", false, "PRs of juniors need a senior or lead reviewer")
+		positional, err := parseArgs(flags, args[1:], 1)
+		if err != nil {
+			return usageError(stderr, "team policy TEAM_NAME [-require-senior] [-senior-for-juniors]")
+		}
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.PostTeamSetReviewPolicyWithResponse(ctx, generated.PostTeamSetReviewPolicyJSONRequestBody{
+			TeamName:         positional[0],
+			RequireSenior:    requireSenior,
+			SeniorForJuniors: seniorForJuniors,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil || resp.JSON200.Team == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.team(resp.JSON200.Team)
+
+	default:
+		return usageError(stderr, teamUsage)
+	}
+}
+
+func syncCommand(a *app, args []string, stdin io.Reader, stderr io.Writer) error {
+	flags := newFlagSet("sync", stderr)
+	file := flags.String("f", "", "JSON/YAML file with all teams (\"-\" reads stdin)")
+	dryRun := flags.Bool("dry-run", false, "only print the plan")
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+	if *file == "" {
+		return usageError(stderr, "sync -f FILE [-dry-run]")
+	}
+
+	var roster generated.TeamSyncRequest
+	if err := readInput(*file, stdin, &roster); err != nil {
+		return err
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+	resp, err := a.client.PostTeamSyncWithResponse(ctx, &generated.PostTeamSyncParams{DryRun: dryRun}, roster)
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse, resp.Body)
+	}
+	return a.printer.syncPlan(resp.JSON200)
+}
+
+func importCommand(a *app, args []string, stdin io.Reader, stderr io.Writer) error {
+	if len(args) != 2 || args[0] != "users" {
+		return usageError(stderr, "import users FILE.csv")
+	}
+
+	data, err := readFile(args[1], stdin)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+	resp, err := a.client.PostImportUsersCsvWithBodyWithResponse(ctx, "text/csv", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if resp.JSON400 != nil {
+		return a.printer.importErrors(resp.JSON400)
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse, resp.Body)
+	}
+	return a.printer.syncPlan(&generated.TeamSyncResponse{Changes: resp.JSON200.Changes})
+}
+
+func exportCommand(a *app, args []string, _ io.Reader, stderr io.Writer) error {
+	if len(args) != 1 || (args[0] != "users" && args[0] != "prs") {
+		return usageError(stderr, "export users|prs")
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+
+	var (
+		httpResp *http.Response
+		body     []byte
+	)
+	if args[0] == "users" {
+		resp, err := a.client.GetExportUsersCsvWithResponse(ctx)
+		if err != nil {
+			return err
+		}
+		httpResp, body = resp.HTTPResponse, resp.Body
+	} else {
+		resp, err := a.client.GetExportPullRequestsCsvWithResponse(ctx)
+		if err != nil {
+			return err
+		}
+		httpResp, body = resp.HTTPResponse, resp.Body
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return apiError(httpResp, body)
+	}
+	// CSV печатается как есть, флаг -o к нему не применяется
+	_, err := a.printer.out.Write(body)
+	return err
+}
+
+func snapshotCommand(a *app, args []string, stdin io.Reader, stderr io.Writer) error {
+	const snapshotUsage = "snapshot export [-f FILE] | snapshot restore FILE"
+	if len(args) == 0 {
+		return usageError(stderr, snapshotUsage)
+	}
+
+	switch args[0] {
+	case "export":
+		flags := newFlagSet("snapshot export", stderr)
+		file := flags.String("f", "", "write the snapshot to FILE instead of stdout")
+		if _, err := parseArgs(flags, args[1:], 0); err != nil {
+			return err
+		}
+
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.GetAdminSnapshotWithResponse(ctx)
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		// Снапшот всегда выводится в JSON, флаг -o к нему не применяется
+		if *file == "" {
+			return (&printer{format: outputJSON, out: a.printer.out}).json(resp.JSON200)
+		}
+		data, err := json.MarshalIndent(resp.JSON200, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(*file, append(data, '\n'), 0o600)
+
+	case "restore":
+		if len(args) != 2 {
+			return usageError(stderr, "snapshot restore FILE")
+		}
+		var snap generated.Snapshot
+		if err := readInput(args[1], stdin, &snap); err != nil {
+			return err
+		}
+
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.PostAdminRestoreWithResponse(ctx, snap)
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.restored(resp.JSON200)
+
+	default:
+		return usageError(stderr, snapshotUsage)
+	}
+}
+
+func userCommand(a *app, args []string, _ io.Reader, stderr io.Writer) error {
+	if len(args) > 0 && args[0] == "limit" {
+		return userLimit(a, args[1:], stderr)
+	}
+	if len(args) > 0 && args[0] == "level" {
+		return userLevel(a, args[1:], stderr)
+	}
+	if len(args) > 0 && args[0] == "learner" {
+		return userLearner(a, args[1:], stderr)
+	}
+	if len(args) != 2 || (args[0] != "activate" && args[0] != "deactivate") {
+		return usageError(stderr, "user activate|deactivate USER_ID | user limit USER_ID N | user level USER_ID [LEVEL] | "+
+			"user learner USER_ID on|off")
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+	resp, err := a.client.PostUsersSetIsActiveWithResponse(ctx, generated.PostUsersSetIsActiveJSONRequestBody{
+		UserId:   args[1],
+		IsActive: args[0] == "activate",
+	})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil || resp.JSON200.User == nil {
+		return apiError(resp.HTTPResponse, resp.Body)
+	}
+	return a.printer.user(resp.JSON200.User)
+}
+
+func userLimit(a *app, args []string, stderr io.Writer) error {
+	if len(args) != 2 {
+		return usageError(stderr, "user limit USER_ID N")
+	}
+	limit, err := strconv.Atoi(args[1])
+	if err != nil {
+		return usageError(stderr, "user limit USER_ID N")
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+	resp, err := a.client.PostUsersSetMaxOpenReviewsWithResponse(ctx,
+		generated.PostUsersSetMaxOpenReviewsJSONRequestBody{UserId: args[0], MaxOpenReviews: limit})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil || resp.JSON200.User == nil {
+		return apiError(resp.HTTPResponse, resp.Body)
+	}
+	return a.printer.user(resp.JSON200.User)
+}
+
+// userLevel задаёт уровень пользователя, без LEVEL уровень снимается
+func userLevel(a *app, args []string, stderr io.Writer) error {
+	if len(args) != 1 && len(args) != 2 {
+		return usageError(stderr, "user level USER_ID [junior|middle|senior|lead]")
+	}
+	level := ""
+	if len(args) == 2 {
+		level = args[1]
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+	resp, err := a.client.PostUsersSetLevelWithResponse(ctx,
+		generated.PostUsersSetLevelJSONRequestBody{UserId: args[0], Level: level})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil || resp.JSON200.User == nil {
+		return apiError(resp.HTTPResponse, resp.Body)
+	}
+	return a.printer.user(resp.JSON200.User)
+}
+
+// userLearner отмечает пользователя учеником (on) или снимает отметку (off)
+func userLearner(a *app, args []string, stderr io.Writer) error {
+	if len(args) != 2 || (args[1] != "on" && args[1] != "off") {
+		return usageError(stderr, "user learner USER_ID on|off")
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+	resp, err := a.client.PostUsersSetLearnerWithResponse(ctx,
+		generated.PostUsersSetLearnerJSONRequestBody{UserId: args[0], IsLearner: args[1] == "on"})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil || resp.JSON200.User == nil {
+		return apiError(resp.HTTPResponse, resp.Body)
+	}
+	return a.printer.user(resp.JSON200.User)
+}
+
+func awayCommand(a *app, args []string, stdin io.Reader, stderr io.Writer) error {
+	const awayUsage = "away add USER_ID -from TIME -to TIME [-reason TEXT] [-reassign] | away list USER_ID | " +
+		"away delete ID | away import FILE.ics [-category NAME] [-reassign]"
+	if len(args) == 0 {
+		return usageError(stderr, awayUsage)
+	}
+
+	switch args[0] {
+	case "add":
+		flags := newFlagSet("away add", stderr)
+		from := flags.String("from", "", "period start: RFC 3339 time or YYYY-MM-DD")
+		to := flags.String("to", "", "period end, not included: RFC 3339 time or YYYY-MM-DD")
+		reason := flags.String("reason", "", "reason shown to other users")
+		reassign := flags.Bool("reassign", false, "reassign open reviews when the period starts")
+		positional, err := parseArgs(flags, args[1:], 1)
+		if err != nil {
+			return usageError(stderr, "away add USER_ID -from TIME -to TIME [-reason TEXT] [-reassign]")
+		}
+		startsAt, err := parseTime(*from)
+		if err != nil {
+			return fmt.Errorf("invalid -from: %w", err)
+		}
+		endsAt, err := parseTime(*to)
+		if err != nil {
+			return fmt.Errorf("invalid -to: %w", err)
+		}
+
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.PostUsersUnavailabilityWithResponse(ctx, generated.PostUsersUnavailabilityJSONRequestBody{
+			UserId:          positional[0],
+			StartsAt:        startsAt,
+			EndsAt:          endsAt,
+			Reason:          optional(*reason),
+			ReassignReviews: reassign,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.JSON201 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.unavailability([]generated.Unavailability{resp.JSON201.Period})
+
+	case "list":
+		if len(args) != 2 {
+			return usageError(stderr, "away list USER_ID")
+		}
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.GetUsersUnavailabilityWithResponse(ctx,
+			&generated.GetUsersUnavailabilityParams{UserId: args[1]})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.unavailability(resp.JSON200.Periods)
+
+	case "delete":
+		if len(args) != 2 {
+			return usageError(stderr, "away delete ID")
+		}
+		id, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid period ID %q", args[1])
+		}
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.DeleteUsersUnavailabilityWithResponse(ctx,
+			&generated.DeleteUsersUnavailabilityParams{Id: id})
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode() != http.StatusNoContent {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		_, err = fmt.Fprintf(a.printer.out, "Deleted period %d\n", id)
+		return err
+
+	case "import":
+		flags := newFlagSet("away import", stderr)
+		category := flags.String("category", "", "category of absence events (default: the service's ICS_CATEGORY)")
+		reassign := flags.Bool("reassign", false, "reassign open reviews when imported periods start")
+		positional, err := parseArgs(flags, args[1:], 1)
+		if err != nil {
+			return usageError(stderr, "away import FILE.ics [-category NAME] [-reassign]")
+		}
+		data, err := readFile(positional[0], stdin)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.PostUsersUnavailabilityImportWithBodyWithResponse(ctx,
+			&generated.PostUsersUnavailabilityImportParams{Category: optional(*category), ReassignReviews: reassign},
+			"text/calendar", bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.calendarImport(resp.JSON200)
+
+	default:
+		return usageError(stderr, awayUsage)
+	}
+}
+
+func aliasesCommand(a *app, args []string, _ io.Reader, stderr io.Writer) error {
+	const aliasesUsage = "aliases get USER_ID | aliases set USER_ID [ALIAS...]"
+	if len(args) < 2 {
+		return usageError(stderr, aliasesUsage)
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+
+	switch args[0] {
+	case "get":
+		if len(args) != 2 {
+			return usageError(stderr, "aliases get USER_ID")
+		}
+		resp, err := a.client.GetUsersAliasesWithResponse(ctx, &generated.GetUsersAliasesParams{UserId: args[1]})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.aliases(resp.JSON200)
+
+	case "set":
+		// Без псевдонимов после USER_ID все псевдонимы пользователя удаляются
+		resp, err := a.client.PutUsersAliasesWithResponse(ctx, generated.PutUsersAliasesJSONRequestBody{
+			UserId:  args[1],
+			Aliases: append([]string{}, args[2:]...),
+		})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.aliases(resp.JSON200)
+
+	default:
+		return usageError(stderr, aliasesUsage)
+	}
+}
+
+func skillsCommand(a *app, args []string, _ io.Reader, stderr io.Writer) error {
+	const skillsUsage = "skills get USER_ID | skills set USER_ID [TAG:LEVEL...]"
+	if len(args) < 2 {
+		return usageError(stderr, skillsUsage)
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+
+	switch args[0] {
+	case "get":
+		if len(args) != 2 {
+			return usageError(stderr, "skills get USER_ID")
+		}
+		resp, err := a.client.GetUsersSkillsWithResponse(ctx, &generated.GetUsersSkillsParams{UserId: args[1]})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.skills(resp.JSON200)
+
+	case "set":
+		// Без навыков после USER_ID все навыки пользователя удаляются
+		skills := []generated.UserSkill{}
+		for _, arg := range args[2:] {
+			tag, level, ok := strings.Cut(arg, ":")
+			levelNumber, err := strconv.Atoi(level)
+			if !ok || err != nil {
+				return fmt.Errorf("invalid skill %q, expected TAG:LEVEL", arg)
+			}
+			skills = append(skills, generated.UserSkill{Tag: tag, Level: levelNumber})
+		}
+		resp, err := a.client.PutUsersSkillsWithResponse(ctx, generated.PutUsersSkillsJSONRequestBody{
+			UserId: args[1],
+			Skills: skills,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.skills(resp.JSON200)
+
+	default:
+		return usageError(stderr, skillsUsage)
+	}
+}
+
+func prCommand(a *app, args []string, stdin io.Reader, stderr io.Writer) error {
+	if len(args) == 0 {
+		return usageError(stderr, "pr create|preview|merge|reassign ...")
+	}
+
+	switch args[0] {
+	case "create":
+		return prCreate(a, args[1:], stdin, stderr)
+	case "preview":
+		return prPreview(a, args[1:], stdin, stderr)
+	case "merge":
+		return prMerge(a, args[1:], stderr)
+	case "reassign":
+		return prReassign(a, args[1:], stderr)
+	default:
+		return usageError(stderr, "pr create|preview|merge|reassign ...")
+	}
+}
+
+func prCreate(a *app, args []string, stdin io.Reader, stderr io.Writer) error {
+	flags := newFlagSet("pr create", stderr)
+	id := flags.String("id", "", "pull request ID")
+	name := flags.String("name", "", "pull request name")
+	author := flags.String("author", "", "author user ID")
+	metadata := newMetadataFlags(flags)
+	file := flags.String("f", "", "JSON/YAML file with the pull request (\"-\" reads stdin)")
+	idempotencyKey := flags.String("idempotency-key", "", "Idempotency-Key header")
+	explain := flags.Bool("explain", false, "print why the reviewers were chosen")
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	var body generated.PostPullRequestCreateJSONRequestBody
+	if *file != "" {
+		if err := readInput(*file, stdin, &body); err != nil {
+			return err
+		}
+	}
+	// Флаги дополняют и переопределяют поля из файла
+	if *id != "" {
+		body.PullRequestId = *id
+	}
+	if *name != "" {
+		body.PullRequestName = *name
+	}
+	if *author != "" {
+		body.AuthorId = *author
+	}
+	metadata.apply(flags, &body.Metadata)
+	if body.PullRequestId == "" || body.PullRequestName == "" || body.AuthorId == "" {
+		return usageError(stderr, "pr create -id ID -name NAME -author USER_ID | -f FILE")
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+	resp, err := a.client.PostPullRequestCreateWithResponse(ctx,
+		&generated.PostPullRequestCreateParams{IdempotencyKey: optional(*idempotencyKey)}, body)
+	if err != nil {
+		return err
+	}
+	if resp.JSON201 == nil || resp.JSON201.Pr == nil {
+		return apiError(resp.HTTPResponse, resp.Body)
+	}
+	// Предупреждения и владельцы кода - в stderr, чтобы не менять вывод для скриптов
+	if assignment := resp.JSON201.Assignment; assignment != nil {
+		printAssignment(stderr, assignment)
+		if *explain && assignment.Candidates != nil {
+			if err := printCandidates(stderr, *assignment.Candidates); err != nil {
+				return err
+			}
+		}
+	}
+	return a.printer.pullRequest(resp.JSON201.Pr, "")
+}
+
+// metadataFlags - флаги метаданных PR, общие для pr create и pr preview
+type metadataFlags struct {
+	additions    *int
+	deletions    *int
+	changedFiles *int
+	repository   *string
+	baseBranch   *string
+	labels       *string
+	paths        *string
+	skills       *string
+}
+
+func newMetadataFlags(flags *flag.FlagSet) *metadataFlags {
+	return &metadataFlags{
+		additions:    flags.Int("additions", 0, "lines added"),
+		deletions:    flags.Int("deletions", 0, "lines deleted"),
+		changedFiles: flags.Int("files", 0, "files changed"),
+		repository:   flags.String("repo", "", "repository"),
+		baseBranch:   flags.String("base", "", "base branch"),
+		labels:       flags.String("labels", "", "comma-separated labels"),
+		paths:        flags.String("paths", "", "comma-separated changed file paths, used to assign code owners"),
+		skills:       flags.String("skills", "", "comma-separated skills needed for the review"),
+	}
+}
+
+// apply переносит в metadata только явно заданные флаги, чтобы не затирать файл нулями
+func (m *metadataFlags) apply(flags *flag.FlagSet, metadata **generated.PRMetadata) {
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "additions", "deletions", "files", "repo", "base", "labels", "paths", "skills":
+			if *metadata == nil {
+				*metadata = &generated.PRMetadata{}
+			}
+		default:
+			return
+		}
+		switch f.Name {
+		case "additions":
+			(*metadata).Additions = m.additions
+		case "deletions":
+			(*metadata).Deletions = m.deletions
+		case "files":
+			(*metadata).ChangedFiles = m.changedFiles
+		case "repo":
+			(*metadata).Repository = m.repository
+		case "base":
+			(*metadata).BaseBranch = m.baseBranch
+		case "labels":
+			list := strings.Split(*m.labels, ",")
+			(*metadata).Labels = &list
+		case "paths":
+			list := strings.Split(*m.paths, ",")
+			(*metadata).Paths = &list
+		case "skills":
+			list := strings.Split(*m.skills, ",")
+			(*metadata).RequiredSkills = &list
+		}
+	})
+}
+
+func prPreview(a *app, args []string, stdin io.Reader, stderr io.Writer) error {
+	flags := newFlagSet("pr preview", stderr)
+	name := flags.String("name", "", "pull request name")
+	author := flags.String("author", "", "author user ID")
+	metadata := newMetadataFlags(flags)
+	file := flags.String("f", "", "JSON/YAML file with the pull request (\"-\" reads stdin)")
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	var body generated.PostPullRequestPreviewJSONRequestBody
+	if *file != "" {
+		if err := readInput(*file, stdin, &body); err != nil {
+			return err
+		}
+	}
+	if *name != "" {
+		body.PullRequestName = name
+	}
+	if *author != "" {
+		body.AuthorId = *author
+	}
+	metadata.apply(flags, &body.Metadata)
+	if body.AuthorId == "" {
+		return usageError(stderr, "pr preview -author USER_ID [metadata flags] | -f FILE")
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+	resp, err := a.client.PostPullRequestPreviewWithResponse(ctx, body)
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse, resp.Body)
+	}
+	printAssignment(stderr, &resp.JSON200.Assignment)
+	return a.printer.reviewPreview(resp.JSON200)
+}
+
+func printAssignment(stderr io.Writer, assignment *generated.ReviewAssignment) {
+	if assignment.CodeOwners != nil {
+		for _, owner := range *assignment.CodeOwners {
+			fmt.Fprintf(stderr, "code owner: %s (line %d: %s)\n", owner.ReviewerId, owner.Rule.Line, owner.Rule.Pattern)
+		}
+	}
+	if assignment.UncoveredRules != nil {
+		for _, rule := range *assignment.UncoveredRules {
+			fmt.Fprintf(stderr, "warning: no available owner for line %d: %s\n", rule.Line, rule.Pattern)
+		}
+	}
+	if assignment.SeniorMissing != nil && *assignment.SeniorMissing {
+		fmt.Fprintln(stderr, "warning: team policy requires a senior reviewer, none available")
+	}
+	if assignment.UnfilledSlots > 0 {
+		fmt.Fprintf(stderr, "warning: %d reviewer slot(s) left unfilled", assignment.UnfilledSlots)
+		if assignment.AtCapacity != nil {
+			fmt.Fprintf(stderr, ", at capacity: %s", strings.Join(*assignment.AtCapacity, ","))
+		}
+		fmt.Fprintln(stderr)
+	}
+}
+
+func prMerge(a *app, args []string, stderr io.Writer) error {
+	flags := newFlagSet("pr merge", stderr)
+	ifMatch := flags.String("if-match", "", "expected PR version (If-Match header)")
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return usageError(stderr, "pr merge PR_ID [-if-match VERSION]")
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+	resp, err := a.client.PostPullRequestMergeWithResponse(ctx,
+		&generated.PostPullRequestMergeParams{IfMatch: etag(*ifMatch)},
+		generated.PostPullRequestMergeJSONRequestBody{PullRequestId: positional[0]})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil || resp.JSON200.Pr == nil {
+		return apiError(resp.HTTPResponse, resp.Body)
+	}
+	return a.printer.pullRequest(resp.JSON200.Pr, "")
+}
+
+func prReassign(a *app, args []string, stderr io.Writer) error {
+	flags := newFlagSet("pr reassign", stderr)
+	ifMatch := flags.String("if-match", "", "expected PR version (If-Match header)")
+	idempotencyKey := flags.String("idempotency-key", "", "Idempotency-Key header")
+	explain := flags.Bool("explain", false, "print why the new reviewer was chosen")
+	positional, err := parseArgs(flags, args, 2)
+	if err != nil {
+		return usageError(stderr, "pr reassign PR_ID OLD_USER_ID [-if-match VERSION] [-explain]")
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+	resp, err := a.client.PostPullRequestReassignWithResponse(ctx,
+		&generated.PostPullRequestReassignParams{IfMatch: etag(*ifMatch), IdempotencyKey: optional(*idempotencyKey)},
+		generated.PostPullRequestReassignJSONRequestBody{PullRequestId: positional[0], OldUserId: positional[1]})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse, resp.Body)
+	}
+	if *explain && resp.JSON200.Candidates != nil {
+		if err := printCandidates(stderr, *resp.JSON200.Candidates); err != nil {
+			return err
+		}
+	}
+	return a.printer.pullRequest(&resp.JSON200.Pr, resp.JSON200.ReplacedBy)
+}
+
+func reviewsCommand(a *app, args []string, _ io.Reader, stderr io.Writer) error {
+	if len(args) != 1 {
+		return usageError(stderr, "reviews USER_ID")
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+	resp, err := a.client.GetUsersGetReviewWithResponse(ctx, &generated.GetUsersGetReviewParams{UserId: args[0]})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse, resp.Body)
+	}
+	return a.printer.reviews(resp.JSON200.UserId, resp.JSON200.PullRequests, resp.JSON200.ShadowPullRequests)
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	return flags
+}
+
+// parseArgs разбирает флаги, стоящие как до, так и после позиционных аргументов
+// (пакет flag останавливается на первом позиционном), и проверяет их количество
+func parseArgs(flags *flag.FlagSet, args []string, positionalCount int) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if len(positional) != positionalCount {
+		return nil, errUsage
+	}
+	return positional, nil
+}
+
+func usageError(stderr io.Writer, usage string) error {
+	fmt.Fprintln(stderr, "usage: prctl", usage)
+	return errUsage
+}
+
+// apiError превращает неуспешный ответ сервиса в ошибку с кодом из ErrorResponse
+func apiError(resp *http.Response, body []byte) error {
+	var errResp generated.ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error.Code != "" {
+		return fmt.Errorf("%s: %s (HTTP %d)", errResp.Error.Code, errResp.Error.Message, resp.StatusCode)
+	}
+	// Ошибки валидации запроса приходят строкой: {"error": "..."}
+	var plain struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &plain); err == nil && plain.Error != "" {
+		return fmt.Errorf("%s (HTTP %d)", plain.Error, resp.StatusCode)
+	}
+	return fmt.Errorf("unexpected response: %s", resp.Status)
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// parseTime принимает время в RFC 3339 или дату, которая означает полночь по UTC
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// etag принимает версию как числом, так и в виде ETag в кавычках
+func etag(version string) *string {
+	if version == "" {
+		return nil
+	}
+	if _, err := strconv.Atoi(version); err == nil {
+		version = strconv.Quote(version)
+	}
+	return &version
+}
+
+func scheduleCommand(a *app, args []string, _ io.Reader, stderr io.Writer) error {
+	const scheduleUsage = "schedule set USER_ID -tz ZONE -hours HH:MM-HH:MM [-days MON,TUE,...] | " +
+		"schedule get USER_ID | schedule delete USER_ID"
+	if len(args) < 2 {
+		return usageError(stderr, scheduleUsage)
+	}
+
+	switch args[0] {
+	case "set":
+		flags := newFlagSet("schedule set", stderr)
+		timeZone := flags.String("tz", "", "IANA time zone, e.g. Europe/Berlin")
+		hours := flags.String("hours", "", "working hours in local time, e.g. 09:00-18:00")
+		days := flags.String("days", "MON,TUE,WED,THU,FRI", "comma-separated days when the working day starts")
+		positional, err := parseArgs(flags, args[1:], 1)
+		if err != nil {
+			return usageError(stderr, "schedule set USER_ID -tz ZONE -hours HH:MM-HH:MM [-days MON,TUE,...]")
+		}
+		workStart, workEnd, ok := strings.Cut(*hours, "-")
+		if !ok {
+			return fmt.Errorf("invalid -hours %q, expected HH:MM-HH:MM", *hours)
+		}
+		var workDays []generated.WorkScheduleWorkDays
+		for _, day := range strings.Split(*days, ",") {
+			workDays = append(workDays, generated.WorkScheduleWorkDays(strings.ToUpper(strings.TrimSpace(day))))
+		}
+
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.PutUsersScheduleWithResponse(ctx, generated.PutUsersScheduleJSONRequestBody{
+			UserId:    positional[0],
+			TimeZone:  *timeZone,
+			WorkStart: workStart,
+			WorkEnd:   workEnd,
+			WorkDays:  &workDays,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.workSchedule(resp.JSON200)
+
+	case "get":
+		if len(args) != 2 {
+			return usageError(stderr, "schedule get USER_ID")
+		}
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.GetUsersScheduleWithResponse(ctx, &generated.GetUsersScheduleParams{UserId: args[1]})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.workSchedule(resp.JSON200)
+
+	case "delete":
+		if len(args) != 2 {
+			return usageError(stderr, "schedule delete USER_ID")
+		}
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.DeleteUsersScheduleWithResponse(ctx, &generated.DeleteUsersScheduleParams{UserId: args[1]})
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode() != http.StatusNoContent {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		_, err = fmt.Fprintf(a.printer.out, "Deleted work schedule of %s\n", args[1])
+		return err
+
+	default:
+		return usageError(stderr, scheduleUsage)
+	}
+}
+
+func ownersCommand(a *app, args []string, stdin io.Reader, stderr io.Writer) error {
+	const ownersUsage = "owners set REPO FILE | owners get REPO | owners delete REPO"
+	if len(args) < 2 {
+		return usageError(stderr, ownersUsage)
+	}
+
+	switch args[0] {
+	case "set":
+		if len(args) != 3 {
+			return usageError(stderr, "owners set REPO FILE")
+		}
+		data, err := readFile(args[2], stdin)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.PutCodeOwnersWithBodyWithResponse(ctx,
+			&generated.PutCodeOwnersParams{Repository: args[1]}, "text/plain", bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.codeOwners(resp.JSON200)
+
+	case "get":
+		if len(args) != 2 {
+			return usageError(stderr, "owners get REPO")
+		}
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.GetCodeOwnersWithResponse(ctx, &generated.GetCodeOwnersParams{Repository: args[1]})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.codeOwners(resp.JSON200)
+
+	case "delete":
+		if len(args) != 2 {
+			return usageError(stderr, "owners delete REPO")
+		}
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.DeleteCodeOwnersWithResponse(ctx, &generated.DeleteCodeOwnersParams{Repository: args[1]})
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode() != http.StatusNoContent {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		_, err = fmt.Fprintf(a.printer.out, "Deleted code owners of %s\n", args[1])
+		return err
+
+	default:
+		return usageError(stderr, ownersUsage)
+	}
+}
+
+func rulesCommand(a *app, args []string, _ io.Reader, stderr io.Writer) error {
+	const rulesUsage = "rules list [AUTHOR_ID] | rules exclude|prefer AUTHOR_ID REVIEWER_ID [-reason TEXT] | " +
+		"rules delete AUTHOR_ID REVIEWER_ID"
+	if len(args) == 0 {
+		return usageError(stderr, rulesUsage)
+	}
+
+	switch args[0] {
+	case "list":
+		if len(args) > 2 {
+			return usageError(stderr, "rules list [AUTHOR_ID]")
+		}
+		params := &generated.GetReviewerRulesParams{}
+		if len(args) == 2 {
+			params.AuthorId = &args[1]
+		}
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.GetReviewerRulesWithResponse(ctx, params)
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.reviewerRules(resp.JSON200.Rules)
+
+	case "exclude", "prefer":
+		flags := newFlagSet("rules "+args[0], stderr)
+		reason := flags.String("reason", "", "why the rule exists")
+		positional, err := parseArgs(flags, args[1:], 2)
+		if err != nil {
+			return usageError(stderr, "rules "+args[0]+" AUTHOR_ID REVIEWER_ID [-reason TEXT]")
+		}
+
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.PutReviewerRulesWithResponse(ctx, generated.PutReviewerRulesJSONRequestBody{
+			AuthorId:   positional[0],
+			ReviewerId: positional[1],
+			Kind:       generated.ReviewerRuleKind(args[0]),
+			Reason:     optional(*reason),
+		})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		return a.printer.reviewerRules([]generated.ReviewerRule{*resp.JSON200})
+
+	case "delete":
+		if len(args) != 3 {
+			return usageError(stderr, "rules delete AUTHOR_ID REVIEWER_ID")
+		}
+		ctx, cancel := a.context()
+		defer cancel()
+		resp, err := a.client.DeleteReviewerRulesWithResponse(ctx,
+			&generated.DeleteReviewerRulesParams{AuthorId: args[1], ReviewerId: args[2]})
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode() != http.StatusNoContent {
+			return apiError(resp.HTTPResponse, resp.Body)
+		}
+		_, err = fmt.Fprintf(a.printer.out, "Deleted rule %s -> %s\n", args[1], args[2])
+		return err
+
+	default:
+		return usageError(stderr, rulesUsage)
+	}
+}
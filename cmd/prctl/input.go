@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readInput читает тело запроса из JSON или YAML файла ("-" - stdin) в target.
+// Формат определяется по расширению; stdin и файлы без расширения читаются как YAML,
+// который включает в себя JSON
+func readInput(path string, stdin io.Reader, target any) error {
+	data, err := readFile(path, stdin)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return decodeJSON(data, target, path)
+	}
+
+	// YAML декодируется в map и перекладывается в JSON, чтобы использовать
+	// json-теги сгенерированных типов вместо отдельных yaml-тегов
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	return decodeJSON(data, target, path)
+}
+
+// readFile читает файл как есть, "-" - stdin
+func readFile(path string, stdin io.Reader) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read input: %w", err)
+	}
+	return data, nil
+}
+
+func decodeJSON(data []byte, target any, path string) error {
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	return nil
+}
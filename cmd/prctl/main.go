@@ -0,0 +1,153 @@
+// prctl - консольный клиент сервиса назначения ревьюверов.
+// Все запросы выполняются через сгенерированный клиент internal/generated
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/generated"
+)
+
+const (
+	defaultServer = "http://localhost:8080"
+	serverEnv     = "PRCTL_SERVER"
+)
+
+const usage = `Usage: prctl [flags] <command> [args]
+
+Commands:
+  team add -f FILE                       create a team from a JSON/YAML file ("-" reads stdin)
+  team get TEAM_NAME                     show a team with its members
+  team limit TEAM_NAME N                 default max open reviews of members (0 - no limit)
+  team policy TEAM_NAME [-require-senior] [-senior-for-juniors]
+                                         require a senior or lead reviewer on every PR or on PRs
+                                         of juniors (no flags - no requirement)
+  user activate USER_ID                  mark a user as active
+  user deactivate USER_ID                mark a user as inactive
+  user limit USER_ID N                   max open reviews of a user (0 - team default)
+  user level USER_ID [LEVEL]             junior, middle, senior or lead (none clears it)
+  user learner USER_ID on|off            mark a user as a learner who shadows reviews
+  pr create -id ID -name NAME -author USER_ID | -f FILE
+            [-additions N] [-deletions N] [-files N] [-repo REPO] [-base BRANCH] [-labels L1,L2]
+            [-paths P1,P2] [-skills S1,S2] [-explain]
+                                         create a PR and assign reviewers, code owners of the paths first,
+                                         reviewers with the skills more often; -explain prints why
+  pr preview -author USER_ID [-name NAME] | -f FILE
+            [-additions N] [-deletions N] [-files N] [-repo REPO] [-base BRANCH] [-labels L1,L2]
+            [-paths P1,P2] [-skills S1,S2]
+                                         show the reviewers a PR would get, with alternatives and why,
+                                         without creating it
+  pr merge PR_ID [-if-match VERSION]     merge a PR
+  pr reassign PR_ID OLD_USER_ID [-if-match VERSION] [-explain]
+                                         replace a reviewer; -explain prints why the new one was chosen
+  reviews USER_ID                        list PRs assigned to a user for review or shadowing
+  sync -f FILE [-dry-run]                bring the listed teams to the state described in FILE
+  import users FILE.csv                  create or update users from CSV, all rows or none
+  export users|prs                       print users or pull requests as CSV
+  snapshot export [-f FILE]              save the whole state as a JSON snapshot
+  snapshot restore FILE                  load a snapshot into an empty service
+  away add USER_ID -from TIME -to TIME [-reason TEXT] [-reassign]
+                                         add a vacation/out-of-office period
+  away list USER_ID                      list unavailability periods of a user
+  away delete ID                         delete an unavailability period
+  away import FILE.ics [-category NAME] [-reassign]
+                                         import absences from an iCalendar file
+  aliases get USER_ID                    show emails and names used to match calendar events
+  aliases set USER_ID [ALIAS...]         replace aliases of a user (none clears them)
+  skills get USER_ID                     show skill tags and levels of a user
+  skills set USER_ID [TAG:LEVEL...]      replace skills of a user, level 1-3 (none clears them)
+  schedule set USER_ID -tz ZONE -hours HH:MM-HH:MM [-days MON,TUE,...]
+                                         set working hours of a user
+  schedule get USER_ID                   show working hours of a user
+  schedule delete USER_ID                remove working hours of a user
+  owners set REPO FILE                   upload a CODEOWNERS file for a repository ("-" reads stdin)
+  owners get REPO                        show code owners rules of a repository
+  owners delete REPO                     remove code owners rules of a repository
+  rules list [AUTHOR_ID]                 list author-reviewer rules, of one author or all
+  rules exclude AUTHOR_ID REVIEWER_ID [-reason TEXT]
+                                         never assign the reviewer to PRs of the author
+  rules prefer AUTHOR_ID REVIEWER_ID [-reason TEXT]
+                                         pick the reviewer for PRs of the author more often
+  rules delete AUTHOR_ID REVIEWER_ID     remove the rule of the pair
+
+Flags:
+`
+
+// errUsage - команда вызвана с неверными аргументами, справка уже напечатана
+var errUsage = errors.New("invalid usage")
+
+// app - общие для всех команд настройки
+type app struct {
+	client  *generated.ClientWithResponses
+	printer *printer
+	timeout time.Duration
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "prctl:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("prctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	server := flags.String("server", envOrDefault(serverEnv, defaultServer), "service URL (env "+serverEnv+")")
+	output := flags.String("o", outputTable, "output format: table or json")
+	timeout := flags.Duration("timeout", 10*time.Second, "request timeout")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+
+	p, err := newPrinter(*output, stdout)
+	if err != nil {
+		return err
+	}
+	client, err := generated.NewClientWithResponses(*server)
+	if err != nil {
+		return fmt.Errorf("create client: %w", err)
+	}
+	a := &app{client: client, printer: p, timeout: *timeout}
+
+	rest := flags.Args()
+	if len(rest) == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	cmd, ok := commands[rest[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", rest[0])
+		flags.Usage()
+		return errUsage
+	}
+	return cmd(a, rest[1:], stdin, stderr)
+}
+
+func (a *app) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), a.timeout)
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return defaultValue
+}
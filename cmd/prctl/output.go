@@ -0,0 +1,359 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/generated"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// printer печатает ответы сервиса таблицей или в JSON
+type printer struct {
+	format string
+	out    io.Writer
+}
+
+func newPrinter(format string, out io.Writer) (*printer, error) {
+	if format != outputTable && format != outputJSON {
+		return nil, fmt.Errorf("unknown output format %q, expected %s or %s", format, outputTable, outputJSON)
+	}
+	return &printer{format: format, out: out}, nil
+}
+
+func (p *printer) team(team *generated.Team) error {
+	if p.format == outputJSON {
+		return p.json(team)
+	}
+	fmt.Fprintf(p.out, "Team: %s\n", team.TeamName)
+	if team.MaxOpenReviews != nil {
+		fmt.Fprintf(p.out, "Max open reviews: %d\n", *team.MaxOpenReviews)
+	}
+	if policy := team.ReviewPolicy; policy != nil {
+		if policy.RequireSenior != nil && *policy.RequireSenior {
+			fmt.Fprintln(p.out, "Review policy: senior reviewer on every PR")
+		} else if policy.SeniorForJuniors != nil && *policy.SeniorForJuniors {
+			fmt.Fprintln(p.out, "Review policy: senior reviewer on PRs of juniors")
+		}
+	}
+	fmt.Fprintln(p.out)
+	return p.table([]string{"USER_ID", "USERNAME", "ACTIVE", "MAX_OPEN", "LEVEL", "LEARNER"}, func(row func(...string)) {
+		for _, m := range team.Members {
+			row(m.UserId, m.Username, strconv.FormatBool(m.IsActive), limitString(m.MaxOpenReviews), levelString(m.Level),
+				learnerString(m.IsLearner))
+		}
+	})
+}
+
+func (p *printer) user(user *generated.User) error {
+	if p.format == outputJSON {
+		return p.json(user)
+	}
+	return p.table([]string{"USER_ID", "USERNAME", "TEAM", "ACTIVE", "MAX_OPEN", "LEVEL", "LEARNER"}, func(row func(...string)) {
+		row(user.UserId, user.Username, user.TeamName, strconv.FormatBool(user.IsActive), limitString(user.MaxOpenReviews),
+			levelString(user.Level), learnerString(user.IsLearner))
+	})
+}
+
+// limitString - предел открытых ревью для таблицы, "-" если он не задан
+func limitString(limit *int) string {
+	if limit == nil {
+		return "-"
+	}
+	return strconv.Itoa(*limit)
+}
+
+func levelString(level *generated.UserLevel) string {
+	if level == nil {
+		return "-"
+	}
+	return string(*level)
+}
+
+func learnerString(isLearner *bool) string {
+	return strconv.FormatBool(isLearner != nil && *isLearner)
+}
+
+// pullRequest печатает PR; replacedBy заполняется только для reassign
+func (p *printer) pullRequest(pr *generated.PullRequest, replacedBy string) error {
+	if p.format == outputJSON {
+		if replacedBy != "" {
+			return p.json(map[string]any{"pr": pr, "replaced_by": replacedBy})
+		}
+		return p.json(pr)
+	}
+
+	version := "-"
+	if pr.Version != nil {
+		version = strconv.Itoa(*pr.Version)
+	}
+	reviewers := strings.Join(pr.AssignedReviewers, ",")
+	if reviewers == "" {
+		reviewers = "-"
+	}
+	err := p.table([]string{"PR_ID", "NAME", "AUTHOR", "STATUS", "REVIEWERS", "SHADOW", "VERSION"}, func(row func(...string)) {
+		row(pr.PullRequestId, pr.PullRequestName, pr.AuthorId, string(pr.Status), reviewers, orDash(pr.ShadowReviewer), version)
+	})
+	if err == nil && replacedBy != "" {
+		_, err = fmt.Fprintf(p.out, "\nReplaced by: %s\n", replacedBy)
+	}
+	return err
+}
+
+func (p *printer) reviews(userID string, prs, shadowPRs []generated.PullRequestShort) error {
+	if p.format == outputJSON {
+		return p.json(map[string]any{"user_id": userID, "pull_requests": prs, "shadow_pull_requests": shadowPRs})
+	}
+	fmt.Fprintf(p.out, "Reviews for %s: %d\n\n", userID, len(prs))
+	err := p.prShortTable(prs)
+	if err != nil || len(shadowPRs) == 0 {
+		return err
+	}
+	fmt.Fprintf(p.out, "\nShadow reviews for %s: %d\n\n", userID, len(shadowPRs))
+	return p.prShortTable(shadowPRs)
+}
+
+func (p *printer) prShortTable(prs []generated.PullRequestShort) error {
+	return p.table([]string{"PR_ID", "NAME", "AUTHOR", "STATUS"}, func(row func(...string)) {
+		for _, pr := range prs {
+			row(pr.PullRequestId, pr.PullRequestName, pr.AuthorId, string(pr.Status))
+		}
+	})
+}
+
+func (p *printer) syncPlan(plan *generated.TeamSyncResponse) error {
+	if p.format == outputJSON {
+		return p.json(plan)
+	}
+	if len(plan.Changes) == 0 {
+		_, err := fmt.Fprintln(p.out, "Teams are up to date")
+		return err
+	}
+	if plan.DryRun {
+		fmt.Fprintf(p.out, "Plan (dry run, nothing applied): %d change(s)\n\n", len(plan.Changes))
+	} else {
+		fmt.Fprintf(p.out, "Applied %d change(s)\n\n", len(plan.Changes))
+	}
+	return p.table([]string{"ACTION", "TEAM", "USER_ID", "USERNAME", "FROM_TEAM"}, func(row func(...string)) {
+		for _, change := range plan.Changes {
+			row(string(change.Action), change.TeamName, orDash(change.UserId), orDash(change.Username), orDash(change.FromTeam))
+		}
+	})
+}
+
+// importErrors печатает отказ импорта вместе с ошибками строк и возвращает ошибку
+func (p *printer) importErrors(resp *generated.ImportErrorResponse) error {
+	if p.format == outputJSON {
+		if err := p.json(resp); err != nil {
+			return err
+		}
+	} else if len(resp.Rows) > 0 {
+		err := p.table([]string{"LINE", "ERROR"}, func(row func(...string)) {
+			for _, rowErr := range resp.Rows {
+				row(strconv.Itoa(rowErr.Line), rowErr.Message)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("%s: %s, nothing imported", resp.Error.Code, resp.Error.Message)
+}
+
+func (p *printer) restored(resp *generated.SnapshotRestoreResponse) error {
+	if p.format == outputJSON {
+		return p.json(resp)
+	}
+	_, err := fmt.Fprintf(p.out, "Restored %d team(s), %d user(s), %d pull request(s)\n",
+		resp.Teams, resp.Users, resp.PullRequests)
+	return err
+}
+
+func (p *printer) unavailability(periods []generated.Unavailability) error {
+	if p.format == outputJSON {
+		return p.json(periods)
+	}
+	return p.table([]string{"ID", "USER_ID", "FROM", "TO", "REASSIGN", "REASSIGNED_AT", "REASON"}, func(row func(...string)) {
+		for _, period := range periods {
+			reassignedAt := "-"
+			if period.ReassignedAt != nil {
+				reassignedAt = period.ReassignedAt.Format(time.RFC3339)
+			}
+			reason := period.Reason
+			if reason == "" {
+				reason = "-"
+			}
+			row(strconv.FormatInt(period.Id, 10), period.UserId,
+				period.StartsAt.Format(time.RFC3339), period.EndsAt.Format(time.RFC3339),
+				strconv.FormatBool(period.ReassignReviews), reassignedAt, reason)
+		}
+	})
+}
+
+func (p *printer) calendarImport(resp *generated.CalendarImportResponse) error {
+	if p.format == outputJSON {
+		return p.json(resp)
+	}
+	fmt.Fprintf(p.out, "Created %d, updated %d, unchanged %d, deleted %d period(s), skipped %d event(s)\n",
+		resp.Created, resp.Updated, resp.Unchanged, resp.Deleted, len(resp.Skipped))
+	if len(resp.Skipped) == 0 {
+		return nil
+	}
+	fmt.Fprintln(p.out)
+	return p.table([]string{"UID", "SUMMARY", "REASON"}, func(row func(...string)) {
+		for _, skip := range resp.Skipped {
+			row(orDash(&skip.Uid), orDash(&skip.Summary), skip.Reason)
+		}
+	})
+}
+
+func (p *printer) aliases(resp *generated.UserAliases) error {
+	if p.format == outputJSON {
+		return p.json(resp)
+	}
+	aliases := strings.Join(resp.Aliases, ", ")
+	if aliases == "" {
+		aliases = "-"
+	}
+	_, err := fmt.Fprintf(p.out, "Aliases of %s: %s\n", resp.UserId, aliases)
+	return err
+}
+
+func (p *printer) skills(resp *generated.UserSkills) error {
+	if p.format == outputJSON {
+		return p.json(resp)
+	}
+	skills := make([]string, len(resp.Skills))
+	for i, skill := range resp.Skills {
+		skills[i] = fmt.Sprintf("%s:%d", skill.Tag, skill.Level)
+	}
+	list := strings.Join(skills, ", ")
+	if list == "" {
+		list = "-"
+	}
+	_, err := fmt.Fprintf(p.out, "Skills of %s: %s\n", resp.UserId, list)
+	return err
+}
+
+func (p *printer) workSchedule(schedule *generated.WorkSchedule) error {
+	if p.format == outputJSON {
+		return p.json(schedule)
+	}
+	days := "-"
+	if schedule.WorkDays != nil {
+		names := make([]string, len(*schedule.WorkDays))
+		for i, day := range *schedule.WorkDays {
+			names[i] = string(day)
+		}
+		days = strings.Join(names, ",")
+	}
+	return p.table([]string{"USER_ID", "TIME_ZONE", "HOURS", "DAYS"}, func(row func(...string)) {
+		row(schedule.UserId, schedule.TimeZone, schedule.WorkStart+"-"+schedule.WorkEnd, days)
+	})
+}
+
+func (p *printer) codeOwners(owners *generated.CodeOwners) error {
+	if p.format == outputJSON {
+		return p.json(owners)
+	}
+	return p.table([]string{"LINE", "PATTERN", "USERS", "TEAMS"}, func(row func(...string)) {
+		for _, rule := range owners.Rules {
+			row(strconv.Itoa(rule.Line), rule.Pattern, joinOrDash(rule.Users), joinOrDash(rule.Teams))
+		}
+	})
+}
+
+func (p *printer) reviewerRules(rules []generated.ReviewerRule) error {
+	if p.format == outputJSON {
+		return p.json(rules)
+	}
+	return p.table([]string{"AUTHOR", "REVIEWER", "KIND", "REASON"}, func(row func(...string)) {
+		for _, rule := range rules {
+			row(rule.AuthorId, rule.ReviewerId, string(rule.Kind), orDash(rule.Reason))
+		}
+	})
+}
+
+// printCandidates печатает таблицей объяснение выбора ревьюверов: оценки кандидатов
+// и причины, по которым остальных нельзя было выбрать
+func (p *printer) reviewPreview(preview *generated.ReviewPreview) error {
+	if p.format == outputJSON {
+		return p.json(preview)
+	}
+	err := p.table([]string{"REVIEWERS", "SHADOW", "ALTERNATIVES"}, func(row func(...string)) {
+		row(joinOrDash(&preview.Reviewers), orDash(preview.ShadowReviewer), joinOrDash(&preview.Alternatives))
+	})
+	if err != nil || preview.Assignment.Candidates == nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(p.out); err != nil {
+		return err
+	}
+	return printCandidates(p.out, *preview.Assignment.Candidates)
+}
+
+func printCandidates(w io.Writer, candidates []generated.CandidateExplanation) error {
+	table := &printer{format: outputTable, out: w}
+	return table.table([]string{"USER_ID", "RESULT", "WEIGHT", "SKILLS", "HOURS", "LOAD", "HISTORY", "RULE"}, func(row func(...string)) {
+		for _, candidate := range candidates {
+			result := "candidate"
+			switch {
+			case candidate.ExcludedBy != nil:
+				result = "excluded: " + string(*candidate.ExcludedBy)
+			case candidate.Selected != nil && *candidate.Selected:
+				result = "selected"
+			case candidate.Shadow != nil && *candidate.Shadow:
+				result = "shadow"
+			}
+			if candidate.Score == nil {
+				row(candidate.UserId, result, "-", "-", "-", "-", "-", "-")
+				continue
+			}
+			score := candidate.Score
+			row(candidate.UserId, result, formatScore(score.Weight), formatScore(score.Skills), formatScore(score.WorkingHours),
+				formatScore(score.Load), formatScore(score.PairHistory), formatScore(score.Rule))
+		}
+	})
+}
+
+func formatScore(value float64) string {
+	return strconv.FormatFloat(value, 'g', 3, 64)
+}
+
+func joinOrDash(values *[]string) string {
+	if values == nil || len(*values) == 0 {
+		return "-"
+	}
+	return strings.Join(*values, ",")
+}
+
+func orDash(value *string) string {
+	if value == nil || *value == "" {
+		return "-"
+	}
+	return *value
+}
+
+func (p *printer) json(value any) error {
+	encoder := json.NewEncoder(p.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func (p *printer) table(header []string, rows func(row func(...string))) error {
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	rows(func(cells ...string) {
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	})
+	return w.Flush()
+}
@@ -3,10 +3,17 @@ package di
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"time"
 
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/ical"
 	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
 	"github.com/pozedorum/set_pr_reviers_service/internal/repository"
+	"github.com/pozedorum/set_pr_reviers_service/internal/repository/memory"
+	"github.com/pozedorum/set_pr_reviers_service/internal/repository/sqlite"
 	"github.com/pozedorum/set_pr_reviers_service/internal/server"
 	"github.com/pozedorum/set_pr_reviers_service/internal/service"
 	"github.com/pozedorum/set_pr_reviers_service/pkg/config"
@@ -18,6 +25,11 @@ type Container struct {
 	service interfaces.Service
 	server  interfaces.Server
 	logger  interfaces.Logger
+
+	reassignInterval time.Duration
+	calendar         config.CalendarConfig
+	stopWorkers      context.CancelFunc
+	workers          sync.WaitGroup
 }
 
 func NewContainer(cfg *config.Config) (*Container, error) {
@@ -30,20 +42,34 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 
 	logger.Info("CONTAINER_INIT", "Starting application container initialization")
 
+	// Миграции. SQLite применяет их сама при открытии базы
+	if cfg.Storage.Type == config.StoragePostgres && cfg.Database.AutoMigrate {
+		if err := migratePostgres(cfg, logger); err != nil {
+			logger.Error("CONTAINER_INIT", "Failed to apply migrations", "error", err)
+			return nil, fmt.Errorf("failed to apply migrations: %w", err)
+		}
+		logger.Info("CONTAINER_INIT", "Migrations applied successfully")
+	}
+
 	// Репозиторий
-	repo, err := repository.NewPRRepository(cfg.Database.GetDSN(), logger)
+	repo, err := newRepository(cfg, logger)
 	if err != nil {
 		logger.Error("CONTAINER_INIT", "Failed to create repository", "error", err)
 		return nil, err
 	}
-	logger.Info("CONTAINER_INIT", "Repository initialized successfully")
+	logger.Info("CONTAINER_INIT", "Repository initialized successfully", "storage", cfg.Storage.Type)
 
 	// Business service
-	service := service.NewPRService(repo, logger)
+	service := service.NewPRServiceWithOptions(repo, logger, service.Options{
+		PreferWorkingHours: cfg.Review.PreferWorkingHours,
+		BalanceLoad:        cfg.Review.BalanceLoad,
+		LinesPerReview:     cfg.Review.LinesPerReview,
+		PairHistoryWindow:  cfg.Review.PairHistoryWindow,
+	})
 	logger.Info("CONTAINER_INIT", "Service initialized successfully")
 
 	// HTTP server
-	server := server.NewPRServer(cfg.Server.Port, service, logger)
+	server := server.NewPRServer(cfg.Server.Port, cfg.Server.RequestTimeout, cfg.Server.BulkRequestTimeout, cfg.Calendar.Category, service, logger)
 	logger.Info("CONTAINER_INIT", "Server initialized successfully")
 
 	return &Container{
@@ -51,18 +77,107 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		service: service,
 		server:  server,
 		logger:  logger,
+
+		reassignInterval: cfg.Review.ReassignCheckInterval,
+		calendar:         cfg.Calendar,
 	}, nil
 }
 
+func newRepository(cfg *config.Config, logger interfaces.Logger) (interfaces.Repository, error) {
+	switch cfg.Storage.Type {
+	case config.StoragePostgres:
+		return repository.NewPRRepository(cfg.Database.GetDSN(), logger)
+	case config.StorageMemory:
+		return memory.NewMemoryRepository(logger), nil
+	case config.StorageSQLite:
+		if err := os.MkdirAll(filepath.Dir(cfg.Storage.SQLitePath), 0o755); err != nil {
+			return nil, fmt.Errorf("create sqlite directory: %w", err)
+		}
+		return sqlite.NewSQLiteRepository(cfg.Storage.SQLitePath, logger)
+	default:
+		return nil, fmt.Errorf("unknown storage type %q", cfg.Storage.Type)
+	}
+}
+
 func (c *Container) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.stopWorkers = cancel
+	if c.calendar.Path != "" && c.calendar.ImportInterval > 0 {
+		c.startWorker(ctx, "CALENDAR_IMPORT_WORKER", c.calendar.ImportInterval, c.importCalendarFile)
+	}
+	if c.reassignInterval > 0 {
+		c.startWorker(ctx, "REASSIGN_WORKER", c.reassignInterval, func(ctx context.Context) error {
+			_, err := c.service.ReassignUnavailableReviews(ctx, time.Now())
+			return err
+		})
+	}
 	return c.server.Start()
 }
 
+// startWorker запускает fn сразу и затем раз в interval до остановки контейнера
+func (c *Container) startWorker(ctx context.Context, operation string, interval time.Duration, fn func(ctx context.Context) error) {
+	c.workers.Add(1)
+	go func() {
+		defer c.workers.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		c.logger.Info(operation, "Worker started", "interval", interval.String())
+		for {
+			if err := fn(ctx); err != nil && ctx.Err() == nil {
+				c.logger.Error(operation, "Worker run failed", "error", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				c.logger.Info(operation, "Worker stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// importCalendarFile импортирует календарь отсутствий из файла ICS_PATH. Файл
+// перечитывается каждый раз, поэтому его можно обновлять без перезапуска сервиса
+func (c *Container) importCalendarFile(ctx context.Context) error {
+	data, err := os.ReadFile(c.calendar.Path)
+	if err != nil {
+		return fmt.Errorf("read calendar: %w", err)
+	}
+	events, err := ical.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parse calendar %s: %w", c.calendar.Path, err)
+	}
+
+	result, err := c.service.ImportCalendar(ctx, events, entity.CalendarImportOptions{
+		Category:        c.calendar.Category,
+		ReassignReviews: c.calendar.ReassignReviews,
+	})
+	if err != nil {
+		return err
+	}
+	for _, skip := range result.Skipped {
+		c.logger.Warn("CALENDAR_IMPORT_WORKER", "Calendar event skipped",
+			"uid", skip.UID,
+			"summary", skip.Summary,
+			"reason", skip.Reason)
+	}
+	return nil
+}
+
 func (c *Container) Shutdown() error {
 	var errors []error
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Stop background workers
+	if c.stopWorkers != nil {
+		c.stopWorkers()
+		c.workers.Wait()
+	}
+
 	// Shutdown server
 	if err := c.server.Shutdown(ctx); err != nil {
 		errors = append(errors, fmt.Errorf("server shutdown: %w", err))
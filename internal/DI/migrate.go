@@ -0,0 +1,142 @@
+package di
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+	"github.com/pozedorum/set_pr_reviers_service/internal/migrator"
+	"github.com/pozedorum/set_pr_reviers_service/internal/repository/sqlite"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/config"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+)
+
+const migrateTimeout = 5 * time.Minute
+
+// RunMigrate выполняет команду "migrate": up (по умолчанию), down [N] или version
+// для хранилища из конфигурации
+func RunMigrate(cfg *config.Config, args []string) error {
+	logger, err := logger.NewLogger("pr-service-migrate", "")
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer logger.Shutdown()
+
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+	steps := 1
+	if command == "down" && len(args) > 1 {
+		steps, err = strconv.Atoi(args[1])
+		if err != nil || steps < 1 {
+			return fmt.Errorf("invalid number of steps %q", args[1])
+		}
+	}
+	if command != "up" && command != "down" && command != "version" {
+		return fmt.Errorf("unknown migrate command %q, expected up, down [N] or version", command)
+	}
+
+	if cfg.Storage.Type == config.StorageMemory {
+		fmt.Println("storage memory has no schema, nothing to migrate")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	db, m, err := openMigrator(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error("MIGRATE", "failed to close database", "error", err)
+		}
+	}()
+
+	switch command {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied %d migration(s)\n", applied)
+	case "down":
+		reverted, err := m.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reverted %d migration(s)\n", reverted)
+	}
+
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("schema version: %d (latest: %d)\n", version, m.Latest())
+	return nil
+}
+
+func openMigrator(ctx context.Context, cfg *config.Config, logger interfaces.Logger) (*sql.DB, *migrator.Migrator, error) {
+	switch cfg.Storage.Type {
+	case config.StoragePostgres:
+		db, err := sql.Open("postgres", cfg.Database.GetDSN())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open database: %w", err)
+		}
+		if err := db.PingContext(ctx); err != nil {
+			_ = db.Close()
+			return nil, nil, fmt.Errorf("failed to ping database: %w", err)
+		}
+		m, err := migrator.New(db, migrator.Postgres, logger)
+		if err != nil {
+			_ = db.Close()
+			return nil, nil, err
+		}
+		return db, m, nil
+	case config.StorageSQLite:
+		if err := os.MkdirAll(filepath.Dir(cfg.Storage.SQLitePath), 0o755); err != nil {
+			return nil, nil, fmt.Errorf("create sqlite directory: %w", err)
+		}
+		db, err := sqlite.OpenDB(cfg.Storage.SQLitePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		m, err := sqlite.NewMigrator(ctx, db, logger)
+		if err != nil {
+			_ = db.Close()
+			return nil, nil, err
+		}
+		return db, m, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage type %q", cfg.Storage.Type)
+	}
+}
+
+// migratePostgres применяет миграции Postgres при старте сервиса (DB_AUTO_MIGRATE=true).
+// Несколько реплик могут стартовать одновременно: миграции применит одна,
+// остальные дождутся advisory-блокировки и ничего не найдут
+func migratePostgres(cfg *config.Config, logger interfaces.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	db, m, err := openMigrator(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error("CONTAINER_INIT", "failed to close migration connection", "error", err)
+		}
+	}()
+
+	_, err = m.Up(ctx)
+	return err
+}
@@ -0,0 +1,194 @@
+// Package codeowners разбирает файлы CODEOWNERS в правила владения кодом и
+// подбирает правила к путям изменённых файлов. Синтаксис шаблонов - как в GitHub:
+// шаблон без "/" в начале или середине совпадает на любой глубине, "/" в конце -
+// только содержимое каталога, "*" и "?" не выходят за пределы одного каталога,
+// "**" - любое число каталогов. Отрицание "!" и диапазоны "[ ]" не поддерживаются.
+// Из нескольких подходящих к пути правил действует последнее
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+)
+
+// ErrUnsupportedPattern возвращается для шаблонов с отрицанием или диапазонами символов
+var ErrUnsupportedPattern = errors.New("negation and character ranges are not supported")
+
+// Parse возвращает правила файла в порядке строк. Владелец @name - пользователь
+// с таким user_id или псевдонимом, @org/name - команда name, адрес почты - псевдоним
+// пользователя. Строки с # в начале и всё после # среди владельцев - комментарии
+func Parse(data []byte) ([]entity.CodeOwnerRule, error) {
+	var rules []entity.CodeOwnerRule
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for number := 1; scanner.Scan(); number++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		// \# в начале шаблона - экранированный символ, а не комментарий
+		rule := entity.CodeOwnerRule{Line: number, Pattern: strings.Replace(fields[0], `\#`, "#", 1)}
+		if _, err := compilePattern(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			user, team, err := parseOwner(owner)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", number, err)
+			}
+			if team != "" {
+				rule.Teams = append(rule.Teams, team)
+			} else {
+				rule.Users = append(rule.Users, user)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read code owners: %w", err)
+	}
+	return rules, nil
+}
+
+func parseOwner(owner string) (user, team string, err error) {
+	name, handle := strings.CutPrefix(owner, "@")
+	switch {
+	case handle && name != "" && !strings.Contains(name, "@"):
+		if _, teamName, ok := strings.Cut(name, "/"); ok {
+			if teamName == "" || strings.Contains(teamName, "/") {
+				return "", "", fmt.Errorf("invalid team owner %q", owner)
+			}
+			return "", teamName, nil
+		}
+		return name, "", nil
+	case !handle && strings.Count(owner, "@") == 1 && !strings.HasSuffix(owner, "@"):
+		return strings.ToLower(owner), "", nil
+	}
+	return "", "", fmt.Errorf("invalid owner %q: expected @user, @org/team or email", owner)
+}
+
+// Ruleset - правила с заранее скомпилированными шаблонами
+type Ruleset struct {
+	rules    []entity.CodeOwnerRule
+	patterns []*regexp.Regexp
+}
+
+// Compile готовит правила к сопоставлению с путями. Ошибка означает неверный шаблон,
+// поэтому Compile проверяет и правила, полученные не через Parse
+func Compile(rules []entity.CodeOwnerRule) (*Ruleset, error) {
+	set := &Ruleset{rules: rules, patterns: make([]*regexp.Regexp, len(rules))}
+	for i, rule := range rules {
+		pattern, err := compilePattern(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", rule.Line, err)
+		}
+		set.patterns[i] = pattern
+	}
+	return set, nil
+}
+
+// Match возвращает правило, которое определяет владельцев пути: последнее из подходящих
+func (set *Ruleset) Match(path string) (entity.CodeOwnerRule, bool) {
+	if i := set.match(path); i >= 0 {
+		return set.rules[i], true
+	}
+	return entity.CodeOwnerRule{}, false
+}
+
+// MatchAll возвращает правила, определяющие владельцев хотя бы одного из путей,
+// без повторов и в порядке файла
+func (set *Ruleset) MatchAll(paths []string) []entity.CodeOwnerRule {
+	matched := make(map[int]bool)
+	for _, path := range paths {
+		if i := set.match(path); i >= 0 {
+			matched[i] = true
+		}
+	}
+
+	var rules []entity.CodeOwnerRule
+	for i, rule := range set.rules {
+		if matched[i] {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// match возвращает индекс последнего подходящего к пути правила или -1
+func (set *Ruleset) match(path string) int {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "./"), "/")
+	for i := len(set.rules) - 1; i >= 0; i-- {
+		if set.patterns[i].MatchString(path) {
+			return i
+		}
+	}
+	return -1
+}
+
+// compilePattern переводит шаблон CODEOWNERS в регулярное выражение для пути от корня репозитория
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+		return nil, fmt.Errorf("pattern %q: %w", pattern, ErrUnsupportedPattern)
+	}
+
+	trimmed := strings.TrimPrefix(pattern, "/")
+	contentsOnly := strings.HasSuffix(trimmed, "/")
+	trimmed = strings.TrimSuffix(trimmed, "/")
+	if trimmed == "" {
+		return nil, fmt.Errorf("pattern %q matches no path", pattern)
+	}
+	// Шаблон с "/" в начале или середине отсчитывается от корня репозитория
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !anchored {
+		expr.WriteString("(?:.*/)?")
+	}
+	segments := strings.Split(trimmed, "/")
+	last := segments[len(segments)-1]
+	for i, segment := range segments {
+		if segment == "**" {
+			if i == len(segments)-1 {
+				expr.WriteString(".*")
+			} else {
+				expr.WriteString("(?:.*/)?")
+			}
+			continue
+		}
+		for _, r := range segment {
+			switch r {
+			case '*':
+				expr.WriteString("[^/]*")
+			case '?':
+				expr.WriteString("[^/]")
+			default:
+				expr.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		if i < len(segments)-1 {
+			expr.WriteString("/")
+		}
+	}
+
+	// Каталог владеет всеми файлами внутри, а docs/* - только файлами самого docs
+	switch {
+	case contentsOnly:
+		expr.WriteString("/.*")
+	case last != "**" && !strings.ContainsAny(last, "*?"):
+		expr.WriteString("(?:/.*)?")
+	}
+	expr.WriteString("$")
+
+	return regexp.Compile(expr.String())
+}
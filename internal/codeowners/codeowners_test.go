@@ -0,0 +1,117 @@
+package codeowners
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func file(lines ...string) []byte {
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+func TestParse_Rules(t *testing.T) {
+	data := file(
+		"# Владельцы по умолчанию",
+		"*       @acme/backend",
+		"",
+		"/docs/  @u2 Bob@Example.com @acme/docs # документация",
+		"/docs/generated/",
+		`\#notes @u3`,
+	)
+
+	rules, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.CodeOwnerRule{
+		{Line: 2, Pattern: "*", Teams: []string{"backend"}},
+		{Line: 4, Pattern: "/docs/", Users: []string{"u2", "bob@example.com"}, Teams: []string{"docs"}},
+		{Line: 5, Pattern: "/docs/generated/"},
+		{Line: 6, Pattern: "#notes", Users: []string{"u3"}},
+	}, rules)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		wantErr string
+	}{
+		{name: "negation", line: "!vendor/ @u1", wantErr: "line 2: pattern \"!vendor/\""},
+		{name: "character range", line: "*.[ch] @u1", wantErr: "line 2: pattern"},
+		{name: "root only", line: "/ @u1", wantErr: "line 2: pattern \"/\" matches no path"},
+		{name: "plain word owner", line: "*.go backend", wantErr: "line 2: invalid owner \"backend\""},
+		{name: "empty handle", line: "*.go @", wantErr: "line 2: invalid owner \"@\""},
+		{name: "nested team", line: "*.go @acme/platform/backend", wantErr: "line 2: invalid team owner"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(file("* @u1", tt.line))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRuleset_Match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		match   []string
+		noMatch []string
+	}{
+		{pattern: "*", match: []string{"README.md", "cmd/main.go"}},
+		{pattern: "*.js", match: []string{"app.js", "web/src/app.js"}, noMatch: []string{"app.jsx"}},
+		{pattern: "/build/logs/", match: []string{"build/logs/a.log", "build/logs/x/b.log"}, noMatch: []string{"build/logs", "src/build/logs/a.log"}},
+		{pattern: "docs/*", match: []string{"docs/intro.md"}, noMatch: []string{"docs/guides/setup.md", "src/docs/intro.md"}},
+		{pattern: "apps/", match: []string{"apps/web/main.go", "services/apps/api.go"}, noMatch: []string{"apps"}},
+		{pattern: "/scripts", match: []string{"scripts", "scripts/deploy.sh"}, noMatch: []string{"tools/scripts/a.sh"}},
+		{pattern: "**/logs", match: []string{"logs/a.log", "deploy/logs/a.log", "a/b/logs"}},
+		{pattern: "internal/**", match: []string{"internal/a.go", "internal/x/y/z.go"}, noMatch: []string{"internal"}},
+		{pattern: "internal/**/*_test.go", match: []string{"internal/a_test.go", "internal/x/y/z_test.go"}, noMatch: []string{"internal/a.go"}},
+		{pattern: "v?.txt", match: []string{"v1.txt", "notes/v2.txt"}, noMatch: []string{"v10.txt"}},
+		{pattern: "go.mod", match: []string{"go.mod", "tools/go.mod"}, noMatch: []string{"gozmod"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			set, err := Compile([]entity.CodeOwnerRule{{Line: 1, Pattern: tt.pattern, Users: []string{"u1"}}})
+			require.NoError(t, err)
+			for _, path := range tt.match {
+				_, ok := set.Match(path)
+				assert.True(t, ok, "%q should match %q", tt.pattern, path)
+			}
+			for _, path := range tt.noMatch {
+				_, ok := set.Match(path)
+				assert.False(t, ok, "%q should not match %q", tt.pattern, path)
+			}
+		})
+	}
+}
+
+func TestRuleset_MatchAll_LastRuleWins(t *testing.T) {
+	rules, err := Parse(file(
+		"*                @acme/backend",
+		"*.md             @u4",
+		"/docs/           @u2",
+		"/docs/generated/",
+		"/api/*.proto     @u3",
+	))
+	require.NoError(t, err)
+	set, err := Compile(rules)
+	require.NoError(t, err)
+
+	rule, ok := set.Match("/docs/guide.md")
+	require.True(t, ok)
+	assert.Equal(t, "/docs/", rule.Pattern)
+
+	// Пути без совпадений и повторно совпавшие правила не дублируются, порядок - как в файле
+	matched := set.MatchAll([]string{"./docs/generated/api.md", "api/v1.proto", "docs/a.md", "docs/b.md", "main.go"})
+	patterns := make([]string, len(matched))
+	for i, rule := range matched {
+		patterns[i] = rule.Pattern
+	}
+	assert.Equal(t, []string{"*", "/docs/", "/docs/generated/", "/api/*.proto"}, patterns)
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "errors"
+
+// ErrVersionConflict возвращается хранилищем, если PR был изменён параллельно
+// и переданная версия больше не совпадает с сохранённой
+var ErrVersionConflict = errors.New("pull request version conflict")
+
+// ErrNoUser возвращается хранилищем, если пользователя нет. Вынесена сюда, чтобы
+// сервис мог отличить отсутствие пользователя от сбоя хранилища
+var ErrNoUser = errors.New("no such user")
+
+// ErrNoUnavailability возвращается хранилищем, если периода недоступности нет
+var ErrNoUnavailability = errors.New("no such unavailability period")
+
+// ErrAliasTaken возвращается хранилищем, если псевдоним уже принадлежит другому пользователю
+var ErrAliasTaken = errors.New("alias belongs to another user")
+
+// ErrNoWorkSchedule возвращается хранилищем, если у пользователя не задано рабочее время
+var ErrNoWorkSchedule = errors.New("no work schedule")
+
+// ErrNoTeam возвращается хранилищем, если команды нет
+var ErrNoTeam = errors.New("no such team")
+
+// ErrNoPR возвращается хранилищем, если PR нет
+var ErrNoPR = errors.New("no such pull request")
+
+// ErrTeamExists, ErrUserExists и ErrPRExists возвращаются хранилищем при создании
+// команды, пользователя или PR с уже занятым идентификатором
+var (
+	ErrTeamExists = errors.New("team already exists")
+	ErrUserExists = errors.New("user already exists")
+	ErrPRExists   = errors.New("pull request already exists")
+)
+
+// ErrNoCodeOwners возвращается хранилищем, если для репозитория не загружены правила CODEOWNERS
+var ErrNoCodeOwners = errors.New("no code owners")
+
+// ErrNoReviewerRule возвращается хранилищем, если для пары автор-ревьювер нет правила
+var ErrNoReviewerRule = errors.New("no reviewer rule")
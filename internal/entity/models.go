@@ -7,17 +7,72 @@ type User struct {
 	Username string
 	TeamName string
 	IsActive bool
+	// MaxOpenReviews - предел одновременно открытых ревью, 0 - как у команды
+	MaxOpenReviews int
+	// Level - уровень пользователя, пустой - не задан
+	Level UserLevel
+	// IsLearner - пользователь учится ревью и назначается теневым ревьювером
+	IsLearner bool
 }
 
 type Team struct {
 	TeamName string
 	Members  []TeamMember
+	// MaxOpenReviews - предел по умолчанию для участников, 0 - без ограничения
+	MaxOpenReviews int
+	ReviewPolicy   ReviewPolicy
 }
 
 type TeamMember struct {
-	UserID   string
-	Username string
-	IsActive bool
+	UserID         string
+	Username       string
+	IsActive       bool
+	MaxOpenReviews int
+	Level          UserLevel
+	IsLearner      bool
+}
+
+// UserLevel - уровень пользователя: junior, middle, senior или lead
+type UserLevel string
+
+const (
+	UserLevelJunior UserLevel = "junior"
+	UserLevelMiddle UserLevel = "middle"
+	UserLevelSenior UserLevel = "senior"
+	UserLevelLead   UserLevel = "lead"
+)
+
+// Rank - порядковый номер уровня от 1 у junior до 4 у lead, 0 - уровень не задан или неизвестен
+func (l UserLevel) Rank() int {
+	switch l {
+	case UserLevelJunior:
+		return 1
+	case UserLevelMiddle:
+		return 2
+	case UserLevelSenior:
+		return 3
+	case UserLevelLead:
+		return 4
+	}
+	return 0
+}
+
+// IsSenior - уровень senior или lead
+func (l UserLevel) IsSenior() bool {
+	return l.Rank() >= UserLevelSenior.Rank()
+}
+
+// ReviewPolicy - требования команды к ревьюверам PR её участников
+type ReviewPolicy struct {
+	// RequireSenior - среди ревьюверов каждого PR есть senior или lead
+	RequireSenior bool
+	// SeniorForJuniors - среди ревьюверов PR автора уровня junior есть senior или lead
+	SeniorForJuniors bool
+}
+
+// NeedsSenior - нужен ли ревьювер senior или lead на PR автора уровня authorLevel
+func (p ReviewPolicy) NeedsSenior(authorLevel UserLevel) bool {
+	return p.RequireSenior || (p.SeniorForJuniors && authorLevel == UserLevelJunior)
 }
 
 type PullRequest struct {
@@ -26,8 +81,40 @@ type PullRequest struct {
 	AuthorID          string
 	Status            PullRequestStatus
 	AssignedReviewers []string
-	CreatedAt         time.Time
-	MergedAt          time.Time
+	// ShadowReviewer - теневой ревьювер из учеников команды, пустой - не назначен. Он не входит
+	// в AssignedReviewers и не занимает место ни одного из них
+	ShadowReviewer string
+	CreatedAt      time.Time
+	MergedAt       time.Time
+	Version        int // Версия для оптимистичной блокировки, начинается с 1
+	Metadata       PRMetadata
+}
+
+// PRMetadata - необязательные сведения о размере PR, передаются при создании и
+// после него не меняются
+type PRMetadata struct {
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+	Repository   string
+	BaseBranch   string
+	Labels       []string
+	// Paths - пути изменённых файлов, по ним выбираются владельцы кода
+	Paths []string
+	// RequiredSkills - навыки, которые нужны для ревью, теги в нижнем регистре
+	RequiredSkills []string
+}
+
+// ChangedLines - добавленные и удалённые строки вместе
+func (m PRMetadata) ChangedLines() int {
+	return m.Additions + m.Deletions
+}
+
+// ReviewLoad - открытые ревью одного пользователя
+type ReviewLoad struct {
+	OpenReviews int
+	// ChangedLines - сумма ChangedLines открытых PR на ревью
+	ChangedLines int
 }
 
 type PullRequestStatus string
@@ -36,3 +123,360 @@ const (
 	PullRequestStatusOpen   PullRequestStatus = "OPEN"
 	PullRequestStatusMerged PullRequestStatus = "MERGED"
 )
+
+// ReviewAssignment - итог автоматического назначения ревьюверов на новый PR
+type ReviewAssignment struct {
+	// UnfilledSlots - сколько мест ревьюверов осталось незанятыми
+	UnfilledSlots int
+	// AtCapacity - участники команды, пропущенные из-за предела открытых ревью
+	AtCapacity []string
+	// CodeOwners - ревьюверы, назначенные по правилам CODEOWNERS, и эти правила
+	CodeOwners []CodeOwnerAssignment
+	// UncoveredRules - совпавшие правила CODEOWNERS, ни одного владельца которых нельзя назначить
+	UncoveredRules []CodeOwnerRule
+	// SeniorMissing - политика команды автора требует senior или lead, но назначить некого
+	SeniorMissing bool
+	// Candidates - почему выбраны именно эти ревьюверы: участники команды автора
+	// с оценками или причиной, по которой их нельзя было выбрать
+	Candidates []CandidateExplanation
+}
+
+// Reassignment - итог переназначения ревьювера
+type Reassignment struct {
+	// NewReviewerID - ревьювер, назначенный вместо прежнего
+	NewReviewerID string
+	// Candidates - почему выбран именно он среди участников команды прежнего ревьювера
+	Candidates []CandidateExplanation
+}
+
+// ReviewPreview - ревьюверы, которых получил бы PR, если создать его сейчас
+type ReviewPreview struct {
+	Reviewers      []string
+	ShadowReviewer string
+	// Alternatives - остальные доступные кандидаты по убыванию веса
+	Alternatives []string
+	Assignment   ReviewAssignment
+}
+
+// ExclusionReason - почему участник команды не мог быть выбран ревьювером
+type ExclusionReason string
+
+const (
+	ExcludedAuthor       ExclusionReason = "author"
+	ExcludedAssigned     ExclusionReason = "already_assigned"
+	ExcludedInactive     ExclusionReason = "inactive"
+	ExcludedUnavailable  ExclusionReason = "unavailable"
+	ExcludedAtCapacity   ExclusionReason = "at_capacity"
+	ExcludedByRule       ExclusionReason = "rule"
+	ExcludedSeniorPolicy ExclusionReason = "senior_policy"
+)
+
+// CandidateScore - множители веса кандидата по факторам выбора. 1 - фактор
+// не повлиял на выбор или отключён
+type CandidateScore struct {
+	Skills       float64
+	WorkingHours float64
+	Load         float64
+	PairHistory  float64
+	Rule         float64
+}
+
+// NewCandidateScore возвращает оценку, в которой ни один фактор не повлиял на выбор
+func NewCandidateScore() CandidateScore {
+	return CandidateScore{Skills: 1, WorkingHours: 1, Load: 1, PairHistory: 1, Rule: 1}
+}
+
+// Weight - итоговый вес кандидата: чем больше, тем вероятнее он будет выбран
+func (s CandidateScore) Weight() float64 {
+	return s.Skills * s.WorkingHours * s.Load * s.PairHistory * s.Rule
+}
+
+// CandidateExplanation - как участник команды оценивался при выборе ревьюверов
+type CandidateExplanation struct {
+	UserID string
+	// ExcludedBy - причина, по которой участника нельзя было выбрать, пусто у кандидатов
+	ExcludedBy ExclusionReason
+	// Score - оценка кандидата, у исключённых нулевая
+	Score CandidateScore
+	// Selected - кандидат назначен ревьювером
+	Selected bool
+	// Shadow - кандидат назначен теневым ревьювером
+	Shadow bool
+}
+
+// CodeOwnerAssignment - ревьювер, назначенный владельцем кода по правилу Rule
+type CodeOwnerAssignment struct {
+	ReviewerID string
+	Rule       CodeOwnerRule
+}
+
+// CodeOwnerRule - строка файла CODEOWNERS: шаблон пути и владельцы подходящих файлов
+type CodeOwnerRule struct {
+	// Line - номер строки в загруженном файле
+	Line    int
+	Pattern string
+	// Users - user_id или псевдонимы владельцев
+	Users []string
+	// Teams - команды-владельцы, любой активный участник которых может быть назначен
+	Teams []string
+}
+
+// HasOwners - у правила есть хотя бы один владелец. Правило без владельцев снимает
+// владение с путей, назначенное правилами выше
+func (r CodeOwnerRule) HasOwners() bool {
+	return len(r.Users) > 0 || len(r.Teams) > 0
+}
+
+// CodeOwners - правила владения кодом одного репозитория в порядке строк файла
+type CodeOwners struct {
+	Repository string
+	Rules      []CodeOwnerRule
+}
+
+// Unavailability - период [StartsAt, EndsAt), когда пользователь не назначается ревьювером
+type Unavailability struct {
+	ID       int64
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+	// ReassignReviews - переназначить открытые ревью пользователя, когда период начнётся
+	ReassignReviews bool
+	// ReassignedAt - когда открытые ревью были переназначены, нулевое - ещё не были
+	ReassignedAt time.Time
+	// ExternalID - UID события календаря, из которого импортирован период, пустой у добавленных вручную
+	ExternalID string
+}
+
+// Covers сообщает, попадает ли момент at в период
+func (u Unavailability) Covers(at time.Time) bool {
+	return !at.Before(u.StartsAt) && at.Before(u.EndsAt)
+}
+
+// WorkSchedule - рабочее время пользователя в его часовом поясе
+type WorkSchedule struct {
+	UserID string
+	// TimeZone - часовой пояс IANA, например Europe/Berlin
+	TimeZone string
+	// StartMinute и EndMinute - начало и конец рабочего дня в минутах от полуночи.
+	// Конец раньше начала - рабочий день переходит через полночь
+	StartMinute int
+	EndMinute   int
+	// Days - дни недели, в которые начинается рабочий день
+	Days []time.Weekday
+}
+
+// WorkDaysMask упаковывает дни недели в битовую маску, бит 0 - воскресенье.
+// В таком виде дни хранятся в столбце work_days
+func WorkDaysMask(days []time.Weekday) int {
+	mask := 0
+	for _, day := range days {
+		mask |= 1 << day
+	}
+	return mask
+}
+
+// WorkDaysFromMask распаковывает маску WorkDaysMask в дни недели по порядку, начиная с воскресенья
+func WorkDaysFromMask(mask int) []time.Weekday {
+	var days []time.Weekday
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if mask&(1<<day) != 0 {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// UntilWorkingHours возвращает, через сколько после момента at начнётся рабочее время,
+// 0 - если оно уже идёт. Неизвестный часовой пояс считается UTC
+func (s WorkSchedule) UntilWorkingHours(at time.Time) time.Duration {
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	length := time.Duration((s.EndMinute-s.StartMinute+24*60)%(24*60)) * time.Minute
+	if length == 0 {
+		return 0
+	}
+
+	local := at.In(location)
+	// Рабочий день, начавшийся вчера, ещё может идти. Дни перебираются по порядку,
+	// поэтому первый не закончившийся к at и есть ближайший
+	for offset := -1; offset <= 7; offset++ {
+		startsAt := time.Date(local.Year(), local.Month(), local.Day()+offset,
+			s.StartMinute/60, s.StartMinute%60, 0, 0, location)
+		if !s.WorksOn(startsAt.Weekday()) || !at.Before(startsAt.Add(length)) {
+			continue
+		}
+		if at.Before(startsAt) {
+			return startsAt.Sub(at)
+		}
+		return 0
+	}
+	// Рабочих дней нет - ждать нечего
+	return 0
+}
+
+// WorksOn сообщает, начинается ли рабочий день в день недели day
+func (s WorkSchedule) WorksOn(day time.Weekday) bool {
+	for _, workDay := range s.Days {
+		if workDay == day {
+			return true
+		}
+	}
+	return false
+}
+
+// UserAlias - псевдоним пользователя (email или имя в календаре) для импорта календаря
+type UserAlias struct {
+	Alias  string
+	UserID string
+}
+
+// Уровни владения навыком
+const (
+	SkillLevelBasic  = 1
+	SkillLevelExpert = 3
+)
+
+// UserSkill - навык пользователя: тег в нижнем регистре (go, frontend, sql) и уровень
+// владения от SkillLevelBasic до SkillLevelExpert
+type UserSkill struct {
+	UserID string
+	Tag    string
+	Level  int
+}
+
+// ReviewerRuleKind - вид правила для пары автор PR и ревьювер
+type ReviewerRuleKind string
+
+const (
+	// ReviewerRuleExclude - ревьювер никогда не назначается на PR автора
+	ReviewerRuleExclude ReviewerRuleKind = "exclude"
+	// ReviewerRulePrefer - ревьювер выбирается на PR автора чаще остальных кандидатов
+	ReviewerRulePrefer ReviewerRuleKind = "prefer"
+)
+
+// ReviewerRule - правило для пары автор PR и ревьювер. Правило действует в одну
+// сторону: на PR ReviewerID оно не влияет. На пару задаётся не больше одного правила
+type ReviewerRule struct {
+	AuthorID   string
+	ReviewerID string
+	Kind       ReviewerRuleKind
+	// Reason - необязательное пояснение, например конфликт интересов
+	Reason string
+}
+
+// CalendarEvent - событие (VEVENT) импортируемого календаря отсутствий
+type CalendarEvent struct {
+	UID      string
+	Summary  string
+	StartsAt time.Time
+	EndsAt   time.Time
+	// Categories - значения CATEGORIES события
+	Categories []string
+	// Cancelled - событие отменено (STATUS:CANCELLED)
+	Cancelled bool
+	// People - отсутствующие: участники события (ATTENDEE), а если их нет - организатор
+	People []CalendarPerson
+}
+
+type CalendarPerson struct {
+	Email string
+	Name  string
+}
+
+// CalendarImportOptions - параметры импорта календаря отсутствий
+type CalendarImportOptions struct {
+	// Category - импортируются только события с этой категорией (без учёта регистра)
+	Category string
+	// ReassignReviews - значение ReassignReviews для создаваемых периодов
+	ReassignReviews bool
+}
+
+// CalendarImportResult - итог импорта календаря. События других категорий, отменённые
+// и уже закончившиеся не импортируются и в итог не попадают
+type CalendarImportResult struct {
+	Created   int
+	Updated   int
+	Unchanged int
+	// Deleted - периоды отменённых и пропавших из календаря событий
+	Deleted int
+	Skipped []CalendarImportSkip
+}
+
+// CalendarImportSkip - событие нужной категории, которое не удалось импортировать
+type CalendarImportSkip struct {
+	UID     string
+	Summary string
+	Reason  string
+}
+
+// IdempotencyRecord - сохранённый ответ на запрос с заголовком Idempotency-Key
+type IdempotencyRecord struct {
+	Key         string
+	Endpoint    string
+	RequestHash string
+	// StatusCode 0 - первый запрос с этим ключом ещё выполняется
+	StatusCode      int
+	ResponseHeaders map[string]string
+	ResponseBody    []byte
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+}
+
+// Pending - запрос с этим ключом ещё выполняется, ответа пока нет
+func (r IdempotencyRecord) Pending() bool {
+	return r.StatusCode == 0
+}
+
+// TeamSyncAction - вид изменения при синхронизации состава команд
+type TeamSyncAction string
+
+const (
+	TeamSyncCreateTeam     TeamSyncAction = "create_team"
+	TeamSyncCreateUser     TeamSyncAction = "create_user"
+	TeamSyncMoveUser       TeamSyncAction = "move_user"
+	TeamSyncRenameUser     TeamSyncAction = "rename_user"
+	TeamSyncActivateUser   TeamSyncAction = "activate_user"
+	TeamSyncDeactivateUser TeamSyncAction = "deactivate_user"
+	// Предел ревью, уровень и признак обучения меняет только SyncTeams
+	TeamSyncSetMaxOpenReviews TeamSyncAction = "set_max_open_reviews"
+	TeamSyncSetLevel          TeamSyncAction = "set_level"
+	TeamSyncSetLearner        TeamSyncAction = "set_learner"
+)
+
+// TeamSyncChange - один шаг плана синхронизации
+type TeamSyncChange struct {
+	Action   TeamSyncAction
+	TeamName string
+	UserID   string
+	Username string
+	// FromTeam - прежняя команда пользователя для move_user
+	FromTeam string
+}
+
+// SnapshotFormatVersion - текущая версия формата снапшота. Увеличивается при
+// несовместимом изменении состава или смысла полей Snapshot
+const SnapshotFormatVersion = 1
+
+// Snapshot - полное состояние хранилища для резервного копирования и восстановления
+type Snapshot struct {
+	FormatVersion int
+	CreatedAt     time.Time
+	Teams         []Team
+	PullRequests  []PullRequest
+	// Unavailability - периоды недоступности всех пользователей. В снапшотах,
+	// выгруженных до появления периодов, отсутствует
+	Unavailability []Unavailability
+	// Aliases - псевдонимы пользователей, в старых снапшотах отсутствуют
+	Aliases []UserAlias
+	// WorkSchedules - рабочее время пользователей, в старых снапшотах отсутствует
+	WorkSchedules []WorkSchedule
+	// CodeOwners - правила владения кодом по репозиториям, в старых снапшотах отсутствуют
+	CodeOwners []CodeOwners
+	// Skills - навыки пользователей, в старых снапшотах отсутствуют
+	Skills []UserSkill
+	// ReviewerRules - правила пар автор-ревьювер, в старых снапшотах отсутствуют
+	ReviewerRules []ReviewerRule
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkSchedule_UntilWorkingHours(t *testing.T) {
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	office := WorkSchedule{TimeZone: "Asia/Yekaterinburg", StartMinute: 9 * 60, EndMinute: 18 * 60, Days: weekdays}
+	night := WorkSchedule{TimeZone: "America/Los_Angeles", StartMinute: 22 * 60, EndMinute: 6 * 60, Days: weekdays}
+
+	tests := []struct {
+		name     string
+		schedule WorkSchedule
+		at       time.Time
+		want     time.Duration
+	}{
+		// Екатеринбург - UTC+5
+		{"inside working hours", office, time.Date(2025, 6, 11, 5, 0, 0, 0, time.UTC), 0},
+		{"before start", office, time.Date(2025, 6, 11, 2, 30, 0, 0, time.UTC), 90 * time.Minute},
+		{"end is excluded", office, time.Date(2025, 6, 11, 13, 0, 0, 0, time.UTC), 15 * time.Hour},
+		{"friday evening waits for monday", office, time.Date(2025, 6, 13, 14, 0, 0, 0, time.UTC), 62 * time.Hour},
+		// Лос-Анджелес летом - UTC-7. Смена с пятницы на субботу идёт и в субботу ночью
+		{"night shift after midnight", night, time.Date(2025, 6, 14, 10, 0, 0, 0, time.UTC), 0},
+		{"night shift starts on monday", night, time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC), 41 * time.Hour},
+		{"unknown time zone is UTC", WorkSchedule{TimeZone: "Nowhere", StartMinute: 600, EndMinute: 660,
+			Days: []time.Weekday{time.Wednesday}}, time.Date(2025, 6, 11, 9, 0, 0, 0, time.UTC), time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.schedule.UntilWorkingHours(tt.at))
+		})
+	}
+}
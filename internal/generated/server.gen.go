@@ -13,27 +13,114 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Восстановить состояние из снапшота в пустое хранилище
+	// (POST /admin/restore)
+	PostAdminRestore(c *gin.Context)
+	// Выгрузить всё состояние сервиса в JSON-снапшот
+	// (GET /admin/snapshot)
+	GetAdminSnapshot(c *gin.Context)
+	// Удалить правила CODEOWNERS репозитория
+	// (DELETE /codeOwners)
+	DeleteCodeOwners(c *gin.Context, params DeleteCodeOwnersParams)
+	// Получить правила CODEOWNERS репозитория
+	// (GET /codeOwners)
+	GetCodeOwners(c *gin.Context, params GetCodeOwnersParams)
+	// Загрузить файл CODEOWNERS репозитория
+	// (PUT /codeOwners)
+	PutCodeOwners(c *gin.Context, params PutCodeOwnersParams)
+	// Выгрузить все PR в CSV
+	// (GET /export/pullRequests.csv)
+	GetExportPullRequestsCsv(c *gin.Context)
+	// Выгрузить всех пользователей в CSV (формат совпадает с импортом)
+	// (GET /export/users.csv)
+	GetExportUsersCsv(c *gin.Context)
+	// Импортировать пользователей и команды из CSV
+	// (POST /import/users.csv)
+	PostImportUsersCsv(c *gin.Context)
 	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
 	// (POST /pullRequest/create)
-	PostPullRequestCreate(c *gin.Context)
+	PostPullRequestCreate(c *gin.Context, params PostPullRequestCreateParams)
 	// Пометить PR как MERGED (идемпотентная операция)
 	// (POST /pullRequest/merge)
-	PostPullRequestMerge(c *gin.Context)
+	PostPullRequestMerge(c *gin.Context, params PostPullRequestMergeParams)
+	// Показать, каких ревьюверов получил бы PR, не создавая его
+	// (POST /pullRequest/preview)
+	PostPullRequestPreview(c *gin.Context)
 	// Переназначить конкретного ревьювера на другого из его команды
 	// (POST /pullRequest/reassign)
-	PostPullRequestReassign(c *gin.Context)
+	PostPullRequestReassign(c *gin.Context, params PostPullRequestReassignParams)
+	// Удалить правило пары автор-ревьювер
+	// (DELETE /reviewerRules)
+	DeleteReviewerRules(c *gin.Context, params DeleteReviewerRulesParams)
+	// Получить правила пар автор-ревьювер
+	// (GET /reviewerRules)
+	GetReviewerRules(c *gin.Context, params GetReviewerRulesParams)
+	// Задать правило пары автор-ревьювер
+	// (PUT /reviewerRules)
+	PutReviewerRules(c *gin.Context)
 	// Создать команду с участниками (создаёт/обновляет пользователей)
 	// (POST /team/add)
-	PostTeamAdd(c *gin.Context)
+	PostTeamAdd(c *gin.Context, params PostTeamAddParams)
 	// Получить команду с участниками
 	// (GET /team/get)
 	GetTeamGet(c *gin.Context, params GetTeamGetParams)
-	// Получить PR'ы, где пользователь назначен ревьювером
+	// Задать предел открытых ревью по умолчанию для участников команды
+	// (POST /team/setMaxOpenReviews)
+	PostTeamSetMaxOpenReviews(c *gin.Context)
+	// Задать требования к уровню ревьюверов PR участников команды
+	// (POST /team/setReviewPolicy)
+	PostTeamSetReviewPolicy(c *gin.Context)
+	// Привести состав команд к описанию (создание, переводы, переименования, деактивации)
+	// (POST /team/sync)
+	PostTeamSync(c *gin.Context, params PostTeamSyncParams)
+	// Получить псевдонимы пользователя для импорта календаря
+	// (GET /users/aliases)
+	GetUsersAliases(c *gin.Context, params GetUsersAliasesParams)
+	// Заменить псевдонимы пользователя
+	// (PUT /users/aliases)
+	PutUsersAliases(c *gin.Context)
+	// Получить PR'ы, где пользователь назначен ревьювером или теневым ревьювером
 	// (GET /users/getReview)
 	GetUsersGetReview(c *gin.Context, params GetUsersGetReviewParams)
+	// Удалить рабочее время пользователя
+	// (DELETE /users/schedule)
+	DeleteUsersSchedule(c *gin.Context, params DeleteUsersScheduleParams)
+	// Получить рабочее время пользователя
+	// (GET /users/schedule)
+	GetUsersSchedule(c *gin.Context, params GetUsersScheduleParams)
+	// Задать рабочее время пользователя
+	// (PUT /users/schedule)
+	PutUsersSchedule(c *gin.Context)
 	// Установить флаг активности пользователя
 	// (POST /users/setIsActive)
 	PostUsersSetIsActive(c *gin.Context)
+	// Отметить пользователя учеником или снять отметку
+	// (POST /users/setLearner)
+	PostUsersSetLearner(c *gin.Context)
+	// Задать уровень пользователя
+	// (POST /users/setLevel)
+	PostUsersSetLevel(c *gin.Context)
+	// Задать предел одновременно открытых ревью пользователя
+	// (POST /users/setMaxOpenReviews)
+	PostUsersSetMaxOpenReviews(c *gin.Context)
+	// Получить навыки пользователя
+	// (GET /users/skills)
+	GetUsersSkills(c *gin.Context, params GetUsersSkillsParams)
+	// Заменить навыки пользователя
+	// (PUT /users/skills)
+	PutUsersSkills(c *gin.Context)
+	// Удалить период недоступности
+	// (DELETE /users/unavailability)
+	DeleteUsersUnavailability(c *gin.Context, params DeleteUsersUnavailabilityParams)
+	// Получить периоды недоступности пользователя
+	// (GET /users/unavailability)
+	GetUsersUnavailability(c *gin.Context, params GetUsersUnavailabilityParams)
+	// Добавить период недоступности (отпуск, больничный)
+	// (POST /users/unavailability)
+	PostUsersUnavailability(c *gin.Context)
+	// Импортировать периоды недоступности из календаря iCalendar (.ics)
+	// (POST /users/unavailability/import)
+	PostUsersUnavailabilityImport(c *gin.Context, params PostUsersUnavailabilityImportParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -45,9 +132,494 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// PostAdminRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminRestore(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAdminRestore(c)
+}
+
+// GetAdminSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminSnapshot(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminSnapshot(c)
+}
+
+// DeleteCodeOwners operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCodeOwners(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteCodeOwnersParams
+
+	// ------------- Required query parameter "repository" -------------
+
+	if paramValue := c.Query("repository"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument repository is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "repository", c.Request.URL.Query(), &params.Repository)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter repository: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteCodeOwners(c, params)
+}
+
+// GetCodeOwners operation middleware
+func (siw *ServerInterfaceWrapper) GetCodeOwners(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCodeOwnersParams
+
+	// ------------- Required query parameter "repository" -------------
+
+	if paramValue := c.Query("repository"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument repository is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "repository", c.Request.URL.Query(), &params.Repository)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter repository: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCodeOwners(c, params)
+}
+
+// PutCodeOwners operation middleware
+func (siw *ServerInterfaceWrapper) PutCodeOwners(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutCodeOwnersParams
+
+	// ------------- Required query parameter "repository" -------------
+
+	if paramValue := c.Query("repository"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument repository is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "repository", c.Request.URL.Query(), &params.Repository)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter repository: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutCodeOwners(c, params)
+}
+
+// GetExportPullRequestsCsv operation middleware
+func (siw *ServerInterfaceWrapper) GetExportPullRequestsCsv(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetExportPullRequestsCsv(c)
+}
+
+// GetExportUsersCsv operation middleware
+func (siw *ServerInterfaceWrapper) GetExportUsersCsv(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetExportUsersCsv(c)
+}
+
+// PostImportUsersCsv operation middleware
+func (siw *ServerInterfaceWrapper) PostImportUsersCsv(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostImportUsersCsv(c)
+}
+
 // PostPullRequestCreate operation middleware
 func (siw *ServerInterfaceWrapper) PostPullRequestCreate(c *gin.Context) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestCreateParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKeyHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPullRequestCreate(c, params)
+}
+
+// PostPullRequestMerge operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestMerge(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestMergeParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPullRequestMerge(c, params)
+}
+
+// PostPullRequestPreview operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestPreview(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPullRequestPreview(c)
+}
+
+// PostPullRequestReassign operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReassign(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestReassignParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKeyHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPullRequestReassign(c, params)
+}
+
+// DeleteReviewerRules operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReviewerRules(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteReviewerRulesParams
+
+	// ------------- Required query parameter "author_id" -------------
+
+	if paramValue := c.Query("author_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument author_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "author_id", c.Request.URL.Query(), &params.AuthorId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter author_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "reviewer_id" -------------
+
+	if paramValue := c.Query("reviewer_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument reviewer_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "reviewer_id", c.Request.URL.Query(), &params.ReviewerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reviewer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteReviewerRules(c, params)
+}
+
+// GetReviewerRules operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewerRules(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReviewerRulesParams
+
+	// ------------- Optional query parameter "author_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author_id", c.Request.URL.Query(), &params.AuthorId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter author_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetReviewerRules(c, params)
+}
+
+// PutReviewerRules operation middleware
+func (siw *ServerInterfaceWrapper) PutReviewerRules(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutReviewerRules(c)
+}
+
+// PostTeamAdd operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamAdd(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTeamAddParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKeyHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTeamAdd(c, params)
+}
+
+// GetTeamGet operation middleware
+func (siw *ServerInterfaceWrapper) GetTeamGet(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamGetParams
+
+	// ------------- Required query parameter "team_name" -------------
+
+	if paramValue := c.Query("team_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument team_name is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", c.Request.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter team_name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTeamGet(c, params)
+}
+
+// PostTeamSetMaxOpenReviews operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamSetMaxOpenReviews(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTeamSetMaxOpenReviews(c)
+}
+
+// PostTeamSetReviewPolicy operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamSetReviewPolicy(c *gin.Context) {
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -55,11 +627,24 @@ func (siw *ServerInterfaceWrapper) PostPullRequestCreate(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostPullRequestCreate(c)
+	siw.Handler.PostTeamSetReviewPolicy(c)
 }
 
-// PostPullRequestMerge operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestMerge(c *gin.Context) {
+// PostTeamSync operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamSync(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTeamSyncParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dry_run", c.Request.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dry_run: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -68,11 +653,31 @@ func (siw *ServerInterfaceWrapper) PostPullRequestMerge(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostPullRequestMerge(c)
+	siw.Handler.PostTeamSync(c, params)
 }
 
-// PostPullRequestReassign operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestReassign(c *gin.Context) {
+// GetUsersAliases operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersAliases(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersAliasesParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := c.Query("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -81,11 +686,11 @@ func (siw *ServerInterfaceWrapper) PostPullRequestReassign(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostPullRequestReassign(c)
+	siw.Handler.GetUsersAliases(c, params)
 }
 
-// PostTeamAdd operation middleware
-func (siw *ServerInterfaceWrapper) PostTeamAdd(c *gin.Context) {
+// PutUsersAliases operation middleware
+func (siw *ServerInterfaceWrapper) PutUsersAliases(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -94,29 +699,29 @@ func (siw *ServerInterfaceWrapper) PostTeamAdd(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostTeamAdd(c)
+	siw.Handler.PutUsersAliases(c)
 }
 
-// GetTeamGet operation middleware
-func (siw *ServerInterfaceWrapper) GetTeamGet(c *gin.Context) {
+// GetUsersGetReview operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersGetReview(c *gin.Context) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetTeamGetParams
+	var params GetUsersGetReviewParams
 
-	// ------------- Required query parameter "team_name" -------------
+	// ------------- Required query parameter "user_id" -------------
 
-	if paramValue := c.Query("team_name"); paramValue != "" {
+	if paramValue := c.Query("user_id"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument team_name is required, but not found"), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "team_name", c.Request.URL.Query(), &params.TeamName)
+	err = runtime.BindQueryParameter("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter team_name: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -127,16 +732,16 @@ func (siw *ServerInterfaceWrapper) GetTeamGet(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetTeamGet(c, params)
+	siw.Handler.GetUsersGetReview(c, params)
 }
 
-// GetUsersGetReview operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersGetReview(c *gin.Context) {
+// DeleteUsersSchedule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersSchedule(c *gin.Context) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersGetReviewParams
+	var params DeleteUsersScheduleParams
 
 	// ------------- Required query parameter "user_id" -------------
 
@@ -160,7 +765,53 @@ func (siw *ServerInterfaceWrapper) GetUsersGetReview(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUsersGetReview(c, params)
+	siw.Handler.DeleteUsersSchedule(c, params)
+}
+
+// GetUsersSchedule operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersSchedule(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersScheduleParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := c.Query("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsersSchedule(c, params)
+}
+
+// PutUsersSchedule operation middleware
+func (siw *ServerInterfaceWrapper) PutUsersSchedule(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutUsersSchedule(c)
 }
 
 // PostUsersSetIsActive operation middleware
@@ -176,6 +827,204 @@ func (siw *ServerInterfaceWrapper) PostUsersSetIsActive(c *gin.Context) {
 	siw.Handler.PostUsersSetIsActive(c)
 }
 
+// PostUsersSetLearner operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersSetLearner(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersSetLearner(c)
+}
+
+// PostUsersSetLevel operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersSetLevel(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersSetLevel(c)
+}
+
+// PostUsersSetMaxOpenReviews operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersSetMaxOpenReviews(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersSetMaxOpenReviews(c)
+}
+
+// GetUsersSkills operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersSkills(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersSkillsParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := c.Query("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsersSkills(c, params)
+}
+
+// PutUsersSkills operation middleware
+func (siw *ServerInterfaceWrapper) PutUsersSkills(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutUsersSkills(c)
+}
+
+// DeleteUsersUnavailability operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersUnavailability(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteUsersUnavailabilityParams
+
+	// ------------- Required query parameter "id" -------------
+
+	if paramValue := c.Query("id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "id", c.Request.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteUsersUnavailability(c, params)
+}
+
+// GetUsersUnavailability operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUnavailability(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersUnavailabilityParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := c.Query("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsersUnavailability(c, params)
+}
+
+// PostUsersUnavailability operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUnavailability(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersUnavailability(c)
+}
+
+// PostUsersUnavailabilityImport operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUnavailabilityImport(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUsersUnavailabilityImportParams
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", c.Request.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter category: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "reassign_reviews" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reassign_reviews", c.Request.URL.Query(), &params.ReassignReviews)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reassign_reviews: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersUnavailabilityImport(c, params)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -203,11 +1052,40 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.POST(options.BaseURL+"/admin/restore", wrapper.PostAdminRestore)
+	router.GET(options.BaseURL+"/admin/snapshot", wrapper.GetAdminSnapshot)
+	router.DELETE(options.BaseURL+"/codeOwners", wrapper.DeleteCodeOwners)
+	router.GET(options.BaseURL+"/codeOwners", wrapper.GetCodeOwners)
+	router.PUT(options.BaseURL+"/codeOwners", wrapper.PutCodeOwners)
+	router.GET(options.BaseURL+"/export/pullRequests.csv", wrapper.GetExportPullRequestsCsv)
+	router.GET(options.BaseURL+"/export/users.csv", wrapper.GetExportUsersCsv)
+	router.POST(options.BaseURL+"/import/users.csv", wrapper.PostImportUsersCsv)
 	router.POST(options.BaseURL+"/pullRequest/create", wrapper.PostPullRequestCreate)
 	router.POST(options.BaseURL+"/pullRequest/merge", wrapper.PostPullRequestMerge)
+	router.POST(options.BaseURL+"/pullRequest/preview", wrapper.PostPullRequestPreview)
 	router.POST(options.BaseURL+"/pullRequest/reassign", wrapper.PostPullRequestReassign)
+	router.DELETE(options.BaseURL+"/reviewerRules", wrapper.DeleteReviewerRules)
+	router.GET(options.BaseURL+"/reviewerRules", wrapper.GetReviewerRules)
+	router.PUT(options.BaseURL+"/reviewerRules", wrapper.PutReviewerRules)
 	router.POST(options.BaseURL+"/team/add", wrapper.PostTeamAdd)
 	router.GET(options.BaseURL+"/team/get", wrapper.GetTeamGet)
+	router.POST(options.BaseURL+"/team/setMaxOpenReviews", wrapper.PostTeamSetMaxOpenReviews)
+	router.POST(options.BaseURL+"/team/setReviewPolicy", wrapper.PostTeamSetReviewPolicy)
+	router.POST(options.BaseURL+"/team/sync", wrapper.PostTeamSync)
+	router.GET(options.BaseURL+"/users/aliases", wrapper.GetUsersAliases)
+	router.PUT(options.BaseURL+"/users/aliases", wrapper.PutUsersAliases)
 	router.GET(options.BaseURL+"/users/getReview", wrapper.GetUsersGetReview)
+	router.DELETE(options.BaseURL+"/users/schedule", wrapper.DeleteUsersSchedule)
+	router.GET(options.BaseURL+"/users/schedule", wrapper.GetUsersSchedule)
+	router.PUT(options.BaseURL+"/users/schedule", wrapper.PutUsersSchedule)
 	router.POST(options.BaseURL+"/users/setIsActive", wrapper.PostUsersSetIsActive)
+	router.POST(options.BaseURL+"/users/setLearner", wrapper.PostUsersSetLearner)
+	router.POST(options.BaseURL+"/users/setLevel", wrapper.PostUsersSetLevel)
+	router.POST(options.BaseURL+"/users/setMaxOpenReviews", wrapper.PostUsersSetMaxOpenReviews)
+	router.GET(options.BaseURL+"/users/skills", wrapper.GetUsersSkills)
+	router.PUT(options.BaseURL+"/users/skills", wrapper.PutUsersSkills)
+	router.DELETE(options.BaseURL+"/users/unavailability", wrapper.DeleteUsersUnavailability)
+	router.GET(options.BaseURL+"/users/unavailability", wrapper.GetUsersUnavailability)
+	router.POST(options.BaseURL+"/users/unavailability", wrapper.PostUsersUnavailability)
+	router.POST(options.BaseURL+"/users/unavailability/import", wrapper.PostUsersUnavailabilityImport)
 }
@@ -7,14 +7,49 @@ import (
 	"time"
 )
 
+// Defines values for CandidateExplanationExcludedBy.
+const (
+	AlreadyAssigned CandidateExplanationExcludedBy = "already_assigned"
+	AtCapacity      CandidateExplanationExcludedBy = "at_capacity"
+	Author          CandidateExplanationExcludedBy = "author"
+	Inactive        CandidateExplanationExcludedBy = "inactive"
+	Rule            CandidateExplanationExcludedBy = "rule"
+	SeniorPolicy    CandidateExplanationExcludedBy = "senior_policy"
+	Unavailable     CandidateExplanationExcludedBy = "unavailable"
+)
+
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	ErrorResponseErrorCodeALIASTAKEN               ErrorResponseErrorCode = "ALIAS_TAKEN"
+	ErrorResponseErrorCodeIDEMPOTENCYKEYINPROGRESS ErrorResponseErrorCode = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	ErrorResponseErrorCodeIDEMPOTENCYKEYREUSED     ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrorResponseErrorCodeINVALIDALIAS             ErrorResponseErrorCode = "INVALID_ALIAS"
+	ErrorResponseErrorCodeINVALIDCALENDAR          ErrorResponseErrorCode = "INVALID_CALENDAR"
+	ErrorResponseErrorCodeINVALIDCODEOWNERS        ErrorResponseErrorCode = "INVALID_CODEOWNERS"
+	ErrorResponseErrorCodeINVALIDCSV               ErrorResponseErrorCode = "INVALID_CSV"
+	ErrorResponseErrorCodeINVALIDPERIOD            ErrorResponseErrorCode = "INVALID_PERIOD"
+	ErrorResponseErrorCodeINVALIDPRMETADATA        ErrorResponseErrorCode = "INVALID_PR_METADATA"
+	ErrorResponseErrorCodeINVALIDREVIEWERRULE      ErrorResponseErrorCode = "INVALID_REVIEWER_RULE"
+	ErrorResponseErrorCodeINVALIDREVIEWLIMIT       ErrorResponseErrorCode = "INVALID_REVIEW_LIMIT"
+	ErrorResponseErrorCodeINVALIDROSTER            ErrorResponseErrorCode = "INVALID_ROSTER"
+	ErrorResponseErrorCodeINVALIDSCHEDULE          ErrorResponseErrorCode = "INVALID_SCHEDULE"
+	ErrorResponseErrorCodeINVALIDSKILL             ErrorResponseErrorCode = "INVALID_SKILL"
+	ErrorResponseErrorCodeINVALIDSNAPSHOT          ErrorResponseErrorCode = "INVALID_SNAPSHOT"
+	ErrorResponseErrorCodeINVALIDUSERLEVEL         ErrorResponseErrorCode = "INVALID_USER_LEVEL"
+	ErrorResponseErrorCodeNOCANDIDATE              ErrorResponseErrorCode = "NO_CANDIDATE"
+	ErrorResponseErrorCodeNOTASSIGNED              ErrorResponseErrorCode = "NOT_ASSIGNED"
+	ErrorResponseErrorCodeNOTFOUND                 ErrorResponseErrorCode = "NOT_FOUND"
+	ErrorResponseErrorCodePREXISTS                 ErrorResponseErrorCode = "PR_EXISTS"
+	ErrorResponseErrorCodePRMERGED                 ErrorResponseErrorCode = "PR_MERGED"
+	ErrorResponseErrorCodePRVERSIONCONFLICT        ErrorResponseErrorCode = "PR_VERSION_CONFLICT"
+	ErrorResponseErrorCodeSENIORREQUIRED           ErrorResponseErrorCode = "SENIOR_REQUIRED"
+	ErrorResponseErrorCodeSTORAGENOTEMPTY          ErrorResponseErrorCode = "STORAGE_NOT_EMPTY"
+	ErrorResponseErrorCodeTEAMEXISTS               ErrorResponseErrorCode = "TEAM_EXISTS"
+)
+
+// Defines values for ImportErrorResponseErrorCode.
+const (
+	ImportErrorResponseErrorCodeINVALIDCSV ImportErrorResponseErrorCode = "INVALID_CSV"
 )
 
 // Defines values for PullRequestStatus.
@@ -29,6 +64,131 @@ const (
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for ReviewerRuleKind.
+const (
+	Exclude ReviewerRuleKind = "exclude"
+	Prefer  ReviewerRuleKind = "prefer"
+)
+
+// Defines values for TeamSyncChangeAction.
+const (
+	ActivateUser      TeamSyncChangeAction = "activate_user"
+	CreateTeam        TeamSyncChangeAction = "create_team"
+	CreateUser        TeamSyncChangeAction = "create_user"
+	DeactivateUser    TeamSyncChangeAction = "deactivate_user"
+	MoveUser          TeamSyncChangeAction = "move_user"
+	RenameUser        TeamSyncChangeAction = "rename_user"
+	SetLearner        TeamSyncChangeAction = "set_learner"
+	SetLevel          TeamSyncChangeAction = "set_level"
+	SetMaxOpenReviews TeamSyncChangeAction = "set_max_open_reviews"
+)
+
+// Defines values for UserLevel.
+const (
+	Junior UserLevel = "junior"
+	Lead   UserLevel = "lead"
+	Middle UserLevel = "middle"
+	Senior UserLevel = "senior"
+)
+
+// Defines values for WorkScheduleWorkDays.
+const (
+	FRI WorkScheduleWorkDays = "FRI"
+	MON WorkScheduleWorkDays = "MON"
+	SAT WorkScheduleWorkDays = "SAT"
+	SUN WorkScheduleWorkDays = "SUN"
+	THU WorkScheduleWorkDays = "THU"
+	TUE WorkScheduleWorkDays = "TUE"
+	WED WorkScheduleWorkDays = "WED"
+)
+
+// CalendarImportResponse defines model for CalendarImportResponse.
+type CalendarImportResponse struct {
+	Created int `json:"created"`
+
+	// Deleted Удалённые периоды отменённых и пропавших из календаря событий
+	Deleted int `json:"deleted"`
+
+	// Skipped События нужной категории, которые не удалось импортировать
+	Skipped   []CalendarImportSkip `json:"skipped"`
+	Unchanged int                  `json:"unchanged"`
+	Updated   int                  `json:"updated"`
+}
+
+// CalendarImportSkip defines model for CalendarImportSkip.
+type CalendarImportSkip struct {
+	Reason  string `json:"reason"`
+	Summary string `json:"summary"`
+	Uid     string `json:"uid"`
+}
+
+// CandidateExplanation defines model for CandidateExplanation.
+type CandidateExplanation struct {
+	// ExcludedBy Почему участника нельзя было выбрать: автор PR, уже назначен на PR (в том числе владельцем кода или теневым ревьювером), неактивен, в отпуске, достиг предела открытых ревью, исключён правилом пары или не senior, когда политика команды требует senior. Нет у кандидатов, допущенных к выбору
+	ExcludedBy *CandidateExplanationExcludedBy `json:"excluded_by,omitempty"`
+
+	// Score Множители веса кандидата по факторам выбора, 1 - фактор не повлиял или отключён. Вероятность выбора кандидата пропорциональна weight
+	Score *CandidateScore `json:"score,omitempty"`
+
+	// Selected Кандидат назначен ревьювером
+	Selected *bool `json:"selected,omitempty"`
+
+	// Shadow Кандидат назначен теневым ревьювером
+	Shadow *bool  `json:"shadow,omitempty"`
+	UserId string `json:"user_id"`
+}
+
+// CandidateExplanationExcludedBy Почему участника нельзя было выбрать: автор PR, уже назначен на PR (в том числе владельцем кода или теневым ревьювером), неактивен, в отпуске, достиг предела открытых ревью, исключён правилом пары или не senior, когда политика команды требует senior. Нет у кандидатов, допущенных к выбору
+type CandidateExplanationExcludedBy string
+
+// CandidateScore Множители веса кандидата по факторам выбора, 1 - фактор не повлиял или отключён. Вероятность выбора кандидата пропорциональна weight
+type CandidateScore struct {
+	// Load Открытые ревью и строки в них (REVIEW_BALANCE_LOAD)
+	Load float64 `json:"load"`
+
+	// PairHistory Прошлые ревью PR автора (REVIEW_PAIR_HISTORY_WINDOW)
+	PairHistory float64 `json:"pair_history"`
+
+	// Rule Правило prefer пары автор-ревьювер
+	Rule float64 `json:"rule"`
+
+	// Skills Совпадение навыков с required_skills PR
+	Skills float64 `json:"skills"`
+
+	// Weight Итоговый вес, произведение множителей
+	Weight float64 `json:"weight"`
+
+	// WorkingHours Ожидание начала рабочего времени (REVIEW_PREFER_WORKING_HOURS)
+	WorkingHours float64 `json:"working_hours"`
+}
+
+// CodeOwnerAssignment defines model for CodeOwnerAssignment.
+type CodeOwnerAssignment struct {
+	ReviewerId string        `json:"reviewer_id"`
+	Rule       CodeOwnerRule `json:"rule"`
+}
+
+// CodeOwnerRule defines model for CodeOwnerRule.
+type CodeOwnerRule struct {
+	// Line Номер строки правила в загруженном файле
+	Line    int    `json:"line"`
+	Pattern string `json:"pattern"`
+
+	// Teams Владельцы-команды
+	Teams *[]string `json:"teams,omitempty"`
+
+	// Users Владельцы-пользователи - user_id или псевдонимы
+	Users *[]string `json:"users,omitempty"`
+}
+
+// CodeOwners defines model for CodeOwners.
+type CodeOwners struct {
+	Repository string `json:"repository"`
+
+	// Rules Правила в порядке файла
+	Rules []CodeOwnerRule `json:"rules"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
@@ -40,16 +200,65 @@ type ErrorResponse struct {
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// ImportErrorResponse defines model for ImportErrorResponse.
+type ImportErrorResponse struct {
+	Error struct {
+		Code    ImportErrorResponseErrorCode `json:"code"`
+		Message string                       `json:"message"`
+	} `json:"error"`
+
+	// Rows Ошибки по строкам, пустой при ошибке в заголовке
+	Rows []ImportRowError `json:"rows"`
+}
+
+// ImportErrorResponseErrorCode defines model for ImportErrorResponse.Error.Code.
+type ImportErrorResponseErrorCode string
+
+// ImportRowError defines model for ImportRowError.
+type ImportRowError struct {
+	// Line Номер строки CSV (заголовок - строка 1)
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// PRMetadata Необязательные сведения о размере PR, задаются при создании
+type PRMetadata struct {
+	// Additions Добавленные строки
+	Additions    *int    `json:"additions,omitempty"`
+	BaseBranch   *string `json:"base_branch,omitempty"`
+	ChangedFiles *int    `json:"changed_files,omitempty"`
+
+	// Deletions Удалённые строки
+	Deletions *int      `json:"deletions,omitempty"`
+	Labels    *[]string `json:"labels,omitempty"`
+
+	// Paths Пути изменённых файлов от корня репозитория, по ним назначаются владельцы кода
+	Paths      *[]string `json:"paths,omitempty"`
+	Repository *string   `json:"repository,omitempty"`
+
+	// RequiredSkills Навыки, нужные для ревью. Кандидаты с этими навыками выбираются чаще, тем чаще, чем выше уровень; остальные кандидаты команды тоже могут быть выбраны
+	RequiredSkills *[]string `json:"required_skills,omitempty"`
+}
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
-	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	// AssignedReviewers user_id назначенных ревьюверов (0..2, больше - если так требуют правила CODEOWNERS)
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	CreatedAt         *time.Time `json:"createdAt"`
+	MergedAt          *time.Time `json:"mergedAt"`
+
+	// Metadata Необязательные сведения о размере PR, задаются при создании
+	Metadata        *PRMetadata `json:"metadata,omitempty"`
+	PullRequestId   string      `json:"pull_request_id"`
+	PullRequestName string      `json:"pull_request_name"`
+
+	// ShadowReviewer user_id теневого ревьювера из учеников команды. Он смотрит ревью для обучения, не входит в assigned_reviewers и не учитывается в пределе открытых ревью
+	ShadowReviewer *string           `json:"shadow_reviewer,omitempty"`
+	Status         PullRequestStatus `json:"status"`
+
+	// Version Версия PR, совпадает со значением ETag
+	Version *int `json:"version,omitempty"`
 }
 
 // PullRequestStatus defines model for PullRequest.Status.
@@ -66,38 +275,316 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// ReviewAssignment defines model for ReviewAssignment.
+type ReviewAssignment struct {
+	// AtCapacity Участники команды, пропущенные из-за предела открытых ревью
+	AtCapacity *[]string `json:"at_capacity,omitempty"`
+
+	// Candidates Почему выбраны именно эти ревьюверы: все участники команды автора по user_id с оценками или причиной, по которой их нельзя было выбрать
+	Candidates *[]CandidateExplanation `json:"candidates,omitempty"`
+
+	// CodeOwners Какое правило CODEOWNERS привело к назначению ревьювера
+	CodeOwners *[]CodeOwnerAssignment `json:"code_owners,omitempty"`
+
+	// SeniorMissing Политика команды требует senior или lead, но назначить некого
+	SeniorMissing *bool `json:"senior_missing,omitempty"`
+
+	// UncoveredRules Совпавшие правила, ни одного владельца которых назначить не удалось
+	UncoveredRules *[]CodeOwnerRule `json:"uncovered_rules,omitempty"`
+
+	// UnfilledSlots Сколько мест ревьюверов осталось незанятыми
+	UnfilledSlots int `json:"unfilled_slots"`
+}
+
+// ReviewPolicy Требования команды к уровню ревьюверов PR её участников
+type ReviewPolicy struct {
+	// RequireSenior Среди ревьюверов каждого PR должен быть senior или lead
+	RequireSenior *bool `json:"require_senior,omitempty"`
+
+	// SeniorForJuniors PR автора уровня junior получает senior или lead
+	SeniorForJuniors *bool `json:"senior_for_juniors,omitempty"`
+}
+
+// ReviewPreview defines model for ReviewPreview.
+type ReviewPreview struct {
+	// Alternatives Остальные доступные кандидаты из команды автора по убыванию веса
+	Alternatives []string         `json:"alternatives"`
+	Assignment   ReviewAssignment `json:"assignment"`
+
+	// Reviewers Ревьюверы, которые были бы назначены
+	Reviewers []string `json:"reviewers"`
+
+	// ShadowReviewer Теневой ревьювер из учеников, если нашёлся
+	ShadowReviewer *string `json:"shadow_reviewer,omitempty"`
+}
+
+// ReviewerRule defines model for ReviewerRule.
+type ReviewerRule struct {
+	AuthorId string `json:"author_id"`
+
+	// Kind exclude - ревьювер никогда не назначается на PR автора, prefer - ревьювер выбирается на PR автора чаще
+	Kind ReviewerRuleKind `json:"kind"`
+
+	// Reason Причина правила для людей, на выбор не влияет
+	Reason     *string `json:"reason,omitempty"`
+	ReviewerId string  `json:"reviewer_id"`
+}
+
+// ReviewerRuleKind exclude - ревьювер никогда не назначается на PR автора, prefer - ревьювер выбирается на PR автора чаще
+type ReviewerRuleKind string
+
+// ReviewerRules defines model for ReviewerRules.
+type ReviewerRules struct {
+	// Rules Правила по автору, затем по ревьюверу
+	Rules []ReviewerRule `json:"rules"`
+}
+
+// Snapshot defines model for Snapshot.
+type Snapshot struct {
+	// Aliases Псевдонимы всех пользователей
+	Aliases *[]UserAlias `json:"aliases,omitempty"`
+
+	// CodeOwners Правила CODEOWNERS репозиториев
+	CodeOwners *[]CodeOwners `json:"code_owners,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+
+	// FormatVersion Версия формата снапшота, сейчас 1
+	FormatVersion int `json:"format_version"`
+
+	// PullRequests Все PR с ревьюверами, временем создания и слияния и версией
+	PullRequests []PullRequest `json:"pull_requests"`
+
+	// ReviewerRules Правила пар автор-ревьювер
+	ReviewerRules *[]ReviewerRule `json:"reviewer_rules,omitempty"`
+
+	// Skills Навыки пользователей
+	Skills *[]UserSkills `json:"skills,omitempty"`
+
+	// Teams Все команды, включая команды без участников
+	Teams []Team `json:"teams"`
+
+	// Unavailability Периоды недоступности всех пользователей, id при восстановлении назначаются заново
+	Unavailability *[]Unavailability `json:"unavailability,omitempty"`
+
+	// WorkSchedules Рабочее время пользователей
+	WorkSchedules *[]WorkSchedule `json:"work_schedules,omitempty"`
+}
+
+// SnapshotRestoreResponse defines model for SnapshotRestoreResponse.
+type SnapshotRestoreResponse struct {
+	PullRequests int `json:"pull_requests"`
+	Teams        int `json:"teams"`
+	Users        int `json:"users"`
+}
+
 // Team defines model for Team.
 type Team struct {
-	Members  []TeamMember `json:"members"`
-	TeamName string       `json:"team_name"`
+	// MaxOpenReviews Предел открытых ревью по умолчанию для участников, 0 или отсутствие - без ограничения
+	MaxOpenReviews *int         `json:"max_open_reviews,omitempty"`
+	Members        []TeamMember `json:"members"`
+
+	// ReviewPolicy Требования команды к уровню ревьюверов PR её участников
+	ReviewPolicy *ReviewPolicy `json:"review_policy,omitempty"`
+	TeamName     string        `json:"team_name"`
 }
 
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+
+	// IsLearner Ученик, назначается теневым ревьювером
+	IsLearner *bool `json:"is_learner,omitempty"`
+
+	// Level Уровень участника, отсутствие - не задан
+	Level *UserLevel `json:"level,omitempty"`
+
+	// MaxOpenReviews Предел одновременно открытых ревью, 0 или отсутствие - как у команды
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty"`
+	UserId         string `json:"user_id"`
+	Username       string `json:"username"`
+}
+
+// TeamSyncChange defines model for TeamSyncChange.
+type TeamSyncChange struct {
+	Action TeamSyncChangeAction `json:"action"`
+
+	// FromTeam Прежняя команда пользователя для move_user
+	FromTeam *string `json:"from_team,omitempty"`
+	TeamName string  `json:"team_name"`
+	UserId   *string `json:"user_id,omitempty"`
+	Username *string `json:"username,omitempty"`
+}
+
+// TeamSyncChangeAction defines model for TeamSyncChange.Action.
+type TeamSyncChangeAction string
+
+// TeamSyncRequest defines model for TeamSyncRequest.
+type TeamSyncRequest struct {
+	// Teams Полный состав перечисленных команд
+	Teams []Team `json:"teams"`
+}
+
+// TeamSyncResponse defines model for TeamSyncResponse.
+type TeamSyncResponse struct {
+	Changes []TeamSyncChange `json:"changes"`
+
+	// DryRun true - изменения только рассчитаны и не применены
+	DryRun bool `json:"dry_run"`
+}
+
+// Unavailability defines model for Unavailability.
+type Unavailability struct {
+	// EndsAt Конец периода, не включается в период
+	EndsAt time.Time `json:"ends_at"`
+
+	// ExternalId UID события календаря, из которого импортирован период
+	ExternalId *string `json:"external_id,omitempty"`
+	Id         int64   `json:"id"`
+	Reason     string  `json:"reason"`
+
+	// ReassignReviews Переназначить открытые ревью пользователя, когда период начнётся
+	ReassignReviews bool `json:"reassign_reviews"`
+
+	// ReassignedAt Когда открытые ревью были переназначены
+	ReassignedAt *time.Time `json:"reassigned_at"`
+	StartsAt     time.Time  `json:"starts_at"`
+	UserId       string     `json:"user_id"`
+}
+
+// UnavailabilityCreateRequest defines model for UnavailabilityCreateRequest.
+type UnavailabilityCreateRequest struct {
+	EndsAt          time.Time `json:"ends_at"`
+	Reason          *string   `json:"reason,omitempty"`
+	ReassignReviews *bool     `json:"reassign_reviews,omitempty"`
+	StartsAt        time.Time `json:"starts_at"`
+	UserId          string    `json:"user_id"`
 }
 
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
-	TeamName string `json:"team_name"`
+	IsActive bool `json:"is_active"`
+
+	// IsLearner Ученик, назначается теневым ревьювером
+	IsLearner *bool `json:"is_learner,omitempty"`
+
+	// Level Уровень участника, отсутствие - не задан
+	Level *UserLevel `json:"level,omitempty"`
+
+	// MaxOpenReviews Предел одновременно открытых ревью, 0 или отсутствие - как у команды
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty"`
+	TeamName       string `json:"team_name"`
+	UserId         string `json:"user_id"`
+	Username       string `json:"username"`
+}
+
+// UserAlias defines model for UserAlias.
+type UserAlias struct {
+	Alias  string `json:"alias"`
+	UserId string `json:"user_id"`
+}
+
+// UserAliases defines model for UserAliases.
+type UserAliases struct {
+	// Aliases Email и имена пользователя в календаре, без учёта регистра
+	Aliases []string `json:"aliases"`
+	UserId  string   `json:"user_id"`
+}
+
+// UserImportResponse defines model for UserImportResponse.
+type UserImportResponse struct {
+	Changes []TeamSyncChange `json:"changes"`
+
+	// Rows Количество строк данных в файле
+	Rows int `json:"rows"`
+}
+
+// UserLevel Уровень участника, отсутствие - не задан
+type UserLevel string
+
+// UserSkill defines model for UserSkill.
+type UserSkill struct {
+	// Level Уровень владения, 1 - начальный, 3 - эксперт
+	Level int `json:"level"`
+
+	// Tag Тег навыка без учёта регистра, например go, frontend, sql, k8s
+	Tag string `json:"tag"`
+}
+
+// UserSkills defines model for UserSkills.
+type UserSkills struct {
+	Skills []UserSkill `json:"skills"`
+	UserId string      `json:"user_id"`
+}
+
+// WorkSchedule defines model for WorkSchedule.
+type WorkSchedule struct {
+	// TimeZone Часовой пояс IANA
+	TimeZone string `json:"time_zone"`
 	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+
+	// WorkDays Дни недели, в которые начинается рабочий день. По умолчанию с понедельника по пятницу
+	WorkDays *[]WorkScheduleWorkDays `json:"work_days,omitempty"`
+
+	// WorkEnd Конец рабочего дня, HH:MM. Раньше начала - рабочий день переходит через полночь
+	WorkEnd string `json:"work_end"`
+
+	// WorkStart Начало рабочего дня по местному времени, HH:MM
+	WorkStart string `json:"work_start"`
 }
 
+// WorkScheduleWorkDays defines model for WorkSchedule.WorkDays.
+type WorkScheduleWorkDays string
+
+// IdempotencyKeyHeader defines model for IdempotencyKeyHeader.
+type IdempotencyKeyHeader = string
+
+// IfMatchHeader defines model for IfMatchHeader.
+type IfMatchHeader = string
+
+// RepositoryQuery defines model for RepositoryQuery.
+type RepositoryQuery = string
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// DeleteCodeOwnersParams defines parameters for DeleteCodeOwners.
+type DeleteCodeOwnersParams struct {
+	// Repository Имя репозитория, как в metadata.repository PR
+	Repository RepositoryQuery `form:"repository" json:"repository"`
+}
+
+// GetCodeOwnersParams defines parameters for GetCodeOwners.
+type GetCodeOwnersParams struct {
+	// Repository Имя репозитория, как в metadata.repository PR
+	Repository RepositoryQuery `form:"repository" json:"repository"`
+}
+
+// PutCodeOwnersTextBody defines parameters for PutCodeOwners.
+type PutCodeOwnersTextBody = string
+
+// PutCodeOwnersParams defines parameters for PutCodeOwners.
+type PutCodeOwnersParams struct {
+	// Repository Имя репозитория, как в metadata.repository PR
+	Repository RepositoryQuery `form:"repository" json:"repository"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
-	PullRequestId   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
+	AuthorId string `json:"author_id"`
+
+	// Metadata Необязательные сведения о размере PR, задаются при создании
+	Metadata        *PRMetadata `json:"metadata,omitempty"`
+	PullRequestId   string      `json:"pull_request_id"`
+	PullRequestName string      `json:"pull_request_name"`
+}
+
+// PostPullRequestCreateParams defines parameters for PostPullRequestCreate.
+type PostPullRequestCreateParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повтор запроса с тем же ключом и телом возвращает сохранённый ответ, с тем же ключом и другим телом - 409 IDEMPOTENCY_KEY_REUSED. Пока первый запрос с ключом выполняется, повтор получает 409 IDEMPOTENCY_KEY_IN_PROGRESS с заголовком Retry-After и может быть отправлен снова
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
 }
 
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
@@ -105,41 +592,207 @@ type PostPullRequestMergeJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestMergeParams defines parameters for PostPullRequestMerge.
+type PostPullRequestMergeParams struct {
+	// IfMatch Ожидаемая версия PR (значение ETag из предыдущего ответа)
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
+// PostPullRequestPreviewJSONBody defines parameters for PostPullRequestPreview.
+type PostPullRequestPreviewJSONBody struct {
+	AuthorId string `json:"author_id"`
+
+	// Metadata Необязательные сведения о размере PR, задаются при создании
+	Metadata        *PRMetadata `json:"metadata,omitempty"`
+	PullRequestName *string     `json:"pull_request_name,omitempty"`
+}
+
 // PostPullRequestReassignJSONBody defines parameters for PostPullRequestReassign.
 type PostPullRequestReassignJSONBody struct {
 	OldUserId     string `json:"old_user_id"`
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestReassignParams defines parameters for PostPullRequestReassign.
+type PostPullRequestReassignParams struct {
+	// IfMatch Ожидаемая версия PR (значение ETag из предыдущего ответа)
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+
+	// IdempotencyKey Ключ идемпотентности. Повтор запроса с тем же ключом и телом возвращает сохранённый ответ, с тем же ключом и другим телом - 409 IDEMPOTENCY_KEY_REUSED. Пока первый запрос с ключом выполняется, повтор получает 409 IDEMPOTENCY_KEY_IN_PROGRESS с заголовком Retry-After и может быть отправлен снова
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
+// DeleteReviewerRulesParams defines parameters for DeleteReviewerRules.
+type DeleteReviewerRulesParams struct {
+	AuthorId   string `form:"author_id" json:"author_id"`
+	ReviewerId string `form:"reviewer_id" json:"reviewer_id"`
+}
+
+// GetReviewerRulesParams defines parameters for GetReviewerRules.
+type GetReviewerRulesParams struct {
+	// AuthorId Автор PR, без него возвращаются правила всех авторов
+	AuthorId *string `form:"author_id,omitempty" json:"author_id,omitempty"`
+}
+
+// PostTeamAddParams defines parameters for PostTeamAdd.
+type PostTeamAddParams struct {
+	// IdempotencyKey Ключ идемпотентности. Повтор запроса с тем же ключом и телом возвращает сохранённый ответ, с тем же ключом и другим телом - 409 IDEMPOTENCY_KEY_REUSED. Пока первый запрос с ключом выполняется, повтор получает 409 IDEMPOTENCY_KEY_IN_PROGRESS с заголовком Retry-After и может быть отправлен снова
+	IdempotencyKey *IdempotencyKeyHeader `json:"Idempotency-Key,omitempty"`
+}
+
 // GetTeamGetParams defines parameters for GetTeamGet.
 type GetTeamGetParams struct {
 	// TeamName Уникальное имя команды
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
+// PostTeamSetMaxOpenReviewsJSONBody defines parameters for PostTeamSetMaxOpenReviews.
+type PostTeamSetMaxOpenReviewsJSONBody struct {
+	MaxOpenReviews int    `json:"max_open_reviews"`
+	TeamName       string `json:"team_name"`
+}
+
+// PostTeamSetReviewPolicyJSONBody defines parameters for PostTeamSetReviewPolicy.
+type PostTeamSetReviewPolicyJSONBody struct {
+	RequireSenior    *bool  `json:"require_senior,omitempty"`
+	SeniorForJuniors *bool  `json:"senior_for_juniors,omitempty"`
+	TeamName         string `json:"team_name"`
+}
+
+// PostTeamSyncParams defines parameters for PostTeamSync.
+type PostTeamSyncParams struct {
+	// DryRun Только рассчитать план, ничего не меняя
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// GetUsersAliasesParams defines parameters for GetUsersAliases.
+type GetUsersAliasesParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
 // GetUsersGetReviewParams defines parameters for GetUsersGetReview.
 type GetUsersGetReviewParams struct {
 	// UserId Идентификатор пользователя
 	UserId UserIdQuery `form:"user_id" json:"user_id"`
 }
 
+// DeleteUsersScheduleParams defines parameters for DeleteUsersSchedule.
+type DeleteUsersScheduleParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// GetUsersScheduleParams defines parameters for GetUsersSchedule.
+type GetUsersScheduleParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
 type PostUsersSetIsActiveJSONBody struct {
 	IsActive bool   `json:"is_active"`
 	UserId   string `json:"user_id"`
 }
 
+// PostUsersSetLearnerJSONBody defines parameters for PostUsersSetLearner.
+type PostUsersSetLearnerJSONBody struct {
+	IsLearner bool   `json:"is_learner"`
+	UserId    string `json:"user_id"`
+}
+
+// PostUsersSetLevelJSONBody defines parameters for PostUsersSetLevel.
+type PostUsersSetLevelJSONBody struct {
+	// Level junior, middle, senior, lead или пустая строка
+	Level  string `json:"level"`
+	UserId string `json:"user_id"`
+}
+
+// PostUsersSetMaxOpenReviewsJSONBody defines parameters for PostUsersSetMaxOpenReviews.
+type PostUsersSetMaxOpenReviewsJSONBody struct {
+	MaxOpenReviews int    `json:"max_open_reviews"`
+	UserId         string `json:"user_id"`
+}
+
+// GetUsersSkillsParams defines parameters for GetUsersSkills.
+type GetUsersSkillsParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// DeleteUsersUnavailabilityParams defines parameters for DeleteUsersUnavailability.
+type DeleteUsersUnavailabilityParams struct {
+	Id int64 `form:"id" json:"id"`
+}
+
+// GetUsersUnavailabilityParams defines parameters for GetUsersUnavailability.
+type GetUsersUnavailabilityParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// PostUsersUnavailabilityImportParams defines parameters for PostUsersUnavailabilityImport.
+type PostUsersUnavailabilityImportParams struct {
+	// Category Категория событий отсутствия, по умолчанию ICS_CATEGORY из конфигурации
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// ReassignReviews Значение reassign_reviews для создаваемых периодов
+	ReassignReviews *bool `form:"reassign_reviews,omitempty" json:"reassign_reviews,omitempty"`
+}
+
+// PostAdminRestoreJSONRequestBody defines body for PostAdminRestore for application/json ContentType.
+type PostAdminRestoreJSONRequestBody = Snapshot
+
+// PutCodeOwnersTextRequestBody defines body for PutCodeOwners for text/plain ContentType.
+type PutCodeOwnersTextRequestBody = PutCodeOwnersTextBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
 // PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
 type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
 
+// PostPullRequestPreviewJSONRequestBody defines body for PostPullRequestPreview for application/json ContentType.
+type PostPullRequestPreviewJSONRequestBody PostPullRequestPreviewJSONBody
+
 // PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
 type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 
+// PutReviewerRulesJSONRequestBody defines body for PutReviewerRules for application/json ContentType.
+type PutReviewerRulesJSONRequestBody = ReviewerRule
+
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
 
+// PostTeamSetMaxOpenReviewsJSONRequestBody defines body for PostTeamSetMaxOpenReviews for application/json ContentType.
+type PostTeamSetMaxOpenReviewsJSONRequestBody PostTeamSetMaxOpenReviewsJSONBody
+
+// PostTeamSetReviewPolicyJSONRequestBody defines body for PostTeamSetReviewPolicy for application/json ContentType.
+type PostTeamSetReviewPolicyJSONRequestBody PostTeamSetReviewPolicyJSONBody
+
+// PostTeamSyncJSONRequestBody defines body for PostTeamSync for application/json ContentType.
+type PostTeamSyncJSONRequestBody = TeamSyncRequest
+
+// PutUsersAliasesJSONRequestBody defines body for PutUsersAliases for application/json ContentType.
+type PutUsersAliasesJSONRequestBody = UserAliases
+
+// PutUsersScheduleJSONRequestBody defines body for PutUsersSchedule for application/json ContentType.
+type PutUsersScheduleJSONRequestBody = WorkSchedule
+
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+
+// PostUsersSetLearnerJSONRequestBody defines body for PostUsersSetLearner for application/json ContentType.
+type PostUsersSetLearnerJSONRequestBody PostUsersSetLearnerJSONBody
+
+// PostUsersSetLevelJSONRequestBody defines body for PostUsersSetLevel for application/json ContentType.
+type PostUsersSetLevelJSONRequestBody PostUsersSetLevelJSONBody
+
+// PostUsersSetMaxOpenReviewsJSONRequestBody defines body for PostUsersSetMaxOpenReviews for application/json ContentType.
+type PostUsersSetMaxOpenReviewsJSONRequestBody PostUsersSetMaxOpenReviewsJSONBody
+
+// PutUsersSkillsJSONRequestBody defines body for PutUsersSkills for application/json ContentType.
+type PutUsersSkillsJSONRequestBody = UserSkills
+
+// PostUsersUnavailabilityJSONRequestBody defines body for PostUsersUnavailability for application/json ContentType.
+type PostUsersUnavailabilityJSONRequestBody = UnavailabilityCreateRequest
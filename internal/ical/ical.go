@@ -0,0 +1,361 @@
+// Package ical разбирает календари iCalendar (RFC 5545) в события отсутствий.
+// Поддерживается только то, что нужно для импорта отпусков: VEVENT с датами или
+// временем начала и конца, категориями, статусом, организатором и участниками.
+// Правила повторения (RRULE) не разворачиваются - берётся только первое вхождение
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+)
+
+// ErrNotCalendar возвращается, если данные не начинаются с BEGIN:VCALENDAR
+var ErrNotCalendar = errors.New("not an iCalendar file")
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+)
+
+// property - строка содержимого календаря: NAME;PARAM=VALUE:VALUE
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse возвращает все события VEVENT календаря. Время без часового пояса и даты
+// без времени считаются в поясе X-WR-TIMEZONE календаря, а если его нет - в UTC.
+// Пояса TZID, неизвестные Go (например, имена Windows), тоже заменяются им
+func Parse(data []byte) ([]entity.CalendarEvent, error) {
+	lines, err := unfold(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0].text, "BEGIN:VCALENDAR") {
+		return nil, ErrNotCalendar
+	}
+
+	var (
+		events   []entity.CalendarEvent
+		event    []property
+		stack    []string
+		location = time.UTC
+	)
+	for _, line := range lines {
+		prop, err := parseProperty(line.text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(prop.value))
+			if len(stack) == 2 && stack[1] == "VEVENT" {
+				event = event[:0]
+			}
+			continue
+		case "END":
+			component := strings.ToUpper(prop.value)
+			if len(stack) == 0 || stack[len(stack)-1] != component {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", line.number, prop.value)
+			}
+			stack = stack[:len(stack)-1]
+			if len(stack) == 1 && component == "VEVENT" {
+				parsed, err := buildEvent(event, location)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line.number, err)
+				}
+				events = append(events, parsed)
+			}
+			continue
+		}
+
+		switch {
+		case len(stack) == 1 && prop.name == "X-WR-TIMEZONE":
+			if loc, err := time.LoadLocation(prop.value); err == nil {
+				location = loc
+			}
+		// Свойства вложенных компонентов (VALARM) к событию не относятся
+		case len(stack) == 2 && stack[1] == "VEVENT":
+			event = append(event, prop)
+		}
+	}
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1])
+	}
+	return events, nil
+}
+
+type contentLine struct {
+	number int
+	text   string
+}
+
+// unfold склеивает строки, перенесённые по RFC 5545 (продолжение начинается
+// с пробела или табуляции), и пропускает пустые
+func unfold(data []byte) ([]contentLine, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	var lines []contentLine
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text != "" && (text[0] == ' ' || text[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		lines = append(lines, contentLine{number: number, text: text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// parseProperty разбирает строку содержимого. Значения параметров могут быть
+// в кавычках и тогда содержат ";", ":" и ","
+func parseProperty(text string) (property, error) {
+	prop := property{params: make(map[string]string)}
+
+	i := strings.IndexAny(text, ";:")
+	if i <= 0 {
+		return prop, fmt.Errorf("invalid content line %q", text)
+	}
+	prop.name = strings.ToUpper(text[:i])
+
+	for text[i] == ';' {
+		rest := text[i+1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return prop, fmt.Errorf("invalid parameter in %s", prop.name)
+		}
+		key := strings.ToUpper(rest[:eq])
+		j := i + 1 + eq + 1
+
+		var value strings.Builder
+		for j < len(text) && text[j] != ';' && text[j] != ':' {
+			if text[j] == '"' {
+				end := strings.IndexByte(text[j+1:], '"')
+				if end < 0 {
+					return prop, fmt.Errorf("unterminated quote in %s", prop.name)
+				}
+				value.WriteString(text[j+1 : j+1+end])
+				j += end + 2
+				continue
+			}
+			value.WriteByte(text[j])
+			j++
+		}
+		if j >= len(text) {
+			return prop, fmt.Errorf("missing value of %s", prop.name)
+		}
+		prop.params[key] = value.String()
+		i = j
+	}
+
+	prop.value = text[i+1:]
+	return prop, nil
+}
+
+func buildEvent(props []property, location *time.Location) (entity.CalendarEvent, error) {
+	var (
+		event     entity.CalendarEvent
+		allDay    bool
+		duration  time.Duration
+		organizer *entity.CalendarPerson
+		attendees []entity.CalendarPerson
+		err       error
+	)
+	for _, prop := range props {
+		switch prop.name {
+		case "UID":
+			event.UID = prop.value
+		case "SUMMARY":
+			event.Summary = unescapeText(prop.value)
+		case "STATUS":
+			event.Cancelled = strings.EqualFold(prop.value, "CANCELLED")
+		case "CATEGORIES":
+			for _, category := range splitText(prop.value) {
+				if category = strings.TrimSpace(category); category != "" {
+					event.Categories = append(event.Categories, category)
+				}
+			}
+		case "DTSTART":
+			event.StartsAt, allDay, err = parseTime(prop, location)
+			if err != nil {
+				return event, fmt.Errorf("DTSTART: %w", err)
+			}
+		case "DTEND":
+			event.EndsAt, _, err = parseTime(prop, location)
+			if err != nil {
+				return event, fmt.Errorf("DTEND: %w", err)
+			}
+		case "DURATION":
+			duration, err = parseDuration(prop.value)
+			if err != nil {
+				return event, fmt.Errorf("DURATION: %w", err)
+			}
+		case "ORGANIZER":
+			person := parsePerson(prop)
+			organizer = &person
+		case "ATTENDEE":
+			attendees = append(attendees, parsePerson(prop))
+		}
+	}
+
+	if event.StartsAt.IsZero() {
+		return event, fmt.Errorf("event %q has no DTSTART", event.UID)
+	}
+	// Без DTEND конец задаёт DURATION, а событие на дату длится весь день
+	if event.EndsAt.IsZero() {
+		switch {
+		case duration != 0:
+			event.EndsAt = event.StartsAt.Add(duration)
+		case allDay:
+			event.EndsAt = event.StartsAt.AddDate(0, 0, 1)
+		default:
+			event.EndsAt = event.StartsAt
+		}
+	}
+
+	event.People = attendees
+	if len(event.People) == 0 && organizer != nil {
+		event.People = []entity.CalendarPerson{*organizer}
+	}
+	return event, nil
+}
+
+// parseTime разбирает DATE или DATE-TIME и сообщает, была ли это дата без времени
+func parseTime(prop property, location *time.Location) (time.Time, bool, error) {
+	value := prop.value
+	if tzid, ok := prop.params["TZID"]; ok {
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			location = loc
+		}
+	}
+
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, value, location)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeLayout, strings.TrimSuffix(value, "Z"))
+		return t, false, err
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, value, location)
+	return t, false, err
+}
+
+// parseDuration разбирает длительность вида P1W, P2D, PT8H30M, P1DT12H
+func parseDuration(value string) (time.Duration, error) {
+	rest := strings.TrimPrefix(value, "+")
+	if !strings.HasPrefix(rest, "P") || strings.HasPrefix(value, "-") {
+		return 0, fmt.Errorf("unsupported duration %q", value)
+	}
+	rest = rest[1:]
+
+	var (
+		total  time.Duration
+		inTime bool
+		number string
+	)
+	for _, r := range rest {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+			continue
+		case r == 'T' && !inTime && number == "":
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		number = ""
+		switch {
+		case r == 'W' && !inTime:
+			total += time.Duration(n) * 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			total += time.Duration(n) * 24 * time.Hour
+		case r == 'H' && inTime:
+			total += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			total += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			total += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+	if number != "" || total == 0 || strings.HasSuffix(rest, "T") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return total, nil
+}
+
+func parsePerson(prop property) entity.CalendarPerson {
+	person := entity.CalendarPerson{Name: prop.params["CN"]}
+	if len(prop.value) > len("mailto:") && strings.EqualFold(prop.value[:len("mailto:")], "mailto:") {
+		person.Email = prop.value[len("mailto:"):]
+	}
+	return person
+}
+
+// splitText делит список TEXT по запятым, не являющимся экранированными
+func splitText(value string) []string {
+	var (
+		parts   []string
+		current strings.Builder
+	)
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			current.WriteByte(value[i])
+			current.WriteByte(value[i+1])
+			i++
+		case value[i] == ',':
+			parts = append(parts, unescapeText(current.String()))
+			current.Reset()
+		default:
+			current.WriteByte(value[i])
+		}
+	}
+	return append(parts, unescapeText(current.String()))
+}
+
+func unescapeText(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			result.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			result.WriteByte('\n')
+		default:
+			result.WriteByte(value[i])
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,146 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func calendar(lines ...string) []byte {
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+func TestParse_Events(t *testing.T) {
+	data := calendar(
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"X-WR-TIMEZONE:Europe/Moscow",
+		"BEGIN:VEVENT",
+		"UID:vacation-1@example.com",
+		"SUMMARY:Отпуск\\, море",
+		"CATEGORIES:Vacation,OOO",
+		"DTSTART;VALUE=DATE:20250701",
+		"DTEND;VALUE=DATE:20250715",
+		`ORGANIZER;CN="Doe, Alice":mailto:alice@example.com`,
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DTSTART:20990101T000000Z",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:trip-2@example.com",
+		"SUMMARY:Conference trip with a very long summary that is folded",
+		"  across two lines",
+		"CATEGORIES:Travel",
+		"CATEGORIES:OOO",
+		"STATUS:CANCELLED",
+		"DTSTART;TZID=Europe/Berlin:20250801T090000",
+		"DURATION:P1DT8H",
+		"ORGANIZER:mailto:manager@example.com",
+		"ATTENDEE;CN=Bob;ROLE=REQ-PARTICIPANT:MAILTO:bob@example.com",
+		"ATTENDEE;CN=Carol:mailto:carol@example.com",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:sick-3@example.com",
+		"DTSTART:20250901T060000Z",
+		"DTEND:20250901T150000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	)
+
+	events, err := Parse(data)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	assert.Equal(t, "vacation-1@example.com", events[0].UID)
+	assert.Equal(t, "Отпуск, море", events[0].Summary)
+	assert.Equal(t, []string{"Vacation", "OOO"}, events[0].Categories)
+	assert.True(t, time.Date(2025, 7, 1, 0, 0, 0, 0, moscow).Equal(events[0].StartsAt))
+	assert.True(t, time.Date(2025, 7, 15, 0, 0, 0, 0, moscow).Equal(events[0].EndsAt))
+	assert.False(t, events[0].Cancelled)
+	assert.Equal(t, []entity.CalendarPerson{{Email: "alice@example.com", Name: "Doe, Alice"}}, events[0].People)
+
+	assert.Equal(t, "Conference trip with a very long summary that is folded across two lines", events[1].Summary)
+	assert.Equal(t, []string{"Travel", "OOO"}, events[1].Categories)
+	assert.True(t, events[1].Cancelled)
+	start := time.Date(2025, 8, 1, 9, 0, 0, 0, berlin)
+	assert.True(t, start.Equal(events[1].StartsAt))
+	assert.True(t, start.Add(32*time.Hour).Equal(events[1].EndsAt))
+	// Отсутствуют участники, а не организатор
+	assert.Equal(t, []entity.CalendarPerson{
+		{Email: "bob@example.com", Name: "Bob"},
+		{Email: "carol@example.com", Name: "Carol"},
+	}, events[1].People)
+
+	assert.True(t, time.Date(2025, 9, 1, 6, 0, 0, 0, time.UTC).Equal(events[2].StartsAt))
+	assert.True(t, time.Date(2025, 9, 1, 15, 0, 0, 0, time.UTC).Equal(events[2].EndsAt))
+	assert.Empty(t, events[2].People)
+}
+
+func TestParse_AllDayWithoutEnd(t *testing.T) {
+	events, err := Parse(calendar(
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:day-off",
+		"DTSTART;VALUE=DATE:20250310",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.True(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC).Equal(events[0].StartsAt))
+	assert.True(t, time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC).Equal(events[0].EndsAt))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"not a calendar", []byte("team_name,user_id\nbackend,u1\n")},
+		{"empty", nil},
+		{"missing end", calendar("BEGIN:VCALENDAR", "BEGIN:VEVENT", "UID:1", "DTSTART:20250101")},
+		{"mismatched end", calendar("BEGIN:VCALENDAR", "BEGIN:VEVENT", "END:VCALENDAR")},
+		{"no start", calendar("BEGIN:VCALENDAR", "BEGIN:VEVENT", "UID:1", "END:VEVENT", "END:VCALENDAR")},
+		{"bad time", calendar("BEGIN:VCALENDAR", "BEGIN:VEVENT", "DTSTART:tomorrow", "END:VEVENT", "END:VCALENDAR")},
+		{"bad duration", calendar("BEGIN:VCALENDAR", "BEGIN:VEVENT", "DTSTART:20250101T100000Z",
+			"DURATION:1 hour", "END:VEVENT", "END:VCALENDAR")},
+		{"line without value", calendar("BEGIN:VCALENDAR", "SUMMARY", "END:VCALENDAR")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.data)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"P1W":      7 * 24 * time.Hour,
+		"P2D":      48 * time.Hour,
+		"PT8H30M":  8*time.Hour + 30*time.Minute,
+		"+P1DT12H": 36 * time.Hour,
+		"PT45S":    45 * time.Second,
+	}
+	for value, want := range tests {
+		got, err := parseDuration(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	for _, value := range []string{"", "P", "-P1D", "PT", "P1H", "PT1D", "P1DT"} {
+		_, err := parseDuration(value)
+		assert.Error(t, err, value)
+	}
+}
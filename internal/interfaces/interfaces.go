@@ -2,44 +2,224 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
 )
 
 type Repository interface {
 	Close() error
+	// WithTx выполняет fn в одной транзакции, откатывая её при ошибке
+	WithTx(ctx context.Context, fn func(repo Repository) error) error
+
 	// Users
-	CreateUser(user *entity.User) error
-	FindUserByID(userID string) (*entity.User, error)
-	UpdateUser(user *entity.User) error
-	FindUsersByTeam(teamName string) ([]*entity.User, error)
-	SetActive(userID string, isActive bool) error
+	CreateUser(ctx context.Context, user *entity.User) error
+	FindUserByID(ctx context.Context, userID string) (*entity.User, error)
+	// UpdateUser меняет имя, команду и активность, не трогая MaxOpenReviews, Level и IsLearner
+	UpdateUser(ctx context.Context, user *entity.User) error
+	FindUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error)
+	SetActive(ctx context.Context, userID string, isActive bool) error
+	// ListUsers возвращает всех пользователей, отсортированных по команде и user_id
+	ListUsers(ctx context.Context) ([]*entity.User, error)
+	// SetMaxOpenReviews задаёт предел открытых ревью пользователя, 0 - как у команды
+	SetMaxOpenReviews(ctx context.Context, userID string, limit int) error
+	// SetUserLevel задаёт уровень пользователя, пустой - не задан
+	SetUserLevel(ctx context.Context, userID string, level entity.UserLevel) error
+	SetUserLearner(ctx context.Context, userID string, isLearner bool) error
+	// FindReviewLoads возвращает число и размер открытых PR на ревью у участников команды
+	FindReviewLoads(ctx context.Context, teamName string) (map[string]entity.ReviewLoad, error)
+	// FindPairReviewCounts возвращает, на сколько PR автора, созданных не раньше since,
+	// назначен ревьювером каждый пользователь
+	FindPairReviewCounts(ctx context.Context, authorID string, since time.Time) (map[string]int, error)
+
+	// Unavailability
+	// CreateUnavailability сохраняет период и заполняет его ID
+	CreateUnavailability(ctx context.Context, period *entity.Unavailability) error
+	// FindUnavailabilitiesByUser возвращает периоды пользователя по возрастанию начала
+	FindUnavailabilitiesByUser(ctx context.Context, userID string) ([]*entity.Unavailability, error)
+	// ListUnavailabilities возвращает периоды всех пользователей по user_id и началу
+	ListUnavailabilities(ctx context.Context) ([]*entity.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, id int64) error
+	// FindUnavailableUserIDs возвращает участников команды, недоступных в момент at
+	FindUnavailableUserIDs(ctx context.Context, teamName string, at time.Time) ([]string, error)
+	// FindPendingReassignments возвращает идущие в момент at периоды с ReassignReviews,
+	// ревью по которым ещё не переназначены
+	FindPendingReassignments(ctx context.Context, at time.Time) ([]*entity.Unavailability, error)
+	MarkUnavailabilityReassigned(ctx context.Context, id int64, at time.Time) error
+	// UpdateUnavailability перезаписывает все поля периода с ID period.ID
+	UpdateUnavailability(ctx context.Context, period *entity.Unavailability) error
+
+	// Aliases
+	// SetUserAliases заменяет псевдонимы пользователя на aliases
+	SetUserAliases(ctx context.Context, userID string, aliases []string) error
+	// FindUserAliases возвращает псевдонимы пользователя по алфавиту
+	FindUserAliases(ctx context.Context, userID string) ([]string, error)
+	// ListUserAliases возвращает псевдонимы всех пользователей по user_id и псевдониму
+	ListUserAliases(ctx context.Context) ([]entity.UserAlias, error)
+	// FindUserIDByAlias возвращает владельца псевдонима или ErrNoUser
+	FindUserIDByAlias(ctx context.Context, alias string) (string, error)
+
+	// Work schedules
+	// SetWorkSchedule создаёт или заменяет рабочее время пользователя
+	SetWorkSchedule(ctx context.Context, schedule *entity.WorkSchedule) error
+	// FindWorkSchedule возвращает рабочее время пользователя или ErrNoWorkSchedule
+	FindWorkSchedule(ctx context.Context, userID string) (*entity.WorkSchedule, error)
+	// FindWorkSchedulesByTeam возвращает рабочее время участников команды, у которых оно задано
+	FindWorkSchedulesByTeam(ctx context.Context, teamName string) ([]*entity.WorkSchedule, error)
+	// ListWorkSchedules возвращает рабочее время всех пользователей по user_id
+	ListWorkSchedules(ctx context.Context) ([]*entity.WorkSchedule, error)
+	DeleteWorkSchedule(ctx context.Context, userID string) error
+
+	// Skills
+	// SetUserSkills заменяет навыки пользователя на skills, UserID в skills не учитывается
+	SetUserSkills(ctx context.Context, userID string, skills []entity.UserSkill) error
+	// FindUserSkills возвращает навыки пользователя по тегу
+	FindUserSkills(ctx context.Context, userID string) ([]entity.UserSkill, error)
+	// FindSkillsByTeam возвращает навыки участников команды по user_id и тегу
+	FindSkillsByTeam(ctx context.Context, teamName string) ([]entity.UserSkill, error)
+	// ListUserSkills возвращает навыки всех пользователей по user_id и тегу
+	ListUserSkills(ctx context.Context) ([]entity.UserSkill, error)
+
+	// Code owners
+	// SetCodeOwners заменяет правила репозитория owners.Repository на owners.Rules
+	SetCodeOwners(ctx context.Context, owners *entity.CodeOwners) error
+	// FindCodeOwners возвращает правила репозитория в порядке строк файла или ErrNoCodeOwners
+	FindCodeOwners(ctx context.Context, repository string) (*entity.CodeOwners, error)
+	// ListCodeOwners возвращает правила всех репозиториев по имени репозитория
+	ListCodeOwners(ctx context.Context) ([]*entity.CodeOwners, error)
+	DeleteCodeOwners(ctx context.Context, repository string) error
+
+	// Reviewer rules
+	// SetReviewerRule создаёт или заменяет правило пары rule.AuthorID и rule.ReviewerID
+	SetReviewerRule(ctx context.Context, rule *entity.ReviewerRule) error
+	// FindReviewerRulesByAuthor возвращает правила для PR автора по reviewer_id
+	FindReviewerRulesByAuthor(ctx context.Context, authorID string) ([]entity.ReviewerRule, error)
+	// ListReviewerRules возвращает все правила по author_id и reviewer_id
+	ListReviewerRules(ctx context.Context) ([]entity.ReviewerRule, error)
+	// DeleteReviewerRule удаляет правило пары или возвращает ErrNoReviewerRule
+	DeleteReviewerRule(ctx context.Context, authorID, reviewerID string) error
 
 	// Teams
-	CreateTeam(team *entity.Team) error
-	FindTeamByName(teamName string) (*entity.Team, error)
-	TeamExists(teamName string) bool
+	CreateTeam(ctx context.Context, team *entity.Team) error
+	FindTeamByName(ctx context.Context, teamName string) (*entity.Team, error)
+	TeamExists(ctx context.Context, teamName string) bool
+	// SetTeamMaxOpenReviews задаёт предел открытых ревью по умолчанию, 0 - без ограничения
+	SetTeamMaxOpenReviews(ctx context.Context, teamName string, limit int) error
+	SetTeamReviewPolicy(ctx context.Context, teamName string, policy entity.ReviewPolicy) error
+	// ListTeams возвращает все команды, включая пустые, отсортированные по имени
+	ListTeams(ctx context.Context) ([]*entity.Team, error)
 
 	// PRs
-	CreatePR(pr *entity.PullRequest) error
-	FindPRByID(prID string) (*entity.PullRequest, error)
-	UpdatePR(pr *entity.PullRequest) error
-	FindPRsByReviewer(userID string) ([]*entity.PullRequest, error)
+	CreatePR(ctx context.Context, pr *entity.PullRequest) error
+	FindPRByID(ctx context.Context, prID string) (*entity.PullRequest, error)
+	FindPRByIDForUpdate(ctx context.Context, prID string) (*entity.PullRequest, error)
+	UpdatePR(ctx context.Context, pr *entity.PullRequest) error
+	FindPRsByReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error)
+	// FindPRsByShadowReviewer возвращает PR, где пользователь теневой ревьювер, от новых к старым
+	FindPRsByShadowReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error)
+	// ListPRs возвращает все PR от старых к новым (при равном created_at - по pull_request_id)
+	ListPRs(ctx context.Context) ([]*entity.PullRequest, error)
+	// RestorePR сохраняет PR как есть, вместе со статусом, временем, версией и ревьюверами
+	RestorePR(ctx context.Context, pr *entity.PullRequest) error
+
+	// Idempotency keys
+	// FindIdempotencyRecord возвращает nil, nil если ключа нет или он истёк
+	FindIdempotencyRecord(ctx context.Context, key, endpoint string) (*entity.IdempotencyRecord, error)
+	// ReserveIdempotencyKey сохраняет незавершённую запись, если ключа ещё нет, и сообщает,
+	// удалось ли её сохранить. Существующая запись, даже истёкшая, не перезаписывается
+	ReserveIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) (bool, error)
+	// CompleteIdempotencyRecord записывает ответ в зарезервированную запись с тем же телом запроса
+	CompleteIdempotencyRecord(ctx context.Context, record *entity.IdempotencyRecord) error
+	// ReleaseIdempotencyKey удаляет незавершённую запись, завершённые не трогает
+	ReleaseIdempotencyKey(ctx context.Context, key, endpoint string) error
+	DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error)
 }
 
 type Service interface {
 	// Teams
-	CreateTeam(team *entity.Team) error
-	GetTeam(teamName string) (*entity.Team, error)
+	CreateTeam(ctx context.Context, team *entity.Team) error
+	GetTeam(ctx context.Context, teamName string) (*entity.Team, error)
+	// SyncTeams приводит состав перечисленных команд к roster, при dryRun только возвращает план
+	SyncTeams(ctx context.Context, roster []entity.Team, dryRun bool) ([]entity.TeamSyncChange, error)
+	// SetTeamMaxOpenReviews задаёт предел открытых ревью по умолчанию, 0 - без ограничения
+	SetTeamMaxOpenReviews(ctx context.Context, teamName string, limit int) (*entity.Team, error)
+	// SetTeamReviewPolicy задаёт требования к уровню ревьюверов PR участников команды
+	SetTeamReviewPolicy(ctx context.Context, teamName string, policy entity.ReviewPolicy) (*entity.Team, error)
 
 	// Users
-	SetUserActive(userID string, isActive bool) (*entity.User, error)
-	GetUserReviews(userID string) ([]*entity.PullRequest, error)
+	SetUserActive(ctx context.Context, userID string, isActive bool) (*entity.User, error)
+	GetUserReviews(ctx context.Context, userID string) ([]*entity.PullRequest, error)
+	// GetUserShadowReviews возвращает PR, где пользователь теневой ревьювер
+	GetUserShadowReviews(ctx context.Context, userID string) ([]*entity.PullRequest, error)
+	// ImportUsers создаёт и обновляет пользователей одной транзакцией, ошибки строк - *service.ImportError
+	ImportUsers(ctx context.Context, users []entity.User) ([]entity.TeamSyncChange, error)
+	ListUsers(ctx context.Context) ([]*entity.User, error)
+	// SetUserMaxOpenReviews задаёт предел открытых ревью пользователя, 0 - как у команды
+	SetUserMaxOpenReviews(ctx context.Context, userID string, limit int) (*entity.User, error)
+	// SetUserLevel задаёт уровень пользователя, пустой - не задан
+	SetUserLevel(ctx context.Context, userID string, level entity.UserLevel) (*entity.User, error)
+	// SetUserLearner отмечает пользователя учеником, которого назначают теневым ревьювером
+	SetUserLearner(ctx context.Context, userID string, isLearner bool) (*entity.User, error)
+
+	// Unavailability
+	// AddUnavailability сохраняет период и сразу переназначает ревью, если период уже идёт
+	AddUnavailability(ctx context.Context, period *entity.Unavailability) error
+	GetUnavailability(ctx context.Context, userID string) ([]*entity.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, id int64) error
+	// ReassignUnavailableReviews обрабатывает начавшиеся к now периоды, возвращает число переназначенных ревью
+	ReassignUnavailableReviews(ctx context.Context, now time.Time) (int, error)
+	// ImportCalendar создаёт и обновляет периоды по событиям календаря отсутствий
+	ImportCalendar(ctx context.Context, events []entity.CalendarEvent, opts entity.CalendarImportOptions) (*entity.CalendarImportResult, error)
+
+	// Aliases
+	// SetUserAliases заменяет псевдонимы пользователя, возвращает их в нормализованном виде
+	SetUserAliases(ctx context.Context, userID string, aliases []string) ([]string, error)
+	GetUserAliases(ctx context.Context, userID string) ([]string, error)
+
+	// Work schedules
+	SetWorkSchedule(ctx context.Context, schedule *entity.WorkSchedule) error
+	GetWorkSchedule(ctx context.Context, userID string) (*entity.WorkSchedule, error)
+	DeleteWorkSchedule(ctx context.Context, userID string) error
+
+	// Skills
+	// SetUserSkills заменяет навыки пользователя, возвращает их в нормализованном виде по тегу
+	SetUserSkills(ctx context.Context, userID string, skills []entity.UserSkill) ([]entity.UserSkill, error)
+	GetUserSkills(ctx context.Context, userID string) ([]entity.UserSkill, error)
+
+	// Code owners
+	// SetCodeOwners заменяет правила CODEOWNERS репозитория, пустой список правил их удаляет
+	SetCodeOwners(ctx context.Context, owners *entity.CodeOwners) error
+	GetCodeOwners(ctx context.Context, repository string) (*entity.CodeOwners, error)
+	DeleteCodeOwners(ctx context.Context, repository string) error
+
+	// Reviewer rules
+	// SetReviewerRule создаёт или заменяет правило пары автор-ревьювер
+	SetReviewerRule(ctx context.Context, rule *entity.ReviewerRule) error
+	// GetReviewerRules возвращает правила для PR автора, с пустым authorID - все правила
+	GetReviewerRules(ctx context.Context, authorID string) ([]entity.ReviewerRule, error)
+	DeleteReviewerRule(ctx context.Context, authorID, reviewerID string) error
 
 	// PRs
-	CreatePR(pr *entity.PullRequest) error
-	MergePR(prID string) (*entity.PullRequest, error)
-	ReassignReviewer(prID, oldUserID string) (*entity.PullRequest, string, error)
+	// CreatePR сохраняет PR с назначенными ревьюверами и сообщает, сколько мест осталось незанятыми
+	CreatePR(ctx context.Context, pr *entity.PullRequest) (*entity.ReviewAssignment, error)
+	// PreviewPR подбирает ревьюверов для PR так же, как CreatePR, но ничего не сохраняет
+	PreviewPR(ctx context.Context, pr *entity.PullRequest) (*entity.ReviewPreview, error)
+	// expectedVersion - ожидаемая версия PR (If-Match), 0 отключает проверку
+	MergePR(ctx context.Context, prID string, expectedVersion int) (*entity.PullRequest, error)
+	ReassignReviewer(ctx context.Context, prID, oldUserID string, expectedVersion int) (*entity.PullRequest, *entity.Reassignment, error)
+	ListPRs(ctx context.Context) ([]*entity.PullRequest, error)
+
+	// Snapshots
+	ExportSnapshot(ctx context.Context) (*entity.Snapshot, error)
+	// RestoreSnapshot восстанавливает снапшот в пустое хранилище, иначе ErrStorageNotEmpty
+	RestoreSnapshot(ctx context.Context, snap *entity.Snapshot) error
+
+	// Idempotency keys
+	// BeginIdempotentRequest резервирует ключ за запросом или возвращает сохранённый ответ для повтора
+	BeginIdempotentRequest(ctx context.Context, key, endpoint, requestHash string) (*entity.IdempotencyRecord, error)
+	SaveIdempotentResponse(ctx context.Context, record *entity.IdempotencyRecord) error
+	// ReleaseIdempotencyKey снимает резерв с ключа, если запрос завершился без сохранённого ответа
+	ReleaseIdempotencyKey(ctx context.Context, key, endpoint string) error
 }
 
 type Server interface {
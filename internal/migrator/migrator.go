@@ -0,0 +1,323 @@
+// Package migrator применяет встроенные миграции из пакета migrations и хранит
+// применённые версии в таблице schema_migrations
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+	"github.com/pozedorum/set_pr_reviers_service/migrations"
+)
+
+var (
+	ErrNoDownMigration = errors.New("down migration is missing")
+	ErrUnknownVersion  = errors.New("database has a migration version unknown to this binary")
+)
+
+// migrationLockID - ключ pg_advisory_lock, общий для всех реплик сервиса
+const migrationLockID int64 = 0x70725f726576 // "pr_rev"
+
+// Dialect описывает отличия СУБД, которые важны для применения миграций
+type Dialect struct {
+	Name string
+	fsys fs.FS
+	dir  string
+	// placeholder возвращает n-й параметр запроса
+	placeholder func(n int) string
+	// lock и unlock не дают нескольким процессам применять миграции одновременно
+	lock   func(ctx context.Context, conn *sql.Conn) error
+	unlock func(ctx context.Context, conn *sql.Conn) error
+}
+
+var Postgres = Dialect{
+	Name:        "postgres",
+	fsys:        migrations.Postgres,
+	dir:         "postgres",
+	placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+	lock: func(ctx context.Context, conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID)
+		return err
+	},
+	unlock: func(ctx context.Context, conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationLockID)
+		return err
+	},
+}
+
+// SQLite не нуждается в блокировке: базу открывает один процесс,
+// а запись сериализуется самой SQLite
+var SQLite = Dialect{
+	Name:        "sqlite",
+	fsys:        migrations.SQLite,
+	dir:         "sqlite",
+	placeholder: func(int) string { return "?" },
+	lock:        func(context.Context, *sql.Conn) error { return nil },
+	unlock:      func(context.Context, *sql.Conn) error { return nil },
+}
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+	logger     interfaces.Logger
+}
+
+func New(db *sql.DB, dialect Dialect, logger interfaces.Logger) (*Migrator, error) {
+	list, err := load(dialect.fsys, dialect.dir)
+	if err != nil {
+		return nil, fmt.Errorf("load %s migrations: %w", dialect.Name, err)
+	}
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: list,
+		logger:     logger,
+	}, nil
+}
+
+var fileNameRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := fileNameRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file name %q", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+
+		script, err := fs.ReadFile(fsys, dir+"/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names: %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(script)
+		} else {
+			m.Down = string(script)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// Latest возвращает версию последней встроенной миграции
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up применяет все непримененные миграции и возвращает их количество
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if current > m.Latest() {
+			return fmt.Errorf("%w: %d", ErrUnknownVersion, current)
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version <= current {
+				continue
+			}
+			start := time.Now()
+			err := m.inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return err
+				}
+				query := fmt.Sprintf(`INSERT INTO schema_migrations (version, name) VALUES (%s, %s)`,
+					m.dialect.placeholder(1), m.dialect.placeholder(2))
+				_, err := tx.ExecContext(ctx, query, migration.Version, migration.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied++
+			m.logger.Info("MIGRATOR_UP", "Migration applied",
+				"dialect", m.dialect.Name,
+				"version", migration.Version,
+				"name", migration.Name,
+				"duration_ms", time.Since(start).Milliseconds())
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down откатывает steps последних применённых миграций
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if migration.Version > current {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("revert migration %d_%s: %w", migration.Version, migration.Name, ErrNoDownMigration)
+			}
+			start := time.Now()
+			err := m.inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+					return err
+				}
+				query := fmt.Sprintf(`DELETE FROM schema_migrations WHERE version = %s`, m.dialect.placeholder(1))
+				_, err := tx.ExecContext(ctx, query, migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("revert migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted++
+			m.logger.Info("MIGRATOR_DOWN", "Migration reverted",
+				"dialect", m.dialect.Name,
+				"version", migration.Version,
+				"name", migration.Name,
+				"duration_ms", time.Since(start).Milliseconds())
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Version возвращает последнюю применённую версию (0 - миграций нет)
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	version := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		var err error
+		version, err = m.currentVersion(ctx, conn)
+		return err
+	})
+	return version, err
+}
+
+// Baseline отмечает миграции до version включительно как применённые, если
+// таблица schema_migrations пуста. Нужен для баз, схема которых была создана
+// до появления версионирования
+func (m *Migrator) Baseline(ctx context.Context, version int) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.currentVersion(ctx, conn)
+		if err != nil || current > 0 {
+			return err
+		}
+		return m.inTx(ctx, conn, func(tx *sql.Tx) error {
+			query := fmt.Sprintf(`INSERT INTO schema_migrations (version, name) VALUES (%s, %s)`,
+				m.dialect.placeholder(1), m.dialect.placeholder(2))
+			for _, migration := range m.migrations {
+				if migration.Version > version {
+					break
+				}
+				if _, err := tx.ExecContext(ctx, query, migration.Version, migration.Name); err != nil {
+					return fmt.Errorf("baseline migration %d: %w", migration.Version, err)
+				}
+			}
+			m.logger.Info("MIGRATOR_BASELINE", "Existing schema marked as migrated",
+				"dialect", m.dialect.Name,
+				"version", version)
+			return nil
+		})
+	})
+}
+
+// withLock выполняет fn на отдельном соединении под блокировкой миграций
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get connection: %w", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			m.logger.Error("MIGRATOR", "failed to close connection", "error", err)
+		}
+	}()
+
+	if err := m.dialect.lock(ctx, conn); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Блокировку снимаем и при отменённом ctx, иначе она останется до закрытия соединения
+		if err := m.dialect.unlock(context.Background(), conn); err != nil {
+			m.logger.Error("MIGRATOR", "failed to release migration lock", "error", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) currentVersion(ctx context.Context, conn *sql.Conn) (int, error) {
+	var version sql.NullInt64
+	if err := conn.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+func (m *Migrator) inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			m.logger.Error("MIGRATOR", "failed to rollback transaction", "error", rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
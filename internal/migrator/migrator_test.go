@@ -0,0 +1,119 @@
+package migrator_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/migrator"
+	"github.com/pozedorum/set_pr_reviers_service/internal/repository/sqlite"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCtx = context.Background()
+
+func newTestMigrator(t *testing.T) (*sql.DB, *migrator.Migrator) {
+	t.Helper()
+	testLogger, err := logger.NewLogger("pr-service-test", "logger_for_tests")
+	require.NoError(t, err)
+
+	db, err := sqlite.OpenDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	m, err := migrator.New(db, migrator.SQLite, testLogger)
+	require.NoError(t, err)
+	return db, m
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, name).Scan(&count)
+	require.NoError(t, err)
+	return count > 0
+}
+
+func columnExists(t *testing.T, db *sql.DB, table, column string) bool {
+	t.Helper()
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&count)
+	require.NoError(t, err)
+	return count > 0
+}
+
+func TestUp_AppliesAllMigrations(t *testing.T) {
+	db, m := newTestMigrator(t)
+
+	applied, err := m.Up(testCtx)
+	require.NoError(t, err)
+	assert.Equal(t, m.Latest(), applied)
+	assert.True(t, tableExists(t, db, "reviewer_rules"))
+
+	version, err := m.Version(testCtx)
+	require.NoError(t, err)
+	assert.Equal(t, m.Latest(), version)
+
+	// Повторный запуск ничего не применяет
+	applied, err = m.Up(testCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, applied)
+}
+
+func TestDown_RevertsSteps(t *testing.T) {
+	db, m := newTestMigrator(t)
+	_, err := m.Up(testCtx)
+	require.NoError(t, err)
+
+	reverted, err := m.Down(testCtx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reverted)
+	assert.False(t, tableExists(t, db, "reviewer_rules"))
+	assert.True(t, columnExists(t, db, "pull_requests", "shadow_reviewer_id"))
+
+	version, err := m.Version(testCtx)
+	require.NoError(t, err)
+	assert.Equal(t, m.Latest()-1, version)
+
+	// Шагов больше, чем применённых миграций: откатываются все
+	reverted, err = m.Down(testCtx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, m.Latest()-1, reverted)
+	assert.False(t, tableExists(t, db, "teams"))
+
+	applied, err := m.Up(testCtx)
+	require.NoError(t, err)
+	assert.Equal(t, m.Latest(), applied)
+	assert.True(t, tableExists(t, db, "teams"))
+}
+
+func TestBaseline_MarksExistingSchema(t *testing.T) {
+	_, m := newTestMigrator(t)
+
+	require.NoError(t, m.Baseline(testCtx, 2))
+	version, err := m.Version(testCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	// Для непустой schema_migrations Baseline ничего не делает
+	require.NoError(t, m.Baseline(testCtx, 1))
+	version, err = m.Version(testCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+}
+
+func TestUp_UnknownVersion(t *testing.T) {
+	db, m := newTestMigrator(t)
+	_, err := m.Up(testCtx)
+	require.NoError(t, err)
+
+	// База мигрирована более новой версией сервиса
+	_, err = db.Exec(`INSERT INTO schema_migrations (version, name) VALUES (?, ?)`, m.Latest()+1, "future")
+	require.NoError(t, err)
+
+	_, err = m.Up(testCtx)
+	assert.ErrorIs(t, err, migrator.ErrUnknownVersion)
+}
@@ -3,9 +3,14 @@
 package mocks
 
 import (
+	context "context"
+
 	entity "github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	interfaces "github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -66,17 +71,17 @@ func (_c *Repository_Close_Call) RunAndReturn(run func() error) *Repository_Clos
 	return _c
 }
 
-// ClosePR provides a mock function with given fields: pr
-func (_m *Repository) ClosePR(pr *entity.PullRequest) error {
-	ret := _m.Called(pr)
+// CompleteIdempotencyRecord provides a mock function with given fields: ctx, record
+func (_m *Repository) CompleteIdempotencyRecord(ctx context.Context, record *entity.IdempotencyRecord) error {
+	ret := _m.Called(ctx, record)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ClosePR")
+		panic("no return value specified for CompleteIdempotencyRecord")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entity.PullRequest) error); ok {
-		r0 = rf(pr)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.IdempotencyRecord) error); ok {
+		r0 = rf(ctx, record)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -84,45 +89,46 @@ func (_m *Repository) ClosePR(pr *entity.PullRequest) error {
 	return r0
 }
 
-// Repository_ClosePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClosePR'
-type Repository_ClosePR_Call struct {
+// Repository_CompleteIdempotencyRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteIdempotencyRecord'
+type Repository_CompleteIdempotencyRecord_Call struct {
 	*mock.Call
 }
 
-// ClosePR is a helper method to define mock.On call
-//   - pr *entity.PullRequest
-func (_e *Repository_Expecter) ClosePR(pr interface{}) *Repository_ClosePR_Call {
-	return &Repository_ClosePR_Call{Call: _e.mock.On("ClosePR", pr)}
+// CompleteIdempotencyRecord is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record *entity.IdempotencyRecord
+func (_e *Repository_Expecter) CompleteIdempotencyRecord(ctx interface{}, record interface{}) *Repository_CompleteIdempotencyRecord_Call {
+	return &Repository_CompleteIdempotencyRecord_Call{Call: _e.mock.On("CompleteIdempotencyRecord", ctx, record)}
 }
 
-func (_c *Repository_ClosePR_Call) Run(run func(pr *entity.PullRequest)) *Repository_ClosePR_Call {
+func (_c *Repository_CompleteIdempotencyRecord_Call) Run(run func(ctx context.Context, record *entity.IdempotencyRecord)) *Repository_CompleteIdempotencyRecord_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*entity.PullRequest))
+		run(args[0].(context.Context), args[1].(*entity.IdempotencyRecord))
 	})
 	return _c
 }
 
-func (_c *Repository_ClosePR_Call) Return(_a0 error) *Repository_ClosePR_Call {
+func (_c *Repository_CompleteIdempotencyRecord_Call) Return(_a0 error) *Repository_CompleteIdempotencyRecord_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Repository_ClosePR_Call) RunAndReturn(run func(*entity.PullRequest) error) *Repository_ClosePR_Call {
+func (_c *Repository_CompleteIdempotencyRecord_Call) RunAndReturn(run func(context.Context, *entity.IdempotencyRecord) error) *Repository_CompleteIdempotencyRecord_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreatePR provides a mock function with given fields: pr
-func (_m *Repository) CreatePR(pr *entity.PullRequest) error {
-	ret := _m.Called(pr)
+// CreatePR provides a mock function with given fields: ctx, pr
+func (_m *Repository) CreatePR(ctx context.Context, pr *entity.PullRequest) error {
+	ret := _m.Called(ctx, pr)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreatePR")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entity.PullRequest) error); ok {
-		r0 = rf(pr)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PullRequest) error); ok {
+		r0 = rf(ctx, pr)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -136,14 +142,15 @@ type Repository_CreatePR_Call struct {
 }
 
 // CreatePR is a helper method to define mock.On call
+//   - ctx context.Context
 //   - pr *entity.PullRequest
-func (_e *Repository_Expecter) CreatePR(pr interface{}) *Repository_CreatePR_Call {
-	return &Repository_CreatePR_Call{Call: _e.mock.On("CreatePR", pr)}
+func (_e *Repository_Expecter) CreatePR(ctx interface{}, pr interface{}) *Repository_CreatePR_Call {
+	return &Repository_CreatePR_Call{Call: _e.mock.On("CreatePR", ctx, pr)}
 }
 
-func (_c *Repository_CreatePR_Call) Run(run func(pr *entity.PullRequest)) *Repository_CreatePR_Call {
+func (_c *Repository_CreatePR_Call) Run(run func(ctx context.Context, pr *entity.PullRequest)) *Repository_CreatePR_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*entity.PullRequest))
+		run(args[0].(context.Context), args[1].(*entity.PullRequest))
 	})
 	return _c
 }
@@ -153,22 +160,22 @@ func (_c *Repository_CreatePR_Call) Return(_a0 error) *Repository_CreatePR_Call
 	return _c
 }
 
-func (_c *Repository_CreatePR_Call) RunAndReturn(run func(*entity.PullRequest) error) *Repository_CreatePR_Call {
+func (_c *Repository_CreatePR_Call) RunAndReturn(run func(context.Context, *entity.PullRequest) error) *Repository_CreatePR_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateTeam provides a mock function with given fields: team
-func (_m *Repository) CreateTeam(team *entity.Team) error {
-	ret := _m.Called(team)
+// CreateTeam provides a mock function with given fields: ctx, team
+func (_m *Repository) CreateTeam(ctx context.Context, team *entity.Team) error {
+	ret := _m.Called(ctx, team)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTeam")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entity.Team) error); ok {
-		r0 = rf(team)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Team) error); ok {
+		r0 = rf(ctx, team)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -182,14 +189,15 @@ type Repository_CreateTeam_Call struct {
 }
 
 // CreateTeam is a helper method to define mock.On call
+//   - ctx context.Context
 //   - team *entity.Team
-func (_e *Repository_Expecter) CreateTeam(team interface{}) *Repository_CreateTeam_Call {
-	return &Repository_CreateTeam_Call{Call: _e.mock.On("CreateTeam", team)}
+func (_e *Repository_Expecter) CreateTeam(ctx interface{}, team interface{}) *Repository_CreateTeam_Call {
+	return &Repository_CreateTeam_Call{Call: _e.mock.On("CreateTeam", ctx, team)}
 }
 
-func (_c *Repository_CreateTeam_Call) Run(run func(team *entity.Team)) *Repository_CreateTeam_Call {
+func (_c *Repository_CreateTeam_Call) Run(run func(ctx context.Context, team *entity.Team)) *Repository_CreateTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*entity.Team))
+		run(args[0].(context.Context), args[1].(*entity.Team))
 	})
 	return _c
 }
@@ -199,22 +207,69 @@ func (_c *Repository_CreateTeam_Call) Return(_a0 error) *Repository_CreateTeam_C
 	return _c
 }
 
-func (_c *Repository_CreateTeam_Call) RunAndReturn(run func(*entity.Team) error) *Repository_CreateTeam_Call {
+func (_c *Repository_CreateTeam_Call) RunAndReturn(run func(context.Context, *entity.Team) error) *Repository_CreateTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUnavailability provides a mock function with given fields: ctx, period
+func (_m *Repository) CreateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	ret := _m.Called(ctx, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUnavailability")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Unavailability) error); ok {
+		r0 = rf(ctx, period)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_CreateUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUnavailability'
+type Repository_CreateUnavailability_Call struct {
+	*mock.Call
+}
+
+// CreateUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - period *entity.Unavailability
+func (_e *Repository_Expecter) CreateUnavailability(ctx interface{}, period interface{}) *Repository_CreateUnavailability_Call {
+	return &Repository_CreateUnavailability_Call{Call: _e.mock.On("CreateUnavailability", ctx, period)}
+}
+
+func (_c *Repository_CreateUnavailability_Call) Run(run func(ctx context.Context, period *entity.Unavailability)) *Repository_CreateUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Unavailability))
+	})
+	return _c
+}
+
+func (_c *Repository_CreateUnavailability_Call) Return(_a0 error) *Repository_CreateUnavailability_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_CreateUnavailability_Call) RunAndReturn(run func(context.Context, *entity.Unavailability) error) *Repository_CreateUnavailability_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUser provides a mock function with given fields: user
-func (_m *Repository) CreateUser(user *entity.User) error {
-	ret := _m.Called(user)
+// CreateUser provides a mock function with given fields: ctx, user
+func (_m *Repository) CreateUser(ctx context.Context, user *entity.User) error {
+	ret := _m.Called(ctx, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entity.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -228,14 +283,15 @@ type Repository_CreateUser_Call struct {
 }
 
 // CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
 //   - user *entity.User
-func (_e *Repository_Expecter) CreateUser(user interface{}) *Repository_CreateUser_Call {
-	return &Repository_CreateUser_Call{Call: _e.mock.On("CreateUser", user)}
+func (_e *Repository_Expecter) CreateUser(ctx interface{}, user interface{}) *Repository_CreateUser_Call {
+	return &Repository_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
 }
 
-func (_c *Repository_CreateUser_Call) Run(run func(user *entity.User)) *Repository_CreateUser_Call {
+func (_c *Repository_CreateUser_Call) Run(run func(ctx context.Context, user *entity.User)) *Repository_CreateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*entity.User))
+		run(args[0].(context.Context), args[1].(*entity.User))
 	})
 	return _c
 }
@@ -245,92 +301,79 @@ func (_c *Repository_CreateUser_Call) Return(_a0 error) *Repository_CreateUser_C
 	return _c
 }
 
-func (_c *Repository_CreateUser_Call) RunAndReturn(run func(*entity.User) error) *Repository_CreateUser_Call {
+func (_c *Repository_CreateUser_Call) RunAndReturn(run func(context.Context, *entity.User) error) *Repository_CreateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindPRByID provides a mock function with given fields: prID
-func (_m *Repository) FindPRByID(prID string) (*entity.PullRequest, error) {
-	ret := _m.Called(prID)
+// DeleteCodeOwners provides a mock function with given fields: ctx, repository
+func (_m *Repository) DeleteCodeOwners(ctx context.Context, repository string) error {
+	ret := _m.Called(ctx, repository)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindPRByID")
-	}
-
-	var r0 *entity.PullRequest
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*entity.PullRequest, error)); ok {
-		return rf(prID)
-	}
-	if rf, ok := ret.Get(0).(func(string) *entity.PullRequest); ok {
-		r0 = rf(prID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.PullRequest)
-		}
+		panic("no return value specified for DeleteCodeOwners")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(prID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, repository)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Repository_FindPRByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPRByID'
-type Repository_FindPRByID_Call struct {
+// Repository_DeleteCodeOwners_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCodeOwners'
+type Repository_DeleteCodeOwners_Call struct {
 	*mock.Call
 }
 
-// FindPRByID is a helper method to define mock.On call
-//   - prID string
-func (_e *Repository_Expecter) FindPRByID(prID interface{}) *Repository_FindPRByID_Call {
-	return &Repository_FindPRByID_Call{Call: _e.mock.On("FindPRByID", prID)}
+// DeleteCodeOwners is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repository string
+func (_e *Repository_Expecter) DeleteCodeOwners(ctx interface{}, repository interface{}) *Repository_DeleteCodeOwners_Call {
+	return &Repository_DeleteCodeOwners_Call{Call: _e.mock.On("DeleteCodeOwners", ctx, repository)}
 }
 
-func (_c *Repository_FindPRByID_Call) Run(run func(prID string)) *Repository_FindPRByID_Call {
+func (_c *Repository_DeleteCodeOwners_Call) Run(run func(ctx context.Context, repository string)) *Repository_DeleteCodeOwners_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Repository_FindPRByID_Call) Return(_a0 *entity.PullRequest, _a1 error) *Repository_FindPRByID_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Repository_DeleteCodeOwners_Call) Return(_a0 error) *Repository_DeleteCodeOwners_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Repository_FindPRByID_Call) RunAndReturn(run func(string) (*entity.PullRequest, error)) *Repository_FindPRByID_Call {
+func (_c *Repository_DeleteCodeOwners_Call) RunAndReturn(run func(context.Context, string) error) *Repository_DeleteCodeOwners_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindPRsByReviewer provides a mock function with given fields: userID
-func (_m *Repository) FindPRsByReviewer(userID string) ([]*entity.PullRequest, error) {
-	ret := _m.Called(userID)
+// DeleteExpiredIdempotencyRecords provides a mock function with given fields: ctx, now
+func (_m *Repository) DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindPRsByReviewer")
+		panic("no return value specified for DeleteExpiredIdempotencyRecords")
 	}
 
-	var r0 []*entity.PullRequest
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]*entity.PullRequest, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, now)
 	}
-	if rf, ok := ret.Get(0).(func(string) []*entity.PullRequest); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.PullRequest)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -338,115 +381,200 @@ func (_m *Repository) FindPRsByReviewer(userID string) ([]*entity.PullRequest, e
 	return r0, r1
 }
 
-// Repository_FindPRsByReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPRsByReviewer'
-type Repository_FindPRsByReviewer_Call struct {
+// Repository_DeleteExpiredIdempotencyRecords_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredIdempotencyRecords'
+type Repository_DeleteExpiredIdempotencyRecords_Call struct {
 	*mock.Call
 }
 
-// FindPRsByReviewer is a helper method to define mock.On call
-//   - userID string
-func (_e *Repository_Expecter) FindPRsByReviewer(userID interface{}) *Repository_FindPRsByReviewer_Call {
-	return &Repository_FindPRsByReviewer_Call{Call: _e.mock.On("FindPRsByReviewer", userID)}
+// DeleteExpiredIdempotencyRecords is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *Repository_Expecter) DeleteExpiredIdempotencyRecords(ctx interface{}, now interface{}) *Repository_DeleteExpiredIdempotencyRecords_Call {
+	return &Repository_DeleteExpiredIdempotencyRecords_Call{Call: _e.mock.On("DeleteExpiredIdempotencyRecords", ctx, now)}
 }
 
-func (_c *Repository_FindPRsByReviewer_Call) Run(run func(userID string)) *Repository_FindPRsByReviewer_Call {
+func (_c *Repository_DeleteExpiredIdempotencyRecords_Call) Run(run func(ctx context.Context, now time.Time)) *Repository_DeleteExpiredIdempotencyRecords_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Repository_FindPRsByReviewer_Call) Return(_a0 []*entity.PullRequest, _a1 error) *Repository_FindPRsByReviewer_Call {
+func (_c *Repository_DeleteExpiredIdempotencyRecords_Call) Return(_a0 int64, _a1 error) *Repository_DeleteExpiredIdempotencyRecords_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_FindPRsByReviewer_Call) RunAndReturn(run func(string) ([]*entity.PullRequest, error)) *Repository_FindPRsByReviewer_Call {
+func (_c *Repository_DeleteExpiredIdempotencyRecords_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *Repository_DeleteExpiredIdempotencyRecords_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindTeamByName provides a mock function with given fields: teamName
-func (_m *Repository) FindTeamByName(teamName string) (*entity.Team, error) {
-	ret := _m.Called(teamName)
+// DeleteReviewerRule provides a mock function with given fields: ctx, authorID, reviewerID
+func (_m *Repository) DeleteReviewerRule(ctx context.Context, authorID string, reviewerID string) error {
+	ret := _m.Called(ctx, authorID, reviewerID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindTeamByName")
+		panic("no return value specified for DeleteReviewerRule")
 	}
 
-	var r0 *entity.Team
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*entity.Team, error)); ok {
-		return rf(teamName)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, authorID, reviewerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteReviewerRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReviewerRule'
+type Repository_DeleteReviewerRule_Call struct {
+	*mock.Call
+}
+
+// DeleteReviewerRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID string
+//   - reviewerID string
+func (_e *Repository_Expecter) DeleteReviewerRule(ctx interface{}, authorID interface{}, reviewerID interface{}) *Repository_DeleteReviewerRule_Call {
+	return &Repository_DeleteReviewerRule_Call{Call: _e.mock.On("DeleteReviewerRule", ctx, authorID, reviewerID)}
+}
+
+func (_c *Repository_DeleteReviewerRule_Call) Run(run func(ctx context.Context, authorID string, reviewerID string)) *Repository_DeleteReviewerRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteReviewerRule_Call) Return(_a0 error) *Repository_DeleteReviewerRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_DeleteReviewerRule_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_DeleteReviewerRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUnavailability provides a mock function with given fields: ctx, id
+func (_m *Repository) DeleteUnavailability(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnavailability")
 	}
-	if rf, ok := ret.Get(0).(func(string) *entity.Team); ok {
-		r0 = rf(teamName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Team)
-		}
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUnavailability'
+type Repository_DeleteUnavailability_Call struct {
+	*mock.Call
+}
+
+// DeleteUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Repository_Expecter) DeleteUnavailability(ctx interface{}, id interface{}) *Repository_DeleteUnavailability_Call {
+	return &Repository_DeleteUnavailability_Call{Call: _e.mock.On("DeleteUnavailability", ctx, id)}
+}
+
+func (_c *Repository_DeleteUnavailability_Call) Run(run func(ctx context.Context, id int64)) *Repository_DeleteUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteUnavailability_Call) Return(_a0 error) *Repository_DeleteUnavailability_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_DeleteUnavailability_Call) RunAndReturn(run func(context.Context, int64) error) *Repository_DeleteUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkSchedule provides a mock function with given fields: ctx, userID
+func (_m *Repository) DeleteWorkSchedule(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkSchedule")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(teamName)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Repository_FindTeamByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTeamByName'
-type Repository_FindTeamByName_Call struct {
+// Repository_DeleteWorkSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkSchedule'
+type Repository_DeleteWorkSchedule_Call struct {
 	*mock.Call
 }
 
-// FindTeamByName is a helper method to define mock.On call
-//   - teamName string
-func (_e *Repository_Expecter) FindTeamByName(teamName interface{}) *Repository_FindTeamByName_Call {
-	return &Repository_FindTeamByName_Call{Call: _e.mock.On("FindTeamByName", teamName)}
+// DeleteWorkSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) DeleteWorkSchedule(ctx interface{}, userID interface{}) *Repository_DeleteWorkSchedule_Call {
+	return &Repository_DeleteWorkSchedule_Call{Call: _e.mock.On("DeleteWorkSchedule", ctx, userID)}
 }
 
-func (_c *Repository_FindTeamByName_Call) Run(run func(teamName string)) *Repository_FindTeamByName_Call {
+func (_c *Repository_DeleteWorkSchedule_Call) Run(run func(ctx context.Context, userID string)) *Repository_DeleteWorkSchedule_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Repository_FindTeamByName_Call) Return(_a0 *entity.Team, _a1 error) *Repository_FindTeamByName_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Repository_DeleteWorkSchedule_Call) Return(_a0 error) *Repository_DeleteWorkSchedule_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Repository_FindTeamByName_Call) RunAndReturn(run func(string) (*entity.Team, error)) *Repository_FindTeamByName_Call {
+func (_c *Repository_DeleteWorkSchedule_Call) RunAndReturn(run func(context.Context, string) error) *Repository_DeleteWorkSchedule_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindUserByID provides a mock function with given fields: userID
-func (_m *Repository) FindUserByID(userID string) (*entity.User, error) {
-	ret := _m.Called(userID)
+// FindCodeOwners provides a mock function with given fields: ctx, repository
+func (_m *Repository) FindCodeOwners(ctx context.Context, repository string) (*entity.CodeOwners, error) {
+	ret := _m.Called(ctx, repository)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindUserByID")
+		panic("no return value specified for FindCodeOwners")
 	}
 
-	var r0 *entity.User
+	var r0 *entity.CodeOwners
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*entity.User, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.CodeOwners, error)); ok {
+		return rf(ctx, repository)
 	}
-	if rf, ok := ret.Get(0).(func(string) *entity.User); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.CodeOwners); ok {
+		r0 = rf(ctx, repository)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.User)
+			r0 = ret.Get(0).(*entity.CodeOwners)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, repository)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -454,57 +582,58 @@ func (_m *Repository) FindUserByID(userID string) (*entity.User, error) {
 	return r0, r1
 }
 
-// Repository_FindUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUserByID'
-type Repository_FindUserByID_Call struct {
+// Repository_FindCodeOwners_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindCodeOwners'
+type Repository_FindCodeOwners_Call struct {
 	*mock.Call
 }
 
-// FindUserByID is a helper method to define mock.On call
-//   - userID string
-func (_e *Repository_Expecter) FindUserByID(userID interface{}) *Repository_FindUserByID_Call {
-	return &Repository_FindUserByID_Call{Call: _e.mock.On("FindUserByID", userID)}
+// FindCodeOwners is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repository string
+func (_e *Repository_Expecter) FindCodeOwners(ctx interface{}, repository interface{}) *Repository_FindCodeOwners_Call {
+	return &Repository_FindCodeOwners_Call{Call: _e.mock.On("FindCodeOwners", ctx, repository)}
 }
 
-func (_c *Repository_FindUserByID_Call) Run(run func(userID string)) *Repository_FindUserByID_Call {
+func (_c *Repository_FindCodeOwners_Call) Run(run func(ctx context.Context, repository string)) *Repository_FindCodeOwners_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Repository_FindUserByID_Call) Return(_a0 *entity.User, _a1 error) *Repository_FindUserByID_Call {
+func (_c *Repository_FindCodeOwners_Call) Return(_a0 *entity.CodeOwners, _a1 error) *Repository_FindCodeOwners_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_FindUserByID_Call) RunAndReturn(run func(string) (*entity.User, error)) *Repository_FindUserByID_Call {
+func (_c *Repository_FindCodeOwners_Call) RunAndReturn(run func(context.Context, string) (*entity.CodeOwners, error)) *Repository_FindCodeOwners_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindUsersByTeam provides a mock function with given fields: teamName
-func (_m *Repository) FindUsersByTeam(teamName string) ([]*entity.User, error) {
-	ret := _m.Called(teamName)
+// FindIdempotencyRecord provides a mock function with given fields: ctx, key, endpoint
+func (_m *Repository) FindIdempotencyRecord(ctx context.Context, key string, endpoint string) (*entity.IdempotencyRecord, error) {
+	ret := _m.Called(ctx, key, endpoint)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindUsersByTeam")
+		panic("no return value specified for FindIdempotencyRecord")
 	}
 
-	var r0 []*entity.User
+	var r0 *entity.IdempotencyRecord
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]*entity.User, error)); ok {
-		return rf(teamName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.IdempotencyRecord, error)); ok {
+		return rf(ctx, key, endpoint)
 	}
-	if rf, ok := ret.Get(0).(func(string) []*entity.User); ok {
-		r0 = rf(teamName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.IdempotencyRecord); ok {
+		r0 = rf(ctx, key, endpoint)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.User)
+			r0 = ret.Get(0).(*entity.IdempotencyRecord)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(teamName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, key, endpoint)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -512,205 +641,2578 @@ func (_m *Repository) FindUsersByTeam(teamName string) ([]*entity.User, error) {
 	return r0, r1
 }
 
-// Repository_FindUsersByTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUsersByTeam'
-type Repository_FindUsersByTeam_Call struct {
+// Repository_FindIdempotencyRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindIdempotencyRecord'
+type Repository_FindIdempotencyRecord_Call struct {
 	*mock.Call
 }
 
-// FindUsersByTeam is a helper method to define mock.On call
-//   - teamName string
-func (_e *Repository_Expecter) FindUsersByTeam(teamName interface{}) *Repository_FindUsersByTeam_Call {
-	return &Repository_FindUsersByTeam_Call{Call: _e.mock.On("FindUsersByTeam", teamName)}
+// FindIdempotencyRecord is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - endpoint string
+func (_e *Repository_Expecter) FindIdempotencyRecord(ctx interface{}, key interface{}, endpoint interface{}) *Repository_FindIdempotencyRecord_Call {
+	return &Repository_FindIdempotencyRecord_Call{Call: _e.mock.On("FindIdempotencyRecord", ctx, key, endpoint)}
 }
 
-func (_c *Repository_FindUsersByTeam_Call) Run(run func(teamName string)) *Repository_FindUsersByTeam_Call {
+func (_c *Repository_FindIdempotencyRecord_Call) Run(run func(ctx context.Context, key string, endpoint string)) *Repository_FindIdempotencyRecord_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Repository_FindUsersByTeam_Call) Return(_a0 []*entity.User, _a1 error) *Repository_FindUsersByTeam_Call {
+func (_c *Repository_FindIdempotencyRecord_Call) Return(_a0 *entity.IdempotencyRecord, _a1 error) *Repository_FindIdempotencyRecord_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_FindUsersByTeam_Call) RunAndReturn(run func(string) ([]*entity.User, error)) *Repository_FindUsersByTeam_Call {
+func (_c *Repository_FindIdempotencyRecord_Call) RunAndReturn(run func(context.Context, string, string) (*entity.IdempotencyRecord, error)) *Repository_FindIdempotencyRecord_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetActive provides a mock function with given fields: userID, isActive
-func (_m *Repository) SetActive(userID string, isActive bool) error {
-	ret := _m.Called(userID, isActive)
+// FindPRByID provides a mock function with given fields: ctx, prID
+func (_m *Repository) FindPRByID(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	ret := _m.Called(ctx, prID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetActive")
+		panic("no return value specified for FindPRByID")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
-		r0 = rf(userID, isActive)
+	var r0 *entity.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PullRequest, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PullRequest); ok {
+		r0 = rf(ctx, prID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PullRequest)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Repository_SetActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActive'
-type Repository_SetActive_Call struct {
+// Repository_FindPRByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPRByID'
+type Repository_FindPRByID_Call struct {
 	*mock.Call
 }
 
-// SetActive is a helper method to define mock.On call
-//   - userID string
-//   - isActive bool
-func (_e *Repository_Expecter) SetActive(userID interface{}, isActive interface{}) *Repository_SetActive_Call {
-	return &Repository_SetActive_Call{Call: _e.mock.On("SetActive", userID, isActive)}
+// FindPRByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *Repository_Expecter) FindPRByID(ctx interface{}, prID interface{}) *Repository_FindPRByID_Call {
+	return &Repository_FindPRByID_Call{Call: _e.mock.On("FindPRByID", ctx, prID)}
 }
 
-func (_c *Repository_SetActive_Call) Run(run func(userID string, isActive bool)) *Repository_SetActive_Call {
+func (_c *Repository_FindPRByID_Call) Run(run func(ctx context.Context, prID string)) *Repository_FindPRByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(bool))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Repository_SetActive_Call) Return(_a0 error) *Repository_SetActive_Call {
-	_c.Call.Return(_a0)
+func (_c *Repository_FindPRByID_Call) Return(_a0 *entity.PullRequest, _a1 error) *Repository_FindPRByID_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_SetActive_Call) RunAndReturn(run func(string, bool) error) *Repository_SetActive_Call {
+func (_c *Repository_FindPRByID_Call) RunAndReturn(run func(context.Context, string) (*entity.PullRequest, error)) *Repository_FindPRByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TeamExists provides a mock function with given fields: teamName
-func (_m *Repository) TeamExists(teamName string) bool {
-	ret := _m.Called(teamName)
+// FindPRByIDForUpdate provides a mock function with given fields: ctx, prID
+func (_m *Repository) FindPRByIDForUpdate(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	ret := _m.Called(ctx, prID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TeamExists")
+		panic("no return value specified for FindPRByIDForUpdate")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(teamName)
+	var r0 *entity.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PullRequest, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PullRequest); ok {
+		r0 = rf(ctx, prID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PullRequest)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Repository_TeamExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TeamExists'
-type Repository_TeamExists_Call struct {
+// Repository_FindPRByIDForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPRByIDForUpdate'
+type Repository_FindPRByIDForUpdate_Call struct {
 	*mock.Call
 }
 
-// TeamExists is a helper method to define mock.On call
-//   - teamName string
-func (_e *Repository_Expecter) TeamExists(teamName interface{}) *Repository_TeamExists_Call {
-	return &Repository_TeamExists_Call{Call: _e.mock.On("TeamExists", teamName)}
+// FindPRByIDForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *Repository_Expecter) FindPRByIDForUpdate(ctx interface{}, prID interface{}) *Repository_FindPRByIDForUpdate_Call {
+	return &Repository_FindPRByIDForUpdate_Call{Call: _e.mock.On("FindPRByIDForUpdate", ctx, prID)}
 }
 
-func (_c *Repository_TeamExists_Call) Run(run func(teamName string)) *Repository_TeamExists_Call {
+func (_c *Repository_FindPRByIDForUpdate_Call) Run(run func(ctx context.Context, prID string)) *Repository_FindPRByIDForUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Repository_TeamExists_Call) Return(_a0 bool) *Repository_TeamExists_Call {
-	_c.Call.Return(_a0)
+func (_c *Repository_FindPRByIDForUpdate_Call) Return(_a0 *entity.PullRequest, _a1 error) *Repository_FindPRByIDForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_TeamExists_Call) RunAndReturn(run func(string) bool) *Repository_TeamExists_Call {
+func (_c *Repository_FindPRByIDForUpdate_Call) RunAndReturn(run func(context.Context, string) (*entity.PullRequest, error)) *Repository_FindPRByIDForUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdatePR provides a mock function with given fields: pr
-func (_m *Repository) UpdatePR(pr *entity.PullRequest) error {
-	ret := _m.Called(pr)
+// FindPRsByReviewer provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindPRsByReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePR")
+		panic("no return value specified for FindPRsByReviewer")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*entity.PullRequest) error); ok {
-		r0 = rf(pr)
+	var r0 []*entity.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.PullRequest, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.PullRequest); ok {
+		r0 = rf(ctx, userID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PullRequest)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Repository_UpdatePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePR'
-type Repository_UpdatePR_Call struct {
+// Repository_FindPRsByReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPRsByReviewer'
+type Repository_FindPRsByReviewer_Call struct {
 	*mock.Call
 }
 
-// UpdatePR is a helper method to define mock.On call
-//   - pr *entity.PullRequest
-func (_e *Repository_Expecter) UpdatePR(pr interface{}) *Repository_UpdatePR_Call {
-	return &Repository_UpdatePR_Call{Call: _e.mock.On("UpdatePR", pr)}
+// FindPRsByReviewer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) FindPRsByReviewer(ctx interface{}, userID interface{}) *Repository_FindPRsByReviewer_Call {
+	return &Repository_FindPRsByReviewer_Call{Call: _e.mock.On("FindPRsByReviewer", ctx, userID)}
 }
 
-func (_c *Repository_UpdatePR_Call) Run(run func(pr *entity.PullRequest)) *Repository_UpdatePR_Call {
+func (_c *Repository_FindPRsByReviewer_Call) Run(run func(ctx context.Context, userID string)) *Repository_FindPRsByReviewer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*entity.PullRequest))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Repository_UpdatePR_Call) Return(_a0 error) *Repository_UpdatePR_Call {
-	_c.Call.Return(_a0)
+func (_c *Repository_FindPRsByReviewer_Call) Return(_a0 []*entity.PullRequest, _a1 error) *Repository_FindPRsByReviewer_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_UpdatePR_Call) RunAndReturn(run func(*entity.PullRequest) error) *Repository_UpdatePR_Call {
+func (_c *Repository_FindPRsByReviewer_Call) RunAndReturn(run func(context.Context, string) ([]*entity.PullRequest, error)) *Repository_FindPRsByReviewer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateUser provides a mock function with given fields: user
-func (_m *Repository) UpdateUser(user *entity.User) error {
-	ret := _m.Called(user)
+// FindPRsByShadowReviewer provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindPRsByShadowReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUser")
+		panic("no return value specified for FindPRsByShadowReviewer")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*entity.User) error); ok {
-		r0 = rf(user)
+	var r0 []*entity.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.PullRequest, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.PullRequest); ok {
+		r0 = rf(ctx, userID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PullRequest)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Repository_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
-type Repository_UpdateUser_Call struct {
+// Repository_FindPRsByShadowReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPRsByShadowReviewer'
+type Repository_FindPRsByShadowReviewer_Call struct {
 	*mock.Call
 }
 
-// UpdateUser is a helper method to define mock.On call
-//   - user *entity.User
-func (_e *Repository_Expecter) UpdateUser(user interface{}) *Repository_UpdateUser_Call {
-	return &Repository_UpdateUser_Call{Call: _e.mock.On("UpdateUser", user)}
+// FindPRsByShadowReviewer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) FindPRsByShadowReviewer(ctx interface{}, userID interface{}) *Repository_FindPRsByShadowReviewer_Call {
+	return &Repository_FindPRsByShadowReviewer_Call{Call: _e.mock.On("FindPRsByShadowReviewer", ctx, userID)}
 }
 
-func (_c *Repository_UpdateUser_Call) Run(run func(user *entity.User)) *Repository_UpdateUser_Call {
+func (_c *Repository_FindPRsByShadowReviewer_Call) Run(run func(ctx context.Context, userID string)) *Repository_FindPRsByShadowReviewer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*entity.User))
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindPRsByShadowReviewer_Call) Return(_a0 []*entity.PullRequest, _a1 error) *Repository_FindPRsByShadowReviewer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindPRsByShadowReviewer_Call) RunAndReturn(run func(context.Context, string) ([]*entity.PullRequest, error)) *Repository_FindPRsByShadowReviewer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPairReviewCounts provides a mock function with given fields: ctx, authorID, since
+func (_m *Repository) FindPairReviewCounts(ctx context.Context, authorID string, since time.Time) (map[string]int, error) {
+	ret := _m.Called(ctx, authorID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPairReviewCounts")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (map[string]int, error)); ok {
+		return rf(ctx, authorID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) map[string]int); ok {
+		r0 = rf(ctx, authorID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, authorID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindPairReviewCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPairReviewCounts'
+type Repository_FindPairReviewCounts_Call struct {
+	*mock.Call
+}
+
+// FindPairReviewCounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID string
+//   - since time.Time
+func (_e *Repository_Expecter) FindPairReviewCounts(ctx interface{}, authorID interface{}, since interface{}) *Repository_FindPairReviewCounts_Call {
+	return &Repository_FindPairReviewCounts_Call{Call: _e.mock.On("FindPairReviewCounts", ctx, authorID, since)}
+}
+
+func (_c *Repository_FindPairReviewCounts_Call) Run(run func(ctx context.Context, authorID string, since time.Time)) *Repository_FindPairReviewCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Repository_FindPairReviewCounts_Call) Return(_a0 map[string]int, _a1 error) *Repository_FindPairReviewCounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindPairReviewCounts_Call) RunAndReturn(run func(context.Context, string, time.Time) (map[string]int, error)) *Repository_FindPairReviewCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPendingReassignments provides a mock function with given fields: ctx, at
+func (_m *Repository) FindPendingReassignments(ctx context.Context, at time.Time) ([]*entity.Unavailability, error) {
+	ret := _m.Called(ctx, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPendingReassignments")
+	}
+
+	var r0 []*entity.Unavailability
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*entity.Unavailability, error)); ok {
+		return rf(ctx, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*entity.Unavailability); ok {
+		r0 = rf(ctx, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Unavailability)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindPendingReassignments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPendingReassignments'
+type Repository_FindPendingReassignments_Call struct {
+	*mock.Call
+}
+
+// FindPendingReassignments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - at time.Time
+func (_e *Repository_Expecter) FindPendingReassignments(ctx interface{}, at interface{}) *Repository_FindPendingReassignments_Call {
+	return &Repository_FindPendingReassignments_Call{Call: _e.mock.On("FindPendingReassignments", ctx, at)}
+}
+
+func (_c *Repository_FindPendingReassignments_Call) Run(run func(ctx context.Context, at time.Time)) *Repository_FindPendingReassignments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Repository_FindPendingReassignments_Call) Return(_a0 []*entity.Unavailability, _a1 error) *Repository_FindPendingReassignments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindPendingReassignments_Call) RunAndReturn(run func(context.Context, time.Time) ([]*entity.Unavailability, error)) *Repository_FindPendingReassignments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindReviewLoads provides a mock function with given fields: ctx, teamName
+func (_m *Repository) FindReviewLoads(ctx context.Context, teamName string) (map[string]entity.ReviewLoad, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindReviewLoads")
+	}
+
+	var r0 map[string]entity.ReviewLoad
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]entity.ReviewLoad, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]entity.ReviewLoad); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]entity.ReviewLoad)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindReviewLoads_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindReviewLoads'
+type Repository_FindReviewLoads_Call struct {
+	*mock.Call
+}
+
+// FindReviewLoads is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *Repository_Expecter) FindReviewLoads(ctx interface{}, teamName interface{}) *Repository_FindReviewLoads_Call {
+	return &Repository_FindReviewLoads_Call{Call: _e.mock.On("FindReviewLoads", ctx, teamName)}
+}
+
+func (_c *Repository_FindReviewLoads_Call) Run(run func(ctx context.Context, teamName string)) *Repository_FindReviewLoads_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindReviewLoads_Call) Return(_a0 map[string]entity.ReviewLoad, _a1 error) *Repository_FindReviewLoads_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindReviewLoads_Call) RunAndReturn(run func(context.Context, string) (map[string]entity.ReviewLoad, error)) *Repository_FindReviewLoads_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindReviewerRulesByAuthor provides a mock function with given fields: ctx, authorID
+func (_m *Repository) FindReviewerRulesByAuthor(ctx context.Context, authorID string) ([]entity.ReviewerRule, error) {
+	ret := _m.Called(ctx, authorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindReviewerRulesByAuthor")
+	}
+
+	var r0 []entity.ReviewerRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.ReviewerRule, error)); ok {
+		return rf(ctx, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.ReviewerRule); ok {
+		r0 = rf(ctx, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ReviewerRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindReviewerRulesByAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindReviewerRulesByAuthor'
+type Repository_FindReviewerRulesByAuthor_Call struct {
+	*mock.Call
+}
+
+// FindReviewerRulesByAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID string
+func (_e *Repository_Expecter) FindReviewerRulesByAuthor(ctx interface{}, authorID interface{}) *Repository_FindReviewerRulesByAuthor_Call {
+	return &Repository_FindReviewerRulesByAuthor_Call{Call: _e.mock.On("FindReviewerRulesByAuthor", ctx, authorID)}
+}
+
+func (_c *Repository_FindReviewerRulesByAuthor_Call) Run(run func(ctx context.Context, authorID string)) *Repository_FindReviewerRulesByAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindReviewerRulesByAuthor_Call) Return(_a0 []entity.ReviewerRule, _a1 error) *Repository_FindReviewerRulesByAuthor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindReviewerRulesByAuthor_Call) RunAndReturn(run func(context.Context, string) ([]entity.ReviewerRule, error)) *Repository_FindReviewerRulesByAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindSkillsByTeam provides a mock function with given fields: ctx, teamName
+func (_m *Repository) FindSkillsByTeam(ctx context.Context, teamName string) ([]entity.UserSkill, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSkillsByTeam")
+	}
+
+	var r0 []entity.UserSkill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.UserSkill, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.UserSkill); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.UserSkill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindSkillsByTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindSkillsByTeam'
+type Repository_FindSkillsByTeam_Call struct {
+	*mock.Call
+}
+
+// FindSkillsByTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *Repository_Expecter) FindSkillsByTeam(ctx interface{}, teamName interface{}) *Repository_FindSkillsByTeam_Call {
+	return &Repository_FindSkillsByTeam_Call{Call: _e.mock.On("FindSkillsByTeam", ctx, teamName)}
+}
+
+func (_c *Repository_FindSkillsByTeam_Call) Run(run func(ctx context.Context, teamName string)) *Repository_FindSkillsByTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindSkillsByTeam_Call) Return(_a0 []entity.UserSkill, _a1 error) *Repository_FindSkillsByTeam_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindSkillsByTeam_Call) RunAndReturn(run func(context.Context, string) ([]entity.UserSkill, error)) *Repository_FindSkillsByTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindTeamByName provides a mock function with given fields: ctx, teamName
+func (_m *Repository) FindTeamByName(ctx context.Context, teamName string) (*entity.Team, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTeamByName")
+	}
+
+	var r0 *entity.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Team, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Team); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindTeamByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTeamByName'
+type Repository_FindTeamByName_Call struct {
+	*mock.Call
+}
+
+// FindTeamByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *Repository_Expecter) FindTeamByName(ctx interface{}, teamName interface{}) *Repository_FindTeamByName_Call {
+	return &Repository_FindTeamByName_Call{Call: _e.mock.On("FindTeamByName", ctx, teamName)}
+}
+
+func (_c *Repository_FindTeamByName_Call) Run(run func(ctx context.Context, teamName string)) *Repository_FindTeamByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindTeamByName_Call) Return(_a0 *entity.Team, _a1 error) *Repository_FindTeamByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindTeamByName_Call) RunAndReturn(run func(context.Context, string) (*entity.Team, error)) *Repository_FindTeamByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUnavailabilitiesByUser provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindUnavailabilitiesByUser(ctx context.Context, userID string) ([]*entity.Unavailability, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnavailabilitiesByUser")
+	}
+
+	var r0 []*entity.Unavailability
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Unavailability, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Unavailability); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Unavailability)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUnavailabilitiesByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUnavailabilitiesByUser'
+type Repository_FindUnavailabilitiesByUser_Call struct {
+	*mock.Call
+}
+
+// FindUnavailabilitiesByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) FindUnavailabilitiesByUser(ctx interface{}, userID interface{}) *Repository_FindUnavailabilitiesByUser_Call {
+	return &Repository_FindUnavailabilitiesByUser_Call{Call: _e.mock.On("FindUnavailabilitiesByUser", ctx, userID)}
+}
+
+func (_c *Repository_FindUnavailabilitiesByUser_Call) Run(run func(ctx context.Context, userID string)) *Repository_FindUnavailabilitiesByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUnavailabilitiesByUser_Call) Return(_a0 []*entity.Unavailability, _a1 error) *Repository_FindUnavailabilitiesByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindUnavailabilitiesByUser_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Unavailability, error)) *Repository_FindUnavailabilitiesByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUnavailableUserIDs provides a mock function with given fields: ctx, teamName, at
+func (_m *Repository) FindUnavailableUserIDs(ctx context.Context, teamName string, at time.Time) ([]string, error) {
+	ret := _m.Called(ctx, teamName, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnavailableUserIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]string, error)); ok {
+		return rf(ctx, teamName, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []string); ok {
+		r0 = rf(ctx, teamName, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, teamName, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUnavailableUserIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUnavailableUserIDs'
+type Repository_FindUnavailableUserIDs_Call struct {
+	*mock.Call
+}
+
+// FindUnavailableUserIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - at time.Time
+func (_e *Repository_Expecter) FindUnavailableUserIDs(ctx interface{}, teamName interface{}, at interface{}) *Repository_FindUnavailableUserIDs_Call {
+	return &Repository_FindUnavailableUserIDs_Call{Call: _e.mock.On("FindUnavailableUserIDs", ctx, teamName, at)}
+}
+
+func (_c *Repository_FindUnavailableUserIDs_Call) Run(run func(ctx context.Context, teamName string, at time.Time)) *Repository_FindUnavailableUserIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUnavailableUserIDs_Call) Return(_a0 []string, _a1 error) *Repository_FindUnavailableUserIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindUnavailableUserIDs_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]string, error)) *Repository_FindUnavailableUserIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUserAliases provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindUserAliases(ctx context.Context, userID string) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUserAliases")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUserAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUserAliases'
+type Repository_FindUserAliases_Call struct {
+	*mock.Call
+}
+
+// FindUserAliases is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) FindUserAliases(ctx interface{}, userID interface{}) *Repository_FindUserAliases_Call {
+	return &Repository_FindUserAliases_Call{Call: _e.mock.On("FindUserAliases", ctx, userID)}
+}
+
+func (_c *Repository_FindUserAliases_Call) Run(run func(ctx context.Context, userID string)) *Repository_FindUserAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUserAliases_Call) Return(_a0 []string, _a1 error) *Repository_FindUserAliases_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindUserAliases_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *Repository_FindUserAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUserByID provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindUserByID(ctx context.Context, userID string) (*entity.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUserByID")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUserByID'
+type Repository_FindUserByID_Call struct {
+	*mock.Call
+}
+
+// FindUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) FindUserByID(ctx interface{}, userID interface{}) *Repository_FindUserByID_Call {
+	return &Repository_FindUserByID_Call{Call: _e.mock.On("FindUserByID", ctx, userID)}
+}
+
+func (_c *Repository_FindUserByID_Call) Run(run func(ctx context.Context, userID string)) *Repository_FindUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUserByID_Call) Return(_a0 *entity.User, _a1 error) *Repository_FindUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindUserByID_Call) RunAndReturn(run func(context.Context, string) (*entity.User, error)) *Repository_FindUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUserIDByAlias provides a mock function with given fields: ctx, alias
+func (_m *Repository) FindUserIDByAlias(ctx context.Context, alias string) (string, error) {
+	ret := _m.Called(ctx, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUserIDByAlias")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, alias)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, alias)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, alias)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUserIDByAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUserIDByAlias'
+type Repository_FindUserIDByAlias_Call struct {
+	*mock.Call
+}
+
+// FindUserIDByAlias is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alias string
+func (_e *Repository_Expecter) FindUserIDByAlias(ctx interface{}, alias interface{}) *Repository_FindUserIDByAlias_Call {
+	return &Repository_FindUserIDByAlias_Call{Call: _e.mock.On("FindUserIDByAlias", ctx, alias)}
+}
+
+func (_c *Repository_FindUserIDByAlias_Call) Run(run func(ctx context.Context, alias string)) *Repository_FindUserIDByAlias_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUserIDByAlias_Call) Return(_a0 string, _a1 error) *Repository_FindUserIDByAlias_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindUserIDByAlias_Call) RunAndReturn(run func(context.Context, string) (string, error)) *Repository_FindUserIDByAlias_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUserSkills provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindUserSkills(ctx context.Context, userID string) ([]entity.UserSkill, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUserSkills")
+	}
+
+	var r0 []entity.UserSkill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.UserSkill, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.UserSkill); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.UserSkill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUserSkills_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUserSkills'
+type Repository_FindUserSkills_Call struct {
+	*mock.Call
+}
+
+// FindUserSkills is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) FindUserSkills(ctx interface{}, userID interface{}) *Repository_FindUserSkills_Call {
+	return &Repository_FindUserSkills_Call{Call: _e.mock.On("FindUserSkills", ctx, userID)}
+}
+
+func (_c *Repository_FindUserSkills_Call) Run(run func(ctx context.Context, userID string)) *Repository_FindUserSkills_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUserSkills_Call) Return(_a0 []entity.UserSkill, _a1 error) *Repository_FindUserSkills_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindUserSkills_Call) RunAndReturn(run func(context.Context, string) ([]entity.UserSkill, error)) *Repository_FindUserSkills_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUsersByTeam provides a mock function with given fields: ctx, teamName
+func (_m *Repository) FindUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUsersByTeam")
+	}
+
+	var r0 []*entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.User, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.User); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindUsersByTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUsersByTeam'
+type Repository_FindUsersByTeam_Call struct {
+	*mock.Call
+}
+
+// FindUsersByTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *Repository_Expecter) FindUsersByTeam(ctx interface{}, teamName interface{}) *Repository_FindUsersByTeam_Call {
+	return &Repository_FindUsersByTeam_Call{Call: _e.mock.On("FindUsersByTeam", ctx, teamName)}
+}
+
+func (_c *Repository_FindUsersByTeam_Call) Run(run func(ctx context.Context, teamName string)) *Repository_FindUsersByTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindUsersByTeam_Call) Return(_a0 []*entity.User, _a1 error) *Repository_FindUsersByTeam_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindUsersByTeam_Call) RunAndReturn(run func(context.Context, string) ([]*entity.User, error)) *Repository_FindUsersByTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindWorkSchedule provides a mock function with given fields: ctx, userID
+func (_m *Repository) FindWorkSchedule(ctx context.Context, userID string) (*entity.WorkSchedule, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWorkSchedule")
+	}
+
+	var r0 *entity.WorkSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.WorkSchedule, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.WorkSchedule); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WorkSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindWorkSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindWorkSchedule'
+type Repository_FindWorkSchedule_Call struct {
+	*mock.Call
+}
+
+// FindWorkSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) FindWorkSchedule(ctx interface{}, userID interface{}) *Repository_FindWorkSchedule_Call {
+	return &Repository_FindWorkSchedule_Call{Call: _e.mock.On("FindWorkSchedule", ctx, userID)}
+}
+
+func (_c *Repository_FindWorkSchedule_Call) Run(run func(ctx context.Context, userID string)) *Repository_FindWorkSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindWorkSchedule_Call) Return(_a0 *entity.WorkSchedule, _a1 error) *Repository_FindWorkSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindWorkSchedule_Call) RunAndReturn(run func(context.Context, string) (*entity.WorkSchedule, error)) *Repository_FindWorkSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindWorkSchedulesByTeam provides a mock function with given fields: ctx, teamName
+func (_m *Repository) FindWorkSchedulesByTeam(ctx context.Context, teamName string) ([]*entity.WorkSchedule, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWorkSchedulesByTeam")
+	}
+
+	var r0 []*entity.WorkSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.WorkSchedule, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.WorkSchedule); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.WorkSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindWorkSchedulesByTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindWorkSchedulesByTeam'
+type Repository_FindWorkSchedulesByTeam_Call struct {
+	*mock.Call
+}
+
+// FindWorkSchedulesByTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *Repository_Expecter) FindWorkSchedulesByTeam(ctx interface{}, teamName interface{}) *Repository_FindWorkSchedulesByTeam_Call {
+	return &Repository_FindWorkSchedulesByTeam_Call{Call: _e.mock.On("FindWorkSchedulesByTeam", ctx, teamName)}
+}
+
+func (_c *Repository_FindWorkSchedulesByTeam_Call) Run(run func(ctx context.Context, teamName string)) *Repository_FindWorkSchedulesByTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_FindWorkSchedulesByTeam_Call) Return(_a0 []*entity.WorkSchedule, _a1 error) *Repository_FindWorkSchedulesByTeam_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindWorkSchedulesByTeam_Call) RunAndReturn(run func(context.Context, string) ([]*entity.WorkSchedule, error)) *Repository_FindWorkSchedulesByTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCodeOwners provides a mock function with given fields: ctx
+func (_m *Repository) ListCodeOwners(ctx context.Context) ([]*entity.CodeOwners, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCodeOwners")
+	}
+
+	var r0 []*entity.CodeOwners
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.CodeOwners, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.CodeOwners); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.CodeOwners)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListCodeOwners_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCodeOwners'
+type Repository_ListCodeOwners_Call struct {
+	*mock.Call
+}
+
+// ListCodeOwners is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListCodeOwners(ctx interface{}) *Repository_ListCodeOwners_Call {
+	return &Repository_ListCodeOwners_Call{Call: _e.mock.On("ListCodeOwners", ctx)}
+}
+
+func (_c *Repository_ListCodeOwners_Call) Run(run func(ctx context.Context)) *Repository_ListCodeOwners_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListCodeOwners_Call) Return(_a0 []*entity.CodeOwners, _a1 error) *Repository_ListCodeOwners_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListCodeOwners_Call) RunAndReturn(run func(context.Context) ([]*entity.CodeOwners, error)) *Repository_ListCodeOwners_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPRs provides a mock function with given fields: ctx
+func (_m *Repository) ListPRs(ctx context.Context) ([]*entity.PullRequest, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPRs")
+	}
+
+	var r0 []*entity.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.PullRequest, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.PullRequest); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListPRs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPRs'
+type Repository_ListPRs_Call struct {
+	*mock.Call
+}
+
+// ListPRs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListPRs(ctx interface{}) *Repository_ListPRs_Call {
+	return &Repository_ListPRs_Call{Call: _e.mock.On("ListPRs", ctx)}
+}
+
+func (_c *Repository_ListPRs_Call) Run(run func(ctx context.Context)) *Repository_ListPRs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListPRs_Call) Return(_a0 []*entity.PullRequest, _a1 error) *Repository_ListPRs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListPRs_Call) RunAndReturn(run func(context.Context) ([]*entity.PullRequest, error)) *Repository_ListPRs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListReviewerRules provides a mock function with given fields: ctx
+func (_m *Repository) ListReviewerRules(ctx context.Context) ([]entity.ReviewerRule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReviewerRules")
+	}
+
+	var r0 []entity.ReviewerRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.ReviewerRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.ReviewerRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ReviewerRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListReviewerRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReviewerRules'
+type Repository_ListReviewerRules_Call struct {
+	*mock.Call
+}
+
+// ListReviewerRules is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListReviewerRules(ctx interface{}) *Repository_ListReviewerRules_Call {
+	return &Repository_ListReviewerRules_Call{Call: _e.mock.On("ListReviewerRules", ctx)}
+}
+
+func (_c *Repository_ListReviewerRules_Call) Run(run func(ctx context.Context)) *Repository_ListReviewerRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListReviewerRules_Call) Return(_a0 []entity.ReviewerRule, _a1 error) *Repository_ListReviewerRules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListReviewerRules_Call) RunAndReturn(run func(context.Context) ([]entity.ReviewerRule, error)) *Repository_ListReviewerRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTeams provides a mock function with given fields: ctx
+func (_m *Repository) ListTeams(ctx context.Context) ([]*entity.Team, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTeams")
+	}
+
+	var r0 []*entity.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Team, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Team); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTeams'
+type Repository_ListTeams_Call struct {
+	*mock.Call
+}
+
+// ListTeams is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListTeams(ctx interface{}) *Repository_ListTeams_Call {
+	return &Repository_ListTeams_Call{Call: _e.mock.On("ListTeams", ctx)}
+}
+
+func (_c *Repository_ListTeams_Call) Run(run func(ctx context.Context)) *Repository_ListTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListTeams_Call) Return(_a0 []*entity.Team, _a1 error) *Repository_ListTeams_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListTeams_Call) RunAndReturn(run func(context.Context) ([]*entity.Team, error)) *Repository_ListTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnavailabilities provides a mock function with given fields: ctx
+func (_m *Repository) ListUnavailabilities(ctx context.Context) ([]*entity.Unavailability, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnavailabilities")
+	}
+
+	var r0 []*entity.Unavailability
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.Unavailability, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Unavailability); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Unavailability)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListUnavailabilities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnavailabilities'
+type Repository_ListUnavailabilities_Call struct {
+	*mock.Call
+}
+
+// ListUnavailabilities is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListUnavailabilities(ctx interface{}) *Repository_ListUnavailabilities_Call {
+	return &Repository_ListUnavailabilities_Call{Call: _e.mock.On("ListUnavailabilities", ctx)}
+}
+
+func (_c *Repository_ListUnavailabilities_Call) Run(run func(ctx context.Context)) *Repository_ListUnavailabilities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListUnavailabilities_Call) Return(_a0 []*entity.Unavailability, _a1 error) *Repository_ListUnavailabilities_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListUnavailabilities_Call) RunAndReturn(run func(context.Context) ([]*entity.Unavailability, error)) *Repository_ListUnavailabilities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserAliases provides a mock function with given fields: ctx
+func (_m *Repository) ListUserAliases(ctx context.Context) ([]entity.UserAlias, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserAliases")
+	}
+
+	var r0 []entity.UserAlias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.UserAlias, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.UserAlias); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.UserAlias)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListUserAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserAliases'
+type Repository_ListUserAliases_Call struct {
+	*mock.Call
+}
+
+// ListUserAliases is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListUserAliases(ctx interface{}) *Repository_ListUserAliases_Call {
+	return &Repository_ListUserAliases_Call{Call: _e.mock.On("ListUserAliases", ctx)}
+}
+
+func (_c *Repository_ListUserAliases_Call) Run(run func(ctx context.Context)) *Repository_ListUserAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListUserAliases_Call) Return(_a0 []entity.UserAlias, _a1 error) *Repository_ListUserAliases_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListUserAliases_Call) RunAndReturn(run func(context.Context) ([]entity.UserAlias, error)) *Repository_ListUserAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserSkills provides a mock function with given fields: ctx
+func (_m *Repository) ListUserSkills(ctx context.Context) ([]entity.UserSkill, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserSkills")
+	}
+
+	var r0 []entity.UserSkill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.UserSkill, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.UserSkill); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.UserSkill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListUserSkills_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserSkills'
+type Repository_ListUserSkills_Call struct {
+	*mock.Call
+}
+
+// ListUserSkills is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListUserSkills(ctx interface{}) *Repository_ListUserSkills_Call {
+	return &Repository_ListUserSkills_Call{Call: _e.mock.On("ListUserSkills", ctx)}
+}
+
+func (_c *Repository_ListUserSkills_Call) Run(run func(ctx context.Context)) *Repository_ListUserSkills_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListUserSkills_Call) Return(_a0 []entity.UserSkill, _a1 error) *Repository_ListUserSkills_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListUserSkills_Call) RunAndReturn(run func(context.Context) ([]entity.UserSkill, error)) *Repository_ListUserSkills_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function with given fields: ctx
+func (_m *Repository) ListUsers(ctx context.Context) ([]*entity.User, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []*entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type Repository_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListUsers(ctx interface{}) *Repository_ListUsers_Call {
+	return &Repository_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx)}
+}
+
+func (_c *Repository_ListUsers_Call) Run(run func(ctx context.Context)) *Repository_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListUsers_Call) Return(_a0 []*entity.User, _a1 error) *Repository_ListUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListUsers_Call) RunAndReturn(run func(context.Context) ([]*entity.User, error)) *Repository_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWorkSchedules provides a mock function with given fields: ctx
+func (_m *Repository) ListWorkSchedules(ctx context.Context) ([]*entity.WorkSchedule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWorkSchedules")
+	}
+
+	var r0 []*entity.WorkSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.WorkSchedule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.WorkSchedule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.WorkSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListWorkSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWorkSchedules'
+type Repository_ListWorkSchedules_Call struct {
+	*mock.Call
+}
+
+// ListWorkSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) ListWorkSchedules(ctx interface{}) *Repository_ListWorkSchedules_Call {
+	return &Repository_ListWorkSchedules_Call{Call: _e.mock.On("ListWorkSchedules", ctx)}
+}
+
+func (_c *Repository_ListWorkSchedules_Call) Run(run func(ctx context.Context)) *Repository_ListWorkSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_ListWorkSchedules_Call) Return(_a0 []*entity.WorkSchedule, _a1 error) *Repository_ListWorkSchedules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListWorkSchedules_Call) RunAndReturn(run func(context.Context) ([]*entity.WorkSchedule, error)) *Repository_ListWorkSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkUnavailabilityReassigned provides a mock function with given fields: ctx, id, at
+func (_m *Repository) MarkUnavailabilityReassigned(ctx context.Context, id int64, at time.Time) error {
+	ret := _m.Called(ctx, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkUnavailabilityReassigned")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_MarkUnavailabilityReassigned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkUnavailabilityReassigned'
+type Repository_MarkUnavailabilityReassigned_Call struct {
+	*mock.Call
+}
+
+// MarkUnavailabilityReassigned is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - at time.Time
+func (_e *Repository_Expecter) MarkUnavailabilityReassigned(ctx interface{}, id interface{}, at interface{}) *Repository_MarkUnavailabilityReassigned_Call {
+	return &Repository_MarkUnavailabilityReassigned_Call{Call: _e.mock.On("MarkUnavailabilityReassigned", ctx, id, at)}
+}
+
+func (_c *Repository_MarkUnavailabilityReassigned_Call) Run(run func(ctx context.Context, id int64, at time.Time)) *Repository_MarkUnavailabilityReassigned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Repository_MarkUnavailabilityReassigned_Call) Return(_a0 error) *Repository_MarkUnavailabilityReassigned_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_MarkUnavailabilityReassigned_Call) RunAndReturn(run func(context.Context, int64, time.Time) error) *Repository_MarkUnavailabilityReassigned_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseIdempotencyKey provides a mock function with given fields: ctx, key, endpoint
+func (_m *Repository) ReleaseIdempotencyKey(ctx context.Context, key string, endpoint string) error {
+	ret := _m.Called(ctx, key, endpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, key, endpoint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_ReleaseIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseIdempotencyKey'
+type Repository_ReleaseIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// ReleaseIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - endpoint string
+func (_e *Repository_Expecter) ReleaseIdempotencyKey(ctx interface{}, key interface{}, endpoint interface{}) *Repository_ReleaseIdempotencyKey_Call {
+	return &Repository_ReleaseIdempotencyKey_Call{Call: _e.mock.On("ReleaseIdempotencyKey", ctx, key, endpoint)}
+}
+
+func (_c *Repository_ReleaseIdempotencyKey_Call) Run(run func(ctx context.Context, key string, endpoint string)) *Repository_ReleaseIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_ReleaseIdempotencyKey_Call) Return(_a0 error) *Repository_ReleaseIdempotencyKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_ReleaseIdempotencyKey_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_ReleaseIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReserveIdempotencyKey provides a mock function with given fields: ctx, record
+func (_m *Repository) ReserveIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) (bool, error) {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveIdempotencyKey")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.IdempotencyRecord) (bool, error)); ok {
+		return rf(ctx, record)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.IdempotencyRecord) bool); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.IdempotencyRecord) error); ok {
+		r1 = rf(ctx, record)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ReserveIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveIdempotencyKey'
+type Repository_ReserveIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// ReserveIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record *entity.IdempotencyRecord
+func (_e *Repository_Expecter) ReserveIdempotencyKey(ctx interface{}, record interface{}) *Repository_ReserveIdempotencyKey_Call {
+	return &Repository_ReserveIdempotencyKey_Call{Call: _e.mock.On("ReserveIdempotencyKey", ctx, record)}
+}
+
+func (_c *Repository_ReserveIdempotencyKey_Call) Run(run func(ctx context.Context, record *entity.IdempotencyRecord)) *Repository_ReserveIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.IdempotencyRecord))
+	})
+	return _c
+}
+
+func (_c *Repository_ReserveIdempotencyKey_Call) Return(_a0 bool, _a1 error) *Repository_ReserveIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ReserveIdempotencyKey_Call) RunAndReturn(run func(context.Context, *entity.IdempotencyRecord) (bool, error)) *Repository_ReserveIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestorePR provides a mock function with given fields: ctx, pr
+func (_m *Repository) RestorePR(ctx context.Context, pr *entity.PullRequest) error {
+	ret := _m.Called(ctx, pr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestorePR")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PullRequest) error); ok {
+		r0 = rf(ctx, pr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_RestorePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestorePR'
+type Repository_RestorePR_Call struct {
+	*mock.Call
+}
+
+// RestorePR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr *entity.PullRequest
+func (_e *Repository_Expecter) RestorePR(ctx interface{}, pr interface{}) *Repository_RestorePR_Call {
+	return &Repository_RestorePR_Call{Call: _e.mock.On("RestorePR", ctx, pr)}
+}
+
+func (_c *Repository_RestorePR_Call) Run(run func(ctx context.Context, pr *entity.PullRequest)) *Repository_RestorePR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.PullRequest))
+	})
+	return _c
+}
+
+func (_c *Repository_RestorePR_Call) Return(_a0 error) *Repository_RestorePR_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_RestorePR_Call) RunAndReturn(run func(context.Context, *entity.PullRequest) error) *Repository_RestorePR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetActive provides a mock function with given fields: ctx, userID, isActive
+func (_m *Repository) SetActive(ctx context.Context, userID string, isActive bool) error {
+	ret := _m.Called(ctx, userID, isActive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetActive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, userID, isActive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SetActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActive'
+type Repository_SetActive_Call struct {
+	*mock.Call
+}
+
+// SetActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - isActive bool
+func (_e *Repository_Expecter) SetActive(ctx interface{}, userID interface{}, isActive interface{}) *Repository_SetActive_Call {
+	return &Repository_SetActive_Call{Call: _e.mock.On("SetActive", ctx, userID, isActive)}
+}
+
+func (_c *Repository_SetActive_Call) Run(run func(ctx context.Context, userID string, isActive bool)) *Repository_SetActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *Repository_SetActive_Call) Return(_a0 error) *Repository_SetActive_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SetActive_Call) RunAndReturn(run func(context.Context, string, bool) error) *Repository_SetActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCodeOwners provides a mock function with given fields: ctx, owners
+func (_m *Repository) SetCodeOwners(ctx context.Context, owners *entity.CodeOwners) error {
+	ret := _m.Called(ctx, owners)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCodeOwners")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.CodeOwners) error); ok {
+		r0 = rf(ctx, owners)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SetCodeOwners_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCodeOwners'
+type Repository_SetCodeOwners_Call struct {
+	*mock.Call
+}
+
+// SetCodeOwners is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owners *entity.CodeOwners
+func (_e *Repository_Expecter) SetCodeOwners(ctx interface{}, owners interface{}) *Repository_SetCodeOwners_Call {
+	return &Repository_SetCodeOwners_Call{Call: _e.mock.On("SetCodeOwners", ctx, owners)}
+}
+
+func (_c *Repository_SetCodeOwners_Call) Run(run func(ctx context.Context, owners *entity.CodeOwners)) *Repository_SetCodeOwners_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.CodeOwners))
+	})
+	return _c
+}
+
+func (_c *Repository_SetCodeOwners_Call) Return(_a0 error) *Repository_SetCodeOwners_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SetCodeOwners_Call) RunAndReturn(run func(context.Context, *entity.CodeOwners) error) *Repository_SetCodeOwners_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMaxOpenReviews provides a mock function with given fields: ctx, userID, limit
+func (_m *Repository) SetMaxOpenReviews(ctx context.Context, userID string, limit int) error {
+	ret := _m.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMaxOpenReviews")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SetMaxOpenReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMaxOpenReviews'
+type Repository_SetMaxOpenReviews_Call struct {
+	*mock.Call
+}
+
+// SetMaxOpenReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - limit int
+func (_e *Repository_Expecter) SetMaxOpenReviews(ctx interface{}, userID interface{}, limit interface{}) *Repository_SetMaxOpenReviews_Call {
+	return &Repository_SetMaxOpenReviews_Call{Call: _e.mock.On("SetMaxOpenReviews", ctx, userID, limit)}
+}
+
+func (_c *Repository_SetMaxOpenReviews_Call) Run(run func(ctx context.Context, userID string, limit int)) *Repository_SetMaxOpenReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_SetMaxOpenReviews_Call) Return(_a0 error) *Repository_SetMaxOpenReviews_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SetMaxOpenReviews_Call) RunAndReturn(run func(context.Context, string, int) error) *Repository_SetMaxOpenReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetReviewerRule provides a mock function with given fields: ctx, rule
+func (_m *Repository) SetReviewerRule(ctx context.Context, rule *entity.ReviewerRule) error {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReviewerRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ReviewerRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SetReviewerRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReviewerRule'
+type Repository_SetReviewerRule_Call struct {
+	*mock.Call
+}
+
+// SetReviewerRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rule *entity.ReviewerRule
+func (_e *Repository_Expecter) SetReviewerRule(ctx interface{}, rule interface{}) *Repository_SetReviewerRule_Call {
+	return &Repository_SetReviewerRule_Call{Call: _e.mock.On("SetReviewerRule", ctx, rule)}
+}
+
+func (_c *Repository_SetReviewerRule_Call) Run(run func(ctx context.Context, rule *entity.ReviewerRule)) *Repository_SetReviewerRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.ReviewerRule))
+	})
+	return _c
+}
+
+func (_c *Repository_SetReviewerRule_Call) Return(_a0 error) *Repository_SetReviewerRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SetReviewerRule_Call) RunAndReturn(run func(context.Context, *entity.ReviewerRule) error) *Repository_SetReviewerRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTeamMaxOpenReviews provides a mock function with given fields: ctx, teamName, limit
+func (_m *Repository) SetTeamMaxOpenReviews(ctx context.Context, teamName string, limit int) error {
+	ret := _m.Called(ctx, teamName, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTeamMaxOpenReviews")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, teamName, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SetTeamMaxOpenReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTeamMaxOpenReviews'
+type Repository_SetTeamMaxOpenReviews_Call struct {
+	*mock.Call
+}
+
+// SetTeamMaxOpenReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - limit int
+func (_e *Repository_Expecter) SetTeamMaxOpenReviews(ctx interface{}, teamName interface{}, limit interface{}) *Repository_SetTeamMaxOpenReviews_Call {
+	return &Repository_SetTeamMaxOpenReviews_Call{Call: _e.mock.On("SetTeamMaxOpenReviews", ctx, teamName, limit)}
+}
+
+func (_c *Repository_SetTeamMaxOpenReviews_Call) Run(run func(ctx context.Context, teamName string, limit int)) *Repository_SetTeamMaxOpenReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_SetTeamMaxOpenReviews_Call) Return(_a0 error) *Repository_SetTeamMaxOpenReviews_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SetTeamMaxOpenReviews_Call) RunAndReturn(run func(context.Context, string, int) error) *Repository_SetTeamMaxOpenReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTeamReviewPolicy provides a mock function with given fields: ctx, teamName, policy
+func (_m *Repository) SetTeamReviewPolicy(ctx context.Context, teamName string, policy entity.ReviewPolicy) error {
+	ret := _m.Called(ctx, teamName, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTeamReviewPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.ReviewPolicy) error); ok {
+		r0 = rf(ctx, teamName, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SetTeamReviewPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTeamReviewPolicy'
+type Repository_SetTeamReviewPolicy_Call struct {
+	*mock.Call
+}
+
+// SetTeamReviewPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - policy entity.ReviewPolicy
+func (_e *Repository_Expecter) SetTeamReviewPolicy(ctx interface{}, teamName interface{}, policy interface{}) *Repository_SetTeamReviewPolicy_Call {
+	return &Repository_SetTeamReviewPolicy_Call{Call: _e.mock.On("SetTeamReviewPolicy", ctx, teamName, policy)}
+}
+
+func (_c *Repository_SetTeamReviewPolicy_Call) Run(run func(ctx context.Context, teamName string, policy entity.ReviewPolicy)) *Repository_SetTeamReviewPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.ReviewPolicy))
+	})
+	return _c
+}
+
+func (_c *Repository_SetTeamReviewPolicy_Call) Return(_a0 error) *Repository_SetTeamReviewPolicy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SetTeamReviewPolicy_Call) RunAndReturn(run func(context.Context, string, entity.ReviewPolicy) error) *Repository_SetTeamReviewPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserAliases provides a mock function with given fields: ctx, userID, aliases
+func (_m *Repository) SetUserAliases(ctx context.Context, userID string, aliases []string) error {
+	ret := _m.Called(ctx, userID, aliases)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserAliases")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, userID, aliases)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SetUserAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserAliases'
+type Repository_SetUserAliases_Call struct {
+	*mock.Call
+}
+
+// SetUserAliases is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - aliases []string
+func (_e *Repository_Expecter) SetUserAliases(ctx interface{}, userID interface{}, aliases interface{}) *Repository_SetUserAliases_Call {
+	return &Repository_SetUserAliases_Call{Call: _e.mock.On("SetUserAliases", ctx, userID, aliases)}
+}
+
+func (_c *Repository_SetUserAliases_Call) Run(run func(ctx context.Context, userID string, aliases []string)) *Repository_SetUserAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *Repository_SetUserAliases_Call) Return(_a0 error) *Repository_SetUserAliases_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SetUserAliases_Call) RunAndReturn(run func(context.Context, string, []string) error) *Repository_SetUserAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserLearner provides a mock function with given fields: ctx, userID, isLearner
+func (_m *Repository) SetUserLearner(ctx context.Context, userID string, isLearner bool) error {
+	ret := _m.Called(ctx, userID, isLearner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserLearner")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, userID, isLearner)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SetUserLearner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserLearner'
+type Repository_SetUserLearner_Call struct {
+	*mock.Call
+}
+
+// SetUserLearner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - isLearner bool
+func (_e *Repository_Expecter) SetUserLearner(ctx interface{}, userID interface{}, isLearner interface{}) *Repository_SetUserLearner_Call {
+	return &Repository_SetUserLearner_Call{Call: _e.mock.On("SetUserLearner", ctx, userID, isLearner)}
+}
+
+func (_c *Repository_SetUserLearner_Call) Run(run func(ctx context.Context, userID string, isLearner bool)) *Repository_SetUserLearner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *Repository_SetUserLearner_Call) Return(_a0 error) *Repository_SetUserLearner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SetUserLearner_Call) RunAndReturn(run func(context.Context, string, bool) error) *Repository_SetUserLearner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserLevel provides a mock function with given fields: ctx, userID, level
+func (_m *Repository) SetUserLevel(ctx context.Context, userID string, level entity.UserLevel) error {
+	ret := _m.Called(ctx, userID, level)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserLevel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.UserLevel) error); ok {
+		r0 = rf(ctx, userID, level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SetUserLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserLevel'
+type Repository_SetUserLevel_Call struct {
+	*mock.Call
+}
+
+// SetUserLevel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - level entity.UserLevel
+func (_e *Repository_Expecter) SetUserLevel(ctx interface{}, userID interface{}, level interface{}) *Repository_SetUserLevel_Call {
+	return &Repository_SetUserLevel_Call{Call: _e.mock.On("SetUserLevel", ctx, userID, level)}
+}
+
+func (_c *Repository_SetUserLevel_Call) Run(run func(ctx context.Context, userID string, level entity.UserLevel)) *Repository_SetUserLevel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.UserLevel))
+	})
+	return _c
+}
+
+func (_c *Repository_SetUserLevel_Call) Return(_a0 error) *Repository_SetUserLevel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SetUserLevel_Call) RunAndReturn(run func(context.Context, string, entity.UserLevel) error) *Repository_SetUserLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserSkills provides a mock function with given fields: ctx, userID, skills
+func (_m *Repository) SetUserSkills(ctx context.Context, userID string, skills []entity.UserSkill) error {
+	ret := _m.Called(ctx, userID, skills)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserSkills")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []entity.UserSkill) error); ok {
+		r0 = rf(ctx, userID, skills)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SetUserSkills_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserSkills'
+type Repository_SetUserSkills_Call struct {
+	*mock.Call
+}
+
+// SetUserSkills is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - skills []entity.UserSkill
+func (_e *Repository_Expecter) SetUserSkills(ctx interface{}, userID interface{}, skills interface{}) *Repository_SetUserSkills_Call {
+	return &Repository_SetUserSkills_Call{Call: _e.mock.On("SetUserSkills", ctx, userID, skills)}
+}
+
+func (_c *Repository_SetUserSkills_Call) Run(run func(ctx context.Context, userID string, skills []entity.UserSkill)) *Repository_SetUserSkills_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]entity.UserSkill))
+	})
+	return _c
+}
+
+func (_c *Repository_SetUserSkills_Call) Return(_a0 error) *Repository_SetUserSkills_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SetUserSkills_Call) RunAndReturn(run func(context.Context, string, []entity.UserSkill) error) *Repository_SetUserSkills_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetWorkSchedule provides a mock function with given fields: ctx, schedule
+func (_m *Repository) SetWorkSchedule(ctx context.Context, schedule *entity.WorkSchedule) error {
+	ret := _m.Called(ctx, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWorkSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.WorkSchedule) error); ok {
+		r0 = rf(ctx, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_SetWorkSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkSchedule'
+type Repository_SetWorkSchedule_Call struct {
+	*mock.Call
+}
+
+// SetWorkSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - schedule *entity.WorkSchedule
+func (_e *Repository_Expecter) SetWorkSchedule(ctx interface{}, schedule interface{}) *Repository_SetWorkSchedule_Call {
+	return &Repository_SetWorkSchedule_Call{Call: _e.mock.On("SetWorkSchedule", ctx, schedule)}
+}
+
+func (_c *Repository_SetWorkSchedule_Call) Run(run func(ctx context.Context, schedule *entity.WorkSchedule)) *Repository_SetWorkSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.WorkSchedule))
+	})
+	return _c
+}
+
+func (_c *Repository_SetWorkSchedule_Call) Return(_a0 error) *Repository_SetWorkSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_SetWorkSchedule_Call) RunAndReturn(run func(context.Context, *entity.WorkSchedule) error) *Repository_SetWorkSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TeamExists provides a mock function with given fields: ctx, teamName
+func (_m *Repository) TeamExists(ctx context.Context, teamName string) bool {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TeamExists")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Repository_TeamExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TeamExists'
+type Repository_TeamExists_Call struct {
+	*mock.Call
+}
+
+// TeamExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *Repository_Expecter) TeamExists(ctx interface{}, teamName interface{}) *Repository_TeamExists_Call {
+	return &Repository_TeamExists_Call{Call: _e.mock.On("TeamExists", ctx, teamName)}
+}
+
+func (_c *Repository_TeamExists_Call) Run(run func(ctx context.Context, teamName string)) *Repository_TeamExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_TeamExists_Call) Return(_a0 bool) *Repository_TeamExists_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_TeamExists_Call) RunAndReturn(run func(context.Context, string) bool) *Repository_TeamExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePR provides a mock function with given fields: ctx, pr
+func (_m *Repository) UpdatePR(ctx context.Context, pr *entity.PullRequest) error {
+	ret := _m.Called(ctx, pr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePR")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PullRequest) error); ok {
+		r0 = rf(ctx, pr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdatePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePR'
+type Repository_UpdatePR_Call struct {
+	*mock.Call
+}
+
+// UpdatePR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr *entity.PullRequest
+func (_e *Repository_Expecter) UpdatePR(ctx interface{}, pr interface{}) *Repository_UpdatePR_Call {
+	return &Repository_UpdatePR_Call{Call: _e.mock.On("UpdatePR", ctx, pr)}
+}
+
+func (_c *Repository_UpdatePR_Call) Run(run func(ctx context.Context, pr *entity.PullRequest)) *Repository_UpdatePR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.PullRequest))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdatePR_Call) Return(_a0 error) *Repository_UpdatePR_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_UpdatePR_Call) RunAndReturn(run func(context.Context, *entity.PullRequest) error) *Repository_UpdatePR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUnavailability provides a mock function with given fields: ctx, period
+func (_m *Repository) UpdateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	ret := _m.Called(ctx, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUnavailability")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Unavailability) error); ok {
+		r0 = rf(ctx, period)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUnavailability'
+type Repository_UpdateUnavailability_Call struct {
+	*mock.Call
+}
+
+// UpdateUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - period *entity.Unavailability
+func (_e *Repository_Expecter) UpdateUnavailability(ctx interface{}, period interface{}) *Repository_UpdateUnavailability_Call {
+	return &Repository_UpdateUnavailability_Call{Call: _e.mock.On("UpdateUnavailability", ctx, period)}
+}
+
+func (_c *Repository_UpdateUnavailability_Call) Run(run func(ctx context.Context, period *entity.Unavailability)) *Repository_UpdateUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Unavailability))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateUnavailability_Call) Return(_a0 error) *Repository_UpdateUnavailability_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_UpdateUnavailability_Call) RunAndReturn(run func(context.Context, *entity.Unavailability) error) *Repository_UpdateUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUser provides a mock function with given fields: ctx, user
+func (_m *Repository) UpdateUser(ctx context.Context, user *entity.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type Repository_UpdateUser_Call struct {
+	*mock.Call
+}
+
+// UpdateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *entity.User
+func (_e *Repository_Expecter) UpdateUser(ctx interface{}, user interface{}) *Repository_UpdateUser_Call {
+	return &Repository_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, user)}
+}
+
+func (_c *Repository_UpdateUser_Call) Run(run func(ctx context.Context, user *entity.User)) *Repository_UpdateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.User))
 	})
 	return _c
 }
@@ -720,7 +3222,54 @@ func (_c *Repository_UpdateUser_Call) Return(_a0 error) *Repository_UpdateUser_C
 	return _c
 }
 
-func (_c *Repository_UpdateUser_Call) RunAndReturn(run func(*entity.User) error) *Repository_UpdateUser_Call {
+func (_c *Repository_UpdateUser_Call) RunAndReturn(run func(context.Context, *entity.User) error) *Repository_UpdateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: ctx, fn
+func (_m *Repository) WithTx(ctx context.Context, fn func(interfaces.Repository) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(interfaces.Repository) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type Repository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(interfaces.Repository) error
+func (_e *Repository_Expecter) WithTx(ctx interface{}, fn interface{}) *Repository_WithTx_Call {
+	return &Repository_WithTx_Call{Call: _e.mock.On("WithTx", ctx, fn)}
+}
+
+func (_c *Repository_WithTx_Call) Run(run func(ctx context.Context, fn func(interfaces.Repository) error)) *Repository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(interfaces.Repository) error))
+	})
+	return _c
+}
+
+func (_c *Repository_WithTx_Call) Return(_a0 error) *Repository_WithTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_WithTx_Call) RunAndReturn(run func(context.Context, func(interfaces.Repository) error) error) *Repository_WithTx_Call {
 	_c.Call.Return(run)
 	return _c
 }
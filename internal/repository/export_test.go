@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+)
+
+// NewContractTestRepo отдаёт общий тестовый репозиторий из TestMain пустым
+// для контрактных тестов из пакета repository_test
+func NewContractTestRepo(t *testing.T) interfaces.Repository {
+	cleanupTestData()
+	t.Cleanup(cleanupTestData)
+	return testRepo
+}
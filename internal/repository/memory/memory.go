@@ -0,0 +1,1331 @@
+// Package memory содержит хранилище в памяти процесса. Оно реализует
+// interfaces.Repository с той же семантикой ошибок, что и PRRepository,
+// и подходит для локального запуска без базы данных и для тестов
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+)
+
+type idempotencyKey struct {
+	key      string
+	endpoint string
+}
+
+type reviewerPair struct {
+	authorID   string
+	reviewerID string
+}
+
+// state - все данные хранилища. Копируется целиком при старте WithTx,
+// чтобы откатить изменения при ошибке
+type state struct {
+	teams       map[string]teamSettings
+	users       map[string]entity.User
+	prs         map[string]entity.PullRequest
+	idempotency map[idempotencyKey]entity.IdempotencyRecord
+
+	unavailability       map[int64]entity.Unavailability
+	lastUnavailabilityID int64
+	// aliases - владелец каждого псевдонима
+	aliases map[string]string
+	// schedules - рабочее время по user_id
+	schedules map[string]entity.WorkSchedule
+	// codeOwners - правила CODEOWNERS по репозиторию, без пустых списков
+	codeOwners map[string][]entity.CodeOwnerRule
+	// skills - уровень каждого навыка по user_id и тегу
+	skills map[string]map[string]int
+	// reviewerRules - правила пар автор-ревьювер
+	reviewerRules map[reviewerPair]entity.ReviewerRule
+
+	// undo - журнал отката идущей транзакции, nil вне транзакции
+	undo *undoLog
+}
+
+// teamSettings - настройки команды без её участников
+type teamSettings struct {
+	maxOpenReviews int
+	policy         entity.ReviewPolicy
+}
+
+func newState() *state {
+	return &state{
+		teams:       make(map[string]teamSettings),
+		users:       make(map[string]entity.User),
+		prs:         make(map[string]entity.PullRequest),
+		idempotency: make(map[idempotencyKey]entity.IdempotencyRecord),
+
+		unavailability: make(map[int64]entity.Unavailability),
+		aliases:        make(map[string]string),
+		schedules:      make(map[string]entity.WorkSchedule),
+		codeOwners:     make(map[string][]entity.CodeOwnerRule),
+		skills:         make(map[string]map[string]int),
+		reviewerRules:  make(map[reviewerPair]entity.ReviewerRule),
+	}
+}
+
+// put и remove меняют таблицу состояния, запоминая прежнее значение в журнале отката.
+// Значения в таблицах не меняются на месте, а только заменяются целиком, поэтому
+// для отката достаточно вернуть прежнее значение по ключу
+func put[K comparable, V any](st *state, table map[K]V, key K, value V) {
+	remember(st, table, key)
+	table[key] = value
+}
+
+func remove[K comparable, V any](st *state, table map[K]V, key K) {
+	remember(st, table, key)
+	delete(table, key)
+}
+
+// remember добавляет в журнал отката идущей транзакции восстановление table[key]
+func remember[K comparable, V any](st *state, table map[K]V, key K) {
+	if st.undo == nil {
+		return
+	}
+	prev, ok := table[key]
+	*st.undo = append(*st.undo, func() {
+		if ok {
+			table[key] = prev
+		} else {
+			delete(table, key)
+		}
+	})
+}
+
+// undoLog - журнал отката транзакции
+type undoLog []func()
+
+// rollback возвращает прежние значения в обратном порядке изменений
+func (log undoLog) rollback() {
+	for i := len(log) - 1; i >= 0; i-- {
+		log[i]()
+	}
+}
+
+type store struct {
+	mu   sync.RWMutex
+	data *state
+}
+
+type MemoryRepository struct {
+	store  *store
+	inTx   bool // true внутри WithTx: блокировка уже захвачена транзакцией
+	logger interfaces.Logger
+}
+
+func NewMemoryRepository(logger interfaces.Logger) *MemoryRepository {
+	logger.Info("MEMORY_REPO", "In-memory repository initialized successfully")
+	return &MemoryRepository{
+		store:  &store{data: newState()},
+		logger: logger,
+	}
+}
+
+func (repo *MemoryRepository) Close() error {
+	repo.logger.Info("MEMORY_REPO", "Closing in-memory repository")
+	return nil
+}
+
+// WithTx выполняет fn под эксклюзивной блокировкой хранилища: транзакции
+// выполняются строго по очереди, поэтому FindPRByIDForUpdate не нужна отдельная
+// блокировка строки. При ошибке fn все изменения откатываются
+func (repo *MemoryRepository) WithTx(ctx context.Context, fn func(repo interfaces.Repository) error) error {
+	if repo.inTx {
+		// Уже внутри транзакции - вложенные вызовы используют её же
+		return fn(repo)
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	// Вместо копии всего хранилища запоминаются только изменённые значения
+	var undo undoLog
+	repo.store.data.undo = &undo
+	defer func() { repo.store.data.undo = nil }()
+
+	txRepo := &MemoryRepository{
+		store:  repo.store,
+		inTx:   true,
+		logger: repo.logger,
+	}
+
+	if err := fn(txRepo); err != nil {
+		undo.rollback()
+		return err
+	}
+	return nil
+}
+
+// read и write выполняют fn под нужной блокировкой, если вызов не внутри WithTx
+func (repo *MemoryRepository) read(ctx context.Context, fn func(st *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !repo.inTx {
+		repo.store.mu.RLock()
+		defer repo.store.mu.RUnlock()
+	}
+	return fn(repo.store.data)
+}
+
+func (repo *MemoryRepository) write(ctx context.Context, fn func(st *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !repo.inTx {
+		repo.store.mu.Lock()
+		defer repo.store.mu.Unlock()
+	}
+	return fn(repo.store.data)
+}
+
+// Users
+
+func (repo *MemoryRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	repo.logger.Debug("MEMORY_CREATE_USER", "Creating user",
+		"user_id", user.UserID,
+		"team_name", user.TeamName)
+
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.teams[user.TeamName]; !ok {
+			return fmt.Errorf("get team ID: %w", entity.ErrNoTeam)
+		}
+		if _, ok := st.users[user.UserID]; ok {
+			return entity.ErrUserExists
+		}
+		put(st, st.users, user.UserID, *user)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindUserByID(ctx context.Context, userID string) (*entity.User, error) {
+	var user entity.User
+	err := repo.read(ctx, func(st *state) error {
+		found, ok := st.users[userID]
+		if !ok {
+			return fmt.Errorf("user not found: %w", entity.ErrNoUser)
+		}
+		user = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (repo *MemoryRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	repo.logger.Debug("MEMORY_UPDATE_USER", "Updating user",
+		"user_id", user.UserID,
+		"team_name", user.TeamName,
+		"is_active", user.IsActive)
+
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.teams[user.TeamName]; !ok {
+			return fmt.Errorf("get team ID: %w", entity.ErrNoTeam)
+		}
+		existing, ok := st.users[user.UserID]
+		if !ok {
+			return entity.ErrNoUser
+		}
+		// Предел открытых ревью, уровень и отметка ученика меняются только своими методами
+		updated := *user
+		updated.MaxOpenReviews = existing.MaxOpenReviews
+		updated.Level = existing.Level
+		updated.IsLearner = existing.IsLearner
+		put(st, st.users, user.UserID, updated)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
+	var users []*entity.User
+	err := repo.read(ctx, func(st *state) error {
+		if _, ok := st.teams[teamName]; !ok {
+			return fmt.Errorf("find users by team: %w", entity.ErrNoTeam)
+		}
+		for _, user := range st.users {
+			if user.TeamName == teamName {
+				u := user
+				users = append(users, &u)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Порядок как в PRRepository: ORDER BY user_id
+	sort.Slice(users, func(i, j int) bool { return users[i].UserID < users[j].UserID })
+	return users, nil
+}
+
+func (repo *MemoryRepository) ListUsers(ctx context.Context) ([]*entity.User, error) {
+	var users []*entity.User
+	err := repo.read(ctx, func(st *state) error {
+		for _, user := range st.users {
+			u := user
+			users = append(users, &u)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Порядок как в PRRepository: ORDER BY team_name, user_id
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].TeamName != users[j].TeamName {
+			return users[i].TeamName < users[j].TeamName
+		}
+		return users[i].UserID < users[j].UserID
+	})
+	return users, nil
+}
+
+func (repo *MemoryRepository) SetActive(ctx context.Context, userID string, isActive bool) error {
+	repo.logger.Debug("MEMORY_SET_ACTIVE", "Setting user active status",
+		"user_id", userID,
+		"is_active", isActive)
+
+	return repo.write(ctx, func(st *state) error {
+		user, ok := st.users[userID]
+		if !ok {
+			return entity.ErrNoUser
+		}
+		user.IsActive = isActive
+		put(st, st.users, userID, user)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) SetMaxOpenReviews(ctx context.Context, userID string, limit int) error {
+	return repo.write(ctx, func(st *state) error {
+		user, ok := st.users[userID]
+		if !ok {
+			return fmt.Errorf("user %s: %w", userID, entity.ErrNoUser)
+		}
+		user.MaxOpenReviews = limit
+		put(st, st.users, userID, user)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) SetUserLevel(ctx context.Context, userID string, level entity.UserLevel) error {
+	return repo.write(ctx, func(st *state) error {
+		user, ok := st.users[userID]
+		if !ok {
+			return fmt.Errorf("user %s: %w", userID, entity.ErrNoUser)
+		}
+		user.Level = level
+		put(st, st.users, userID, user)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) SetUserLearner(ctx context.Context, userID string, isLearner bool) error {
+	return repo.write(ctx, func(st *state) error {
+		user, ok := st.users[userID]
+		if !ok {
+			return fmt.Errorf("user %s: %w", userID, entity.ErrNoUser)
+		}
+		user.IsLearner = isLearner
+		put(st, st.users, userID, user)
+		return nil
+	})
+}
+
+// FindReviewLoads возвращает открытые ревью участников команды: их число и размер.
+// Участники без открытых ревью в результат не попадают
+func (repo *MemoryRepository) FindReviewLoads(ctx context.Context, teamName string) (map[string]entity.ReviewLoad, error) {
+	loads := make(map[string]entity.ReviewLoad)
+	err := repo.read(ctx, func(st *state) error {
+		for _, pr := range st.prs {
+			if pr.Status != entity.PullRequestStatusOpen {
+				continue
+			}
+			for _, reviewerID := range pr.AssignedReviewers {
+				if user, ok := st.users[reviewerID]; ok && user.TeamName == teamName {
+					load := loads[reviewerID]
+					load.OpenReviews++
+					load.ChangedLines += pr.Metadata.ChangedLines()
+					loads[reviewerID] = load
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loads, nil
+}
+
+// FindPairReviewCounts возвращает, на сколько PR автора, созданных не раньше since,
+// назначен ревьювером каждый пользователь, включая смерженные PR.
+// Пользователи без таких ревью в результат не попадают
+func (repo *MemoryRepository) FindPairReviewCounts(ctx context.Context, authorID string, since time.Time) (map[string]int, error) {
+	counts := make(map[string]int)
+	err := repo.read(ctx, func(st *state) error {
+		for _, pr := range st.prs {
+			if pr.AuthorID != authorID || pr.CreatedAt.Before(since) {
+				continue
+			}
+			for _, reviewerID := range pr.AssignedReviewers {
+				counts[reviewerID]++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// Teams
+
+func (repo *MemoryRepository) CreateTeam(ctx context.Context, team *entity.Team) error {
+	repo.logger.Debug("MEMORY_CREATE_TEAM", "Creating team",
+		"team_name", team.TeamName,
+		"members_count", len(team.Members))
+
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.teams[team.TeamName]; ok {
+			return entity.ErrTeamExists
+		}
+		// Сначала проверяем всех участников, чтобы не оставить команду созданной наполовину
+		seen := make(map[string]struct{}, len(team.Members))
+		for _, member := range team.Members {
+			if _, ok := st.users[member.UserID]; ok {
+				return entity.ErrUserExists
+			}
+			if _, ok := seen[member.UserID]; ok {
+				return entity.ErrUserExists
+			}
+			seen[member.UserID] = struct{}{}
+		}
+
+		put(st, st.teams, team.TeamName, teamSettings{maxOpenReviews: team.MaxOpenReviews, policy: team.ReviewPolicy})
+		for _, member := range team.Members {
+			put(st, st.users, member.UserID, entity.User{
+				UserID:         member.UserID,
+				Username:       member.Username,
+				TeamName:       team.TeamName,
+				IsActive:       member.IsActive,
+				MaxOpenReviews: member.MaxOpenReviews,
+				Level:          member.Level,
+				IsLearner:      member.IsLearner,
+			})
+		}
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindTeamByName(ctx context.Context, teamName string) (*entity.Team, error) {
+	var members []entity.TeamMember
+	var settings teamSettings
+	err := repo.read(ctx, func(st *state) error {
+		var ok bool
+		settings, ok = st.teams[teamName]
+		if !ok {
+			return entity.ErrNoTeam
+		}
+		for _, user := range st.users {
+			if user.TeamName == teamName {
+				members = append(members, entity.TeamMember{
+					UserID:         user.UserID,
+					Username:       user.Username,
+					IsActive:       user.IsActive,
+					MaxOpenReviews: user.MaxOpenReviews,
+					Level:          user.Level,
+					IsLearner:      user.IsLearner,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(members, func(i, j int) bool { return members[i].UserID < members[j].UserID })
+	return &entity.Team{
+		TeamName:       teamName,
+		Members:        members,
+		MaxOpenReviews: settings.maxOpenReviews,
+		ReviewPolicy:   settings.policy,
+	}, nil
+}
+
+func (repo *MemoryRepository) TeamExists(ctx context.Context, teamName string) bool {
+	exists := false
+	err := repo.read(ctx, func(st *state) error {
+		_, exists = st.teams[teamName]
+		return nil
+	})
+	if err != nil {
+		repo.logger.Error("MEMORY_TEAM_EXISTS", "Failed to check team existence",
+			"team_name", teamName, "error", err)
+		return false
+	}
+	return exists
+}
+
+func (repo *MemoryRepository) SetTeamMaxOpenReviews(ctx context.Context, teamName string, limit int) error {
+	return repo.write(ctx, func(st *state) error {
+		settings, ok := st.teams[teamName]
+		if !ok {
+			return entity.ErrNoTeam
+		}
+		settings.maxOpenReviews = limit
+		put(st, st.teams, teamName, settings)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) SetTeamReviewPolicy(ctx context.Context, teamName string, policy entity.ReviewPolicy) error {
+	return repo.write(ctx, func(st *state) error {
+		settings, ok := st.teams[teamName]
+		if !ok {
+			return entity.ErrNoTeam
+		}
+		settings.policy = policy
+		put(st, st.teams, teamName, settings)
+		return nil
+	})
+}
+
+// ListTeams возвращает все команды, включая пустые, отсортированные по имени
+func (repo *MemoryRepository) ListTeams(ctx context.Context) ([]*entity.Team, error) {
+	var teams []*entity.Team
+	err := repo.read(ctx, func(st *state) error {
+		byName := make(map[string]*entity.Team, len(st.teams))
+		for name, settings := range st.teams {
+			team := &entity.Team{TeamName: name, MaxOpenReviews: settings.maxOpenReviews, ReviewPolicy: settings.policy}
+			byName[name] = team
+			teams = append(teams, team)
+		}
+		for _, user := range st.users {
+			team := byName[user.TeamName]
+			team.Members = append(team.Members, entity.TeamMember{
+				UserID:         user.UserID,
+				Username:       user.Username,
+				IsActive:       user.IsActive,
+				MaxOpenReviews: user.MaxOpenReviews,
+				Level:          user.Level,
+				IsLearner:      user.IsLearner,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(teams, func(i, j int) bool { return teams[i].TeamName < teams[j].TeamName })
+	for _, team := range teams {
+		members := team.Members
+		sort.Slice(members, func(i, j int) bool { return members[i].UserID < members[j].UserID })
+	}
+	return teams, nil
+}
+
+// PRs
+
+func (repo *MemoryRepository) CreatePR(ctx context.Context, pr *entity.PullRequest) error {
+	repo.logger.Debug("MEMORY_CREATE_PR", "Creating pull request",
+		"pr_id", pr.PullRequestID,
+		"author_id", pr.AuthorID,
+		"reviewers_count", len(pr.AssignedReviewers))
+
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.prs[pr.PullRequestID]; ok {
+			return entity.ErrPRExists
+		}
+		if _, ok := st.users[pr.AuthorID]; !ok {
+			return fmt.Errorf("author %s: %w", pr.AuthorID, entity.ErrNoUser)
+		}
+		if err := checkReviewersExist(st, pr.AssignedReviewers); err != nil {
+			return err
+		}
+
+		stored := copyPR(*pr)
+		stored.CreatedAt = time.Now()
+		stored.MergedAt = time.Time{}
+		stored.Version = 1
+		put(st, st.prs, pr.PullRequestID, stored)
+
+		pr.Version = stored.Version
+		return nil
+	})
+}
+
+// RestorePR сохраняет PR как есть: статус, время создания и слияния, версию и ревьюверов.
+// Используется при восстановлении из снапшота
+func (repo *MemoryRepository) RestorePR(ctx context.Context, pr *entity.PullRequest) error {
+	repo.logger.Debug("MEMORY_RESTORE_PR", "Restoring pull request",
+		"pr_id", pr.PullRequestID,
+		"status", pr.Status,
+		"version", pr.Version)
+
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.prs[pr.PullRequestID]; ok {
+			return entity.ErrPRExists
+		}
+		if _, ok := st.users[pr.AuthorID]; !ok {
+			return fmt.Errorf("author %s: %w", pr.AuthorID, entity.ErrNoUser)
+		}
+		if err := checkReviewersExist(st, pr.AssignedReviewers); err != nil {
+			return err
+		}
+
+		put(st, st.prs, pr.PullRequestID, copyPR(*pr))
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindPRByID(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	var pr entity.PullRequest
+	err := repo.read(ctx, func(st *state) error {
+		found, ok := st.prs[prID]
+		if !ok {
+			return entity.ErrNoPR
+		}
+		pr = copyPR(found)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// FindPRByIDForUpdate совпадает с FindPRByID: внутри WithTx хранилище
+// уже заблокировано целиком
+func (repo *MemoryRepository) FindPRByIDForUpdate(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	return repo.FindPRByID(ctx, prID)
+}
+
+func (repo *MemoryRepository) UpdatePR(ctx context.Context, pr *entity.PullRequest) error {
+	repo.logger.Debug("MEMORY_UPDATE_PR", "Updating pull request",
+		"pr_id", pr.PullRequestID,
+		"status", pr.Status,
+		"version", pr.Version)
+
+	return repo.write(ctx, func(st *state) error {
+		stored, ok := st.prs[pr.PullRequestID]
+		if !ok {
+			return entity.ErrNoPR
+		}
+		if stored.Version != pr.Version {
+			repo.logger.Warn("MEMORY_UPDATE_PR", "Pull request version conflict",
+				"pr_id", pr.PullRequestID,
+				"version", pr.Version)
+			return entity.ErrVersionConflict
+		}
+		if err := checkReviewersExist(st, pr.AssignedReviewers); err != nil {
+			return err
+		}
+
+		// Как и в PRRepository, автор и время создания не меняются
+		stored.PullRequestName = pr.PullRequestName
+		stored.Status = pr.Status
+		stored.MergedAt = pr.MergedAt
+		stored.AssignedReviewers = sortedReviewers(pr.AssignedReviewers)
+		stored.ShadowReviewer = pr.ShadowReviewer
+		stored.Version++
+		put(st, st.prs, pr.PullRequestID, stored)
+
+		pr.Version = stored.Version
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindPRsByReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+	var prs []*entity.PullRequest
+	err := repo.read(ctx, func(st *state) error {
+		for _, pr := range st.prs {
+			for _, reviewerID := range pr.AssignedReviewers {
+				if reviewerID == userID {
+					cp := copyPR(pr)
+					prs = append(prs, &cp)
+					break
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Порядок как в PRRepository: ORDER BY created_at DESC
+	sort.Slice(prs, func(i, j int) bool {
+		if prs[i].CreatedAt.Equal(prs[j].CreatedAt) {
+			return prs[i].PullRequestID < prs[j].PullRequestID
+		}
+		return prs[i].CreatedAt.After(prs[j].CreatedAt)
+	})
+	return prs, nil
+}
+
+func (repo *MemoryRepository) FindPRsByShadowReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+	var prs []*entity.PullRequest
+	err := repo.read(ctx, func(st *state) error {
+		for _, pr := range st.prs {
+			if pr.ShadowReviewer == userID {
+				cp := copyPR(pr)
+				prs = append(prs, &cp)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(prs, func(i, j int) bool {
+		if prs[i].CreatedAt.Equal(prs[j].CreatedAt) {
+			return prs[i].PullRequestID < prs[j].PullRequestID
+		}
+		return prs[i].CreatedAt.After(prs[j].CreatedAt)
+	})
+	return prs, nil
+}
+
+func (repo *MemoryRepository) ListPRs(ctx context.Context) ([]*entity.PullRequest, error) {
+	var prs []*entity.PullRequest
+	err := repo.read(ctx, func(st *state) error {
+		for _, pr := range st.prs {
+			cp := copyPR(pr)
+			prs = append(prs, &cp)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Порядок как в PRRepository: ORDER BY created_at, pull_request_id
+	sort.Slice(prs, func(i, j int) bool {
+		if prs[i].CreatedAt.Equal(prs[j].CreatedAt) {
+			return prs[i].PullRequestID < prs[j].PullRequestID
+		}
+		return prs[i].CreatedAt.Before(prs[j].CreatedAt)
+	})
+	return prs, nil
+}
+
+// Unavailability
+
+func (repo *MemoryRepository) CreateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	repo.logger.Debug("MEMORY_CREATE_UNAVAILABILITY", "Creating unavailability period",
+		"user_id", period.UserID,
+		"starts_at", period.StartsAt,
+		"ends_at", period.EndsAt)
+
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.users[period.UserID]; !ok {
+			return fmt.Errorf("user %s: %w", period.UserID, entity.ErrNoUser)
+		}
+		// Как и последовательность в PostgreSQL, счётчик при откате транзакции не возвращается
+		st.lastUnavailabilityID++
+		period.ID = st.lastUnavailabilityID
+		put(st, st.unavailability, period.ID, *period)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindUnavailabilitiesByUser(ctx context.Context, userID string) ([]*entity.Unavailability, error) {
+	return repo.findUnavailabilities(ctx, func(period entity.Unavailability) bool {
+		return period.UserID == userID
+	})
+}
+
+func (repo *MemoryRepository) ListUnavailabilities(ctx context.Context) ([]*entity.Unavailability, error) {
+	periods, err := repo.findUnavailabilities(ctx, func(entity.Unavailability) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	// Порядок как в PRRepository: ORDER BY user_id, starts_at, unavailability_id
+	sort.SliceStable(periods, func(i, j int) bool { return periods[i].UserID < periods[j].UserID })
+	return periods, nil
+}
+
+func (repo *MemoryRepository) FindPendingReassignments(ctx context.Context, at time.Time) ([]*entity.Unavailability, error) {
+	return repo.findUnavailabilities(ctx, func(period entity.Unavailability) bool {
+		return period.ReassignReviews && period.ReassignedAt.IsZero() && period.Covers(at)
+	})
+}
+
+// findUnavailabilities возвращает подходящие периоды по возрастанию начала, затем ID
+func (repo *MemoryRepository) findUnavailabilities(ctx context.Context, match func(entity.Unavailability) bool) ([]*entity.Unavailability, error) {
+	var periods []*entity.Unavailability
+	err := repo.read(ctx, func(st *state) error {
+		for _, period := range st.unavailability {
+			if match(period) {
+				cp := period
+				periods = append(periods, &cp)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		if periods[i].StartsAt.Equal(periods[j].StartsAt) {
+			return periods[i].ID < periods[j].ID
+		}
+		return periods[i].StartsAt.Before(periods[j].StartsAt)
+	})
+	return periods, nil
+}
+
+func (repo *MemoryRepository) DeleteUnavailability(ctx context.Context, id int64) error {
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.unavailability[id]; !ok {
+			return entity.ErrNoUnavailability
+		}
+		remove(st, st.unavailability, id)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindUnavailableUserIDs(ctx context.Context, teamName string, at time.Time) ([]string, error) {
+	var userIDs []string
+	err := repo.read(ctx, func(st *state) error {
+		seen := make(map[string]struct{})
+		for _, period := range st.unavailability {
+			if _, ok := seen[period.UserID]; ok || !period.Covers(at) {
+				continue
+			}
+			if st.users[period.UserID].TeamName == teamName {
+				seen[period.UserID] = struct{}{}
+				userIDs = append(userIDs, period.UserID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+func (repo *MemoryRepository) MarkUnavailabilityReassigned(ctx context.Context, id int64, at time.Time) error {
+	return repo.write(ctx, func(st *state) error {
+		period, ok := st.unavailability[id]
+		if !ok {
+			return entity.ErrNoUnavailability
+		}
+		period.ReassignedAt = at
+		put(st, st.unavailability, id, period)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) UpdateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	repo.logger.Debug("MEMORY_UPDATE_UNAVAILABILITY", "Updating unavailability period",
+		"unavailability_id", period.ID,
+		"starts_at", period.StartsAt,
+		"ends_at", period.EndsAt)
+
+	return repo.write(ctx, func(st *state) error {
+		stored, ok := st.unavailability[period.ID]
+		if !ok {
+			return entity.ErrNoUnavailability
+		}
+		updated := *period
+		// Владельца периода UPDATE в PRRepository не меняет
+		updated.UserID = stored.UserID
+		put(st, st.unavailability, period.ID, updated)
+		return nil
+	})
+}
+
+// Aliases
+
+func (repo *MemoryRepository) SetUserAliases(ctx context.Context, userID string, aliases []string) error {
+	repo.logger.Debug("MEMORY_SET_USER_ALIASES", "Setting user aliases",
+		"user_id", userID,
+		"aliases_count", len(aliases))
+
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.users[userID]; !ok {
+			return fmt.Errorf("user %s: %w", userID, entity.ErrNoUser)
+		}
+		// Проверяем всё до изменений, чтобы ошибка не оставила псевдонимы заменёнными наполовину
+		seen := make(map[string]struct{}, len(aliases))
+		for _, alias := range aliases {
+			owner, taken := st.aliases[alias]
+			if _, dup := seen[alias]; dup || (taken && owner != userID) {
+				return fmt.Errorf("alias %s: %w", alias, entity.ErrAliasTaken)
+			}
+			seen[alias] = struct{}{}
+		}
+
+		for alias, owner := range st.aliases {
+			if owner == userID {
+				remove(st, st.aliases, alias)
+			}
+		}
+		for _, alias := range aliases {
+			put(st, st.aliases, alias, userID)
+		}
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindUserAliases(ctx context.Context, userID string) ([]string, error) {
+	aliases := []string{}
+	err := repo.read(ctx, func(st *state) error {
+		for alias, owner := range st.aliases {
+			if owner == userID {
+				aliases = append(aliases, alias)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(aliases)
+	return aliases, nil
+}
+
+func (repo *MemoryRepository) ListUserAliases(ctx context.Context) ([]entity.UserAlias, error) {
+	var aliases []entity.UserAlias
+	err := repo.read(ctx, func(st *state) error {
+		for alias, userID := range st.aliases {
+			aliases = append(aliases, entity.UserAlias{Alias: alias, UserID: userID})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(aliases, func(i, j int) bool {
+		if aliases[i].UserID == aliases[j].UserID {
+			return aliases[i].Alias < aliases[j].Alias
+		}
+		return aliases[i].UserID < aliases[j].UserID
+	})
+	return aliases, nil
+}
+
+func (repo *MemoryRepository) FindUserIDByAlias(ctx context.Context, alias string) (string, error) {
+	var userID string
+	err := repo.read(ctx, func(st *state) error {
+		owner, ok := st.aliases[alias]
+		if !ok {
+			return fmt.Errorf("alias %s: %w", alias, entity.ErrNoUser)
+		}
+		userID = owner
+		return nil
+	})
+	return userID, err
+}
+
+// Work schedules
+
+func copyWorkSchedule(schedule entity.WorkSchedule) entity.WorkSchedule {
+	schedule.Days = append([]time.Weekday(nil), schedule.Days...)
+	return schedule
+}
+
+func (repo *MemoryRepository) SetWorkSchedule(ctx context.Context, schedule *entity.WorkSchedule) error {
+	repo.logger.Debug("MEMORY_SET_WORK_SCHEDULE", "Setting work schedule",
+		"user_id", schedule.UserID,
+		"time_zone", schedule.TimeZone)
+
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.users[schedule.UserID]; !ok {
+			return fmt.Errorf("user %s: %w", schedule.UserID, entity.ErrNoUser)
+		}
+		// Дни хранятся как в SQL хранилищах: без повторов, начиная с воскресенья
+		stored := copyWorkSchedule(*schedule)
+		stored.Days = entity.WorkDaysFromMask(entity.WorkDaysMask(schedule.Days))
+		put(st, st.schedules, schedule.UserID, stored)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindWorkSchedule(ctx context.Context, userID string) (*entity.WorkSchedule, error) {
+	var result *entity.WorkSchedule
+	err := repo.read(ctx, func(st *state) error {
+		schedule, ok := st.schedules[userID]
+		if !ok {
+			return fmt.Errorf("user %s: %w", userID, entity.ErrNoWorkSchedule)
+		}
+		schedule = copyWorkSchedule(schedule)
+		result = &schedule
+		return nil
+	})
+	return result, err
+}
+
+func (repo *MemoryRepository) FindWorkSchedulesByTeam(ctx context.Context, teamName string) ([]*entity.WorkSchedule, error) {
+	return repo.listWorkSchedules(ctx, func(st *state, schedule entity.WorkSchedule) bool {
+		return st.users[schedule.UserID].TeamName == teamName
+	})
+}
+
+func (repo *MemoryRepository) ListWorkSchedules(ctx context.Context) ([]*entity.WorkSchedule, error) {
+	return repo.listWorkSchedules(ctx, func(*state, entity.WorkSchedule) bool { return true })
+}
+
+func (repo *MemoryRepository) listWorkSchedules(ctx context.Context, match func(st *state, schedule entity.WorkSchedule) bool) ([]*entity.WorkSchedule, error) {
+	var schedules []*entity.WorkSchedule
+	err := repo.read(ctx, func(st *state) error {
+		for _, schedule := range st.schedules {
+			if match(st, schedule) {
+				schedule = copyWorkSchedule(schedule)
+				schedules = append(schedules, &schedule)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].UserID < schedules[j].UserID })
+	return schedules, nil
+}
+
+func (repo *MemoryRepository) DeleteWorkSchedule(ctx context.Context, userID string) error {
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.schedules[userID]; !ok {
+			return fmt.Errorf("user %s: %w", userID, entity.ErrNoWorkSchedule)
+		}
+		remove(st, st.schedules, userID)
+		return nil
+	})
+}
+
+// Skills
+
+func (repo *MemoryRepository) SetUserSkills(ctx context.Context, userID string, skills []entity.UserSkill) error {
+	repo.logger.Debug("MEMORY_SET_USER_SKILLS", "Setting user skills",
+		"user_id", userID,
+		"skills_count", len(skills))
+
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.users[userID]; !ok {
+			return fmt.Errorf("user %s: %w", userID, entity.ErrNoUser)
+		}
+		if len(skills) == 0 {
+			remove(st, st.skills, userID)
+			return nil
+		}
+		levels := make(map[string]int, len(skills))
+		for _, skill := range skills {
+			levels[skill.Tag] = skill.Level
+		}
+		put(st, st.skills, userID, levels)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindUserSkills(ctx context.Context, userID string) ([]entity.UserSkill, error) {
+	return repo.listUserSkills(ctx, func(_ *state, skillUserID string) bool { return skillUserID == userID })
+}
+
+func (repo *MemoryRepository) FindSkillsByTeam(ctx context.Context, teamName string) ([]entity.UserSkill, error) {
+	return repo.listUserSkills(ctx, func(st *state, userID string) bool {
+		return st.users[userID].TeamName == teamName
+	})
+}
+
+func (repo *MemoryRepository) ListUserSkills(ctx context.Context) ([]entity.UserSkill, error) {
+	return repo.listUserSkills(ctx, func(*state, string) bool { return true })
+}
+
+func (repo *MemoryRepository) listUserSkills(ctx context.Context, match func(st *state, userID string) bool) ([]entity.UserSkill, error) {
+	var skills []entity.UserSkill
+	err := repo.read(ctx, func(st *state) error {
+		for userID, levels := range st.skills {
+			if !match(st, userID) {
+				continue
+			}
+			for tag, level := range levels {
+				skills = append(skills, entity.UserSkill{UserID: userID, Tag: tag, Level: level})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(skills, func(i, j int) bool {
+		if skills[i].UserID == skills[j].UserID {
+			return skills[i].Tag < skills[j].Tag
+		}
+		return skills[i].UserID < skills[j].UserID
+	})
+	return skills, nil
+}
+
+// Code owners
+
+func copyCodeOwnerRules(rules []entity.CodeOwnerRule) []entity.CodeOwnerRule {
+	cp := make([]entity.CodeOwnerRule, len(rules))
+	for i, rule := range rules {
+		rule.Users = copyStrings(rule.Users)
+		rule.Teams = copyStrings(rule.Teams)
+		cp[i] = rule
+	}
+	return cp
+}
+
+func (repo *MemoryRepository) SetCodeOwners(ctx context.Context, owners *entity.CodeOwners) error {
+	repo.logger.Debug("MEMORY_SET_CODE_OWNERS", "Setting code owners",
+		"repository", owners.Repository,
+		"rules_count", len(owners.Rules))
+
+	return repo.write(ctx, func(st *state) error {
+		if len(owners.Rules) == 0 {
+			remove(st, st.codeOwners, owners.Repository)
+			return nil
+		}
+		put(st, st.codeOwners, owners.Repository, copyCodeOwnerRules(owners.Rules))
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindCodeOwners(ctx context.Context, repositoryName string) (*entity.CodeOwners, error) {
+	var result *entity.CodeOwners
+	err := repo.read(ctx, func(st *state) error {
+		rules, ok := st.codeOwners[repositoryName]
+		if !ok {
+			return fmt.Errorf("repository %s: %w", repositoryName, entity.ErrNoCodeOwners)
+		}
+		result = &entity.CodeOwners{Repository: repositoryName, Rules: copyCodeOwnerRules(rules)}
+		return nil
+	})
+	return result, err
+}
+
+func (repo *MemoryRepository) ListCodeOwners(ctx context.Context) ([]*entity.CodeOwners, error) {
+	var owners []*entity.CodeOwners
+	err := repo.read(ctx, func(st *state) error {
+		for repositoryName, rules := range st.codeOwners {
+			owners = append(owners, &entity.CodeOwners{Repository: repositoryName, Rules: copyCodeOwnerRules(rules)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(owners, func(i, j int) bool { return owners[i].Repository < owners[j].Repository })
+	return owners, nil
+}
+
+func (repo *MemoryRepository) DeleteCodeOwners(ctx context.Context, repositoryName string) error {
+	return repo.write(ctx, func(st *state) error {
+		if _, ok := st.codeOwners[repositoryName]; !ok {
+			return fmt.Errorf("repository %s: %w", repositoryName, entity.ErrNoCodeOwners)
+		}
+		remove(st, st.codeOwners, repositoryName)
+		return nil
+	})
+}
+
+// Reviewer rules
+
+func (repo *MemoryRepository) SetReviewerRule(ctx context.Context, rule *entity.ReviewerRule) error {
+	repo.logger.Debug("MEMORY_SET_REVIEWER_RULE", "Setting reviewer rule",
+		"author_id", rule.AuthorID,
+		"reviewer_id", rule.ReviewerID,
+		"kind", rule.Kind)
+
+	return repo.write(ctx, func(st *state) error {
+		for _, userID := range []string{rule.AuthorID, rule.ReviewerID} {
+			if _, ok := st.users[userID]; !ok {
+				return fmt.Errorf("user %s: %w", userID, entity.ErrNoUser)
+			}
+		}
+		put(st, st.reviewerRules, reviewerPair{authorID: rule.AuthorID, reviewerID: rule.ReviewerID}, *rule)
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) FindReviewerRulesByAuthor(ctx context.Context, authorID string) ([]entity.ReviewerRule, error) {
+	return repo.listReviewerRules(ctx, func(pair reviewerPair) bool { return pair.authorID == authorID })
+}
+
+func (repo *MemoryRepository) ListReviewerRules(ctx context.Context) ([]entity.ReviewerRule, error) {
+	return repo.listReviewerRules(ctx, func(reviewerPair) bool { return true })
+}
+
+func (repo *MemoryRepository) listReviewerRules(ctx context.Context, match func(pair reviewerPair) bool) ([]entity.ReviewerRule, error) {
+	var rules []entity.ReviewerRule
+	err := repo.read(ctx, func(st *state) error {
+		for pair, rule := range st.reviewerRules {
+			if match(pair) {
+				rules = append(rules, rule)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].AuthorID == rules[j].AuthorID {
+			return rules[i].ReviewerID < rules[j].ReviewerID
+		}
+		return rules[i].AuthorID < rules[j].AuthorID
+	})
+	return rules, nil
+}
+
+func (repo *MemoryRepository) DeleteReviewerRule(ctx context.Context, authorID, reviewerID string) error {
+	return repo.write(ctx, func(st *state) error {
+		pair := reviewerPair{authorID: authorID, reviewerID: reviewerID}
+		if _, ok := st.reviewerRules[pair]; !ok {
+			return fmt.Errorf("author %s, reviewer %s: %w", authorID, reviewerID, entity.ErrNoReviewerRule)
+		}
+		remove(st, st.reviewerRules, pair)
+		return nil
+	})
+}
+
+// Idempotency keys
+
+func (repo *MemoryRepository) FindIdempotencyRecord(ctx context.Context, key, endpoint string) (*entity.IdempotencyRecord, error) {
+	var record *entity.IdempotencyRecord
+	err := repo.read(ctx, func(st *state) error {
+		found, ok := st.idempotency[idempotencyKey{key: key, endpoint: endpoint}]
+		if ok && found.ExpiresAt.After(time.Now()) {
+			cp := copyIdempotencyRecord(found)
+			record = &cp
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (repo *MemoryRepository) ReserveIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) (bool, error) {
+	var reserved bool
+	err := repo.write(ctx, func(st *state) error {
+		id := idempotencyKey{key: record.Key, endpoint: record.Endpoint}
+		// Существующую запись не трогаем - как ON CONFLICT DO NOTHING в PRRepository
+		if _, ok := st.idempotency[id]; ok {
+			return nil
+		}
+		pending := copyIdempotencyRecord(*record)
+		pending.ResponseHeaders = map[string]string{}
+		pending.ResponseBody = []byte{}
+		put(st, st.idempotency, id, pending)
+		reserved = true
+		return nil
+	})
+	return reserved, err
+}
+
+func (repo *MemoryRepository) CompleteIdempotencyRecord(ctx context.Context, record *entity.IdempotencyRecord) error {
+	return repo.write(ctx, func(st *state) error {
+		id := idempotencyKey{key: record.Key, endpoint: record.Endpoint}
+		if existing, ok := st.idempotency[id]; ok && existing.RequestHash == record.RequestHash {
+			put(st, st.idempotency, id, copyIdempotencyRecord(*record))
+		}
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) ReleaseIdempotencyKey(ctx context.Context, key, endpoint string) error {
+	return repo.write(ctx, func(st *state) error {
+		id := idempotencyKey{key: key, endpoint: endpoint}
+		if existing, ok := st.idempotency[id]; ok && existing.Pending() {
+			remove(st, st.idempotency, id)
+		}
+		return nil
+	})
+}
+
+func (repo *MemoryRepository) DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error) {
+	var deleted int64
+	err := repo.write(ctx, func(st *state) error {
+		for id, record := range st.idempotency {
+			if !record.ExpiresAt.After(now) {
+				remove(st, st.idempotency, id)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// Вспомогательные функции
+
+func checkReviewersExist(st *state, reviewers []string) error {
+	for _, reviewerID := range reviewers {
+		if _, ok := st.users[reviewerID]; !ok {
+			return fmt.Errorf("reviewer %s: %w", reviewerID, entity.ErrNoUser)
+		}
+	}
+	return nil
+}
+
+func sortedReviewers(reviewers []string) []string {
+	if len(reviewers) == 0 {
+		return nil
+	}
+	cp := append([]string(nil), reviewers...)
+	sort.Strings(cp)
+	return cp
+}
+
+// copyPR копирует PR вместе со слайсами ревьюверов, меток и путей, чтобы вызывающий код
+// не мог изменить данные хранилища в обход UpdatePR
+func copyPR(pr entity.PullRequest) entity.PullRequest {
+	pr.AssignedReviewers = sortedReviewers(pr.AssignedReviewers)
+	pr.Metadata.Labels = copyStrings(pr.Metadata.Labels)
+	pr.Metadata.Paths = copyStrings(pr.Metadata.Paths)
+	pr.Metadata.RequiredSkills = copyStrings(pr.Metadata.RequiredSkills)
+	return pr
+}
+
+// copyStrings копирует слайс, пустой становится nil - как после чтения из SQL хранилищ
+func copyStrings(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return append([]string(nil), values...)
+}
+
+func copyIdempotencyRecord(record entity.IdempotencyRecord) entity.IdempotencyRecord {
+	headers := make(map[string]string, len(record.ResponseHeaders))
+	for k, v := range record.ResponseHeaders {
+		headers[k] = v
+	}
+	record.ResponseHeaders = headers
+	record.ResponseBody = append([]byte(nil), record.ResponseBody...)
+	return record
+}
@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+	"github.com/pozedorum/set_pr_reviers_service/internal/repository/repositorytest"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCtx = context.Background()
+
+func newTestRepo(t *testing.T) *MemoryRepository {
+	t.Helper()
+	testLogger, err := logger.NewLogger("pr-service-test", "logger_for_tests")
+	require.NoError(t, err)
+	return NewMemoryRepository(testLogger)
+}
+
+func TestMemoryRepository_Contract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) interfaces.Repository {
+		return newTestRepo(t)
+	})
+}
+
+func TestFindPRByID_ReturnsCopy(t *testing.T) {
+	repo := newTestRepo(t)
+	require.NoError(t, repo.CreateTeam(testCtx, &entity.Team{
+		TeamName: "backend",
+		Members: []entity.TeamMember{
+			{UserID: "author1", Username: "Alice", IsActive: true},
+			{UserID: "reviewer1", Username: "Bob", IsActive: true},
+			{UserID: "user2", Username: "Charlie", IsActive: true},
+		},
+	}))
+
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-123",
+		PullRequestName:   "Test PR",
+		AuthorID:          "author1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user2", "reviewer1"},
+	}
+	require.NoError(t, repo.CreatePR(testCtx, pr))
+
+	// Изменение аргумента после сохранения не затрагивает хранилище
+	pr.AssignedReviewers[0] = "someone"
+
+	found, err := repo.FindPRByID(testCtx, "pr-123")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reviewer1", "user2"}, found.AssignedReviewers)
+
+	// Как и изменение результата
+	found.AssignedReviewers[0] = "someone"
+	again, err := repo.FindPRByID(testCtx, "pr-123")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reviewer1", "user2"}, again.AssignedReviewers)
+}
+
+func TestWithTx_RollbackRestoresChangedValuesOnly(t *testing.T) {
+	repo := newTestRepo(t)
+	require.NoError(t, repo.CreateTeam(testCtx, &entity.Team{
+		TeamName: "backend",
+		Members: []entity.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	}))
+	require.NoError(t, repo.SetUserAliases(testCtx, "u1", []string{"alice@example.com"}))
+	require.NoError(t, repo.CreatePR(testCtx, &entity.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2"},
+	}))
+
+	errBoom := assert.AnError
+	err := repo.WithTx(testCtx, func(tx interfaces.Repository) error {
+		// Новые значения удаляются, изменённые и удалённые возвращаются
+		if err := tx.CreateTeam(testCtx, &entity.Team{
+			TeamName: "frontend",
+			Members:  []entity.TeamMember{{UserID: "u3", Username: "Carol", IsActive: true}},
+		}); err != nil {
+			return err
+		}
+		if err := tx.SetUserAliases(testCtx, "u1", []string{"a@example.com"}); err != nil {
+			return err
+		}
+		// Повторная запись того же PR откатывается к значению до транзакции
+		for range 2 {
+			pr, err := tx.FindPRByIDForUpdate(testCtx, "pr-1")
+			if err != nil {
+				return err
+			}
+			pr.AssignedReviewers = []string{"u3"}
+			if err := tx.UpdatePR(testCtx, pr); err != nil {
+				return err
+			}
+		}
+		return errBoom
+	})
+	require.ErrorIs(t, err, errBoom)
+
+	assert.False(t, repo.TeamExists(testCtx, "frontend"))
+	_, err = repo.FindUserByID(testCtx, "u3")
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+
+	aliases, err := repo.FindUserAliases(testCtx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice@example.com"}, aliases)
+	_, err = repo.FindUserIDByAlias(testCtx, "a@example.com")
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+
+	pr, err := repo.FindPRByID(testCtx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+	assert.Equal(t, 1, pr.Version)
+
+	// После отката журнал выключен: обычная запись не откатывается следующей транзакцией
+	require.NoError(t, repo.SetActive(testCtx, "u2", false))
+	require.NoError(t, repo.WithTx(testCtx, func(interfaces.Repository) error { return nil }))
+	user, err := repo.FindUserByID(testCtx, "u2")
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+}
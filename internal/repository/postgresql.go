@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -13,16 +14,89 @@ import (
 )
 
 var (
-	ErrNoTeam = errors.New("no such team")
-	ErrNoUser = errors.New("no such user")
-	ErrNoPR   = errors.New("no such pull request")
+	ErrNoTeam = entity.ErrNoTeam
+	ErrNoUser = entity.ErrNoUser
+	ErrNoPR   = entity.ErrNoPR
+
+	ErrNoUnavailability = entity.ErrNoUnavailability
+	ErrAliasTaken       = entity.ErrAliasTaken
+	ErrNoWorkSchedule   = entity.ErrNoWorkSchedule
+	ErrNoCodeOwners     = entity.ErrNoCodeOwners
+	ErrNoReviewerRule   = entity.ErrNoReviewerRule
+
+	ErrTeamExists = entity.ErrTeamExists
+	ErrUserExists = entity.ErrUserExists
+	ErrPRExists   = entity.ErrPRExists
+
+	ErrVersionConflict = entity.ErrVersionConflict
+)
+
+// Коды ошибок PostgreSQL, которые переводятся в ошибки репозитория
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
 )
 
+func isPgError(err error, code string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && string(pqErr.Code) == code
+}
+
+// stringsOrEmpty заменяет nil пустым срезом: столбцы-массивы объявлены NOT NULL
+func stringsOrEmpty(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// stringsOrNil - пустой массив читается как nil, как он и был сохранён
+func stringsOrNil(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// nullString - пустая строка хранится как NULL, например у PR без теневого ревьювера
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
 type PRRepository struct {
 	db     *sql.DB
+	tx     *sql.Tx // Не nil внутри WithTx
 	logger interfaces.Logger
 }
 
+// querier - общие методы *sql.DB и *sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// txScope - транзакция метода репозитория. Если метод вызван внутри WithTx,
+// используется внешняя транзакция, а Commit и Rollback ничего не делают
+type txScope struct {
+	*sql.Tx
+	owned bool
+}
+
+func (t *txScope) Commit() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *txScope) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
 func NewPRRepository(dataSourceName string, logger interfaces.Logger) (*PRRepository, error) {
 	db, err := sql.Open("postgres", dataSourceName)
 	if err != nil {
@@ -55,9 +129,61 @@ func (repo *PRRepository) Close() error {
 	return repo.db.Close()
 }
 
+// WithTx выполняет fn в одной транзакции: все вызовы репозитория внутри fn
+// видят одни и те же данные, а блокировки FOR UPDATE держатся до коммита
+func (repo *PRRepository) WithTx(ctx context.Context, fn func(repo interfaces.Repository) error) error {
+	if repo.tx != nil {
+		// Уже внутри транзакции - вложенные вызовы используют её же
+		return fn(repo)
+	}
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		repo.logger.Error("POSTGRES_WITH_TX", "Failed to begin transaction", "error", err)
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	txRepo := &PRRepository{
+		db:     repo.db,
+		tx:     tx,
+		logger: repo.logger,
+	}
+
+	if err := fn(txRepo); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			repo.logger.Error("POSTGRES_WITH_TX", "failed to rollback transaction", "error", rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		repo.logger.Error("POSTGRES_WITH_TX", "Failed to commit transaction", "error", err)
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (repo *PRRepository) conn() querier {
+	if repo.tx != nil {
+		return repo.tx
+	}
+	return repo.db
+}
+
+func (repo *PRRepository) beginTx(ctx context.Context) (*txScope, error) {
+	if repo.tx != nil {
+		return &txScope{Tx: repo.tx, owned: false}, nil
+	}
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &txScope{Tx: tx, owned: true}, nil
+}
+
 // Users
 
-func (repo *PRRepository) CreateUser(user *entity.User) error {
+func (repo *PRRepository) CreateUser(ctx context.Context, user *entity.User) error {
 	start := time.Now()
 
 	repo.logger.Debug("POSTGRES_CREATE_USER", "Creating user",
@@ -67,7 +193,7 @@ func (repo *PRRepository) CreateUser(user *entity.User) error {
 		"is_active", user.IsActive)
 
 	// Получаем team_id по team_name
-	teamID, err := repo.getTeamIDByName(user.TeamName)
+	teamID, err := repo.getTeamIDByName(ctx, user.TeamName)
 	if err != nil {
 		repo.logger.Error("POSTGRES_CREATE_USER", "Failed to get team ID",
 			"user_id", user.UserID,
@@ -78,11 +204,18 @@ func (repo *PRRepository) CreateUser(user *entity.User) error {
 	}
 
 	query := `
-		INSERT INTO users (user_id, username, team_id, is_active)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users (user_id, username, team_id, is_active, max_open_reviews, level, is_learner)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 
-	_, err = repo.db.Exec(query, user.UserID, user.Username, teamID, user.IsActive)
+	_, err = repo.conn().ExecContext(ctx, query, user.UserID, user.Username, teamID, user.IsActive, user.MaxOpenReviews,
+		user.Level, user.IsLearner)
+	if isPgError(err, pgUniqueViolation) {
+		repo.logger.Warn("POSTGRES_CREATE_USER", "User already exists",
+			"user_id", user.UserID,
+			"duration_ms", time.Since(start).Milliseconds())
+		return ErrUserExists
+	}
 	if err != nil {
 		repo.logger.Error("POSTGRES_CREATE_USER", "Failed to create user",
 			"user_id", user.UserID,
@@ -97,25 +230,28 @@ func (repo *PRRepository) CreateUser(user *entity.User) error {
 	return nil
 }
 
-func (repo *PRRepository) FindUserByID(userID string) (*entity.User, error) {
+func (repo *PRRepository) FindUserByID(ctx context.Context, userID string) (*entity.User, error) {
 	start := time.Now()
 
 	repo.logger.Debug("POSTGRES_FIND_USER_BY_ID", "Finding user by ID",
 		"user_id", userID)
 
 	query := `
-		SELECT u.user_id, u.username, t.team_name, u.is_active
+		SELECT u.user_id, u.username, t.team_name, u.is_active, u.max_open_reviews, u.level, u.is_learner
 		FROM users u
 		JOIN teams t ON u.team_id = t.team_id
 		WHERE u.user_id = $1
 	`
 
 	var user entity.User
-	err := repo.db.QueryRow(query, userID).Scan(
+	err := repo.conn().QueryRowContext(ctx, query, userID).Scan(
 		&user.UserID,
 		&user.Username,
 		&user.TeamName,
 		&user.IsActive,
+		&user.MaxOpenReviews,
+		&user.Level,
+		&user.IsLearner,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -137,7 +273,7 @@ func (repo *PRRepository) FindUserByID(userID string) (*entity.User, error) {
 	return &user, nil
 }
 
-func (repo *PRRepository) UpdateUser(user *entity.User) error {
+func (repo *PRRepository) UpdateUser(ctx context.Context, user *entity.User) error {
 	start := time.Now()
 
 	repo.logger.Debug("POSTGRES_UPDATE_USER", "Updating user",
@@ -147,7 +283,7 @@ func (repo *PRRepository) UpdateUser(user *entity.User) error {
 		"is_active", user.IsActive)
 
 	// Получаем team_id по team_name
-	teamID, err := repo.getTeamIDByName(user.TeamName)
+	teamID, err := repo.getTeamIDByName(ctx, user.TeamName)
 	if err != nil {
 		repo.logger.Error("POSTGRES_UPDATE_USER", "Failed to get team ID",
 			"user_id", user.UserID,
@@ -163,7 +299,7 @@ func (repo *PRRepository) UpdateUser(user *entity.User) error {
 		WHERE user_id = $4
 	`
 
-	result, err := repo.db.Exec(query, user.Username, teamID, user.IsActive, user.UserID)
+	result, err := repo.conn().ExecContext(ctx, query, user.Username, teamID, user.IsActive, user.UserID)
 	if err != nil {
 		repo.logger.Error("POSTGRES_UPDATE_USER", "Failed to update user",
 			"user_id", user.UserID,
@@ -186,25 +322,25 @@ func (repo *PRRepository) UpdateUser(user *entity.User) error {
 	return nil
 }
 
-func (repo *PRRepository) FindUsersByTeam(teamName string) ([]*entity.User, error) {
+func (repo *PRRepository) FindUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
 	start := time.Now()
 
 	repo.logger.Debug("POSTGRES_FIND_USERS_BY_TEAM", "Finding users by team", "team_name", teamName)
 
 	// Получаем team_id
-	teamID, err := repo.getTeamIDByName(teamName)
+	teamID, err := repo.getTeamIDByName(ctx, teamName)
 	if err != nil {
 		return nil, fmt.Errorf("find users by team: %w", err)
 	}
 
 	query := `
-		SELECT user_id, username, is_active
+		SELECT user_id, username, is_active, max_open_reviews, level, is_learner
 		FROM users
 		WHERE team_id = $1
 		ORDER BY user_id
 	`
 
-	rows, err := repo.db.Query(query, teamID)
+	rows, err := repo.conn().QueryContext(ctx, query, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("query users by team: %w", err)
 	}
@@ -217,7 +353,8 @@ func (repo *PRRepository) FindUsersByTeam(teamName string) ([]*entity.User, erro
 	var users []*entity.User
 	for rows.Next() {
 		var user entity.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive); err != nil {
+		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive, &user.MaxOpenReviews, &user.Level,
+			&user.IsLearner); err != nil {
 			return nil, fmt.Errorf("scan user row: %w", err)
 		}
 		user.TeamName = teamName // Заполняем team_name для API
@@ -234,7 +371,52 @@ func (repo *PRRepository) FindUsersByTeam(teamName string) ([]*entity.User, erro
 	return users, nil
 }
 
-func (repo *PRRepository) SetActive(userID string, isActive bool) error {
+func (repo *PRRepository) ListUsers(ctx context.Context) ([]*entity.User, error) {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_LIST_USERS", "Listing all users")
+
+	query := `
+		SELECT u.user_id, u.username, t.team_name, u.is_active, u.max_open_reviews, u.level, u.is_learner
+		FROM users u
+		JOIN teams t ON u.team_id = t.team_id
+		ORDER BY t.team_name, u.user_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query)
+	if err != nil {
+		repo.logger.Error("POSTGRES_LIST_USERS", "Failed to query users",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_LIST_USERS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var users []*entity.User
+	for rows.Next() {
+		var user entity.User
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.MaxOpenReviews, &user.Level,
+			&user.IsLearner); err != nil {
+			return nil, fmt.Errorf("scan user row: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user rows: %w", err)
+	}
+
+	repo.logger.Debug("POSTGRES_LIST_USERS", "Users listed successfully",
+		"users_count", len(users),
+		"duration_ms", time.Since(start).Milliseconds())
+	return users, nil
+}
+
+func (repo *PRRepository) SetActive(ctx context.Context, userID string, isActive bool) error {
 	start := time.Now()
 
 	repo.logger.Debug("POSTGRES_SET_ACTIVE", "Setting user active status",
@@ -247,7 +429,7 @@ func (repo *PRRepository) SetActive(userID string, isActive bool) error {
 		WHERE user_id = $2
 	`
 
-	result, err := repo.db.Exec(query, isActive, userID)
+	result, err := repo.conn().ExecContext(ctx, query, isActive, userID)
 	if err != nil {
 		repo.logger.Error("POSTGRES_SET_ACTIVE", "Failed to set user active status",
 			"user_id", userID,
@@ -276,15 +458,176 @@ func (repo *PRRepository) SetActive(userID string, isActive bool) error {
 	return nil
 }
 
+func (repo *PRRepository) SetMaxOpenReviews(ctx context.Context, userID string, limit int) error {
+	start := time.Now()
+
+	query := `
+		UPDATE users
+		SET max_open_reviews = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $2
+	`
+
+	result, err := repo.conn().ExecContext(ctx, query, limit, userID)
+	if err != nil {
+		repo.logger.Error("POSTGRES_SET_MAX_OPEN_REVIEWS", "Failed to set user review limit",
+			"user_id", userID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("set max open reviews: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("user %s: %w", userID, ErrNoUser)
+	}
+
+	repo.logger.Info("POSTGRES_SET_MAX_OPEN_REVIEWS", "User review limit updated",
+		"user_id", userID,
+		"max_open_reviews", limit,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *PRRepository) SetUserLevel(ctx context.Context, userID string, level entity.UserLevel) error {
+	start := time.Now()
+
+	query := `
+		UPDATE users
+		SET level = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $2
+	`
+
+	result, err := repo.conn().ExecContext(ctx, query, level, userID)
+	if err != nil {
+		repo.logger.Error("POSTGRES_SET_USER_LEVEL", "Failed to set user level",
+			"user_id", userID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("set user level: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("user %s: %w", userID, ErrNoUser)
+	}
+
+	repo.logger.Info("POSTGRES_SET_USER_LEVEL", "User level updated",
+		"user_id", userID,
+		"level", level,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *PRRepository) SetUserLearner(ctx context.Context, userID string, isLearner bool) error {
+	start := time.Now()
+
+	query := `
+		UPDATE users
+		SET is_learner = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $2
+	`
+
+	result, err := repo.conn().ExecContext(ctx, query, isLearner, userID)
+	if err != nil {
+		repo.logger.Error("POSTGRES_SET_USER_LEARNER", "Failed to set user learner flag",
+			"user_id", userID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("set user learner: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("user %s: %w", userID, ErrNoUser)
+	}
+
+	repo.logger.Info("POSTGRES_SET_USER_LEARNER", "User learner flag updated",
+		"user_id", userID,
+		"is_learner", isLearner,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+// FindReviewLoads возвращает открытые ревью участников команды: их число и размер.
+// Участники без открытых ревью в результат не попадают
+func (repo *PRRepository) FindReviewLoads(ctx context.Context, teamName string) (map[string]entity.ReviewLoad, error) {
+	query := `
+		SELECT prr.reviewer_id, COUNT(*), COALESCE(SUM(pr.additions + pr.deletions), 0)
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		JOIN users u ON u.user_id = prr.reviewer_id
+		JOIN teams t ON t.team_id = u.team_id
+		WHERE t.team_name = $1 AND pr.status = $2
+		GROUP BY prr.reviewer_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query, teamName, entity.PullRequestStatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("find review loads: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_FIND_REVIEW_LOADS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	loads := make(map[string]entity.ReviewLoad)
+	for rows.Next() {
+		var userID string
+		var load entity.ReviewLoad
+		if err := rows.Scan(&userID, &load.OpenReviews, &load.ChangedLines); err != nil {
+			return nil, fmt.Errorf("scan review load row: %w", err)
+		}
+		loads[userID] = load
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate review load rows: %w", err)
+	}
+	return loads, nil
+}
+
+// FindPairReviewCounts возвращает, на сколько PR автора, созданных не раньше since,
+// назначен ревьювером каждый пользователь, включая смерженные PR.
+// Пользователи без таких ревью в результат не попадают
+func (repo *PRRepository) FindPairReviewCounts(ctx context.Context, authorID string, since time.Time) (map[string]int, error) {
+	query := `
+		SELECT prr.reviewer_id, COUNT(*)
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE pr.author_id = $1 AND pr.created_at >= $2
+		GROUP BY prr.reviewer_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query, authorID, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("find pair review counts: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_FIND_PAIR_REVIEW_COUNTS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var reviewerID string
+		var count int
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			return nil, fmt.Errorf("scan pair review count row: %w", err)
+		}
+		counts[reviewerID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pair review count rows: %w", err)
+	}
+	return counts, nil
+}
+
 // Teams
 
-func (repo *PRRepository) CreateTeam(team *entity.Team) error {
+func (repo *PRRepository) CreateTeam(ctx context.Context, team *entity.Team) error {
 	repo.logger.Debug("POSTGRES_CREATE_TEAM", "Creating team",
 		"team_name", team.TeamName,
 		"members_count", len(team.Members))
 
 	// Начинаем транзакцию
-	tx, err := repo.db.Begin()
+	tx, err := repo.beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
@@ -296,8 +639,17 @@ func (repo *PRRepository) CreateTeam(team *entity.Team) error {
 
 	// Создаем команду и получаем team_id
 	var teamID int
-	teamQuery := `INSERT INTO teams (team_name) VALUES ($1) RETURNING team_id`
-	err = tx.QueryRow(teamQuery, team.TeamName).Scan(&teamID)
+	teamQuery := `
+		INSERT INTO teams (team_name, max_open_reviews, require_senior, senior_for_juniors)
+		VALUES ($1, $2, $3, $4)
+		RETURNING team_id
+	`
+	err = tx.QueryRowContext(ctx, teamQuery, team.TeamName, team.MaxOpenReviews,
+		team.ReviewPolicy.RequireSenior, team.ReviewPolicy.SeniorForJuniors).Scan(&teamID)
+	if isPgError(err, pgUniqueViolation) {
+		repo.logger.Warn("POSTGRES_CREATE_TEAM", "Team already exists", "team_name", team.TeamName)
+		return ErrTeamExists
+	}
 	if err != nil {
 		repo.logger.Error("POSTGRES_CREATE_TEAM", "Failed to create team",
 			"team_name", team.TeamName, "error", err)
@@ -305,11 +657,17 @@ func (repo *PRRepository) CreateTeam(team *entity.Team) error {
 	}
 	// Создаем пользователей
 	userQuery := `
-		INSERT INTO users (user_id, username, team_id, is_active)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users (user_id, username, team_id, is_active, max_open_reviews, level, is_learner)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 	for _, member := range team.Members {
-		_, err := tx.Exec(userQuery, member.UserID, member.Username, teamID, member.IsActive)
+		_, err := tx.ExecContext(ctx, userQuery, member.UserID, member.Username, teamID, member.IsActive,
+			member.MaxOpenReviews, member.Level, member.IsLearner)
+		if isPgError(err, pgUniqueViolation) {
+			repo.logger.Warn("POSTGRES_CREATE_TEAM", "Team member already exists",
+				"team_name", team.TeamName, "user_id", member.UserID)
+			return ErrUserExists
+		}
 		if err != nil {
 			repo.logger.Error("POSTGRES_CREATE_TEAM", "Failed to create team member",
 				"team_name", team.TeamName, "user_id", member.UserID, "error", err)
@@ -328,13 +686,15 @@ func (repo *PRRepository) CreateTeam(team *entity.Team) error {
 	return nil
 }
 
-func (repo *PRRepository) FindTeamByName(teamName string) (*entity.Team, error) {
+func (repo *PRRepository) FindTeamByName(ctx context.Context, teamName string) (*entity.Team, error) {
 	repo.logger.Debug("POSTGRES_FIND_TEAM_BY_NAME", "Finding team by name", "team_name", teamName)
 
 	// Получаем team_id по team_name
-	var teamID int
-	teamQuery := `SELECT team_id FROM teams WHERE team_name = $1`
-	err := repo.db.QueryRow(teamQuery, teamName).Scan(&teamID)
+	var teamID, maxOpenReviews int
+	var policy entity.ReviewPolicy
+	teamQuery := `SELECT team_id, max_open_reviews, require_senior, senior_for_juniors FROM teams WHERE team_name = $1`
+	err := repo.conn().QueryRowContext(ctx, teamQuery, teamName).
+		Scan(&teamID, &maxOpenReviews, &policy.RequireSenior, &policy.SeniorForJuniors)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrNoTeam
@@ -344,13 +704,13 @@ func (repo *PRRepository) FindTeamByName(teamName string) (*entity.Team, error)
 
 	// Получаем участников команды по team_id
 	membersQuery := `
-		SELECT user_id, username, is_active
+		SELECT user_id, username, is_active, max_open_reviews, level, is_learner
 		FROM users
 		WHERE team_id = $1
 		ORDER BY user_id
 	`
 
-	rows, err := repo.db.Query(membersQuery, teamID)
+	rows, err := repo.conn().QueryContext(ctx, membersQuery, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("query team members: %w", err)
 	}
@@ -363,7 +723,8 @@ func (repo *PRRepository) FindTeamByName(teamName string) (*entity.Team, error)
 	var members []entity.TeamMember
 	for rows.Next() {
 		var member entity.TeamMember
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.MaxOpenReviews, &member.Level,
+			&member.IsLearner); err != nil {
 			return nil, fmt.Errorf("scan team member row: %w", err)
 		}
 		members = append(members, member)
@@ -374,8 +735,10 @@ func (repo *PRRepository) FindTeamByName(teamName string) (*entity.Team, error)
 	}
 
 	team := &entity.Team{
-		TeamName: teamName, // Возвращаем только team_name, teamID скрыт
-		Members:  members,
+		TeamName:       teamName, // Возвращаем только team_name, teamID скрыт
+		Members:        members,
+		MaxOpenReviews: maxOpenReviews,
+		ReviewPolicy:   policy,
 	}
 
 	repo.logger.Debug("POSTGRES_FIND_TEAM_BY_NAME", "Team found successfully",
@@ -383,12 +746,12 @@ func (repo *PRRepository) FindTeamByName(teamName string) (*entity.Team, error)
 	return team, nil
 }
 
-func (repo *PRRepository) TeamExists(teamName string) bool {
+func (repo *PRRepository) TeamExists(ctx context.Context, teamName string) bool {
 	repo.logger.Debug("POSTGRES_TEAM_EXISTS", "Checking if team exists", "team_name", teamName)
 
 	query := `SELECT 1 FROM teams WHERE team_name = $1`
 	var exists bool
-	err := repo.db.QueryRow(query, teamName).Scan(&exists)
+	err := repo.conn().QueryRowContext(ctx, query, teamName).Scan(&exists)
 
 	if err != nil && err != sql.ErrNoRows {
 		repo.logger.Error("POSTGRES_TEAM_EXISTS", "Failed to check team existence",
@@ -403,9 +766,123 @@ func (repo *PRRepository) TeamExists(teamName string) bool {
 	return exists
 }
 
+func (repo *PRRepository) SetTeamMaxOpenReviews(ctx context.Context, teamName string, limit int) error {
+	start := time.Now()
+
+	query := `UPDATE teams SET max_open_reviews = $1 WHERE team_name = $2`
+	result, err := repo.conn().ExecContext(ctx, query, limit, teamName)
+	if err != nil {
+		repo.logger.Error("POSTGRES_SET_TEAM_MAX_OPEN_REVIEWS", "Failed to set team review limit",
+			"team_name", teamName,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("set team max open reviews: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrNoTeam
+	}
+
+	repo.logger.Info("POSTGRES_SET_TEAM_MAX_OPEN_REVIEWS", "Team review limit updated",
+		"team_name", teamName,
+		"max_open_reviews", limit,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *PRRepository) SetTeamReviewPolicy(ctx context.Context, teamName string, policy entity.ReviewPolicy) error {
+	start := time.Now()
+
+	query := `UPDATE teams SET require_senior = $1, senior_for_juniors = $2 WHERE team_name = $3`
+	result, err := repo.conn().ExecContext(ctx, query, policy.RequireSenior, policy.SeniorForJuniors, teamName)
+	if err != nil {
+		repo.logger.Error("POSTGRES_SET_TEAM_REVIEW_POLICY", "Failed to set team review policy",
+			"team_name", teamName,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("set team review policy: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrNoTeam
+	}
+
+	repo.logger.Info("POSTGRES_SET_TEAM_REVIEW_POLICY", "Team review policy updated",
+		"team_name", teamName,
+		"require_senior", policy.RequireSenior,
+		"senior_for_juniors", policy.SeniorForJuniors,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+// ListTeams возвращает все команды, включая пустые, отсортированные по имени
+func (repo *PRRepository) ListTeams(ctx context.Context) ([]*entity.Team, error) {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_LIST_TEAMS", "Listing all teams")
+
+	// LEFT JOIN оставляет команды без участников: у них user_id будет NULL
+	query := `
+		SELECT t.team_name, t.max_open_reviews, t.require_senior, t.senior_for_juniors,
+			u.user_id, u.username, u.is_active, u.max_open_reviews, u.level, u.is_learner
+		FROM teams t
+		LEFT JOIN users u ON u.team_id = t.team_id
+		ORDER BY t.team_name, u.user_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query)
+	if err != nil {
+		repo.logger.Error("POSTGRES_LIST_TEAMS", "Failed to query teams",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("list teams: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_LIST_TEAMS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var teams []*entity.Team
+	for rows.Next() {
+		var teamName string
+		var teamMaxOpenReviews int
+		var policy entity.ReviewPolicy
+		var userID, username, level sql.NullString
+		var isActive, isLearner sql.NullBool
+		var maxOpenReviews sql.NullInt64
+		if err := rows.Scan(&teamName, &teamMaxOpenReviews, &policy.RequireSenior, &policy.SeniorForJuniors,
+			&userID, &username, &isActive, &maxOpenReviews, &level, &isLearner); err != nil {
+			return nil, fmt.Errorf("scan team row: %w", err)
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != teamName {
+			teams = append(teams, &entity.Team{TeamName: teamName, MaxOpenReviews: teamMaxOpenReviews, ReviewPolicy: policy})
+		}
+		if userID.Valid {
+			team := teams[len(teams)-1]
+			team.Members = append(team.Members, entity.TeamMember{
+				UserID:         userID.String,
+				Username:       username.String,
+				IsActive:       isActive.Bool,
+				MaxOpenReviews: int(maxOpenReviews.Int64),
+				Level:          entity.UserLevel(level.String),
+				IsLearner:      isLearner.Bool,
+			})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate team rows: %w", err)
+	}
+
+	repo.logger.Debug("POSTGRES_LIST_TEAMS", "Teams listed successfully",
+		"teams_count", len(teams),
+		"duration_ms", time.Since(start).Milliseconds())
+	return teams, nil
+}
+
 // PRs
 
-func (repo *PRRepository) CreatePR(pr *entity.PullRequest) error {
+func (repo *PRRepository) CreatePR(ctx context.Context, pr *entity.PullRequest) error {
 	start := time.Now()
 
 	repo.logger.Debug("POSTGRES_CREATE_PR", "Creating pull request",
@@ -415,7 +892,7 @@ func (repo *PRRepository) CreatePR(pr *entity.PullRequest) error {
 		"reviewers_count", len(pr.AssignedReviewers))
 
 	// Начинаем транзакцию
-	tx, err := repo.db.Begin()
+	tx, err := repo.beginTx(ctx)
 	if err != nil {
 		repo.logger.Error("POSTGRES_CREATE_PR", "Failed to begin transaction",
 			"pr_id", pr.PullRequestID,
@@ -431,16 +908,38 @@ func (repo *PRRepository) CreatePR(pr *entity.PullRequest) error {
 
 	// Создаем PR
 	prQuery := `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status,
+			additions, deletions, changed_files, repository, base_branch, labels, paths, required_skills,
+			shadow_reviewer_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING version
 	`
 
-	_, err = tx.Exec(prQuery,
+	var version int
+	err = tx.QueryRowContext(ctx, prQuery,
 		pr.PullRequestID,
 		pr.PullRequestName,
 		pr.AuthorID,
 		string(pr.Status),
-	)
+		pr.Metadata.Additions,
+		pr.Metadata.Deletions,
+		pr.Metadata.ChangedFiles,
+		pr.Metadata.Repository,
+		pr.Metadata.BaseBranch,
+		pq.Array(stringsOrEmpty(pr.Metadata.Labels)),
+		pq.Array(stringsOrEmpty(pr.Metadata.Paths)),
+		pq.Array(stringsOrEmpty(pr.Metadata.RequiredSkills)),
+		nullString(pr.ShadowReviewer),
+	).Scan(&version)
+	if isPgError(err, pgUniqueViolation) {
+		repo.logger.Warn("POSTGRES_CREATE_PR", "Pull request already exists",
+			"pr_id", pr.PullRequestID,
+			"duration_ms", time.Since(start).Milliseconds())
+		return ErrPRExists
+	}
+	if isPgError(err, pgForeignKeyViolation) {
+		return fmt.Errorf("author or shadow reviewer of %s: %w", pr.PullRequestID, ErrNoUser)
+	}
 	if err != nil {
 		repo.logger.Error("POSTGRES_CREATE_PR", "Failed to create pull request",
 			"pr_id", pr.PullRequestID,
@@ -456,7 +955,10 @@ func (repo *PRRepository) CreatePR(pr *entity.PullRequest) error {
 	`
 
 	for _, reviewerID := range pr.AssignedReviewers {
-		_, err := tx.Exec(reviewerQuery, pr.PullRequestID, reviewerID)
+		_, err := tx.ExecContext(ctx, reviewerQuery, pr.PullRequestID, reviewerID)
+		if isPgError(err, pgForeignKeyViolation) {
+			return fmt.Errorf("reviewer %s: %w", reviewerID, ErrNoUser)
+		}
 		if err != nil {
 			repo.logger.Error("POSTGRES_CREATE_PR", "Failed to add reviewer to PR",
 				"pr_id", pr.PullRequestID,
@@ -476,6 +978,8 @@ func (repo *PRRepository) CreatePR(pr *entity.PullRequest) error {
 		return fmt.Errorf("commit transaction: %w", err)
 	}
 
+	pr.Version = version
+
 	repo.logger.Info("POSTGRES_CREATE_PR", "Pull request created successfully",
 		"pr_id", pr.PullRequestID,
 		"reviewers_count", len(pr.AssignedReviewers),
@@ -483,30 +987,145 @@ func (repo *PRRepository) CreatePR(pr *entity.PullRequest) error {
 	return nil
 }
 
-func (repo *PRRepository) FindPRByID(prID string) (*entity.PullRequest, error) {
+// RestorePR сохраняет PR как есть: статус, время создания и слияния, версию и ревьюверов.
+// Используется при восстановлении из снапшота
+func (repo *PRRepository) RestorePR(ctx context.Context, pr *entity.PullRequest) error {
 	start := time.Now()
 
-	repo.logger.Debug("POSTGRES_FIND_PR_BY_ID", "Finding pull request by ID",
-		"pr_id", prID)
-
-	// Получаем основную информацию о PR
-	prQuery := `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-		FROM pull_requests
-		WHERE pull_request_id = $1
-	`
+	repo.logger.Debug("POSTGRES_RESTORE_PR", "Restoring pull request",
+		"pr_id", pr.PullRequestID,
+		"status", pr.Status,
+		"version", pr.Version)
 
-	var pr entity.PullRequest
-	var status string
-	var mergedAt sql.NullTime
+	tx, err := repo.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err = tx.Rollback(); err != nil {
+			repo.logger.Error("POSTGRES_RESTORE_PR", "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	var mergedAt sql.NullTime
+	if !pr.MergedAt.IsZero() {
+		mergedAt = sql.NullTime{Time: pr.MergedAt.UTC(), Valid: true}
+	}
+
+	prQuery := `
+		INSERT INTO pull_requests
+			(pull_request_id, pull_request_name, author_id, status, created_at, merged_at, version,
+			additions, deletions, changed_files, repository, base_branch, labels, paths, required_skills,
+			shadow_reviewer_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`
+	_, err = tx.ExecContext(ctx, prQuery,
+		pr.PullRequestID,
+		pr.PullRequestName,
+		pr.AuthorID,
+		string(pr.Status),
+		pr.CreatedAt.UTC(),
+		mergedAt,
+		pr.Version,
+		pr.Metadata.Additions,
+		pr.Metadata.Deletions,
+		pr.Metadata.ChangedFiles,
+		pr.Metadata.Repository,
+		pr.Metadata.BaseBranch,
+		pq.Array(stringsOrEmpty(pr.Metadata.Labels)),
+		pq.Array(stringsOrEmpty(pr.Metadata.Paths)),
+		pq.Array(stringsOrEmpty(pr.Metadata.RequiredSkills)),
+		nullString(pr.ShadowReviewer),
+	)
+	if isPgError(err, pgUniqueViolation) {
+		return ErrPRExists
+	}
+	if isPgError(err, pgForeignKeyViolation) {
+		return fmt.Errorf("author or shadow reviewer of %s: %w", pr.PullRequestID, ErrNoUser)
+	}
+	if err != nil {
+		repo.logger.Error("POSTGRES_RESTORE_PR", "Failed to restore pull request",
+			"pr_id", pr.PullRequestID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("restore pull request: %w", err)
+	}
+
+	reviewerQuery := `
+		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id)
+		VALUES ($1, $2)
+	`
+	for _, reviewerID := range pr.AssignedReviewers {
+		_, err := tx.ExecContext(ctx, reviewerQuery, pr.PullRequestID, reviewerID)
+		if isPgError(err, pgForeignKeyViolation) {
+			return fmt.Errorf("reviewer %s: %w", reviewerID, ErrNoUser)
+		}
+		if err != nil {
+			return fmt.Errorf("add reviewer %s to PR: %w", reviewerID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	repo.logger.Info("POSTGRES_RESTORE_PR", "Pull request restored successfully",
+		"pr_id", pr.PullRequestID,
+		"reviewers_count", len(pr.AssignedReviewers),
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *PRRepository) FindPRByID(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	return repo.findPRByID(ctx, prID, false)
+}
+
+// FindPRByIDForUpdate читает PR с блокировкой строки до конца транзакции WithTx
+func (repo *PRRepository) FindPRByIDForUpdate(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	return repo.findPRByID(ctx, prID, true)
+}
+
+func (repo *PRRepository) findPRByID(ctx context.Context, prID string, forUpdate bool) (*entity.PullRequest, error) {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_FIND_PR_BY_ID", "Finding pull request by ID",
+		"pr_id", prID,
+		"for_update", forUpdate)
+
+	// Получаем основную информацию о PR
+	prQuery := `
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, version,
+			additions, deletions, changed_files, repository, base_branch, labels, paths, required_skills,
+			shadow_reviewer_id
+		FROM pull_requests
+		WHERE pull_request_id = $1
+	`
+	if forUpdate {
+		prQuery += ` FOR UPDATE`
+	}
+
+	var pr entity.PullRequest
+	var status string
+	var mergedAt sql.NullTime
+	var shadowReviewer sql.NullString
 
-	err := repo.db.QueryRow(prQuery, prID).Scan(
+	err := repo.conn().QueryRowContext(ctx, prQuery, prID).Scan(
 		&pr.PullRequestID,
 		&pr.PullRequestName,
 		&pr.AuthorID,
 		&status,
 		&pr.CreatedAt,
 		&mergedAt,
+		&pr.Version,
+		&pr.Metadata.Additions,
+		&pr.Metadata.Deletions,
+		&pr.Metadata.ChangedFiles,
+		&pr.Metadata.Repository,
+		&pr.Metadata.BaseBranch,
+		pq.Array(&pr.Metadata.Labels),
+		pq.Array(&pr.Metadata.Paths),
+		pq.Array(&pr.Metadata.RequiredSkills),
+		&shadowReviewer,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -526,6 +1145,10 @@ func (repo *PRRepository) FindPRByID(prID string) (*entity.PullRequest, error) {
 	if mergedAt.Valid {
 		pr.MergedAt = mergedAt.Time
 	}
+	pr.ShadowReviewer = shadowReviewer.String
+	pr.Metadata.Labels = stringsOrNil(pr.Metadata.Labels)
+	pr.Metadata.Paths = stringsOrNil(pr.Metadata.Paths)
+	pr.Metadata.RequiredSkills = stringsOrNil(pr.Metadata.RequiredSkills)
 
 	// Получаем ревьюверов
 	reviewersQuery := `
@@ -535,7 +1158,7 @@ func (repo *PRRepository) FindPRByID(prID string) (*entity.PullRequest, error) {
 		ORDER BY reviewer_id
 	`
 
-	rows, err := repo.db.Query(reviewersQuery, prID)
+	rows, err := repo.conn().QueryContext(ctx, reviewersQuery, prID)
 	if err != nil {
 		repo.logger.Error("POSTGRES_FIND_PR_BY_ID", "Failed to query PR reviewers",
 			"pr_id", prID,
@@ -579,16 +1202,17 @@ func (repo *PRRepository) FindPRByID(prID string) (*entity.PullRequest, error) {
 	return &pr, nil
 }
 
-func (repo *PRRepository) UpdatePR(pr *entity.PullRequest) error {
+func (repo *PRRepository) UpdatePR(ctx context.Context, pr *entity.PullRequest) error {
 	start := time.Now()
 
 	repo.logger.Debug("POSTGRES_UPDATE_PR", "Updating pull request",
 		"pr_id", pr.PullRequestID,
 		"status", pr.Status,
+		"version", pr.Version,
 		"reviewers_count", len(pr.AssignedReviewers))
 
 	// Начинаем транзакцию
-	tx, err := repo.db.Begin()
+	tx, err := repo.beginTx(ctx)
 	if err != nil {
 		repo.logger.Error("POSTGRES_UPDATE_PR", "Failed to begin transaction",
 			"pr_id", pr.PullRequestID,
@@ -602,14 +1226,42 @@ func (repo *PRRepository) UpdatePR(pr *entity.PullRequest) error {
 		}
 	}()
 
-	// Обновляем основную информацию о PR
+	// Обновляем основную информацию о PR только если версия не изменилась
 	prQuery := `
 		UPDATE pull_requests 
-		SET pull_request_name = $1, status = $2, merged_at = $3
-		WHERE pull_request_id = $4
+		SET pull_request_name = $1, status = $2, merged_at = $3, shadow_reviewer_id = $4, version = version + 1
+		WHERE pull_request_id = $5 AND version = $6
+		RETURNING version
 	`
 
-	result, err := tx.Exec(prQuery, pr.PullRequestName, string(pr.Status), pr.MergedAt, pr.PullRequestID)
+	// Нулевое время хранится как NULL, как у только что созданного PR
+	var mergedAt sql.NullTime
+	if !pr.MergedAt.IsZero() {
+		mergedAt = sql.NullTime{Time: pr.MergedAt, Valid: true}
+	}
+
+	var newVersion int
+	err = tx.QueryRowContext(ctx, prQuery, pr.PullRequestName, string(pr.Status), mergedAt, nullString(pr.ShadowReviewer),
+		pr.PullRequestID, pr.Version).Scan(&newVersion)
+	if err == sql.ErrNoRows {
+		// Отличаем отсутствующий PR от параллельного изменения
+		var exists bool
+		existsQuery := `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)`
+		if err := tx.QueryRowContext(ctx, existsQuery, pr.PullRequestID).Scan(&exists); err != nil {
+			return fmt.Errorf("check PR existence: %w", err)
+		}
+		if !exists {
+			repo.logger.Warn("POSTGRES_UPDATE_PR", "No pull request found to update",
+				"pr_id", pr.PullRequestID,
+				"duration_ms", time.Since(start).Milliseconds())
+			return ErrNoPR
+		}
+		repo.logger.Warn("POSTGRES_UPDATE_PR", "Pull request version conflict",
+			"pr_id", pr.PullRequestID,
+			"version", pr.Version,
+			"duration_ms", time.Since(start).Milliseconds())
+		return ErrVersionConflict
+	}
 	if err != nil {
 		repo.logger.Error("POSTGRES_UPDATE_PR", "Failed to update pull request",
 			"pr_id", pr.PullRequestID,
@@ -618,17 +1270,9 @@ func (repo *PRRepository) UpdatePR(pr *entity.PullRequest) error {
 		return fmt.Errorf("update PR: %w", err)
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		repo.logger.Warn("POSTGRES_UPDATE_PR", "No pull request found to update",
-			"pr_id", pr.PullRequestID,
-			"duration_ms", time.Since(start).Milliseconds())
-		return ErrNoPR
-	}
-
 	// Обновляем ревьюверов: удаляем старых и добавляем новых
 	deleteReviewersQuery := `DELETE FROM pull_request_reviewers WHERE pull_request_id = $1`
-	if _, err := tx.Exec(deleteReviewersQuery, pr.PullRequestID); err != nil {
+	if _, err := tx.ExecContext(ctx, deleteReviewersQuery, pr.PullRequestID); err != nil {
 		repo.logger.Error("POSTGRES_UPDATE_PR", "Failed to delete old reviewers",
 			"pr_id", pr.PullRequestID,
 			"error", err,
@@ -642,7 +1286,11 @@ func (repo *PRRepository) UpdatePR(pr *entity.PullRequest) error {
 	`
 
 	for _, reviewerID := range pr.AssignedReviewers {
-		if _, err := tx.Exec(insertReviewerQuery, pr.PullRequestID, reviewerID); err != nil {
+		_, err := tx.ExecContext(ctx, insertReviewerQuery, pr.PullRequestID, reviewerID)
+		if isPgError(err, pgForeignKeyViolation) {
+			return fmt.Errorf("reviewer %s: %w", reviewerID, ErrNoUser)
+		}
+		if err != nil {
 			repo.logger.Error("POSTGRES_UPDATE_PR", "Failed to add reviewer to PR",
 				"pr_id", pr.PullRequestID,
 				"reviewer_id", reviewerID,
@@ -661,14 +1309,17 @@ func (repo *PRRepository) UpdatePR(pr *entity.PullRequest) error {
 		return fmt.Errorf("commit transaction: %w", err)
 	}
 
+	pr.Version = newVersion
+
 	repo.logger.Info("POSTGRES_UPDATE_PR", "Pull request updated successfully",
 		"pr_id", pr.PullRequestID,
+		"version", pr.Version,
 		"reviewers_count", len(pr.AssignedReviewers),
 		"duration_ms", time.Since(start).Milliseconds())
 	return nil
 }
 
-func (repo *PRRepository) FindPRsByReviewer(userID string) ([]*entity.PullRequest, error) {
+func (repo *PRRepository) FindPRsByReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
 	start := time.Now()
 
 	repo.logger.Debug("POSTGRES_FIND_PRS_BY_REVIEWER", "Finding PRs by reviewer",
@@ -684,7 +1335,17 @@ func (repo *PRRepository) FindPRsByReviewer(userID string) ([]*entity.PullReques
 				pr.status, 
 				pr.created_at, 
 				pr.merged_at,
-				ARRAY_AGG(prr.reviewer_id) as reviewer_ids
+				pr.version,
+				pr.additions,
+				pr.deletions,
+				pr.changed_files,
+				pr.repository,
+				pr.base_branch,
+				pr.labels,
+				pr.paths,
+				pr.required_skills,
+				pr.shadow_reviewer_id,
+				ARRAY_AGG(prr.reviewer_id ORDER BY prr.reviewer_id) as reviewer_ids
 			FROM pull_requests pr
 			INNER JOIN pull_request_reviewers prr ON pr.pull_request_id = prr.pull_request_id
 			WHERE pr.pull_request_id IN (
@@ -698,7 +1359,17 @@ func (repo *PRRepository) FindPRsByReviewer(userID string) ([]*entity.PullReques
 				pr.author_id, 
 				pr.status, 
 				pr.created_at, 
-				pr.merged_at
+				pr.merged_at,
+				pr.version,
+				pr.additions,
+				pr.deletions,
+				pr.changed_files,
+				pr.repository,
+				pr.base_branch,
+				pr.labels,
+				pr.paths,
+				pr.required_skills,
+				pr.shadow_reviewer_id
 		)
 		SELECT 
 			pull_request_id, 
@@ -707,12 +1378,22 @@ func (repo *PRRepository) FindPRsByReviewer(userID string) ([]*entity.PullReques
 			status, 
 			created_at, 
 			merged_at,
+			version,
+			additions,
+			deletions,
+			changed_files,
+			repository,
+			base_branch,
+			labels,
+			paths,
+			required_skills,
+			shadow_reviewer_id,
 			reviewer_ids
 		FROM prs_with_reviewers
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, pull_request_id
 	`
 
-	rows, err := repo.db.Query(query, userID)
+	rows, err := repo.conn().QueryContext(ctx, query, userID)
 	if err != nil {
 		repo.logger.Error("POSTGRES_FIND_PRS_BY_REVIEWER", "Failed to query PRs by reviewer",
 			"user_id", userID,
@@ -731,6 +1412,7 @@ func (repo *PRRepository) FindPRsByReviewer(userID string) ([]*entity.PullReques
 		var pr entity.PullRequest
 		var status string
 		var mergedAt sql.NullTime
+		var shadowReviewer sql.NullString
 		var reviewerIDs []string
 
 		if err := rows.Scan(
@@ -740,6 +1422,16 @@ func (repo *PRRepository) FindPRsByReviewer(userID string) ([]*entity.PullReques
 			&status,
 			&pr.CreatedAt,
 			&mergedAt,
+			&pr.Version,
+			&pr.Metadata.Additions,
+			&pr.Metadata.Deletions,
+			&pr.Metadata.ChangedFiles,
+			&pr.Metadata.Repository,
+			&pr.Metadata.BaseBranch,
+			pq.Array(&pr.Metadata.Labels),
+			pq.Array(&pr.Metadata.Paths),
+			pq.Array(&pr.Metadata.RequiredSkills),
+			&shadowReviewer,
 			pq.Array(&reviewerIDs),
 		); err != nil {
 			repo.logger.Error("POSTGRES_FIND_PRS_BY_REVIEWER", "Failed to scan PR row",
@@ -754,6 +1446,10 @@ func (repo *PRRepository) FindPRsByReviewer(userID string) ([]*entity.PullReques
 			pr.MergedAt = mergedAt.Time
 		}
 		pr.AssignedReviewers = reviewerIDs
+		pr.ShadowReviewer = shadowReviewer.String
+		pr.Metadata.Labels = stringsOrNil(pr.Metadata.Labels)
+		pr.Metadata.Paths = stringsOrNil(pr.Metadata.Paths)
+		pr.Metadata.RequiredSkills = stringsOrNil(pr.Metadata.RequiredSkills)
 
 		prs = append(prs, &pr)
 	}
@@ -773,10 +1469,1206 @@ func (repo *PRRepository) FindPRsByReviewer(userID string) ([]*entity.PullReques
 	return prs, nil
 }
 
-func (repo *PRRepository) getTeamIDByName(teamName string) (int, error) {
+func (repo *PRRepository) FindPRsByShadowReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_FIND_PRS_BY_SHADOW_REVIEWER", "Finding PRs by shadow reviewer",
+		"user_id", userID)
+
+	query := `
+		SELECT
+			pr.pull_request_id,
+			pr.pull_request_name,
+			pr.author_id,
+			pr.status,
+			pr.created_at,
+			pr.merged_at,
+			pr.version,
+			pr.additions,
+			pr.deletions,
+			pr.changed_files,
+			pr.repository,
+			pr.base_branch,
+			pr.labels,
+			pr.paths,
+			pr.required_skills,
+			COALESCE(
+				ARRAY_AGG(prr.reviewer_id ORDER BY prr.reviewer_id) FILTER (WHERE prr.reviewer_id IS NOT NULL),
+				'{}'
+			) AS reviewer_ids
+		FROM pull_requests pr
+		LEFT JOIN pull_request_reviewers prr ON pr.pull_request_id = prr.pull_request_id
+		WHERE pr.shadow_reviewer_id = $1
+		GROUP BY pr.pull_request_id
+		ORDER BY pr.created_at DESC, pr.pull_request_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query, userID)
+	if err != nil {
+		repo.logger.Error("POSTGRES_FIND_PRS_BY_SHADOW_REVIEWER", "Failed to query PRs by shadow reviewer",
+			"user_id", userID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("find PRs by shadow reviewer: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_FIND_PRS_BY_SHADOW_REVIEWER", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var prs []*entity.PullRequest
+	for rows.Next() {
+		pr := entity.PullRequest{ShadowReviewer: userID}
+		var status string
+		var mergedAt sql.NullTime
+		var reviewerIDs []string
+
+		if err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&status,
+			&pr.CreatedAt,
+			&mergedAt,
+			&pr.Version,
+			&pr.Metadata.Additions,
+			&pr.Metadata.Deletions,
+			&pr.Metadata.ChangedFiles,
+			&pr.Metadata.Repository,
+			&pr.Metadata.BaseBranch,
+			pq.Array(&pr.Metadata.Labels),
+			pq.Array(&pr.Metadata.Paths),
+			pq.Array(&pr.Metadata.RequiredSkills),
+			pq.Array(&reviewerIDs),
+		); err != nil {
+			return nil, fmt.Errorf("scan PR row: %w", err)
+		}
+
+		pr.Status = entity.PullRequestStatus(status)
+		if mergedAt.Valid {
+			pr.MergedAt = mergedAt.Time
+		}
+		pr.AssignedReviewers = reviewerIDs
+		pr.Metadata.Labels = stringsOrNil(pr.Metadata.Labels)
+		pr.Metadata.Paths = stringsOrNil(pr.Metadata.Paths)
+		pr.Metadata.RequiredSkills = stringsOrNil(pr.Metadata.RequiredSkills)
+
+		prs = append(prs, &pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate PR rows: %w", err)
+	}
+
+	repo.logger.Debug("POSTGRES_FIND_PRS_BY_SHADOW_REVIEWER", "PRs found successfully",
+		"user_id", userID,
+		"prs_count", len(prs),
+		"duration_ms", time.Since(start).Milliseconds())
+	return prs, nil
+}
+
+func (repo *PRRepository) ListPRs(ctx context.Context) ([]*entity.PullRequest, error) {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_LIST_PRS", "Listing all PRs")
+
+	// LEFT JOIN оставляет PR без ревьюверов, FILTER убирает NULL из их массива
+	query := `
+		SELECT
+			pr.pull_request_id,
+			pr.pull_request_name,
+			pr.author_id,
+			pr.status,
+			pr.created_at,
+			pr.merged_at,
+			pr.version,
+			pr.additions,
+			pr.deletions,
+			pr.changed_files,
+			pr.repository,
+			pr.base_branch,
+			pr.labels,
+			pr.paths,
+			pr.required_skills,
+			pr.shadow_reviewer_id,
+			COALESCE(
+				ARRAY_AGG(prr.reviewer_id ORDER BY prr.reviewer_id) FILTER (WHERE prr.reviewer_id IS NOT NULL),
+				'{}'
+			) AS reviewer_ids
+		FROM pull_requests pr
+		LEFT JOIN pull_request_reviewers prr ON pr.pull_request_id = prr.pull_request_id
+		GROUP BY
+			pr.pull_request_id,
+			pr.pull_request_name,
+			pr.author_id,
+			pr.status,
+			pr.created_at,
+			pr.merged_at,
+			pr.version,
+			pr.additions,
+			pr.deletions,
+			pr.changed_files,
+			pr.repository,
+			pr.base_branch,
+			pr.labels,
+			pr.paths,
+			pr.required_skills,
+			pr.shadow_reviewer_id
+		ORDER BY pr.created_at, pr.pull_request_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query)
+	if err != nil {
+		repo.logger.Error("POSTGRES_LIST_PRS", "Failed to query PRs",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("list PRs: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_LIST_PRS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var prs []*entity.PullRequest
+	for rows.Next() {
+		var pr entity.PullRequest
+		var status string
+		var mergedAt sql.NullTime
+		var shadowReviewer sql.NullString
+		var reviewerIDs []string
+
+		if err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&status,
+			&pr.CreatedAt,
+			&mergedAt,
+			&pr.Version,
+			&pr.Metadata.Additions,
+			&pr.Metadata.Deletions,
+			&pr.Metadata.ChangedFiles,
+			&pr.Metadata.Repository,
+			&pr.Metadata.BaseBranch,
+			pq.Array(&pr.Metadata.Labels),
+			pq.Array(&pr.Metadata.Paths),
+			pq.Array(&pr.Metadata.RequiredSkills),
+			&shadowReviewer,
+			pq.Array(&reviewerIDs),
+		); err != nil {
+			return nil, fmt.Errorf("scan PR row: %w", err)
+		}
+
+		pr.Status = entity.PullRequestStatus(status)
+		if mergedAt.Valid {
+			pr.MergedAt = mergedAt.Time
+		}
+		pr.AssignedReviewers = reviewerIDs
+		pr.ShadowReviewer = shadowReviewer.String
+		pr.Metadata.Labels = stringsOrNil(pr.Metadata.Labels)
+		pr.Metadata.Paths = stringsOrNil(pr.Metadata.Paths)
+		pr.Metadata.RequiredSkills = stringsOrNil(pr.Metadata.RequiredSkills)
+
+		prs = append(prs, &pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate PR rows: %w", err)
+	}
+
+	repo.logger.Debug("POSTGRES_LIST_PRS", "PRs listed successfully",
+		"prs_count", len(prs),
+		"duration_ms", time.Since(start).Milliseconds())
+	return prs, nil
+}
+
+// Unavailability
+
+const unavailabilityColumns = `
+	unavailability_id, user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at, external_id
+`
+
+func (repo *PRRepository) CreateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_CREATE_UNAVAILABILITY", "Creating unavailability period",
+		"user_id", period.UserID,
+		"starts_at", period.StartsAt,
+		"ends_at", period.EndsAt)
+
+	var reassignedAt sql.NullTime
+	if !period.ReassignedAt.IsZero() {
+		reassignedAt = sql.NullTime{Time: period.ReassignedAt.UTC(), Valid: true}
+	}
+
+	query := `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at, external_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING unavailability_id
+	`
+	err := repo.conn().QueryRowContext(ctx, query,
+		period.UserID,
+		period.StartsAt.UTC(),
+		period.EndsAt.UTC(),
+		period.Reason,
+		period.ReassignReviews,
+		reassignedAt,
+		period.ExternalID,
+	).Scan(&period.ID)
+	if isPgError(err, pgForeignKeyViolation) {
+		return fmt.Errorf("user %s: %w", period.UserID, ErrNoUser)
+	}
+	if err != nil {
+		repo.logger.Error("POSTGRES_CREATE_UNAVAILABILITY", "Failed to create unavailability period",
+			"user_id", period.UserID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("create unavailability: %w", err)
+	}
+
+	repo.logger.Info("POSTGRES_CREATE_UNAVAILABILITY", "Unavailability period created successfully",
+		"user_id", period.UserID,
+		"unavailability_id", period.ID,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *PRRepository) FindUnavailabilitiesByUser(ctx context.Context, userID string) ([]*entity.Unavailability, error) {
+	query := `SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		WHERE user_id = $1
+		ORDER BY starts_at, unavailability_id
+	`
+	return repo.queryUnavailabilities(ctx, "POSTGRES_FIND_UNAVAILABILITIES_BY_USER", query, userID)
+}
+
+func (repo *PRRepository) ListUnavailabilities(ctx context.Context) ([]*entity.Unavailability, error) {
+	query := `SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		ORDER BY user_id, starts_at, unavailability_id
+	`
+	return repo.queryUnavailabilities(ctx, "POSTGRES_LIST_UNAVAILABILITIES", query)
+}
+
+func (repo *PRRepository) FindPendingReassignments(ctx context.Context, at time.Time) ([]*entity.Unavailability, error) {
+	query := `SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		WHERE reassign_reviews AND reassigned_at IS NULL AND starts_at <= $1 AND ends_at > $1
+		ORDER BY starts_at, unavailability_id
+	`
+	return repo.queryUnavailabilities(ctx, "POSTGRES_FIND_PENDING_REASSIGNMENTS", query, at.UTC())
+}
+
+func (repo *PRRepository) queryUnavailabilities(ctx context.Context, operation, query string, args ...any) ([]*entity.Unavailability, error) {
+	start := time.Now()
+
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		repo.logger.Error(operation, "Failed to query unavailability periods",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("query unavailability: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error(operation, "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var periods []*entity.Unavailability
+	for rows.Next() {
+		var period entity.Unavailability
+		var reassignedAt sql.NullTime
+		if err := rows.Scan(
+			&period.ID,
+			&period.UserID,
+			&period.StartsAt,
+			&period.EndsAt,
+			&period.Reason,
+			&period.ReassignReviews,
+			&reassignedAt,
+			&period.ExternalID,
+		); err != nil {
+			return nil, fmt.Errorf("scan unavailability row: %w", err)
+		}
+		if reassignedAt.Valid {
+			period.ReassignedAt = reassignedAt.Time
+		}
+		periods = append(periods, &period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate unavailability rows: %w", err)
+	}
+
+	repo.logger.Debug(operation, "Unavailability periods found",
+		"periods_count", len(periods),
+		"duration_ms", time.Since(start).Milliseconds())
+	return periods, nil
+}
+
+func (repo *PRRepository) DeleteUnavailability(ctx context.Context, id int64) error {
+	repo.logger.Debug("POSTGRES_DELETE_UNAVAILABILITY", "Deleting unavailability period",
+		"unavailability_id", id)
+
+	result, err := repo.conn().ExecContext(ctx,
+		`DELETE FROM user_unavailability WHERE unavailability_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete unavailability: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrNoUnavailability
+	}
+
+	repo.logger.Info("POSTGRES_DELETE_UNAVAILABILITY", "Unavailability period deleted successfully",
+		"unavailability_id", id)
+	return nil
+}
+
+func (repo *PRRepository) FindUnavailableUserIDs(ctx context.Context, teamName string, at time.Time) ([]string, error) {
+	query := `
+		SELECT DISTINCT uu.user_id
+		FROM user_unavailability uu
+		JOIN users u ON u.user_id = uu.user_id
+		JOIN teams t ON t.team_id = u.team_id
+		WHERE t.team_name = $1 AND uu.starts_at <= $2 AND uu.ends_at > $2
+		ORDER BY uu.user_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query, teamName, at.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("query unavailable users: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_FIND_UNAVAILABLE_USER_IDS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("scan unavailable user row: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate unavailable user rows: %w", err)
+	}
+	return userIDs, nil
+}
+
+func (repo *PRRepository) MarkUnavailabilityReassigned(ctx context.Context, id int64, at time.Time) error {
+	result, err := repo.conn().ExecContext(ctx,
+		`UPDATE user_unavailability SET reassigned_at = $1 WHERE unavailability_id = $2`, at.UTC(), id)
+	if err != nil {
+		return fmt.Errorf("mark unavailability reassigned: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrNoUnavailability
+	}
+	return nil
+}
+
+func (repo *PRRepository) UpdateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_UPDATE_UNAVAILABILITY", "Updating unavailability period",
+		"unavailability_id", period.ID,
+		"starts_at", period.StartsAt,
+		"ends_at", period.EndsAt)
+
+	var reassignedAt sql.NullTime
+	if !period.ReassignedAt.IsZero() {
+		reassignedAt = sql.NullTime{Time: period.ReassignedAt.UTC(), Valid: true}
+	}
+
+	query := `
+		UPDATE user_unavailability
+		SET starts_at = $1, ends_at = $2, reason = $3, reassign_reviews = $4,
+			reassigned_at = $5, external_id = $6
+		WHERE unavailability_id = $7
+	`
+	result, err := repo.conn().ExecContext(ctx, query,
+		period.StartsAt.UTC(),
+		period.EndsAt.UTC(),
+		period.Reason,
+		period.ReassignReviews,
+		reassignedAt,
+		period.ExternalID,
+		period.ID,
+	)
+	if err != nil {
+		repo.logger.Error("POSTGRES_UPDATE_UNAVAILABILITY", "Failed to update unavailability period",
+			"unavailability_id", period.ID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("update unavailability: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrNoUnavailability
+	}
+
+	repo.logger.Info("POSTGRES_UPDATE_UNAVAILABILITY", "Unavailability period updated successfully",
+		"unavailability_id", period.ID,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+// Aliases
+
+func (repo *PRRepository) SetUserAliases(ctx context.Context, userID string, aliases []string) error {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_SET_USER_ALIASES", "Setting user aliases",
+		"user_id", userID,
+		"aliases_count", len(aliases))
+
+	tx, err := repo.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			repo.logger.Error("POSTGRES_SET_USER_ALIASES", "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE user_id = $1)`, userID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("check user: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("user %s: %w", userID, ErrNoUser)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_aliases WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete user aliases: %w", err)
+	}
+	for _, alias := range aliases {
+		_, err := tx.ExecContext(ctx, `INSERT INTO user_aliases (alias, user_id) VALUES ($1, $2)`, alias, userID)
+		if isPgError(err, pgUniqueViolation) {
+			return fmt.Errorf("alias %s: %w", alias, ErrAliasTaken)
+		}
+		if err != nil {
+			repo.logger.Error("POSTGRES_SET_USER_ALIASES", "Failed to insert alias",
+				"user_id", userID,
+				"alias", alias,
+				"error", err)
+			return fmt.Errorf("insert user alias: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	repo.logger.Info("POSTGRES_SET_USER_ALIASES", "User aliases set successfully",
+		"user_id", userID,
+		"aliases_count", len(aliases),
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *PRRepository) FindUserAliases(ctx context.Context, userID string) ([]string, error) {
+	aliases, err := repo.queryUserAliases(ctx, `
+		SELECT alias, user_id FROM user_aliases WHERE user_id = $1 ORDER BY alias
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(aliases))
+	for i, alias := range aliases {
+		result[i] = alias.Alias
+	}
+	return result, nil
+}
+
+func (repo *PRRepository) ListUserAliases(ctx context.Context) ([]entity.UserAlias, error) {
+	return repo.queryUserAliases(ctx, `
+		SELECT alias, user_id FROM user_aliases ORDER BY user_id, alias
+	`)
+}
+
+func (repo *PRRepository) queryUserAliases(ctx context.Context, query string, args ...any) ([]entity.UserAlias, error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query user aliases: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_QUERY_USER_ALIASES", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var aliases []entity.UserAlias
+	for rows.Next() {
+		var alias entity.UserAlias
+		if err := rows.Scan(&alias.Alias, &alias.UserID); err != nil {
+			return nil, fmt.Errorf("scan user alias row: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user alias rows: %w", err)
+	}
+	return aliases, nil
+}
+
+func (repo *PRRepository) FindUserIDByAlias(ctx context.Context, alias string) (string, error) {
+	var userID string
+	err := repo.conn().QueryRowContext(ctx,
+		`SELECT user_id FROM user_aliases WHERE alias = $1`, alias).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("alias %s: %w", alias, ErrNoUser)
+	}
+	if err != nil {
+		return "", fmt.Errorf("find user by alias: %w", err)
+	}
+	return userID, nil
+}
+
+// Work schedules
+
+func (repo *PRRepository) SetWorkSchedule(ctx context.Context, schedule *entity.WorkSchedule) error {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_SET_WORK_SCHEDULE", "Setting work schedule",
+		"user_id", schedule.UserID,
+		"time_zone", schedule.TimeZone)
+
+	query := `
+		INSERT INTO user_schedules (user_id, time_zone, start_minute, end_minute, work_days, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET time_zone = EXCLUDED.time_zone,
+			start_minute = EXCLUDED.start_minute,
+			end_minute = EXCLUDED.end_minute,
+			work_days = EXCLUDED.work_days,
+			updated_at = EXCLUDED.updated_at
+	`
+	_, err := repo.conn().ExecContext(ctx, query,
+		schedule.UserID,
+		schedule.TimeZone,
+		schedule.StartMinute,
+		schedule.EndMinute,
+		entity.WorkDaysMask(schedule.Days),
+	)
+	if isPgError(err, pgForeignKeyViolation) {
+		return fmt.Errorf("user %s: %w", schedule.UserID, ErrNoUser)
+	}
+	if err != nil {
+		repo.logger.Error("POSTGRES_SET_WORK_SCHEDULE", "Failed to set work schedule",
+			"user_id", schedule.UserID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("set work schedule: %w", err)
+	}
+
+	repo.logger.Info("POSTGRES_SET_WORK_SCHEDULE", "Work schedule set successfully",
+		"user_id", schedule.UserID,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+const workScheduleColumns = `user_id, time_zone, start_minute, end_minute, work_days`
+
+func (repo *PRRepository) FindWorkSchedule(ctx context.Context, userID string) (*entity.WorkSchedule, error) {
+	schedules, err := repo.queryWorkSchedules(ctx, `
+		SELECT `+workScheduleColumns+` FROM user_schedules WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		return nil, fmt.Errorf("user %s: %w", userID, ErrNoWorkSchedule)
+	}
+	return schedules[0], nil
+}
+
+func (repo *PRRepository) FindWorkSchedulesByTeam(ctx context.Context, teamName string) ([]*entity.WorkSchedule, error) {
+	return repo.queryWorkSchedules(ctx, `
+		SELECT us.user_id, us.time_zone, us.start_minute, us.end_minute, us.work_days
+		FROM user_schedules us
+		JOIN users u ON u.user_id = us.user_id
+		JOIN teams t ON t.team_id = u.team_id
+		WHERE t.team_name = $1
+		ORDER BY us.user_id
+	`, teamName)
+}
+
+func (repo *PRRepository) ListWorkSchedules(ctx context.Context) ([]*entity.WorkSchedule, error) {
+	return repo.queryWorkSchedules(ctx, `
+		SELECT `+workScheduleColumns+` FROM user_schedules ORDER BY user_id
+	`)
+}
+
+func (repo *PRRepository) queryWorkSchedules(ctx context.Context, query string, args ...any) ([]*entity.WorkSchedule, error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query work schedules: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_QUERY_WORK_SCHEDULES", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var schedules []*entity.WorkSchedule
+	for rows.Next() {
+		var schedule entity.WorkSchedule
+		var days int
+		if err := rows.Scan(
+			&schedule.UserID,
+			&schedule.TimeZone,
+			&schedule.StartMinute,
+			&schedule.EndMinute,
+			&days,
+		); err != nil {
+			return nil, fmt.Errorf("scan work schedule row: %w", err)
+		}
+		schedule.Days = entity.WorkDaysFromMask(days)
+		schedules = append(schedules, &schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate work schedule rows: %w", err)
+	}
+	return schedules, nil
+}
+
+func (repo *PRRepository) DeleteWorkSchedule(ctx context.Context, userID string) error {
+	result, err := repo.conn().ExecContext(ctx, `DELETE FROM user_schedules WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("delete work schedule: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("user %s: %w", userID, ErrNoWorkSchedule)
+	}
+	return nil
+}
+
+// Skills
+
+func (repo *PRRepository) SetUserSkills(ctx context.Context, userID string, skills []entity.UserSkill) error {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_SET_USER_SKILLS", "Setting user skills",
+		"user_id", userID,
+		"skills_count", len(skills))
+
+	tx, err := repo.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			repo.logger.Error("POSTGRES_SET_USER_SKILLS", "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE user_id = $1)`, userID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("check user: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("user %s: %w", userID, ErrNoUser)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_skills WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete user skills: %w", err)
+	}
+	for _, skill := range skills {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO user_skills (user_id, tag, level) VALUES ($1, $2, $3)`, userID, skill.Tag, skill.Level)
+		if err != nil {
+			repo.logger.Error("POSTGRES_SET_USER_SKILLS", "Failed to insert skill",
+				"user_id", userID,
+				"tag", skill.Tag,
+				"error", err)
+			return fmt.Errorf("insert user skill: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	repo.logger.Info("POSTGRES_SET_USER_SKILLS", "User skills set successfully",
+		"user_id", userID,
+		"skills_count", len(skills),
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *PRRepository) FindUserSkills(ctx context.Context, userID string) ([]entity.UserSkill, error) {
+	return repo.queryUserSkills(ctx, `
+		SELECT user_id, tag, level FROM user_skills WHERE user_id = $1 ORDER BY tag
+	`, userID)
+}
+
+func (repo *PRRepository) FindSkillsByTeam(ctx context.Context, teamName string) ([]entity.UserSkill, error) {
+	return repo.queryUserSkills(ctx, `
+		SELECT us.user_id, us.tag, us.level
+		FROM user_skills us
+		JOIN users u ON u.user_id = us.user_id
+		JOIN teams t ON t.team_id = u.team_id
+		WHERE t.team_name = $1
+		ORDER BY us.user_id, us.tag
+	`, teamName)
+}
+
+func (repo *PRRepository) ListUserSkills(ctx context.Context) ([]entity.UserSkill, error) {
+	return repo.queryUserSkills(ctx, `
+		SELECT user_id, tag, level FROM user_skills ORDER BY user_id, tag
+	`)
+}
+
+func (repo *PRRepository) queryUserSkills(ctx context.Context, query string, args ...any) ([]entity.UserSkill, error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query user skills: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_QUERY_USER_SKILLS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var skills []entity.UserSkill
+	for rows.Next() {
+		var skill entity.UserSkill
+		if err := rows.Scan(&skill.UserID, &skill.Tag, &skill.Level); err != nil {
+			return nil, fmt.Errorf("scan user skill row: %w", err)
+		}
+		skills = append(skills, skill)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user skill rows: %w", err)
+	}
+	return skills, nil
+}
+
+// Code owners
+
+func (repo *PRRepository) SetCodeOwners(ctx context.Context, owners *entity.CodeOwners) error {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_SET_CODE_OWNERS", "Setting code owners",
+		"repository", owners.Repository,
+		"rules_count", len(owners.Rules))
+
+	tx, err := repo.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			repo.logger.Error("POSTGRES_SET_CODE_OWNERS", "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM code_owner_rules WHERE repository = $1`, owners.Repository); err != nil {
+		return fmt.Errorf("delete code owner rules: %w", err)
+	}
+	for i, rule := range owners.Rules {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO code_owner_rules (repository, position, line, pattern, user_owners, team_owners)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`,
+			owners.Repository,
+			i,
+			rule.Line,
+			rule.Pattern,
+			pq.Array(stringsOrEmpty(rule.Users)),
+			pq.Array(stringsOrEmpty(rule.Teams)),
+		)
+		if err != nil {
+			repo.logger.Error("POSTGRES_SET_CODE_OWNERS", "Failed to insert code owner rule",
+				"repository", owners.Repository,
+				"line", rule.Line,
+				"error", err)
+			return fmt.Errorf("insert code owner rule: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	repo.logger.Info("POSTGRES_SET_CODE_OWNERS", "Code owners set successfully",
+		"repository", owners.Repository,
+		"rules_count", len(owners.Rules),
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+const codeOwnerRuleColumns = `repository, line, pattern, user_owners, team_owners`
+
+func (repo *PRRepository) FindCodeOwners(ctx context.Context, repository string) (*entity.CodeOwners, error) {
+	owners, err := repo.queryCodeOwners(ctx, `
+		SELECT `+codeOwnerRuleColumns+` FROM code_owner_rules WHERE repository = $1 ORDER BY position
+	`, repository)
+	if err != nil {
+		return nil, err
+	}
+	if len(owners) == 0 {
+		return nil, fmt.Errorf("repository %s: %w", repository, ErrNoCodeOwners)
+	}
+	return owners[0], nil
+}
+
+func (repo *PRRepository) ListCodeOwners(ctx context.Context) ([]*entity.CodeOwners, error) {
+	return repo.queryCodeOwners(ctx, `
+		SELECT `+codeOwnerRuleColumns+` FROM code_owner_rules ORDER BY repository, position
+	`)
+}
+
+// queryCodeOwners собирает правила, упорядоченные по репозиторию, в CodeOwners
+func (repo *PRRepository) queryCodeOwners(ctx context.Context, query string, args ...any) ([]*entity.CodeOwners, error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query code owners: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_QUERY_CODE_OWNERS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var owners []*entity.CodeOwners
+	for rows.Next() {
+		var repository string
+		var rule entity.CodeOwnerRule
+		if err := rows.Scan(
+			&repository,
+			&rule.Line,
+			&rule.Pattern,
+			pq.Array(&rule.Users),
+			pq.Array(&rule.Teams),
+		); err != nil {
+			return nil, fmt.Errorf("scan code owner rule row: %w", err)
+		}
+		rule.Users = stringsOrNil(rule.Users)
+		rule.Teams = stringsOrNil(rule.Teams)
+
+		if len(owners) == 0 || owners[len(owners)-1].Repository != repository {
+			owners = append(owners, &entity.CodeOwners{Repository: repository})
+		}
+		current := owners[len(owners)-1]
+		current.Rules = append(current.Rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate code owner rule rows: %w", err)
+	}
+	return owners, nil
+}
+
+func (repo *PRRepository) DeleteCodeOwners(ctx context.Context, repository string) error {
+	result, err := repo.conn().ExecContext(ctx, `DELETE FROM code_owner_rules WHERE repository = $1`, repository)
+	if err != nil {
+		return fmt.Errorf("delete code owners: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("repository %s: %w", repository, ErrNoCodeOwners)
+	}
+	return nil
+}
+
+// Reviewer rules
+
+func (repo *PRRepository) SetReviewerRule(ctx context.Context, rule *entity.ReviewerRule) error {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_SET_REVIEWER_RULE", "Setting reviewer rule",
+		"author_id", rule.AuthorID,
+		"reviewer_id", rule.ReviewerID,
+		"kind", rule.Kind)
+
+	query := `
+		INSERT INTO reviewer_rules (author_id, reviewer_id, kind, reason)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (author_id, reviewer_id) DO UPDATE
+		SET kind = EXCLUDED.kind,
+			reason = EXCLUDED.reason
+	`
+	_, err := repo.conn().ExecContext(ctx, query, rule.AuthorID, rule.ReviewerID, string(rule.Kind), rule.Reason)
+	if isPgError(err, pgForeignKeyViolation) {
+		return fmt.Errorf("author %s or reviewer %s: %w", rule.AuthorID, rule.ReviewerID, ErrNoUser)
+	}
+	if err != nil {
+		repo.logger.Error("POSTGRES_SET_REVIEWER_RULE", "Failed to set reviewer rule",
+			"author_id", rule.AuthorID,
+			"reviewer_id", rule.ReviewerID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("set reviewer rule: %w", err)
+	}
+
+	repo.logger.Info("POSTGRES_SET_REVIEWER_RULE", "Reviewer rule set successfully",
+		"author_id", rule.AuthorID,
+		"reviewer_id", rule.ReviewerID,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *PRRepository) FindReviewerRulesByAuthor(ctx context.Context, authorID string) ([]entity.ReviewerRule, error) {
+	return repo.queryReviewerRules(ctx, `
+		SELECT author_id, reviewer_id, kind, reason FROM reviewer_rules WHERE author_id = $1 ORDER BY reviewer_id
+	`, authorID)
+}
+
+func (repo *PRRepository) ListReviewerRules(ctx context.Context) ([]entity.ReviewerRule, error) {
+	return repo.queryReviewerRules(ctx, `
+		SELECT author_id, reviewer_id, kind, reason FROM reviewer_rules ORDER BY author_id, reviewer_id
+	`)
+}
+
+func (repo *PRRepository) queryReviewerRules(ctx context.Context, query string, args ...any) ([]entity.ReviewerRule, error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query reviewer rules: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("POSTGRES_QUERY_REVIEWER_RULES", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var rules []entity.ReviewerRule
+	for rows.Next() {
+		var rule entity.ReviewerRule
+		var kind string
+		if err := rows.Scan(&rule.AuthorID, &rule.ReviewerID, &kind, &rule.Reason); err != nil {
+			return nil, fmt.Errorf("scan reviewer rule row: %w", err)
+		}
+		rule.Kind = entity.ReviewerRuleKind(kind)
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewer rule rows: %w", err)
+	}
+	return rules, nil
+}
+
+func (repo *PRRepository) DeleteReviewerRule(ctx context.Context, authorID, reviewerID string) error {
+	result, err := repo.conn().ExecContext(ctx,
+		`DELETE FROM reviewer_rules WHERE author_id = $1 AND reviewer_id = $2`, authorID, reviewerID)
+	if err != nil {
+		return fmt.Errorf("delete reviewer rule: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("author %s, reviewer %s: %w", authorID, reviewerID, ErrNoReviewerRule)
+	}
+	return nil
+}
+
+// Idempotency keys
+
+func (repo *PRRepository) FindIdempotencyRecord(ctx context.Context, key, endpoint string) (*entity.IdempotencyRecord, error) {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_FIND_IDEMPOTENCY_RECORD", "Finding idempotency record",
+		"key", key,
+		"endpoint", endpoint)
+
+	query := `
+		SELECT idempotency_key, endpoint, request_hash, status_code, response_headers,
+			response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE idempotency_key = $1 AND endpoint = $2 AND expires_at > $3
+	`
+
+	var record entity.IdempotencyRecord
+	var headers string
+	err := repo.conn().QueryRowContext(ctx, query, key, endpoint, time.Now()).Scan(
+		&record.Key,
+		&record.Endpoint,
+		&record.RequestHash,
+		&record.StatusCode,
+		&headers,
+		&record.ResponseBody,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repo.logger.Debug("POSTGRES_FIND_IDEMPOTENCY_RECORD", "Idempotency record not found",
+				"key", key,
+				"endpoint", endpoint,
+				"duration_ms", time.Since(start).Milliseconds())
+			return nil, nil
+		}
+		repo.logger.Error("POSTGRES_FIND_IDEMPOTENCY_RECORD", "Failed to find idempotency record",
+			"key", key,
+			"endpoint", endpoint,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("find idempotency record: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(headers), &record.ResponseHeaders); err != nil {
+		return nil, fmt.Errorf("decode idempotency record headers: %w", err)
+	}
+
+	repo.logger.Debug("POSTGRES_FIND_IDEMPOTENCY_RECORD", "Idempotency record found",
+		"key", key,
+		"endpoint", endpoint,
+		"duration_ms", time.Since(start).Milliseconds())
+	return &record, nil
+}
+
+func (repo *PRRepository) ReserveIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) (bool, error) {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_RESERVE_IDEMPOTENCY_KEY", "Reserving idempotency key",
+		"key", record.Key,
+		"endpoint", record.Endpoint)
+
+	// Из двух одновременных запросов с одним ключом запись сохранит только один,
+	// второй увидит её и не станет выполнять запрос повторно
+	query := `
+		INSERT INTO idempotency_keys (idempotency_key, endpoint, request_hash, status_code,
+			response_headers, response_body, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, '{}', $5, $6, $7)
+		ON CONFLICT (idempotency_key, endpoint) DO NOTHING
+	`
+
+	result, err := repo.conn().ExecContext(ctx, query,
+		record.Key,
+		record.Endpoint,
+		record.RequestHash,
+		record.StatusCode,
+		[]byte{},
+		record.CreatedAt,
+		record.ExpiresAt,
+	)
+	if err != nil {
+		repo.logger.Error("POSTGRES_RESERVE_IDEMPOTENCY_KEY", "Failed to reserve idempotency key",
+			"key", record.Key,
+			"endpoint", record.Endpoint,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return false, fmt.Errorf("reserve idempotency key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("get rows affected: %w", err)
+	}
+
+	repo.logger.Debug("POSTGRES_RESERVE_IDEMPOTENCY_KEY", "Idempotency key reservation finished",
+		"key", record.Key,
+		"endpoint", record.Endpoint,
+		"reserved", rowsAffected > 0,
+		"duration_ms", time.Since(start).Milliseconds())
+	return rowsAffected > 0, nil
+}
+
+func (repo *PRRepository) CompleteIdempotencyRecord(ctx context.Context, record *entity.IdempotencyRecord) error {
+	start := time.Now()
+
+	repo.logger.Debug("POSTGRES_COMPLETE_IDEMPOTENCY_RECORD", "Completing idempotency record",
+		"key", record.Key,
+		"endpoint", record.Endpoint,
+		"status_code", record.StatusCode)
+
+	headers, err := json.Marshal(record.ResponseHeaders)
+	if err != nil {
+		return fmt.Errorf("encode idempotency record headers: %w", err)
+	}
+
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $4, response_headers = $5, response_body = $6, created_at = $7, expires_at = $8
+		WHERE idempotency_key = $1 AND endpoint = $2 AND request_hash = $3
+	`
+
+	_, err = repo.conn().ExecContext(ctx, query,
+		record.Key,
+		record.Endpoint,
+		record.RequestHash,
+		record.StatusCode,
+		string(headers),
+		record.ResponseBody,
+		record.CreatedAt,
+		record.ExpiresAt,
+	)
+	if err != nil {
+		repo.logger.Error("POSTGRES_COMPLETE_IDEMPOTENCY_RECORD", "Failed to complete idempotency record",
+			"key", record.Key,
+			"endpoint", record.Endpoint,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("complete idempotency record: %w", err)
+	}
+
+	repo.logger.Debug("POSTGRES_COMPLETE_IDEMPOTENCY_RECORD", "Idempotency record completed",
+		"key", record.Key,
+		"endpoint", record.Endpoint,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *PRRepository) ReleaseIdempotencyKey(ctx context.Context, key, endpoint string) error {
+	start := time.Now()
+
+	_, err := repo.conn().ExecContext(ctx,
+		`DELETE FROM idempotency_keys WHERE idempotency_key = $1 AND endpoint = $2 AND status_code = 0`,
+		key, endpoint)
+	if err != nil {
+		repo.logger.Error("POSTGRES_RELEASE_IDEMPOTENCY_KEY", "Failed to release idempotency key",
+			"key", key,
+			"endpoint", endpoint,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+
+	repo.logger.Debug("POSTGRES_RELEASE_IDEMPOTENCY_KEY", "Idempotency key released",
+		"key", key,
+		"endpoint", endpoint,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *PRRepository) DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error) {
+	start := time.Now()
+
+	result, err := repo.conn().ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		repo.logger.Error("POSTGRES_DELETE_EXPIRED_IDEMPOTENCY_RECORDS", "Failed to delete expired idempotency records",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return 0, fmt.Errorf("delete expired idempotency records: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get rows affected: %w", err)
+	}
+
+	repo.logger.Debug("POSTGRES_DELETE_EXPIRED_IDEMPOTENCY_RECORDS", "Expired idempotency records deleted",
+		"deleted", deleted,
+		"duration_ms", time.Since(start).Milliseconds())
+	return deleted, nil
+}
+
+func (repo *PRRepository) getTeamIDByName(ctx context.Context, teamName string) (int, error) {
 	var teamID int
 	query := `SELECT team_id FROM teams WHERE team_name = $1`
-	err := repo.db.QueryRow(query, teamName).Scan(&teamID)
+	err := repo.conn().QueryRowContext(ctx, query, teamName).Scan(&teamID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return 0, ErrNoTeam
@@ -0,0 +1,12 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/repository"
+	"github.com/pozedorum/set_pr_reviers_service/internal/repository/repositorytest"
+)
+
+func TestPRRepository_Contract(t *testing.T) {
+	repositorytest.Run(t, repository.NewContractTestRepo)
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -11,6 +13,7 @@ import (
 	"github.com/ory/dockertest/v3/docker"
 	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
 	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+	"github.com/pozedorum/set_pr_reviers_service/internal/migrator"
 	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,6 +23,7 @@ var (
 	testDB     *sql.DB
 	testRepo   *PRRepository
 	testLogger interfaces.Logger
+	testCtx    = context.Background()
 )
 
 func TestMain(m *testing.M) {
@@ -92,67 +96,41 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// initTestSchema создаёт схему теми же миграциями, что применяются в проде
 func initTestSchema(db *sql.DB) error {
-	_, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS teams (
-			team_id SERIAL PRIMARY KEY,
-			team_name VARCHAR(255) UNIQUE NOT NULL,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-		);
-
-		CREATE TABLE IF NOT EXISTS users (
-			user_id VARCHAR(255) PRIMARY KEY,
-			username VARCHAR(255) NOT NULL,
-			team_id INTEGER NOT NULL,
-			is_active BOOLEAN DEFAULT TRUE,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			FOREIGN KEY (team_id) REFERENCES teams(team_id) ON DELETE CASCADE
-		);
-
-		CREATE TABLE IF NOT EXISTS pull_requests (
-			pull_request_id VARCHAR(255) PRIMARY KEY,
-			pull_request_name VARCHAR(255) NOT NULL,
-			author_id VARCHAR(255) NOT NULL,
-			status VARCHAR(50) NOT NULL DEFAULT 'OPEN',
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			merged_at TIMESTAMP NULL,
-			FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE
-		);
-
-		CREATE TABLE IF NOT EXISTS pull_request_reviewers (
-			pull_request_id VARCHAR(255) NOT NULL,
-			reviewer_id VARCHAR(255) NOT NULL,
-			assigned_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			PRIMARY KEY (pull_request_id, reviewer_id),
-			FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
-			FOREIGN KEY (reviewer_id) REFERENCES users(user_id) ON DELETE CASCADE
-		);
-
-		-- Индексы для производительности
-		CREATE INDEX IF NOT EXISTS idx_users_team_id ON users(team_id);
-		CREATE INDEX IF NOT EXISTS idx_users_is_active ON users(is_active);
-		CREATE INDEX IF NOT EXISTS idx_prs_status ON pull_requests(status);
-		CREATE INDEX IF NOT EXISTS idx_prs_author_id ON pull_requests(author_id);
-		CREATE INDEX IF NOT EXISTS idx_pr_reviewers_reviewer_id ON pull_request_reviewers(reviewer_id);
-		CREATE INDEX IF NOT EXISTS idx_teams_name ON teams(team_name);
-	`)
+	m, err := migrator.New(db, migrator.Postgres, testLogger)
+	if err != nil {
+		return err
+	}
+	_, err = m.Up(context.Background())
 	return err
 }
 
 func cleanupTestData() {
+	if _, err := testDB.Exec("DELETE FROM idempotency_keys"); err != nil {
+		panic("failed to cleanupTestData 0")
+	}
 	if _, err := testDB.Exec("DELETE FROM pull_request_reviewers"); err != nil {
 		panic("failed to cleanupTestData 1")
 	}
 	if _, err := testDB.Exec("DELETE FROM pull_requests"); err != nil {
 		panic("failed to cleanupTestData 2")
 	}
-	if _, err := testDB.Exec("DELETE FROM users"); err != nil {
+	if _, err := testDB.Exec("DELETE FROM user_unavailability"); err != nil {
 		panic("failed to cleanupTestData 3")
 	}
-	if _, err := testDB.Exec("DELETE FROM teams"); err != nil {
+	if _, err := testDB.Exec("DELETE FROM user_aliases"); err != nil {
 		panic("failed to cleanupTestData 4")
 	}
+	if _, err := testDB.Exec("DELETE FROM user_schedules"); err != nil {
+		panic("failed to cleanupTestData 5")
+	}
+	if _, err := testDB.Exec("DELETE FROM users"); err != nil {
+		panic("failed to cleanupTestData 6")
+	}
+	if _, err := testDB.Exec("DELETE FROM teams"); err != nil {
+		panic("failed to cleanupTestData 7")
+	}
 }
 
 func TestCreateTeam_Success(t *testing.T) {
@@ -174,11 +152,11 @@ func TestCreateTeam_Success(t *testing.T) {
 		},
 	}
 
-	err := testRepo.CreateTeam(team)
+	err := testRepo.CreateTeam(testCtx, team)
 	require.NoError(t, err)
 
 	// Проверяем что команда создалась
-	foundTeam, err := testRepo.FindTeamByName("backend")
+	foundTeam, err := testRepo.FindTeamByName(testCtx, "backend")
 	require.NoError(t, err)
 	assert.Equal(t, "backend", foundTeam.TeamName)
 	assert.Len(t, foundTeam.Members, 2)
@@ -194,7 +172,7 @@ func TestCreateTeam_AlreadyExists(t *testing.T) {
 		},
 	}
 
-	err := testRepo.CreateTeam(team1)
+	err := testRepo.CreateTeam(testCtx, team1)
 	require.NoError(t, err)
 
 	// Пытаемся создать команду с тем же именем
@@ -205,8 +183,8 @@ func TestCreateTeam_AlreadyExists(t *testing.T) {
 		},
 	}
 
-	err = testRepo.CreateTeam(team2)
-	assert.Error(t, err)
+	err = testRepo.CreateTeam(testCtx, team2)
+	assert.ErrorIs(t, err, ErrTeamExists)
 }
 
 func TestFindTeamByName_Success(t *testing.T) {
@@ -223,10 +201,10 @@ func TestFindTeamByName_Success(t *testing.T) {
 		},
 	}
 
-	err := testRepo.CreateTeam(team)
+	err := testRepo.CreateTeam(testCtx, team)
 	require.NoError(t, err)
 
-	foundTeam, err := testRepo.FindTeamByName("frontend")
+	foundTeam, err := testRepo.FindTeamByName(testCtx, "frontend")
 	require.NoError(t, err)
 	assert.Equal(t, "frontend", foundTeam.TeamName)
 	assert.Len(t, foundTeam.Members, 1)
@@ -238,11 +216,22 @@ func TestFindTeamByName_Success(t *testing.T) {
 func TestFindTeamByName_NotFound(t *testing.T) {
 	defer cleanupTestData()
 
-	team, err := testRepo.FindTeamByName("nonexistent")
+	team, err := testRepo.FindTeamByName(testCtx, "nonexistent")
 	assert.Error(t, err)
 	assert.Nil(t, team)
 }
 
+func TestFindTeamByName_CanceledContext(t *testing.T) {
+	defer cleanupTestData()
+
+	ctx, cancel := context.WithCancel(testCtx)
+	cancel()
+
+	team, err := testRepo.FindTeamByName(ctx, "backend")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, team)
+}
+
 func TestTeamExists_Success(t *testing.T) {
 	defer cleanupTestData()
 
@@ -253,13 +242,13 @@ func TestTeamExists_Success(t *testing.T) {
 		},
 	}
 
-	err := testRepo.CreateTeam(team)
+	err := testRepo.CreateTeam(testCtx, team)
 	require.NoError(t, err)
 
-	exists := testRepo.TeamExists("devops")
+	exists := testRepo.TeamExists(testCtx, "devops")
 	assert.True(t, exists)
 
-	exists = testRepo.TeamExists("nonexistent")
+	exists = testRepo.TeamExists(testCtx, "nonexistent")
 	assert.False(t, exists)
 }
 
@@ -271,7 +260,7 @@ func TestCreateUser_Success(t *testing.T) {
 		TeamName: "backend",
 		Members:  []entity.TeamMember{},
 	}
-	err := testRepo.CreateTeam(team)
+	err := testRepo.CreateTeam(testCtx, team)
 	require.NoError(t, err)
 
 	// Создаем пользователя
@@ -282,11 +271,11 @@ func TestCreateUser_Success(t *testing.T) {
 		IsActive: true,
 	}
 
-	err = testRepo.CreateUser(user)
+	err = testRepo.CreateUser(testCtx, user)
 	require.NoError(t, err)
 
 	// Проверяем что пользователь создался
-	foundUser, err := testRepo.FindUserByID("user1")
+	foundUser, err := testRepo.FindUserByID(testCtx, "user1")
 	require.NoError(t, err)
 	assert.Equal(t, "user1", foundUser.UserID)
 	assert.Equal(t, "Alice", foundUser.Username)
@@ -297,7 +286,7 @@ func TestCreateUser_Success(t *testing.T) {
 func TestFindUserByID_NotFound(t *testing.T) {
 	defer cleanupTestData()
 
-	user, err := testRepo.FindUserByID("nonexistent")
+	user, err := testRepo.FindUserByID(testCtx, "nonexistent")
 	assert.Error(t, err)
 	assert.Nil(t, user)
 }
@@ -312,7 +301,7 @@ func TestUpdateUser_Success(t *testing.T) {
 			{UserID: "user1", Username: "Alice", IsActive: true},
 		},
 	}
-	err := testRepo.CreateTeam(team)
+	err := testRepo.CreateTeam(testCtx, team)
 	require.NoError(t, err)
 
 	user := &entity.User{
@@ -322,11 +311,11 @@ func TestUpdateUser_Success(t *testing.T) {
 		IsActive: false,
 	}
 
-	err = testRepo.UpdateUser(user)
+	err = testRepo.UpdateUser(testCtx, user)
 	require.NoError(t, err)
 
 	// Проверяем обновление
-	updatedUser, err := testRepo.FindUserByID("user1")
+	updatedUser, err := testRepo.FindUserByID(testCtx, "user1")
 	require.NoError(t, err)
 	assert.Equal(t, "Alice Updated", updatedUser.Username)
 	assert.False(t, updatedUser.IsActive)
@@ -344,10 +333,10 @@ func TestFindUsersByTeam_Success(t *testing.T) {
 			{UserID: "user3", Username: "Charlie", IsActive: false},
 		},
 	}
-	err := testRepo.CreateTeam(team)
+	err := testRepo.CreateTeam(testCtx, team)
 	require.NoError(t, err)
 
-	users, err := testRepo.FindUsersByTeam("backend")
+	users, err := testRepo.FindUsersByTeam(testCtx, "backend")
 	require.NoError(t, err)
 	assert.Len(t, users, 3)
 
@@ -367,23 +356,23 @@ func TestSetActive_Success(t *testing.T) {
 			{UserID: "user1", Username: "Alice", IsActive: true},
 		},
 	}
-	err := testRepo.CreateTeam(team)
+	err := testRepo.CreateTeam(testCtx, team)
 	require.NoError(t, err)
 
 	// Деактивируем пользователя
-	err = testRepo.SetActive("user1", false)
+	err = testRepo.SetActive(testCtx, "user1", false)
 	require.NoError(t, err)
 
 	// Проверяем изменение
-	user, err := testRepo.FindUserByID("user1")
+	user, err := testRepo.FindUserByID(testCtx, "user1")
 	require.NoError(t, err)
 	assert.False(t, user.IsActive)
 
 	// Активируем обратно
-	err = testRepo.SetActive("user1", true)
+	err = testRepo.SetActive(testCtx, "user1", true)
 	require.NoError(t, err)
 
-	user, err = testRepo.FindUserByID("user1")
+	user, err = testRepo.FindUserByID(testCtx, "user1")
 	require.NoError(t, err)
 	assert.True(t, user.IsActive)
 }
@@ -391,7 +380,7 @@ func TestSetActive_Success(t *testing.T) {
 func TestSetActive_UserNotFound(t *testing.T) {
 	defer cleanupTestData()
 
-	err := testRepo.SetActive("nonexistent", true)
+	err := testRepo.SetActive(testCtx, "nonexistent", true)
 	assert.Error(t, err)
 }
 
@@ -405,7 +394,7 @@ func setupTestTeamAndUsers() {
 			{UserID: "reviewer3", Username: "Reviewer3", IsActive: false},
 		},
 	}
-	if err := testRepo.CreateTeam(team); err != nil {
+	if err := testRepo.CreateTeam(testCtx, team); err != nil {
 		panic("failed to setupTestTeamAndUsers")
 	}
 }
@@ -423,11 +412,11 @@ func TestCreatePR_Success(t *testing.T) {
 		CreatedAt:         []time.Time{time.Now()}[0],
 	}
 
-	err := testRepo.CreatePR(pr)
+	err := testRepo.CreatePR(testCtx, pr)
 	require.NoError(t, err)
 
 	// Проверяем что PR создался
-	foundPR, err := testRepo.FindPRByID("pr-123")
+	foundPR, err := testRepo.FindPRByID(testCtx, "pr-123")
 	require.NoError(t, err)
 	assert.Equal(t, "pr-123", foundPR.PullRequestID)
 	assert.Equal(t, "Test PR", foundPR.PullRequestName)
@@ -436,12 +425,14 @@ func TestCreatePR_Success(t *testing.T) {
 	assert.Len(t, foundPR.AssignedReviewers, 2)
 	assert.Contains(t, foundPR.AssignedReviewers, "reviewer1")
 	assert.Contains(t, foundPR.AssignedReviewers, "reviewer2")
+	assert.Equal(t, 1, pr.Version)
+	assert.Equal(t, 1, foundPR.Version)
 }
 
 func TestFindPRByID_NotFound(t *testing.T) {
 	defer cleanupTestData()
 
-	pr, err := testRepo.FindPRByID("nonexistent")
+	pr, err := testRepo.FindPRByID(testCtx, "nonexistent")
 	assert.Error(t, err)
 	assert.Nil(t, pr)
 }
@@ -458,7 +449,7 @@ func TestUpdatePR_Success(t *testing.T) {
 		Status:            entity.PullRequestStatusOpen,
 		AssignedReviewers: []string{"reviewer1"},
 	}
-	err := testRepo.CreatePR(pr)
+	err := testRepo.CreatePR(testCtx, pr)
 	require.NoError(t, err)
 
 	// Обновляем PR
@@ -469,18 +460,67 @@ func TestUpdatePR_Success(t *testing.T) {
 		Status:            entity.PullRequestStatusMerged,
 		AssignedReviewers: []string{"reviewer1", "reviewer2"},
 		MergedAt:          []time.Time{time.Now()}[0],
+		Version:           pr.Version,
 	}
 
-	err = testRepo.UpdatePR(updatedPR)
+	err = testRepo.UpdatePR(testCtx, updatedPR)
 	require.NoError(t, err)
+	assert.Equal(t, 2, updatedPR.Version)
 
 	// Проверяем обновление
-	foundPR, err := testRepo.FindPRByID("pr-123")
+	foundPR, err := testRepo.FindPRByID(testCtx, "pr-123")
 	require.NoError(t, err)
 	assert.Equal(t, "Updated PR", foundPR.PullRequestName)
 	assert.Equal(t, entity.PullRequestStatusMerged, foundPR.Status)
 	assert.Len(t, foundPR.AssignedReviewers, 2)
 	assert.NotNil(t, foundPR.MergedAt)
+	assert.Equal(t, 2, foundPR.Version)
+}
+
+func TestUpdatePR_VersionConflict(t *testing.T) {
+	defer cleanupTestData()
+	setupTestTeamAndUsers()
+
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-123",
+		PullRequestName:   "Test PR",
+		AuthorID:          "author1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"reviewer1"},
+	}
+	err := testRepo.CreatePR(testCtx, pr)
+	require.NoError(t, err)
+
+	// Два клиента читают одну и ту же версию PR
+	first, err := testRepo.FindPRByID(testCtx, "pr-123")
+	require.NoError(t, err)
+	second, err := testRepo.FindPRByID(testCtx, "pr-123")
+	require.NoError(t, err)
+
+	first.AssignedReviewers = []string{"reviewer2"}
+	require.NoError(t, testRepo.UpdatePR(testCtx, first))
+
+	// Второе обновление со старой версией не должно перезаписать первое
+	second.AssignedReviewers = []string{"reviewer1", "reviewer2"}
+	err = testRepo.UpdatePR(testCtx, second)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	foundPR, err := testRepo.FindPRByID(testCtx, "pr-123")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reviewer2"}, foundPR.AssignedReviewers)
+	assert.Equal(t, 2, foundPR.Version)
+}
+
+func TestUpdatePR_NotFound(t *testing.T) {
+	defer cleanupTestData()
+
+	err := testRepo.UpdatePR(testCtx, &entity.PullRequest{
+		PullRequestID:   "nonexistent",
+		PullRequestName: "Test PR",
+		Status:          entity.PullRequestStatusOpen,
+		Version:         1,
+	})
+	assert.ErrorIs(t, err, ErrNoPR)
 }
 
 // УДАЛЕН: TestClosePR_Success - используем UpdatePR вместо ClosePR
@@ -497,7 +537,7 @@ func TestFindPRsByReviewer_Success(t *testing.T) {
 		Status:            entity.PullRequestStatusOpen,
 		AssignedReviewers: []string{"reviewer1"},
 	}
-	err := testRepo.CreatePR(pr1)
+	err := testRepo.CreatePR(testCtx, pr1)
 	require.NoError(t, err)
 
 	pr2 := &entity.PullRequest{
@@ -507,7 +547,7 @@ func TestFindPRsByReviewer_Success(t *testing.T) {
 		Status:            entity.PullRequestStatusMerged,
 		AssignedReviewers: []string{"reviewer1", "reviewer2"},
 	}
-	err = testRepo.CreatePR(pr2)
+	err = testRepo.CreatePR(testCtx, pr2)
 	require.NoError(t, err)
 
 	// PR без нашего ревьювера
@@ -518,11 +558,11 @@ func TestFindPRsByReviewer_Success(t *testing.T) {
 		Status:            entity.PullRequestStatusOpen,
 		AssignedReviewers: []string{"reviewer2"},
 	}
-	err = testRepo.CreatePR(pr3)
+	err = testRepo.CreatePR(testCtx, pr3)
 	require.NoError(t, err)
 
 	// Ищем PR для reviewer1
-	prs, err := testRepo.FindPRsByReviewer("reviewer1")
+	prs, err := testRepo.FindPRsByReviewer(testCtx, "reviewer1")
 	require.NoError(t, err)
 	assert.Len(t, prs, 2)
 
@@ -545,7 +585,7 @@ func TestFindPRsByReviewer_NoPRs(t *testing.T) {
 	defer cleanupTestData()
 	setupTestTeamAndUsers()
 
-	prs, err := testRepo.FindPRsByReviewer("reviewer1")
+	prs, err := testRepo.FindPRsByReviewer(testCtx, "reviewer1")
 	require.NoError(t, err)
 	assert.Empty(t, prs)
 }
@@ -560,7 +600,7 @@ func TestCreateTeam_TransactionRollbackOnDuplicateUser(t *testing.T) {
 			{UserID: "user1", Username: "Alice", IsActive: true},
 		},
 	}
-	err := testRepo.CreateTeam(team1)
+	err := testRepo.CreateTeam(testCtx, team1)
 	require.NoError(t, err)
 
 	// Пытаемся создать другую команду с тем же пользователем (дубликат user_id)
@@ -571,15 +611,15 @@ func TestCreateTeam_TransactionRollbackOnDuplicateUser(t *testing.T) {
 		},
 	}
 
-	err = testRepo.CreateTeam(team2)
+	err = testRepo.CreateTeam(testCtx, team2)
 	assert.Error(t, err, "Should fail due to duplicate user_id")
 
 	// Проверяем что вторая команда не создалась
-	exists := testRepo.TeamExists("frontend")
+	exists := testRepo.TeamExists(testCtx, "frontend")
 	assert.False(t, exists, "Team should not be created due to transaction rollback")
 
 	// Проверяем что первая команда осталась нетронутой
-	backendTeam, err := testRepo.FindTeamByName("backend")
+	backendTeam, err := testRepo.FindTeamByName(testCtx, "backend")
 	require.NoError(t, err)
 	assert.Equal(t, "backend", backendTeam.TeamName)
 	assert.Len(t, backendTeam.Members, 1)
@@ -607,7 +647,7 @@ func TestCreatePR_TransactionRollbackOnInvalidReviewer(t *testing.T) {
 			{UserID: "reviewer1", Username: "Reviewer1", IsActive: true},
 		},
 	}
-	err := testRepo.CreateTeam(team)
+	err := testRepo.CreateTeam(testCtx, team)
 	require.NoError(t, err)
 
 	// Пытаемся создать PR с несуществующим ревьювером
@@ -619,11 +659,11 @@ func TestCreatePR_TransactionRollbackOnInvalidReviewer(t *testing.T) {
 		AssignedReviewers: []string{"reviewer1", "nonexistent_reviewer"}, // Один ревьювер не существует
 	}
 
-	err = testRepo.CreatePR(pr)
+	err = testRepo.CreatePR(testCtx, pr)
 	assert.Error(t, err, "Should fail due to foreign key constraint")
 
 	// Проверяем что PR не создался
-	foundPR, err := testRepo.FindPRByID("pr-123")
+	foundPR, err := testRepo.FindPRByID(testCtx, "pr-123")
 	assert.Error(t, err)
 	assert.Nil(t, foundPR)
 
@@ -649,7 +689,7 @@ func TestUpdatePR_TransactionAtomicity(t *testing.T) {
 		Status:            entity.PullRequestStatusOpen,
 		AssignedReviewers: []string{"reviewer1"},
 	}
-	err := testRepo.CreatePR(pr)
+	err := testRepo.CreatePR(testCtx, pr)
 	require.NoError(t, err)
 
 	// Пытаемся обновить PR с несуществующим ревьювером
@@ -659,18 +699,130 @@ func TestUpdatePR_TransactionAtomicity(t *testing.T) {
 		AuthorID:          "author1",
 		Status:            entity.PullRequestStatusOpen,
 		AssignedReviewers: []string{"nonexistent_reviewer"}, // Несуществующий ревьювер
+		Version:           pr.Version,
 	}
 
-	err = testRepo.UpdatePR(updatedPR)
+	err = testRepo.UpdatePR(testCtx, updatedPR)
 	assert.Error(t, err, "Should fail due to foreign key constraint")
 
 	// Проверяем что оригинальные данные не изменились
-	foundPR, err := testRepo.FindPRByID("pr-123")
+	foundPR, err := testRepo.FindPRByID(testCtx, "pr-123")
 	require.NoError(t, err)
 	assert.Equal(t, "Test PR", foundPR.PullRequestName, "PR name should not change")
 	assert.Equal(t, []string{"reviewer1"}, foundPR.AssignedReviewers, "Reviewers should not change")
 }
 
+func TestWithTx_RollbackOnError(t *testing.T) {
+	defer cleanupTestData()
+	setupTestTeamAndUsers()
+
+	errStop := errors.New("stop")
+	err := testRepo.WithTx(testCtx, func(repo interfaces.Repository) error {
+		if err := repo.SetActive(testCtx, "reviewer1", false); err != nil {
+			return err
+		}
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+
+	// Изменение внутри транзакции должно откатиться
+	user, err := testRepo.FindUserByID(testCtx, "reviewer1")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+}
+
+func TestWithTx_CommitNestedWrites(t *testing.T) {
+	defer cleanupTestData()
+	setupTestTeamAndUsers()
+
+	err := testRepo.WithTx(testCtx, func(repo interfaces.Repository) error {
+		// CreatePR и UpdatePR открывают свои транзакции - внутри WithTx они
+		// должны использовать внешнюю
+		pr := &entity.PullRequest{
+			PullRequestID:     "pr-123",
+			PullRequestName:   "Test PR",
+			AuthorID:          "author1",
+			Status:            entity.PullRequestStatusOpen,
+			AssignedReviewers: []string{"reviewer1"},
+		}
+		if err := repo.CreatePR(testCtx, pr); err != nil {
+			return err
+		}
+		locked, err := repo.FindPRByIDForUpdate(testCtx, "pr-123")
+		if err != nil {
+			return err
+		}
+		locked.AssignedReviewers = []string{"reviewer2"}
+		return repo.UpdatePR(testCtx, locked)
+	})
+	require.NoError(t, err)
+
+	foundPR, err := testRepo.FindPRByID(testCtx, "pr-123")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reviewer2"}, foundPR.AssignedReviewers)
+	assert.Equal(t, 2, foundPR.Version)
+}
+
+func TestFindPRByIDForUpdate_SerializesTransactions(t *testing.T) {
+	defer cleanupTestData()
+	setupTestTeamAndUsers()
+
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-123",
+		PullRequestName:   "Test PR",
+		AuthorID:          "author1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"reviewer1"},
+	}
+	require.NoError(t, testRepo.CreatePR(testCtx, pr))
+
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	firstDone := make(chan error, 1)
+
+	// Первая транзакция блокирует PR и держит блокировку до release
+	go func() {
+		firstDone <- testRepo.WithTx(testCtx, func(repo interfaces.Repository) error {
+			found, err := repo.FindPRByIDForUpdate(testCtx, "pr-123")
+			if err != nil {
+				close(locked)
+				return err
+			}
+			close(locked)
+			<-release
+			found.AssignedReviewers = []string{"reviewer2"}
+			return repo.UpdatePR(testCtx, found)
+		})
+	}()
+	<-locked
+
+	secondDone := make(chan *entity.PullRequest, 1)
+	go func() {
+		var seen *entity.PullRequest
+		_ = testRepo.WithTx(testCtx, func(repo interfaces.Repository) error {
+			var err error
+			seen, err = repo.FindPRByIDForUpdate(testCtx, "pr-123")
+			return err
+		})
+		secondDone <- seen
+	}()
+
+	// Пока первая транзакция не завершена, вторая ждёт блокировку
+	select {
+	case <-secondDone:
+		t.Fatal("second transaction must wait for the row lock")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-firstDone)
+
+	seen := <-secondDone
+	require.NotNil(t, seen)
+	assert.Equal(t, []string{"reviewer2"}, seen.AssignedReviewers)
+	assert.Equal(t, 2, seen.Version)
+}
+
 func TestCreateTeam_TransactionCommitSuccess(t *testing.T) {
 	defer cleanupTestData()
 
@@ -682,20 +834,20 @@ func TestCreateTeam_TransactionCommitSuccess(t *testing.T) {
 		},
 	}
 
-	err := testRepo.CreateTeam(team)
+	err := testRepo.CreateTeam(testCtx, team)
 	require.NoError(t, err)
 
 	// Проверяем что команда создалась
-	exists := testRepo.TeamExists("backend")
+	exists := testRepo.TeamExists(testCtx, "backend")
 	assert.True(t, exists)
 
 	// Проверяем что все пользователи создались
-	user1, err := testRepo.FindUserByID("user1")
+	user1, err := testRepo.FindUserByID(testCtx, "user1")
 	require.NoError(t, err)
 	assert.Equal(t, "Alice", user1.Username)
 	assert.Equal(t, "backend", user1.TeamName)
 
-	user2, err := testRepo.FindUserByID("user2")
+	user2, err := testRepo.FindUserByID(testCtx, "user2")
 	require.NoError(t, err)
 	assert.Equal(t, "Bob", user2.Username)
 	assert.Equal(t, "backend", user2.TeamName)
@@ -728,7 +880,7 @@ func TestRepository_ConnectionPool(t *testing.T) {
 			{UserID: "user1", Username: "Alice", IsActive: true},
 		},
 	}
-	err := testRepo.CreateTeam(team)
+	err := testRepo.CreateTeam(testCtx, team)
 	require.NoError(t, err)
 
 	// Параллельные чтения
@@ -736,7 +888,7 @@ func TestRepository_ConnectionPool(t *testing.T) {
 
 	for i := 0; i < 3; i++ {
 		go func() {
-			user, err := testRepo.FindUserByID("user1")
+			user, err := testRepo.FindUserByID(testCtx, "user1")
 			assert.NoError(t, err)
 			assert.NotNil(t, user)
 			done <- true
@@ -761,11 +913,11 @@ func TestCreateUser_WithoutTeam(t *testing.T) {
 		IsActive: true,
 	}
 
-	err := testRepo.CreateUser(user)
+	err := testRepo.CreateUser(testCtx, user)
 	assert.Error(t, err, "Should fail due to foreign key constraint")
 
 	// Проверяем что пользователь не создался
-	foundUser, err := testRepo.FindUserByID("user1")
+	foundUser, err := testRepo.FindUserByID(testCtx, "user1")
 	assert.Error(t, err)
 	assert.Nil(t, foundUser)
 }
@@ -773,8 +925,155 @@ func TestCreateUser_WithoutTeam(t *testing.T) {
 func TestSetActive_UserNotExists(t *testing.T) {
 	defer cleanupTestData()
 
-	err := testRepo.SetActive("nonexistent_user", true)
+	err := testRepo.SetActive(testCtx, "nonexistent_user", true)
 	assert.Error(t, err)
 	// Исправлено: проверяем на нашу кастомную ошибку, а не sql.ErrNoRows
 	assert.ErrorIs(t, err, ErrNoUser)
 }
+
+func TestIdempotencyRecord_SaveAndFind(t *testing.T) {
+	defer cleanupTestData()
+
+	now := time.Now()
+	record := &entity.IdempotencyRecord{
+		Key:             "key-1",
+		Endpoint:        "/pullRequest/create",
+		RequestHash:     "hash",
+		StatusCode:      201,
+		ResponseHeaders: map[string]string{"ETag": `"1"`},
+		ResponseBody:    []byte(`{"pr":{"pull_request_id":"pr-1"}}`),
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(time.Hour),
+	}
+
+	reserved, err := testRepo.ReserveIdempotencyKey(testCtx, &entity.IdempotencyRecord{
+		Key: "key-1", Endpoint: "/pullRequest/create", RequestHash: "hash",
+		CreatedAt: now, ExpiresAt: now.Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.True(t, reserved)
+	require.NoError(t, testRepo.CompleteIdempotencyRecord(testCtx, record))
+
+	found, err := testRepo.FindIdempotencyRecord(testCtx, "key-1", "/pullRequest/create")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "hash", found.RequestHash)
+	assert.Equal(t, 201, found.StatusCode)
+	assert.Equal(t, `"1"`, found.ResponseHeaders["ETag"])
+	assert.Equal(t, record.ResponseBody, found.ResponseBody)
+
+	// Ключ привязан к эндпоинту
+	other, err := testRepo.FindIdempotencyRecord(testCtx, "key-1", "/team/add")
+	require.NoError(t, err)
+	assert.Nil(t, other)
+}
+
+func TestIdempotencyRecord_KeepsFirstResponse(t *testing.T) {
+	defer cleanupTestData()
+
+	now := time.Now()
+	first := &entity.IdempotencyRecord{
+		Key: "key-1", Endpoint: "/team/add", RequestHash: "first",
+		CreatedAt: now, ExpiresAt: now.Add(time.Minute),
+	}
+	second := &entity.IdempotencyRecord{
+		Key: "key-1", Endpoint: "/team/add", RequestHash: "second",
+		CreatedAt: now, ExpiresAt: now.Add(time.Minute),
+	}
+
+	reserved, err := testRepo.ReserveIdempotencyKey(testCtx, first)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	reserved, err = testRepo.ReserveIdempotencyKey(testCtx, second)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+
+	// Ответ записывается только в резерв с тем же телом запроса
+	second.StatusCode = 201
+	second.ResponseBody = []byte(`{}`)
+	second.ExpiresAt = now.Add(time.Hour)
+	require.NoError(t, testRepo.CompleteIdempotencyRecord(testCtx, second))
+
+	found, err := testRepo.FindIdempotencyRecord(testCtx, "key-1", "/team/add")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "first", found.RequestHash)
+	assert.True(t, found.Pending())
+}
+
+func TestIdempotencyRecord_Expired(t *testing.T) {
+	defer cleanupTestData()
+
+	now := time.Now()
+	record := &entity.IdempotencyRecord{
+		Key: "key-1", Endpoint: "/team/add", RequestHash: "hash",
+		CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Hour),
+	}
+	_, err := testRepo.ReserveIdempotencyKey(testCtx, record)
+	require.NoError(t, err)
+
+	// Истёкший ключ считается новым
+	found, err := testRepo.FindIdempotencyRecord(testCtx, "key-1", "/team/add")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	deleted, err := testRepo.DeleteExpiredIdempotencyRecords(testCtx, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestMigrator_ConcurrentUp(t *testing.T) {
+	m, err := migrator.New(testDB, migrator.Postgres, testLogger)
+	require.NoError(t, err)
+
+	// Реплики стартуют одновременно: advisory-блокировка сериализует их,
+	// а схема уже актуальна, поэтому никто ничего не применяет
+	const runners = 5
+	errs := make(chan error, runners)
+	for i := 0; i < runners; i++ {
+		go func() {
+			applied, err := m.Up(testCtx)
+			if err == nil && applied != 0 {
+				err = fmt.Errorf("applied %d migrations", applied)
+			}
+			errs <- err
+		}()
+	}
+	for i := 0; i < runners; i++ {
+		assert.NoError(t, <-errs)
+	}
+
+	version, err := m.Version(testCtx)
+	require.NoError(t, err)
+	assert.Equal(t, m.Latest(), version)
+}
+
+func TestMigrator_DownAndUp(t *testing.T) {
+	m, err := migrator.New(testDB, migrator.Postgres, testLogger)
+	require.NoError(t, err)
+
+	reverted, err := m.Down(testCtx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reverted)
+
+	version, err := m.Version(testCtx)
+	require.NoError(t, err)
+	assert.Equal(t, m.Latest()-1, version)
+
+	applied, err := m.Up(testCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+
+	// Откаченная таблица снова доступна
+	defer cleanupTestData()
+	now := time.Now()
+	record := &entity.IdempotencyRecord{
+		Key:         "migrator-key",
+		Endpoint:    "/team/add",
+		RequestHash: "hash",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+	_, err = testRepo.ReserveIdempotencyKey(testCtx, record)
+	require.NoError(t, err)
+}
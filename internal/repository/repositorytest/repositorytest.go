@@ -0,0 +1,1454 @@
+// Package repositorytest содержит общий набор поведенческих тестов для любой
+// реализации interfaces.Repository. Каждое хранилище подключает его в своём
+// _test.go через Run, поэтому все реализации обязаны вести себя одинаково
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory возвращает пустое хранилище для одного теста. Освобождение ресурсов
+// (закрытие соединения, очистка таблиц) регистрируется через t.Cleanup
+type Factory func(t *testing.T) interfaces.Repository
+
+// Run запускает весь набор тестов против хранилища из factory
+func Run(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo interfaces.Repository)
+	}{
+		{"CreateTeam_MembersSortedByUserID", testCreateTeamMembersSorted},
+		{"CreateTeam_AlreadyExists", testCreateTeamAlreadyExists},
+		{"CreateTeam_DuplicateUserIsAtomic", testCreateTeamDuplicateUserIsAtomic},
+		{"CreateTeam_WithoutMembers", testCreateTeamWithoutMembers},
+		{"FindTeamByName_NotFound", testFindTeamByNameNotFound},
+		{"ListTeams_IncludesEmptyTeams", testListTeamsIncludesEmpty},
+		{"CreateUser_Duplicate", testCreateUserDuplicate},
+		{"CreateUser_MissingTeam", testCreateUserMissingTeam},
+		{"FindUserByID_NotFound", testFindUserByIDNotFound},
+		{"UpdateUser_MovesToAnotherTeam", testUpdateUserMovesTeam},
+		{"UpdateUser_Errors", testUpdateUserErrors},
+		{"FindUsersByTeam_MissingTeam", testFindUsersByTeamMissingTeam},
+		{"SetActive", testSetActive},
+		{"ListUsers_SortedByTeamAndID", testListUsersSorted},
+		{"MaxOpenReviews", testMaxOpenReviews},
+		{"LevelsAndReviewPolicy", testLevelsAndReviewPolicy},
+		{"LearnersAndShadowReviewer", testLearnersAndShadowReviewer},
+		{"FindReviewLoads", testFindReviewLoads},
+		{"FindPairReviewCounts", testFindPairReviewCounts},
+		{"PRMetadata_RoundTrip", testPRMetadataRoundTrip},
+		{"CreatePR_Duplicate", testCreatePRDuplicate},
+		{"CreatePR_UnknownUsers", testCreatePRUnknownUsers},
+		{"FindPRByID_ReviewersSorted", testFindPRByIDReviewersSorted},
+		{"FindPRByID_NotFound", testFindPRByIDNotFound},
+		{"UpdatePR_MergedTimestamp", testUpdatePRMergedTimestamp},
+		{"UpdatePR_ReplacesReviewers", testUpdatePRReplacesReviewers},
+		{"UpdatePR_VersionConflict", testUpdatePRVersionConflict},
+		{"UpdatePR_NotFound", testUpdatePRNotFound},
+		{"FindPRsByReviewer_NewestFirst", testFindPRsByReviewerNewestFirst},
+		{"FindPRsByReviewer_Empty", testFindPRsByReviewerEmpty},
+		{"ListPRs_OldestFirst", testListPRsOldestFirst},
+		{"RestorePR_KeepsStatusTimesAndVersion", testRestorePRKeepsState},
+		{"RestorePR_Errors", testRestorePRErrors},
+		{"Unavailability_CRUD", testUnavailabilityCRUD},
+		{"Unavailability_UnavailableUsersAndPending", testUnavailableUsersAndPendingReassignments},
+		{"UpdateUnavailability", testUpdateUnavailability},
+		{"UserAliases", testUserAliases},
+		{"WorkSchedules", testWorkSchedules},
+		{"UserSkills", testUserSkills},
+		{"CodeOwners", testCodeOwners},
+		{"ReviewerRules", testReviewerRules},
+		{"WithTx_RollbackOnError", testWithTxRollbackOnError},
+		{"WithTx_ConcurrentUpdatesAreSerialized", testWithTxConcurrentUpdates},
+		{"ConcurrentUpdates_OneWins", testConcurrentUpdatesOneWins},
+		{"IdempotencyRecords", testIdempotencyRecords},
+		{"CanceledContext", testCanceledContext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, factory(t))
+		})
+	}
+}
+
+var ctx = context.Background()
+
+// Вспомогательные функции
+
+func createTeam(t *testing.T, repo interfaces.Repository, teamName string, userIDs ...string) {
+	t.Helper()
+	team := &entity.Team{TeamName: teamName}
+	for _, userID := range userIDs {
+		team.Members = append(team.Members, entity.TeamMember{
+			UserID:   userID,
+			Username: "name-" + userID,
+			IsActive: true,
+		})
+	}
+	require.NoError(t, repo.CreateTeam(ctx, team))
+}
+
+func createPR(t *testing.T, repo interfaces.Repository, prID, authorID string, reviewers ...string) *entity.PullRequest {
+	t.Helper()
+	pr := &entity.PullRequest{
+		PullRequestID:     prID,
+		PullRequestName:   "name-" + prID,
+		AuthorID:          authorID,
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: reviewers,
+	}
+	require.NoError(t, repo.CreatePR(ctx, pr))
+	return pr
+}
+
+// Teams
+
+func testCreateTeamMembersSorted(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u3", "u1", "u2")
+
+	assert.True(t, repo.TeamExists(ctx, "backend"))
+	team, err := repo.FindTeamByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", team.TeamName)
+	require.Len(t, team.Members, 3)
+	assert.Equal(t, "u1", team.Members[0].UserID)
+	assert.Equal(t, "u2", team.Members[1].UserID)
+	assert.Equal(t, "u3", team.Members[2].UserID)
+	assert.Equal(t, "name-u1", team.Members[0].Username)
+	assert.True(t, team.Members[0].IsActive)
+
+	users, err := repo.FindUsersByTeam(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, users, 3)
+	assert.Equal(t, "u1", users[0].UserID)
+	assert.Equal(t, "backend", users[0].TeamName)
+}
+
+func testCreateTeamAlreadyExists(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1")
+
+	err := repo.CreateTeam(ctx, &entity.Team{
+		TeamName: "backend",
+		Members:  []entity.TeamMember{{UserID: "u2", Username: "Bob", IsActive: true}},
+	})
+	assert.ErrorIs(t, err, entity.ErrTeamExists)
+
+	// Участник неудачного создания не должен появиться
+	_, err = repo.FindUserByID(ctx, "u2")
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+}
+
+func testCreateTeamDuplicateUserIsAtomic(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1")
+
+	err := repo.CreateTeam(ctx, &entity.Team{
+		TeamName: "frontend",
+		Members: []entity.TeamMember{
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u1", Username: "Alice", IsActive: true},
+		},
+	})
+	assert.ErrorIs(t, err, entity.ErrUserExists)
+
+	assert.False(t, repo.TeamExists(ctx, "frontend"))
+	_, err = repo.FindUserByID(ctx, "u2")
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+
+	user, err := repo.FindUserByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", user.TeamName)
+}
+
+// Синхронизация состава создаёт пустую команду и затем переводит в неё пользователей
+func testCreateTeamWithoutMembers(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1")
+	require.NoError(t, repo.CreateTeam(ctx, &entity.Team{TeamName: "platform"}))
+
+	team, err := repo.FindTeamByName(ctx, "platform")
+	require.NoError(t, err)
+	assert.Empty(t, team.Members)
+
+	require.NoError(t, repo.UpdateUser(ctx, &entity.User{UserID: "u1", Username: "Alice", TeamName: "platform", IsActive: true}))
+	users, err := repo.FindUsersByTeam(ctx, "platform")
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "u1", users[0].UserID)
+
+	// Отсутствие пользователя различимо и без пакета repository
+	_, err = repo.FindUserByID(ctx, "ghost")
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+}
+
+func testFindTeamByNameNotFound(t *testing.T, repo interfaces.Repository) {
+	team, err := repo.FindTeamByName(ctx, "nonexistent")
+	assert.ErrorIs(t, err, entity.ErrNoTeam)
+	assert.Nil(t, team)
+	assert.False(t, repo.TeamExists(ctx, "nonexistent"))
+}
+
+func testListTeamsIncludesEmpty(t *testing.T, repo interfaces.Repository) {
+	teams, err := repo.ListTeams(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, teams)
+
+	createTeam(t, repo, "frontend", "u2", "u1")
+	createTeam(t, repo, "backend", "u3")
+	require.NoError(t, repo.CreateTeam(ctx, &entity.Team{TeamName: "platform"}))
+
+	teams, err = repo.ListTeams(ctx)
+	require.NoError(t, err)
+	require.Len(t, teams, 3)
+	assert.Equal(t, "backend", teams[0].TeamName)
+	assert.Equal(t, "frontend", teams[1].TeamName)
+	assert.Equal(t, "platform", teams[2].TeamName)
+
+	require.Len(t, teams[1].Members, 2)
+	assert.Equal(t, entity.TeamMember{UserID: "u1", Username: "name-u1", IsActive: true}, teams[1].Members[0])
+	assert.Equal(t, "u2", teams[1].Members[1].UserID)
+	assert.Empty(t, teams[2].Members)
+}
+
+// Users
+
+func testCreateUserDuplicate(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1")
+
+	err := repo.CreateUser(ctx, &entity.User{UserID: "u1", Username: "Other", TeamName: "backend", IsActive: true})
+	assert.ErrorIs(t, err, entity.ErrUserExists)
+
+	user, err := repo.FindUserByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "name-u1", user.Username)
+}
+
+func testCreateUserMissingTeam(t *testing.T, repo interfaces.Repository) {
+	err := repo.CreateUser(ctx, &entity.User{UserID: "u1", Username: "Alice", TeamName: "nonexistent", IsActive: true})
+	assert.ErrorIs(t, err, entity.ErrNoTeam)
+}
+
+func testFindUserByIDNotFound(t *testing.T, repo interfaces.Repository) {
+	user, err := repo.FindUserByID(ctx, "nonexistent")
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+	assert.Nil(t, user)
+}
+
+func testUpdateUserMovesTeam(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	createTeam(t, repo, "frontend", "u3")
+
+	require.NoError(t, repo.UpdateUser(ctx, &entity.User{UserID: "u2", Username: "Bobby", TeamName: "frontend", IsActive: false}))
+
+	user, err := repo.FindUserByID(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, "Bobby", user.Username)
+	assert.Equal(t, "frontend", user.TeamName)
+	assert.False(t, user.IsActive)
+
+	backend, err := repo.FindUsersByTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Len(t, backend, 1)
+	frontend, err := repo.FindUsersByTeam(ctx, "frontend")
+	require.NoError(t, err)
+	assert.Len(t, frontend, 2)
+}
+
+func testUpdateUserErrors(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1")
+
+	err := repo.UpdateUser(ctx, &entity.User{UserID: "ghost", Username: "Ghost", TeamName: "backend"})
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+
+	err = repo.UpdateUser(ctx, &entity.User{UserID: "u1", Username: "Alice", TeamName: "nonexistent"})
+	assert.ErrorIs(t, err, entity.ErrNoTeam)
+}
+
+func testFindUsersByTeamMissingTeam(t *testing.T, repo interfaces.Repository) {
+	users, err := repo.FindUsersByTeam(ctx, "nonexistent")
+	assert.ErrorIs(t, err, entity.ErrNoTeam)
+	assert.Empty(t, users)
+}
+
+func testListUsersSorted(t *testing.T, repo interfaces.Repository) {
+	users, err := repo.ListUsers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	createTeam(t, repo, "frontend", "u2", "u1")
+	createTeam(t, repo, "backend", "u4", "u3")
+	require.NoError(t, repo.SetActive(ctx, "u4", false))
+
+	users, err = repo.ListUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 4)
+	var ids []string
+	for _, user := range users {
+		ids = append(ids, user.TeamName+"/"+user.UserID)
+	}
+	assert.Equal(t, []string{"backend/u3", "backend/u4", "frontend/u1", "frontend/u2"}, ids)
+	assert.False(t, users[1].IsActive)
+	assert.Equal(t, "name-u4", users[1].Username)
+}
+
+func testSetActive(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1")
+
+	require.NoError(t, repo.SetActive(ctx, "u1", false))
+	user, err := repo.FindUserByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+
+	require.NoError(t, repo.SetActive(ctx, "u1", true))
+	user, err = repo.FindUserByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+
+	assert.ErrorIs(t, repo.SetActive(ctx, "nonexistent", true), entity.ErrNoUser)
+}
+
+func testMaxOpenReviews(t *testing.T, repo interfaces.Repository) {
+	require.NoError(t, repo.CreateTeam(ctx, &entity.Team{
+		TeamName:       "backend",
+		MaxOpenReviews: 3,
+		Members: []entity.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true, MaxOpenReviews: 5},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	}))
+
+	team, err := repo.FindTeamByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, 3, team.MaxOpenReviews)
+	assert.Equal(t, 5, team.Members[0].MaxOpenReviews)
+	assert.Equal(t, 0, team.Members[1].MaxOpenReviews)
+
+	require.NoError(t, repo.SetMaxOpenReviews(ctx, "u2", 1))
+	require.NoError(t, repo.SetTeamMaxOpenReviews(ctx, "backend", 0))
+
+	user, err := repo.FindUserByID(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, user.MaxOpenReviews)
+
+	// UpdateUser не сбрасывает предел
+	user.Username = "Robert"
+	user.MaxOpenReviews = 0
+	require.NoError(t, repo.UpdateUser(ctx, user))
+	users, err := repo.FindUsersByTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, 1, users[1].MaxOpenReviews)
+
+	teams, err := repo.ListTeams(ctx)
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, 0, teams[0].MaxOpenReviews)
+	assert.Equal(t, []int{5, 1}, []int{teams[0].Members[0].MaxOpenReviews, teams[0].Members[1].MaxOpenReviews})
+
+	all, err := repo.ListUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, all[0].MaxOpenReviews)
+
+	assert.ErrorIs(t, repo.SetMaxOpenReviews(ctx, "ghost", 1), entity.ErrNoUser)
+	assert.ErrorIs(t, repo.SetTeamMaxOpenReviews(ctx, "ghost", 1), entity.ErrNoTeam)
+}
+
+func testLevelsAndReviewPolicy(t *testing.T, repo interfaces.Repository) {
+	require.NoError(t, repo.CreateTeam(ctx, &entity.Team{
+		TeamName:     "backend",
+		ReviewPolicy: entity.ReviewPolicy{SeniorForJuniors: true},
+		Members: []entity.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true, Level: entity.UserLevelLead},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	}))
+	require.NoError(t, repo.CreateUser(ctx, &entity.User{
+		UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true, Level: entity.UserLevelJunior,
+	}))
+
+	team, err := repo.FindTeamByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, entity.ReviewPolicy{SeniorForJuniors: true}, team.ReviewPolicy)
+	assert.Equal(t, []entity.UserLevel{entity.UserLevelLead, "", entity.UserLevelJunior},
+		[]entity.UserLevel{team.Members[0].Level, team.Members[1].Level, team.Members[2].Level})
+
+	require.NoError(t, repo.SetUserLevel(ctx, "u2", entity.UserLevelSenior))
+	policy := entity.ReviewPolicy{RequireSenior: true}
+	require.NoError(t, repo.SetTeamReviewPolicy(ctx, "backend", policy))
+
+	user, err := repo.FindUserByID(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserLevelSenior, user.Level)
+
+	// UpdateUser не сбрасывает уровень
+	user.Username = "Robert"
+	user.Level = ""
+	require.NoError(t, repo.UpdateUser(ctx, user))
+	users, err := repo.FindUsersByTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserLevelSenior, users[1].Level)
+
+	teams, err := repo.ListTeams(ctx)
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, policy, teams[0].ReviewPolicy)
+	assert.Equal(t, entity.UserLevelSenior, teams[0].Members[1].Level)
+
+	all, err := repo.ListUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserLevelJunior, all[2].Level)
+
+	assert.ErrorIs(t, repo.SetUserLevel(ctx, "ghost", entity.UserLevelLead), entity.ErrNoUser)
+	assert.ErrorIs(t, repo.SetTeamReviewPolicy(ctx, "ghost", policy), entity.ErrNoTeam)
+}
+
+func testLearnersAndShadowReviewer(t *testing.T, repo interfaces.Repository) {
+	require.NoError(t, repo.CreateTeam(ctx, &entity.Team{
+		TeamName: "backend",
+		Members: []entity.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true, IsLearner: true},
+		},
+	}))
+	require.NoError(t, repo.CreateUser(ctx, &entity.User{
+		UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true,
+	}))
+	require.NoError(t, repo.SetUserLearner(ctx, "u4", true))
+
+	team, err := repo.FindTeamByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, false, true, true},
+		[]bool{team.Members[0].IsLearner, team.Members[1].IsLearner, team.Members[2].IsLearner, team.Members[3].IsLearner})
+
+	// UpdateUser не сбрасывает отметку ученика
+	user, err := repo.FindUserByID(ctx, "u3")
+	require.NoError(t, err)
+	assert.True(t, user.IsLearner)
+	user.Username = "Caroline"
+	user.IsLearner = false
+	require.NoError(t, repo.UpdateUser(ctx, user))
+	users, err := repo.FindUsersByTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.True(t, users[2].IsLearner)
+
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Add search",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2"},
+		ShadowReviewer:    "u3",
+	}
+	require.NoError(t, repo.CreatePR(ctx, pr))
+	time.Sleep(10 * time.Millisecond)
+	createPR(t, repo, "pr-2", "u1", "u2")
+
+	found, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "u3", found.ShadowReviewer)
+	// Теневой ревьювер не входит в назначенных
+	assert.Equal(t, []string{"u2"}, found.AssignedReviewers)
+
+	reviews, err := repo.FindPRsByReviewer(ctx, "u3")
+	require.NoError(t, err)
+	assert.Empty(t, reviews)
+
+	found.ShadowReviewer = "u4"
+	require.NoError(t, repo.UpdatePR(ctx, found))
+	shadows, err := repo.FindPRsByShadowReviewer(ctx, "u3")
+	require.NoError(t, err)
+	assert.Empty(t, shadows)
+
+	pr2, err := repo.FindPRByID(ctx, "pr-2")
+	require.NoError(t, err)
+	pr2.ShadowReviewer = "u4"
+	require.NoError(t, repo.UpdatePR(ctx, pr2))
+
+	shadows, err = repo.FindPRsByShadowReviewer(ctx, "u4")
+	require.NoError(t, err)
+	require.Len(t, shadows, 2)
+	assert.Equal(t, "pr-2", shadows[0].PullRequestID)
+	assert.Equal(t, "pr-1", shadows[1].PullRequestID)
+	assert.Equal(t, []string{"u2"}, shadows[1].AssignedReviewers)
+
+	prs, err := repo.ListPRs(ctx)
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, "u4", prs[0].ShadowReviewer)
+
+	reviews, err = repo.FindPRsByReviewer(ctx, "u2")
+	require.NoError(t, err)
+	require.Len(t, reviews, 2)
+	assert.Equal(t, "u4", reviews[0].ShadowReviewer)
+
+	assert.ErrorIs(t, repo.SetUserLearner(ctx, "ghost", true), entity.ErrNoUser)
+}
+
+func testFindReviewLoads(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2", "u3")
+	createTeam(t, repo, "frontend", "u4")
+
+	require.NoError(t, repo.CreatePR(ctx, &entity.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Big",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+		Metadata:          entity.PRMetadata{Additions: 300, Deletions: 100},
+	}))
+	createPR(t, repo, "pr-2", "u3", "u2", "u4")
+	merged := createPR(t, repo, "pr-3", "u1", "u2")
+	merged.Status = entity.PullRequestStatusMerged
+	merged.MergedAt = time.Now().UTC()
+	require.NoError(t, repo.UpdatePR(ctx, merged))
+
+	// Смерженные PR и ревьюверы других команд не учитываются
+	loads, err := repo.FindReviewLoads(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]entity.ReviewLoad{
+		"u2": {OpenReviews: 2, ChangedLines: 400},
+		"u3": {OpenReviews: 1, ChangedLines: 400},
+	}, loads)
+
+	loads, err = repo.FindReviewLoads(ctx, "empty")
+	require.NoError(t, err)
+	assert.Empty(t, loads)
+}
+
+func testFindPairReviewCounts(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2", "u3", "u4")
+
+	createPR(t, repo, "pr-1", "u1", "u2", "u3")
+	merged := createPR(t, repo, "pr-2", "u1", "u2")
+	merged.Status = entity.PullRequestStatusMerged
+	merged.MergedAt = time.Now().UTC()
+	require.NoError(t, repo.UpdatePR(ctx, merged))
+	createPR(t, repo, "pr-3", "u2", "u1", "u3")
+	require.NoError(t, repo.RestorePR(ctx, &entity.PullRequest{
+		PullRequestID:     "pr-old",
+		PullRequestName:   "Old",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusMerged,
+		AssignedReviewers: []string{"u4"},
+		CreatedAt:         time.Now().Add(-48 * time.Hour),
+		MergedAt:          time.Now().Add(-47 * time.Hour),
+		Version:           1,
+	}))
+
+	// Учитываются только PR автора u1 в окне, смерженные тоже
+	counts, err := repo.FindPairReviewCounts(ctx, "u1", time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"u2": 2, "u3": 1}, counts)
+
+	counts, err = repo.FindPairReviewCounts(ctx, "u1", time.Now().Add(-72*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"u2": 2, "u3": 1, "u4": 1}, counts)
+
+	counts, err = repo.FindPairReviewCounts(ctx, "u4", time.Now().Add(-72*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+}
+
+// PRs
+
+func testPRMetadataRoundTrip(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	metadata := entity.PRMetadata{
+		Additions:      120,
+		Deletions:      30,
+		ChangedFiles:   7,
+		Repository:     "payments",
+		BaseBranch:     "main",
+		Labels:         []string{"backend", "security"},
+		Paths:          []string{"payments/refund.go", "payments/refund_test.go"},
+		RequiredSkills: []string{"go", "postgres"},
+	}
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Add refunds",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2"},
+		Metadata:          metadata,
+	}
+	require.NoError(t, repo.CreatePR(ctx, pr))
+	createPR(t, repo, "pr-2", "u2", "u1")
+
+	found, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, metadata, found.Metadata)
+
+	// PR без метаданных читается с нулевыми значениями и nil-метками
+	plain, err := repo.FindPRByID(ctx, "pr-2")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PRMetadata{}, plain.Metadata)
+
+	byReviewer, err := repo.FindPRsByReviewer(ctx, "u2")
+	require.NoError(t, err)
+	require.Len(t, byReviewer, 1)
+	assert.Equal(t, metadata, byReviewer[0].Metadata)
+
+	// Метаданные задаются при создании и не меняются при обновлении PR
+	found.Status = entity.PullRequestStatusMerged
+	found.MergedAt = time.Now().UTC()
+	found.Metadata = entity.PRMetadata{}
+	require.NoError(t, repo.UpdatePR(ctx, found))
+
+	prs, err := repo.ListPRs(ctx)
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, metadata, prs[0].Metadata)
+
+	restored := *prs[0]
+	restored.PullRequestID = "pr-3"
+	require.NoError(t, repo.RestorePR(ctx, &restored))
+	found, err = repo.FindPRByID(ctx, "pr-3")
+	require.NoError(t, err)
+	assert.Equal(t, metadata, found.Metadata)
+}
+
+func testCreatePRDuplicate(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	pr := createPR(t, repo, "pr-1", "u1", "u2")
+	assert.Equal(t, 1, pr.Version)
+
+	err := repo.CreatePR(ctx, &entity.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Other",
+		AuthorID:        "u2",
+		Status:          entity.PullRequestStatusOpen,
+	})
+	assert.ErrorIs(t, err, entity.ErrPRExists)
+
+	found, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", found.AuthorID)
+}
+
+func testCreatePRUnknownUsers(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1")
+
+	err := repo.CreatePR(ctx, &entity.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "PR",
+		AuthorID:        "ghost",
+		Status:          entity.PullRequestStatusOpen,
+	})
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+
+	err = repo.CreatePR(ctx, &entity.PullRequest{
+		PullRequestID:     "pr-2",
+		PullRequestName:   "PR",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"ghost"},
+	})
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+
+	// PR с несуществующим ревьювером не сохраняется частично
+	_, err = repo.FindPRByID(ctx, "pr-2")
+	assert.ErrorIs(t, err, entity.ErrNoPR)
+}
+
+func testFindPRByIDReviewersSorted(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2", "u3")
+	createPR(t, repo, "pr-1", "u1", "u3", "u2")
+
+	pr, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "name-pr-1", pr.PullRequestName)
+	assert.Equal(t, entity.PullRequestStatusOpen, pr.Status)
+	assert.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+	assert.Equal(t, 1, pr.Version)
+	assert.False(t, pr.CreatedAt.IsZero())
+	assert.True(t, pr.MergedAt.IsZero())
+
+	locked, err := repo.FindPRByIDForUpdate(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, pr.AssignedReviewers, locked.AssignedReviewers)
+}
+
+func testFindPRByIDNotFound(t *testing.T, repo interfaces.Repository) {
+	pr, err := repo.FindPRByID(ctx, "nonexistent")
+	assert.ErrorIs(t, err, entity.ErrNoPR)
+	assert.Nil(t, pr)
+}
+
+func testUpdatePRMergedTimestamp(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	createPR(t, repo, "pr-1", "u1", "u2")
+
+	pr, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+
+	mergedAt := time.Now().UTC()
+	pr.Status = entity.PullRequestStatusMerged
+	pr.MergedAt = mergedAt
+	require.NoError(t, repo.UpdatePR(ctx, pr))
+	assert.Equal(t, 2, pr.Version)
+
+	found, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PullRequestStatusMerged, found.Status)
+	// Хранилища с точностью до микросекунд обрезают время
+	assert.WithinDuration(t, mergedAt, found.MergedAt, time.Millisecond)
+	assert.Equal(t, 2, found.Version)
+	assert.Equal(t, []string{"u2"}, found.AssignedReviewers)
+
+	// Время создания не меняется при обновлении
+	assert.False(t, found.CreatedAt.IsZero())
+	assert.False(t, found.CreatedAt.After(found.MergedAt.Add(time.Second)))
+}
+
+func testUpdatePRReplacesReviewers(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2", "u3", "u4")
+	createPR(t, repo, "pr-1", "u1", "u2", "u3")
+
+	pr, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	pr.AssignedReviewers = []string{"u4", "u2"}
+	require.NoError(t, repo.UpdatePR(ctx, pr))
+
+	found, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u4"}, found.AssignedReviewers)
+
+	reviews, err := repo.FindPRsByReviewer(ctx, "u3")
+	require.NoError(t, err)
+	assert.Empty(t, reviews)
+
+	pr = found
+	pr.AssignedReviewers = []string{"ghost"}
+	assert.ErrorIs(t, repo.UpdatePR(ctx, pr), entity.ErrNoUser)
+}
+
+func testUpdatePRVersionConflict(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2", "u3")
+	createPR(t, repo, "pr-1", "u1", "u2")
+
+	first, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	stale, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+
+	first.AssignedReviewers = []string{"u3"}
+	require.NoError(t, repo.UpdatePR(ctx, first))
+
+	stale.Status = entity.PullRequestStatusMerged
+	assert.ErrorIs(t, repo.UpdatePR(ctx, stale), entity.ErrVersionConflict)
+
+	found, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PullRequestStatusOpen, found.Status)
+	assert.Equal(t, []string{"u3"}, found.AssignedReviewers)
+}
+
+func testUpdatePRNotFound(t *testing.T, repo interfaces.Repository) {
+	err := repo.UpdatePR(ctx, &entity.PullRequest{
+		PullRequestID:   "nonexistent",
+		PullRequestName: "PR",
+		Status:          entity.PullRequestStatusOpen,
+		Version:         1,
+	})
+	assert.ErrorIs(t, err, entity.ErrNoPR)
+}
+
+func testFindPRsByReviewerNewestFirst(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2", "u3")
+	createPR(t, repo, "pr-1", "u1", "u3", "u2")
+	time.Sleep(10 * time.Millisecond)
+	createPR(t, repo, "pr-2", "u1", "u2")
+	time.Sleep(10 * time.Millisecond)
+	createPR(t, repo, "pr-3", "u1", "u3")
+
+	prs, err := repo.FindPRsByReviewer(ctx, "u2")
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, "pr-2", prs[0].PullRequestID)
+	assert.Equal(t, "pr-1", prs[1].PullRequestID)
+	// В списке все ревьюверы PR, а не только запрошенный
+	assert.Equal(t, []string{"u2", "u3"}, prs[1].AssignedReviewers)
+	assert.Equal(t, 1, prs[1].Version)
+}
+
+func testFindPRsByReviewerEmpty(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	createPR(t, repo, "pr-1", "u1")
+
+	prs, err := repo.FindPRsByReviewer(ctx, "u2")
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+}
+
+func testListPRsOldestFirst(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2", "u3")
+	createPR(t, repo, "pr-2", "u1", "u3", "u2")
+	time.Sleep(10 * time.Millisecond)
+	createPR(t, repo, "pr-1", "u1")
+
+	prs, err := repo.ListPRs(ctx)
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, "pr-2", prs[0].PullRequestID)
+	assert.Equal(t, []string{"u2", "u3"}, prs[0].AssignedReviewers)
+	assert.Equal(t, entity.PullRequestStatusOpen, prs[0].Status)
+	assert.Equal(t, 1, prs[0].Version)
+	// PR без ревьюверов тоже попадает в список
+	assert.Equal(t, "pr-1", prs[1].PullRequestID)
+	assert.Empty(t, prs[1].AssignedReviewers)
+	assert.True(t, prs[1].MergedAt.IsZero())
+}
+
+func testRestorePRKeepsState(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2", "u3")
+
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	mergedAt := createdAt.Add(2 * time.Hour)
+	require.NoError(t, repo.RestorePR(ctx, &entity.PullRequest{
+		PullRequestID:     "pr-merged",
+		PullRequestName:   "Merged",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusMerged,
+		AssignedReviewers: []string{"u3", "u2"},
+		CreatedAt:         createdAt,
+		MergedAt:          mergedAt,
+		Version:           4,
+	}))
+	require.NoError(t, repo.RestorePR(ctx, &entity.PullRequest{
+		PullRequestID:   "pr-open",
+		PullRequestName: "Open",
+		AuthorID:        "u2",
+		Status:          entity.PullRequestStatusOpen,
+		CreatedAt:       createdAt.Add(time.Hour),
+		Version:         1,
+	}))
+
+	found, err := repo.FindPRByID(ctx, "pr-merged")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PullRequestStatusMerged, found.Status)
+	assert.Equal(t, []string{"u2", "u3"}, found.AssignedReviewers)
+	assert.True(t, createdAt.Equal(found.CreatedAt), "created_at %s", found.CreatedAt)
+	assert.True(t, mergedAt.Equal(found.MergedAt), "merged_at %s", found.MergedAt)
+	assert.Equal(t, 4, found.Version)
+
+	// Версия продолжает расти от восстановленной
+	require.NoError(t, repo.UpdatePR(ctx, found))
+	assert.Equal(t, 5, found.Version)
+
+	prs, err := repo.ListPRs(ctx)
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, "pr-merged", prs[0].PullRequestID)
+	assert.Equal(t, "pr-open", prs[1].PullRequestID)
+	assert.True(t, prs[1].MergedAt.IsZero())
+}
+
+func testRestorePRErrors(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	createPR(t, repo, "pr-1", "u1")
+
+	pr := &entity.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "PR",
+		AuthorID:        "u1",
+		Status:          entity.PullRequestStatusOpen,
+		CreatedAt:       time.Now(),
+		Version:         1,
+	}
+	assert.ErrorIs(t, repo.RestorePR(ctx, pr), entity.ErrPRExists)
+
+	pr.PullRequestID = "pr-2"
+	pr.AuthorID = "ghost"
+	assert.ErrorIs(t, repo.RestorePR(ctx, pr), entity.ErrNoUser)
+
+	pr.AuthorID = "u1"
+	pr.AssignedReviewers = []string{"ghost"}
+	assert.ErrorIs(t, repo.RestorePR(ctx, pr), entity.ErrNoUser)
+
+	_, err := repo.FindPRByID(ctx, "pr-2")
+	assert.ErrorIs(t, err, entity.ErrNoPR)
+}
+
+// Unavailability
+
+func createUnavailability(t *testing.T, repo interfaces.Repository, userID string, startsAt, endsAt time.Time, reassign bool) *entity.Unavailability {
+	t.Helper()
+	period := &entity.Unavailability{
+		UserID:          userID,
+		StartsAt:        startsAt,
+		EndsAt:          endsAt,
+		Reason:          "vacation",
+		ReassignReviews: reassign,
+	}
+	require.NoError(t, repo.CreateUnavailability(ctx, period))
+	return period
+}
+
+func testUnavailabilityCRUD(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	base := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	later := createUnavailability(t, repo, "u1", base.Add(48*time.Hour), base.Add(72*time.Hour), false)
+	earlier := createUnavailability(t, repo, "u1", base, base.Add(24*time.Hour), true)
+	other := createUnavailability(t, repo, "u2", base.Add(-time.Hour), base, false)
+	assert.NotZero(t, later.ID)
+	assert.NotEqual(t, later.ID, earlier.ID)
+
+	periods, err := repo.FindUnavailabilitiesByUser(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, periods, 2)
+	assert.Equal(t, earlier.ID, periods[0].ID)
+	assert.Equal(t, "u1", periods[0].UserID)
+	assert.True(t, base.Equal(periods[0].StartsAt), "starts_at %s", periods[0].StartsAt)
+	assert.True(t, base.Add(24*time.Hour).Equal(periods[0].EndsAt), "ends_at %s", periods[0].EndsAt)
+	assert.Equal(t, "vacation", periods[0].Reason)
+	assert.True(t, periods[0].ReassignReviews)
+	assert.True(t, periods[0].ReassignedAt.IsZero())
+	assert.Equal(t, later.ID, periods[1].ID)
+
+	all, err := repo.ListUnavailabilities(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, []int64{earlier.ID, later.ID, other.ID}, []int64{all[0].ID, all[1].ID, all[2].ID})
+
+	require.NoError(t, repo.DeleteUnavailability(ctx, later.ID))
+	assert.ErrorIs(t, repo.DeleteUnavailability(ctx, later.ID), entity.ErrNoUnavailability)
+	periods, err = repo.FindUnavailabilitiesByUser(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, periods, 1)
+
+	err = repo.CreateUnavailability(ctx, &entity.Unavailability{UserID: "ghost", StartsAt: base, EndsAt: base.Add(time.Hour)})
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+}
+
+func testUnavailableUsersAndPendingReassignments(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2", "u3", "u4")
+	createTeam(t, repo, "frontend", "u5")
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	current := createUnavailability(t, repo, "u1", now.Add(-time.Hour), now.Add(time.Hour), true)
+	createUnavailability(t, repo, "u1", now.Add(-30*time.Minute), now.Add(time.Hour), false)
+	createUnavailability(t, repo, "u2", now, now.Add(time.Hour), false) // начинается ровно сейчас
+	createUnavailability(t, repo, "u3", now.Add(-time.Hour), now, true) // закончился ровно сейчас
+	createUnavailability(t, repo, "u4", now.Add(time.Hour), now.Add(2*time.Hour), true)
+	createUnavailability(t, repo, "u5", now.Add(-time.Hour), now.Add(time.Hour), false)
+
+	userIDs, err := repo.FindUnavailableUserIDs(ctx, "backend", now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, userIDs)
+
+	userIDs, err = repo.FindUnavailableUserIDs(ctx, "backend", now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, userIDs)
+
+	pending, err := repo.FindPendingReassignments(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, current.ID, pending[0].ID)
+
+	reassignedAt := now.Add(time.Minute)
+	require.NoError(t, repo.MarkUnavailabilityReassigned(ctx, current.ID, reassignedAt))
+	assert.ErrorIs(t, repo.MarkUnavailabilityReassigned(ctx, 0, reassignedAt), entity.ErrNoUnavailability)
+
+	pending, err = repo.FindPendingReassignments(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	periods, err := repo.FindUnavailabilitiesByUser(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, periods, 2)
+	assert.True(t, reassignedAt.Equal(periods[0].ReassignedAt), "reassigned_at %s", periods[0].ReassignedAt)
+}
+
+func testUpdateUnavailability(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1")
+	base := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	period := createUnavailability(t, repo, "u1", base, base.Add(time.Hour), false)
+	period.StartsAt = base.Add(24 * time.Hour)
+	period.EndsAt = base.Add(48 * time.Hour)
+	period.Reason = "sick leave"
+	period.ReassignReviews = true
+	period.ReassignedAt = base.Add(25 * time.Hour)
+	period.ExternalID = "event-1@calendar"
+	require.NoError(t, repo.UpdateUnavailability(ctx, period))
+
+	periods, err := repo.FindUnavailabilitiesByUser(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, periods, 1)
+	assert.Equal(t, period.ID, periods[0].ID)
+	assert.True(t, period.StartsAt.Equal(periods[0].StartsAt), "starts_at %s", periods[0].StartsAt)
+	assert.True(t, period.EndsAt.Equal(periods[0].EndsAt), "ends_at %s", periods[0].EndsAt)
+	assert.Equal(t, "sick leave", periods[0].Reason)
+	assert.True(t, periods[0].ReassignReviews)
+	assert.True(t, period.ReassignedAt.Equal(periods[0].ReassignedAt), "reassigned_at %s", periods[0].ReassignedAt)
+	assert.Equal(t, "event-1@calendar", periods[0].ExternalID)
+
+	// Нулевое время переназначения сбрасывает его
+	period.ReassignedAt = time.Time{}
+	require.NoError(t, repo.UpdateUnavailability(ctx, period))
+	periods, err = repo.FindUnavailabilitiesByUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.True(t, periods[0].ReassignedAt.IsZero())
+
+	period.ID = 0
+	assert.ErrorIs(t, repo.UpdateUnavailability(ctx, period), entity.ErrNoUnavailability)
+}
+
+func testUserAliases(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+
+	require.NoError(t, repo.SetUserAliases(ctx, "u1", []string{"alice@example.com", "alice"}))
+	require.NoError(t, repo.SetUserAliases(ctx, "u2", []string{"bob@example.com"}))
+
+	aliases, err := repo.FindUserAliases(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "alice@example.com"}, aliases)
+
+	userID, err := repo.FindUserIDByAlias(ctx, "bob@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "u2", userID)
+	_, err = repo.FindUserIDByAlias(ctx, "carol@example.com")
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+
+	// Чужой псевдоним не выдаётся, и псевдонимы пользователя остаются прежними
+	err = repo.SetUserAliases(ctx, "u1", []string{"alice", "bob@example.com"})
+	assert.ErrorIs(t, err, entity.ErrAliasTaken)
+	aliases, err = repo.FindUserAliases(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "alice@example.com"}, aliases)
+
+	// Набор псевдонимов заменяется целиком
+	require.NoError(t, repo.SetUserAliases(ctx, "u1", []string{"alice@corp.example.com"}))
+	all, err := repo.ListUserAliases(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.UserAlias{
+		{Alias: "alice@corp.example.com", UserID: "u1"},
+		{Alias: "bob@example.com", UserID: "u2"},
+	}, all)
+
+	require.NoError(t, repo.SetUserAliases(ctx, "u2", nil))
+	aliases, err = repo.FindUserAliases(ctx, "u2")
+	require.NoError(t, err)
+	assert.Empty(t, aliases)
+
+	assert.ErrorIs(t, repo.SetUserAliases(ctx, "ghost", []string{"ghost"}), entity.ErrNoUser)
+}
+
+func testWorkSchedules(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	createTeam(t, repo, "frontend", "u3")
+
+	_, err := repo.FindWorkSchedule(ctx, "u1")
+	assert.ErrorIs(t, err, entity.ErrNoWorkSchedule)
+
+	berlin := &entity.WorkSchedule{
+		UserID:      "u1",
+		TimeZone:    "Europe/Berlin",
+		StartMinute: 9 * 60,
+		EndMinute:   18 * 60,
+		Days:        []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	}
+	require.NoError(t, repo.SetWorkSchedule(ctx, berlin))
+	// Дни хранятся по порядку начиная с воскресенья
+	require.NoError(t, repo.SetWorkSchedule(ctx, &entity.WorkSchedule{
+		UserID: "u3", TimeZone: "America/Los_Angeles", StartMinute: 22 * 60, EndMinute: 6 * 60,
+		Days: []time.Weekday{time.Saturday, time.Sunday},
+	}))
+
+	schedule, err := repo.FindWorkSchedule(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, berlin, schedule)
+
+	// Повторная запись заменяет рабочее время
+	berlin.EndMinute = 17*60 + 30
+	require.NoError(t, repo.SetWorkSchedule(ctx, berlin))
+	team, err := repo.FindWorkSchedulesByTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.WorkSchedule{berlin}, team)
+
+	all, err := repo.ListWorkSchedules(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "u3", all[1].UserID)
+	assert.Equal(t, []time.Weekday{time.Sunday, time.Saturday}, all[1].Days)
+
+	require.NoError(t, repo.DeleteWorkSchedule(ctx, "u1"))
+	assert.ErrorIs(t, repo.DeleteWorkSchedule(ctx, "u1"), entity.ErrNoWorkSchedule)
+	team, err = repo.FindWorkSchedulesByTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Empty(t, team)
+
+	berlin.UserID = "ghost"
+	assert.ErrorIs(t, repo.SetWorkSchedule(ctx, berlin), entity.ErrNoUser)
+}
+
+// Skills
+
+func testUserSkills(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	createTeam(t, repo, "frontend", "u3")
+
+	skills, err := repo.FindUserSkills(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, skills)
+
+	require.NoError(t, repo.SetUserSkills(ctx, "u1", []entity.UserSkill{
+		{Tag: "sql", Level: entity.SkillLevelBasic},
+		{Tag: "go", Level: entity.SkillLevelExpert},
+	}))
+	require.NoError(t, repo.SetUserSkills(ctx, "u3", []entity.UserSkill{{Tag: "react", Level: 2}}))
+
+	// Навыки возвращаются по алфавиту тегов с заполненным user_id
+	skills, err = repo.FindUserSkills(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []entity.UserSkill{
+		{UserID: "u1", Tag: "go", Level: entity.SkillLevelExpert},
+		{UserID: "u1", Tag: "sql", Level: entity.SkillLevelBasic},
+	}, skills)
+
+	// Повторная запись заменяет навыки целиком
+	require.NoError(t, repo.SetUserSkills(ctx, "u1", []entity.UserSkill{{Tag: "kafka", Level: 2}}))
+	require.NoError(t, repo.SetUserSkills(ctx, "u2", []entity.UserSkill{{Tag: "go", Level: 2}}))
+	team, err := repo.FindSkillsByTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, []entity.UserSkill{
+		{UserID: "u1", Tag: "kafka", Level: 2},
+		{UserID: "u2", Tag: "go", Level: 2},
+	}, team)
+
+	all, err := repo.ListUserSkills(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "u3", all[2].UserID)
+
+	// Пустой список удаляет навыки пользователя
+	require.NoError(t, repo.SetUserSkills(ctx, "u1", nil))
+	skills, err = repo.FindUserSkills(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, skills)
+
+	assert.ErrorIs(t, repo.SetUserSkills(ctx, "ghost", []entity.UserSkill{{Tag: "go", Level: 1}}), entity.ErrNoUser)
+}
+
+// Code owners
+
+func testCodeOwners(t *testing.T, repo interfaces.Repository) {
+	_, err := repo.FindCodeOwners(ctx, "payments")
+	assert.ErrorIs(t, err, entity.ErrNoCodeOwners)
+
+	// Правила хранятся в порядке файла, владельцы не обязаны существовать
+	payments := &entity.CodeOwners{
+		Repository: "payments",
+		Rules: []entity.CodeOwnerRule{
+			{Line: 2, Pattern: "*", Teams: []string{"backend"}},
+			{Line: 5, Pattern: "/docs/", Users: []string{"u2", "bob@example.com"}, Teams: []string{"docs"}},
+			{Line: 6, Pattern: "/docs/generated/"},
+		},
+	}
+	require.NoError(t, repo.SetCodeOwners(ctx, payments))
+	require.NoError(t, repo.SetCodeOwners(ctx, &entity.CodeOwners{
+		Repository: "api",
+		Rules:      []entity.CodeOwnerRule{{Line: 1, Pattern: "*.proto", Users: []string{"u1"}}},
+	}))
+
+	found, err := repo.FindCodeOwners(ctx, "payments")
+	require.NoError(t, err)
+	assert.Equal(t, payments, found)
+
+	// Повторная запись заменяет все правила репозитория
+	payments.Rules = payments.Rules[1:2]
+	require.NoError(t, repo.SetCodeOwners(ctx, payments))
+	all, err := repo.ListCodeOwners(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "api", all[0].Repository)
+	assert.Equal(t, payments, all[1])
+
+	// Пустой список правил удаляет их
+	require.NoError(t, repo.SetCodeOwners(ctx, &entity.CodeOwners{Repository: "api"}))
+	_, err = repo.FindCodeOwners(ctx, "api")
+	assert.ErrorIs(t, err, entity.ErrNoCodeOwners)
+
+	require.NoError(t, repo.DeleteCodeOwners(ctx, "payments"))
+	assert.ErrorIs(t, repo.DeleteCodeOwners(ctx, "payments"), entity.ErrNoCodeOwners)
+	all, err = repo.ListCodeOwners(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func testReviewerRules(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2", "u3")
+
+	require.NoError(t, repo.SetReviewerRule(ctx, &entity.ReviewerRule{
+		AuthorID: "u1", ReviewerID: "u3", Kind: entity.ReviewerRuleExclude, Reason: "co-author",
+	}))
+	require.NoError(t, repo.SetReviewerRule(ctx, &entity.ReviewerRule{
+		AuthorID: "u1", ReviewerID: "u2", Kind: entity.ReviewerRulePrefer,
+	}))
+	require.NoError(t, repo.SetReviewerRule(ctx, &entity.ReviewerRule{
+		AuthorID: "u2", ReviewerID: "u1", Kind: entity.ReviewerRuleExclude,
+	}))
+
+	rules, err := repo.FindReviewerRulesByAuthor(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []entity.ReviewerRule{
+		{AuthorID: "u1", ReviewerID: "u2", Kind: entity.ReviewerRulePrefer},
+		{AuthorID: "u1", ReviewerID: "u3", Kind: entity.ReviewerRuleExclude, Reason: "co-author"},
+	}, rules)
+
+	// Повторная запись заменяет правило пары
+	require.NoError(t, repo.SetReviewerRule(ctx, &entity.ReviewerRule{
+		AuthorID: "u1", ReviewerID: "u3", Kind: entity.ReviewerRulePrefer,
+	}))
+	all, err := repo.ListReviewerRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, entity.ReviewerRule{AuthorID: "u1", ReviewerID: "u3", Kind: entity.ReviewerRulePrefer}, all[1])
+	assert.Equal(t, "u2", all[2].AuthorID)
+
+	err = repo.SetReviewerRule(ctx, &entity.ReviewerRule{AuthorID: "u1", ReviewerID: "ghost", Kind: entity.ReviewerRuleExclude})
+	assert.ErrorIs(t, err, entity.ErrNoUser)
+
+	require.NoError(t, repo.DeleteReviewerRule(ctx, "u1", "u3"))
+	assert.ErrorIs(t, repo.DeleteReviewerRule(ctx, "u1", "u3"), entity.ErrNoReviewerRule)
+	rules, err = repo.FindReviewerRulesByAuthor(ctx, "u3")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+// Transactions and concurrency
+
+func testWithTxRollbackOnError(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	createPR(t, repo, "pr-1", "u1", "u2")
+
+	errBoom := errors.New("boom")
+	err := repo.WithTx(ctx, func(tx interfaces.Repository) error {
+		if err := tx.SetActive(ctx, "u2", false); err != nil {
+			return err
+		}
+		pr, err := tx.FindPRByIDForUpdate(ctx, "pr-1")
+		if err != nil {
+			return err
+		}
+		pr.Status = entity.PullRequestStatusMerged
+		if err := tx.UpdatePR(ctx, pr); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	user, err := repo.FindUserByID(ctx, "u2")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+
+	pr, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PullRequestStatusOpen, pr.Status)
+	assert.Equal(t, 1, pr.Version)
+}
+
+func testWithTxConcurrentUpdates(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	createPR(t, repo, "pr-1", "u1")
+
+	// Чтение с блокировкой и запись внутри WithTx не пересекаются
+	// с другими транзакциями, поэтому конфликтов версий нет
+	const workers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- repo.WithTx(ctx, func(tx interfaces.Repository) error {
+				pr, err := tx.FindPRByIDForUpdate(ctx, "pr-1")
+				if err != nil {
+					return err
+				}
+				return tx.UpdatePR(ctx, pr)
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	pr, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1+workers, pr.Version)
+}
+
+func testConcurrentUpdatesOneWins(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1", "u2")
+	createPR(t, repo, "pr-1", "u1")
+
+	// Все читают одну версию без транзакции - успешно обновить может только один
+	const workers = 8
+	prs := make([]*entity.PullRequest, workers)
+	for i := range prs {
+		pr, err := repo.FindPRByID(ctx, "pr-1")
+		require.NoError(t, err)
+		prs[i] = pr
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for _, pr := range prs {
+		wg.Add(1)
+		go func(pr *entity.PullRequest) {
+			defer wg.Done()
+			pr.AssignedReviewers = []string{"u2"}
+			errs <- repo.UpdatePR(ctx, pr)
+		}(pr)
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, entity.ErrVersionConflict)
+	}
+	assert.Equal(t, 1, succeeded)
+
+	pr, err := repo.FindPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, pr.Version)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+}
+
+// Idempotency keys
+
+func testIdempotencyRecords(t *testing.T, repo interfaces.Repository) {
+	now := time.Now().UTC()
+
+	pending := &entity.IdempotencyRecord{
+		Key:         "key-1",
+		Endpoint:    "/pullRequest/create",
+		RequestHash: "hash",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Minute),
+	}
+	reserved, err := repo.ReserveIdempotencyKey(ctx, pending)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	// Второй запрос с тем же ключом резерв не получает, даже с другим телом
+	other := *pending
+	other.RequestHash = "other"
+	reserved, err = repo.ReserveIdempotencyKey(ctx, &other)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+
+	found, err := repo.FindIdempotencyRecord(ctx, "key-1", "/pullRequest/create")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.True(t, found.Pending())
+	assert.Equal(t, "hash", found.RequestHash)
+
+	record := &entity.IdempotencyRecord{
+		Key:             "key-1",
+		Endpoint:        "/pullRequest/create",
+		RequestHash:     "hash",
+		StatusCode:      201,
+		ResponseHeaders: map[string]string{"Content-Type": "application/json", "ETag": `"1"`},
+		ResponseBody:    []byte(`{"pr":{}}`),
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(time.Hour),
+	}
+	require.NoError(t, repo.CompleteIdempotencyRecord(ctx, record))
+
+	// Завершённая запись резерв не снимает
+	require.NoError(t, repo.ReleaseIdempotencyKey(ctx, "key-1", "/pullRequest/create"))
+
+	found, err = repo.FindIdempotencyRecord(ctx, "key-1", "/pullRequest/create")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.False(t, found.Pending())
+	assert.Equal(t, "hash", found.RequestHash)
+	assert.Equal(t, 201, found.StatusCode)
+	assert.Equal(t, record.ResponseHeaders, found.ResponseHeaders)
+	assert.Equal(t, record.ResponseBody, found.ResponseBody)
+
+	// Ключ привязан к эндпоинту
+	found, err = repo.FindIdempotencyRecord(ctx, "key-1", "/team/add")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	// Снятый резерв можно занять снова
+	released := &entity.IdempotencyRecord{
+		Key: "key-2", Endpoint: "/team/add", RequestHash: "hash",
+		CreatedAt: now, ExpiresAt: now.Add(time.Minute),
+	}
+	reserved, err = repo.ReserveIdempotencyKey(ctx, released)
+	require.NoError(t, err)
+	require.True(t, reserved)
+	require.NoError(t, repo.ReleaseIdempotencyKey(ctx, "key-2", "/team/add"))
+	found, err = repo.FindIdempotencyRecord(ctx, "key-2", "/team/add")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	reserved, err = repo.ReserveIdempotencyKey(ctx, released)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	// Истёкшая запись не находится и освобождает ключ после удаления
+	expired := &entity.IdempotencyRecord{
+		Key: "key-3", Endpoint: "/team/add", RequestHash: "old",
+		CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Hour),
+	}
+	reserved, err = repo.ReserveIdempotencyKey(ctx, expired)
+	require.NoError(t, err)
+	require.True(t, reserved)
+	found, err = repo.FindIdempotencyRecord(ctx, "key-3", "/team/add")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	deleted, err := repo.DeleteExpiredIdempotencyRecords(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	fresh := *expired
+	fresh.RequestHash = "new"
+	fresh.CreatedAt = now
+	fresh.ExpiresAt = now.Add(time.Minute)
+	reserved, err = repo.ReserveIdempotencyKey(ctx, &fresh)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	deleted, err = repo.DeleteExpiredIdempotencyRecords(ctx, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+}
+
+func testCanceledContext(t *testing.T, repo interfaces.Repository) {
+	createTeam(t, repo, "backend", "u1")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err := repo.FindTeamByName(canceled, "backend")
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.FindUserByID(canceled, "u1")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Error(t, repo.SetActive(canceled, "u1", false))
+
+	user, err := repo.FindUserByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+}
@@ -0,0 +1,2124 @@
+// Package sqlite содержит реализацию interfaces.Repository поверх SQLite
+// (драйвер modernc.org/sqlite без cgo) с той же семантикой ошибок, что и PRRepository
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+	"github.com/pozedorum/set_pr_reviers_service/internal/migrator"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type SQLiteRepository struct {
+	db     *sql.DB
+	tx     *sql.Tx // Не nil внутри WithTx
+	logger interfaces.Logger
+}
+
+// querier - общие методы *sql.DB и *sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// txScope - транзакция метода репозитория. Если метод вызван внутри WithTx,
+// используется внешняя транзакция, а Commit и Rollback ничего не делают
+type txScope struct {
+	*sql.Tx
+	owned bool
+}
+
+func (t *txScope) Commit() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *txScope) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// OpenDB открывает базу по пути path (":memory:" - база в памяти) с настройками,
+// на которые рассчитывает репозиторий
+func OpenDB(path string) (*sql.DB, error) {
+	// _time_format=sqlite пишет время в формате "2006-01-02 15:04:05.999999999-07:00",
+	// который сравнивается как строка - на этом держатся ORDER BY created_at и проверка expires_at
+	dsn := "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// SQLite допускает одного писателя. Одно соединение сериализует транзакции,
+	// поэтому FOR UPDATE не нужен, а база ":memory:" общая для всех запросов
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+// legacySchemaVersion - версия схемы баз, созданных до появления schema_migrations:
+// тогда при открытии сразу применялись все миграции 001-003
+const legacySchemaVersion = 3
+
+// NewMigrator возвращает мигратор для базы SQLite. Базу, созданную до появления
+// schema_migrations, он сразу отмечает как мигрированную до legacySchemaVersion
+func NewMigrator(ctx context.Context, db *sql.DB, logger interfaces.Logger) (*migrator.Migrator, error) {
+	m, err := migrator.New(db, migrator.SQLite, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var legacy int
+	err = db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM sqlite_master
+		WHERE type = 'table' AND name = 'teams'
+			AND NOT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations')
+	`).Scan(&legacy)
+	if err != nil {
+		return nil, fmt.Errorf("check schema: %w", err)
+	}
+	if legacy > 0 {
+		if err := m.Baseline(ctx, legacySchemaVersion); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// NewSQLiteRepository открывает базу по пути path и применяет к ней недостающие миграции
+func NewSQLiteRepository(path string, logger interfaces.Logger) (*SQLiteRepository, error) {
+	db, err := OpenDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	closeOnError := func(err error) (*SQLiteRepository, error) {
+		if closeErr := db.Close(); closeErr != nil {
+			logger.Error("SQLITE_REPO", "failed to close database", "error", closeErr)
+		}
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		return closeOnError(fmt.Errorf("failed to ping database: %w", err))
+	}
+
+	m, err := NewMigrator(ctx, db, logger)
+	if err != nil {
+		return closeOnError(fmt.Errorf("failed to init schema: %w", err))
+	}
+	if _, err := m.Up(ctx); err != nil {
+		return closeOnError(fmt.Errorf("failed to init schema: %w", err))
+	}
+
+	logger.Info("SQLITE_REPO", "SQLite repository initialized successfully", "path", path)
+	return &SQLiteRepository{
+		db:     db,
+		logger: logger,
+	}, nil
+}
+
+func (repo *SQLiteRepository) Close() error {
+	repo.logger.Info("SQLITE_REPO", "Closing database connection")
+	return repo.db.Close()
+}
+
+// WithTx выполняет fn в одной транзакции, откатывая её при ошибке
+func (repo *SQLiteRepository) WithTx(ctx context.Context, fn func(repo interfaces.Repository) error) error {
+	if repo.tx != nil {
+		// Уже внутри транзакции - вложенные вызовы используют её же
+		return fn(repo)
+	}
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		repo.logger.Error("SQLITE_WITH_TX", "Failed to begin transaction", "error", err)
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	txRepo := &SQLiteRepository{
+		db:     repo.db,
+		tx:     tx,
+		logger: repo.logger,
+	}
+
+	if err := fn(txRepo); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			repo.logger.Error("SQLITE_WITH_TX", "failed to rollback transaction", "error", rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		repo.logger.Error("SQLITE_WITH_TX", "Failed to commit transaction", "error", err)
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (repo *SQLiteRepository) conn() querier {
+	if repo.tx != nil {
+		return repo.tx
+	}
+	return repo.db
+}
+
+func (repo *SQLiteRepository) beginTx(ctx context.Context) (*txScope, error) {
+	if repo.tx != nil {
+		return &txScope{Tx: repo.tx, owned: false}, nil
+	}
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &txScope{Tx: tx, owned: true}, nil
+}
+
+// Users
+
+func (repo *SQLiteRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	start := time.Now()
+
+	repo.logger.Debug("SQLITE_CREATE_USER", "Creating user",
+		"user_id", user.UserID,
+		"team_name", user.TeamName)
+
+	teamID, err := repo.getTeamIDByName(ctx, user.TeamName)
+	if err != nil {
+		return fmt.Errorf("get team ID: %w", err)
+	}
+
+	query := `
+		INSERT INTO users (user_id, username, team_id, is_active, max_open_reviews, level, is_learner)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = repo.conn().ExecContext(ctx, query, user.UserID, user.Username, teamID, user.IsActive, user.MaxOpenReviews,
+		user.Level, user.IsLearner)
+	if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY) {
+		return entity.ErrUserExists
+	}
+	if err != nil {
+		repo.logger.Error("SQLITE_CREATE_USER", "Failed to create user",
+			"user_id", user.UserID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("create user: %w", err)
+	}
+
+	repo.logger.Info("SQLITE_CREATE_USER", "User created successfully",
+		"user_id", user.UserID,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *SQLiteRepository) FindUserByID(ctx context.Context, userID string) (*entity.User, error) {
+	query := `
+		SELECT u.user_id, u.username, t.team_name, u.is_active, u.max_open_reviews, u.level, u.is_learner
+		FROM users u
+		JOIN teams t ON u.team_id = t.team_id
+		WHERE u.user_id = ?
+	`
+
+	var user entity.User
+	err := repo.conn().QueryRowContext(ctx, query, userID).Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.MaxOpenReviews,
+		&user.Level,
+		&user.IsLearner,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user not found: %w", entity.ErrNoUser)
+		}
+		repo.logger.Error("SQLITE_FIND_USER_BY_ID", "Failed to find user",
+			"user_id", userID,
+			"error", err)
+		return nil, fmt.Errorf("find user by ID: %w", err)
+	}
+	return &user, nil
+}
+
+func (repo *SQLiteRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	repo.logger.Debug("SQLITE_UPDATE_USER", "Updating user",
+		"user_id", user.UserID,
+		"team_name", user.TeamName,
+		"is_active", user.IsActive)
+
+	teamID, err := repo.getTeamIDByName(ctx, user.TeamName)
+	if err != nil {
+		return fmt.Errorf("get team ID: %w", err)
+	}
+
+	query := `
+		UPDATE users
+		SET username = ?, team_id = ?, is_active = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = ?
+	`
+	result, err := repo.conn().ExecContext(ctx, query, user.Username, teamID, user.IsActive, user.UserID)
+	if err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return entity.ErrNoUser
+	}
+	return nil
+}
+
+func (repo *SQLiteRepository) FindUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
+	teamID, err := repo.getTeamIDByName(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("find users by team: %w", err)
+	}
+
+	query := `
+		SELECT user_id, username, is_active, max_open_reviews, level, is_learner
+		FROM users
+		WHERE team_id = ?
+		ORDER BY user_id
+	`
+	rows, err := repo.conn().QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("query users by team: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_FIND_USERS_BY_TEAM", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var users []*entity.User
+	for rows.Next() {
+		var user entity.User
+		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive, &user.MaxOpenReviews, &user.Level,
+			&user.IsLearner); err != nil {
+			return nil, fmt.Errorf("scan user row: %w", err)
+		}
+		user.TeamName = teamName
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user rows: %w", err)
+	}
+	return users, nil
+}
+
+func (repo *SQLiteRepository) ListUsers(ctx context.Context) ([]*entity.User, error) {
+	query := `
+		SELECT u.user_id, u.username, t.team_name, u.is_active, u.max_open_reviews, u.level, u.is_learner
+		FROM users u
+		JOIN teams t ON u.team_id = t.team_id
+		ORDER BY t.team_name, u.user_id
+	`
+	rows, err := repo.conn().QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_LIST_USERS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var users []*entity.User
+	for rows.Next() {
+		var user entity.User
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.MaxOpenReviews, &user.Level,
+			&user.IsLearner); err != nil {
+			return nil, fmt.Errorf("scan user row: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user rows: %w", err)
+	}
+	return users, nil
+}
+
+func (repo *SQLiteRepository) SetActive(ctx context.Context, userID string, isActive bool) error {
+	repo.logger.Debug("SQLITE_SET_ACTIVE", "Setting user active status",
+		"user_id", userID,
+		"is_active", isActive)
+
+	query := `UPDATE users SET is_active = ?, updated_at = CURRENT_TIMESTAMP WHERE user_id = ?`
+	result, err := repo.conn().ExecContext(ctx, query, isActive, userID)
+	if err != nil {
+		return fmt.Errorf("set user active: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return entity.ErrNoUser
+	}
+	return nil
+}
+
+func (repo *SQLiteRepository) SetMaxOpenReviews(ctx context.Context, userID string, limit int) error {
+	query := `UPDATE users SET max_open_reviews = ?, updated_at = CURRENT_TIMESTAMP WHERE user_id = ?`
+	result, err := repo.conn().ExecContext(ctx, query, limit, userID)
+	if err != nil {
+		return fmt.Errorf("set max open reviews: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("user %s: %w", userID, entity.ErrNoUser)
+	}
+	return nil
+}
+
+func (repo *SQLiteRepository) SetUserLevel(ctx context.Context, userID string, level entity.UserLevel) error {
+	query := `UPDATE users SET level = ?, updated_at = CURRENT_TIMESTAMP WHERE user_id = ?`
+	result, err := repo.conn().ExecContext(ctx, query, level, userID)
+	if err != nil {
+		return fmt.Errorf("set user level: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("user %s: %w", userID, entity.ErrNoUser)
+	}
+	return nil
+}
+
+func (repo *SQLiteRepository) SetUserLearner(ctx context.Context, userID string, isLearner bool) error {
+	query := `UPDATE users SET is_learner = ?, updated_at = CURRENT_TIMESTAMP WHERE user_id = ?`
+	result, err := repo.conn().ExecContext(ctx, query, isLearner, userID)
+	if err != nil {
+		return fmt.Errorf("set user learner: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("user %s: %w", userID, entity.ErrNoUser)
+	}
+	return nil
+}
+
+// FindReviewLoads возвращает открытые ревью участников команды: их число и размер.
+// Участники без открытых ревью в результат не попадают
+func (repo *SQLiteRepository) FindReviewLoads(ctx context.Context, teamName string) (map[string]entity.ReviewLoad, error) {
+	query := `
+		SELECT prr.reviewer_id, COUNT(*), COALESCE(SUM(pr.additions + pr.deletions), 0)
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		JOIN users u ON u.user_id = prr.reviewer_id
+		JOIN teams t ON t.team_id = u.team_id
+		WHERE t.team_name = ? AND pr.status = ?
+		GROUP BY prr.reviewer_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query, teamName, entity.PullRequestStatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("find review loads: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_FIND_REVIEW_LOADS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	loads := make(map[string]entity.ReviewLoad)
+	for rows.Next() {
+		var userID string
+		var load entity.ReviewLoad
+		if err := rows.Scan(&userID, &load.OpenReviews, &load.ChangedLines); err != nil {
+			return nil, fmt.Errorf("scan review load row: %w", err)
+		}
+		loads[userID] = load
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate review load rows: %w", err)
+	}
+	return loads, nil
+}
+
+// FindPairReviewCounts возвращает, на сколько PR автора, созданных не раньше since,
+// назначен ревьювером каждый пользователь, включая смерженные PR.
+// Пользователи без таких ревью в результат не попадают
+func (repo *SQLiteRepository) FindPairReviewCounts(ctx context.Context, authorID string, since time.Time) (map[string]int, error) {
+	query := `
+		SELECT prr.reviewer_id, COUNT(*)
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE pr.author_id = ? AND pr.created_at >= ?
+		GROUP BY prr.reviewer_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query, authorID, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("find pair review counts: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_FIND_PAIR_REVIEW_COUNTS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var reviewerID string
+		var count int
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			return nil, fmt.Errorf("scan pair review count row: %w", err)
+		}
+		counts[reviewerID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pair review count rows: %w", err)
+	}
+	return counts, nil
+}
+
+// Teams
+
+func (repo *SQLiteRepository) CreateTeam(ctx context.Context, team *entity.Team) error {
+	repo.logger.Debug("SQLITE_CREATE_TEAM", "Creating team",
+		"team_name", team.TeamName,
+		"members_count", len(team.Members))
+
+	tx, err := repo.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			repo.logger.Error("SQLITE_CREATE_TEAM", "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	var teamID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO teams (team_name, max_open_reviews, require_senior, senior_for_juniors)
+		VALUES (?, ?, ?, ?)
+		RETURNING team_id
+	`, team.TeamName, team.MaxOpenReviews, team.ReviewPolicy.RequireSenior, team.ReviewPolicy.SeniorForJuniors).Scan(&teamID)
+	if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+		return entity.ErrTeamExists
+	}
+	if err != nil {
+		return fmt.Errorf("create team: %w", err)
+	}
+
+	userQuery := `
+		INSERT INTO users (user_id, username, team_id, is_active, max_open_reviews, level, is_learner)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	for _, member := range team.Members {
+		_, err := tx.ExecContext(ctx, userQuery, member.UserID, member.Username, teamID, member.IsActive,
+			member.MaxOpenReviews, member.Level, member.IsLearner)
+		if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY) {
+			return entity.ErrUserExists
+		}
+		if err != nil {
+			return fmt.Errorf("create team member %s: %w", member.UserID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	repo.logger.Info("SQLITE_CREATE_TEAM", "Team created successfully",
+		"team_name", team.TeamName, "team_id", teamID)
+	return nil
+}
+
+func (repo *SQLiteRepository) FindTeamByName(ctx context.Context, teamName string) (*entity.Team, error) {
+	var teamID int64
+	var maxOpenReviews int
+	var policy entity.ReviewPolicy
+	err := repo.conn().QueryRowContext(ctx, `
+		SELECT team_id, max_open_reviews, require_senior, senior_for_juniors FROM teams WHERE team_name = ?
+	`, teamName).Scan(&teamID, &maxOpenReviews, &policy.RequireSenior, &policy.SeniorForJuniors)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entity.ErrNoTeam
+		}
+		return nil, fmt.Errorf("find team by name: %w", err)
+	}
+
+	query := `
+		SELECT user_id, username, is_active, max_open_reviews, level, is_learner
+		FROM users
+		WHERE team_id = ?
+		ORDER BY user_id
+	`
+	rows, err := repo.conn().QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("query team members: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_FIND_TEAM_BY_NAME", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var members []entity.TeamMember
+	for rows.Next() {
+		var member entity.TeamMember
+		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.MaxOpenReviews, &member.Level,
+			&member.IsLearner); err != nil {
+			return nil, fmt.Errorf("scan team member row: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate team member rows: %w", err)
+	}
+
+	return &entity.Team{
+		TeamName:       teamName,
+		Members:        members,
+		MaxOpenReviews: maxOpenReviews,
+		ReviewPolicy:   policy,
+	}, nil
+}
+
+func (repo *SQLiteRepository) TeamExists(ctx context.Context, teamName string) bool {
+	_, err := repo.getTeamIDByName(ctx, teamName)
+	if err != nil && !errors.Is(err, entity.ErrNoTeam) {
+		repo.logger.Error("SQLITE_TEAM_EXISTS", "Failed to check team existence",
+			"team_name", teamName, "error", err)
+	}
+	return err == nil
+}
+
+func (repo *SQLiteRepository) SetTeamMaxOpenReviews(ctx context.Context, teamName string, limit int) error {
+	result, err := repo.conn().ExecContext(ctx, `UPDATE teams SET max_open_reviews = ? WHERE team_name = ?`, limit, teamName)
+	if err != nil {
+		return fmt.Errorf("set team max open reviews: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return entity.ErrNoTeam
+	}
+	return nil
+}
+
+func (repo *SQLiteRepository) SetTeamReviewPolicy(ctx context.Context, teamName string, policy entity.ReviewPolicy) error {
+	result, err := repo.conn().ExecContext(ctx, `UPDATE teams SET require_senior = ?, senior_for_juniors = ? WHERE team_name = ?`,
+		policy.RequireSenior, policy.SeniorForJuniors, teamName)
+	if err != nil {
+		return fmt.Errorf("set team review policy: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return entity.ErrNoTeam
+	}
+	return nil
+}
+
+// ListTeams возвращает все команды, включая пустые, отсортированные по имени
+func (repo *SQLiteRepository) ListTeams(ctx context.Context) ([]*entity.Team, error) {
+	// LEFT JOIN оставляет команды без участников: у них user_id будет NULL
+	query := `
+		SELECT t.team_name, t.max_open_reviews, t.require_senior, t.senior_for_juniors,
+			u.user_id, u.username, u.is_active, u.max_open_reviews, u.level, u.is_learner
+		FROM teams t
+		LEFT JOIN users u ON u.team_id = t.team_id
+		ORDER BY t.team_name, u.user_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query)
+	if err != nil {
+		repo.logger.Error("SQLITE_LIST_TEAMS", "Failed to query teams", "error", err)
+		return nil, fmt.Errorf("list teams: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_LIST_TEAMS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var teams []*entity.Team
+	for rows.Next() {
+		var teamName string
+		var teamMaxOpenReviews int
+		var policy entity.ReviewPolicy
+		var userID, username, level sql.NullString
+		var isActive, isLearner sql.NullBool
+		var maxOpenReviews sql.NullInt64
+		if err := rows.Scan(&teamName, &teamMaxOpenReviews, &policy.RequireSenior, &policy.SeniorForJuniors,
+			&userID, &username, &isActive, &maxOpenReviews, &level, &isLearner); err != nil {
+			return nil, fmt.Errorf("scan team row: %w", err)
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != teamName {
+			teams = append(teams, &entity.Team{TeamName: teamName, MaxOpenReviews: teamMaxOpenReviews, ReviewPolicy: policy})
+		}
+		if userID.Valid {
+			team := teams[len(teams)-1]
+			team.Members = append(team.Members, entity.TeamMember{
+				UserID:         userID.String,
+				Username:       username.String,
+				IsActive:       isActive.Bool,
+				MaxOpenReviews: int(maxOpenReviews.Int64),
+				Level:          entity.UserLevel(level.String),
+				IsLearner:      isLearner.Bool,
+			})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate team rows: %w", err)
+	}
+	return teams, nil
+}
+
+// PRs
+
+func (repo *SQLiteRepository) CreatePR(ctx context.Context, pr *entity.PullRequest) error {
+	start := time.Now()
+
+	repo.logger.Debug("SQLITE_CREATE_PR", "Creating pull request",
+		"pr_id", pr.PullRequestID,
+		"author_id", pr.AuthorID,
+		"reviewers_count", len(pr.AssignedReviewers))
+
+	tx, err := repo.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			repo.logger.Error("SQLITE_CREATE_PR", "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	// created_at пишем из Go: CURRENT_TIMESTAMP в SQLite хранит только секунды,
+	// и порядок PR в FindPRsByReviewer стал бы неоднозначным
+	prQuery := `
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at,
+			additions, deletions, changed_files, repository, base_branch, labels, paths, required_skills,
+			shadow_reviewer_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING version
+	`
+
+	labels, err := encodeStrings(pr.Metadata.Labels)
+	if err != nil {
+		return err
+	}
+	paths, err := encodeStrings(pr.Metadata.Paths)
+	if err != nil {
+		return err
+	}
+	requiredSkills, err := encodeStrings(pr.Metadata.RequiredSkills)
+	if err != nil {
+		return err
+	}
+
+	var version int
+	err = tx.QueryRowContext(ctx, prQuery,
+		pr.PullRequestID,
+		pr.PullRequestName,
+		pr.AuthorID,
+		string(pr.Status),
+		time.Now().UTC(),
+		pr.Metadata.Additions,
+		pr.Metadata.Deletions,
+		pr.Metadata.ChangedFiles,
+		pr.Metadata.Repository,
+		pr.Metadata.BaseBranch,
+		labels,
+		paths,
+		requiredSkills,
+		nullString(pr.ShadowReviewer),
+	).Scan(&version)
+	if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY) {
+		return entity.ErrPRExists
+	}
+	if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+		return fmt.Errorf("author %s: %w", pr.AuthorID, entity.ErrNoUser)
+	}
+	if err != nil {
+		repo.logger.Error("SQLITE_CREATE_PR", "Failed to create pull request",
+			"pr_id", pr.PullRequestID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return fmt.Errorf("create pull request: %w", err)
+	}
+
+	if err := insertReviewers(ctx, tx, pr.PullRequestID, pr.AssignedReviewers); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	pr.Version = version
+
+	repo.logger.Info("SQLITE_CREATE_PR", "Pull request created successfully",
+		"pr_id", pr.PullRequestID,
+		"reviewers_count", len(pr.AssignedReviewers),
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+// RestorePR сохраняет PR как есть: статус, время создания и слияния, версию и ревьюверов.
+// Используется при восстановлении из снапшота
+func (repo *SQLiteRepository) RestorePR(ctx context.Context, pr *entity.PullRequest) error {
+	repo.logger.Debug("SQLITE_RESTORE_PR", "Restoring pull request",
+		"pr_id", pr.PullRequestID,
+		"status", pr.Status,
+		"version", pr.Version)
+
+	tx, err := repo.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			repo.logger.Error("SQLITE_RESTORE_PR", "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	var mergedAt sql.NullTime
+	if !pr.MergedAt.IsZero() {
+		mergedAt = sql.NullTime{Time: pr.MergedAt.UTC(), Valid: true}
+	}
+
+	prQuery := `
+		INSERT INTO pull_requests
+			(pull_request_id, pull_request_name, author_id, status, created_at, merged_at, version,
+			additions, deletions, changed_files, repository, base_branch, labels, paths, required_skills,
+			shadow_reviewer_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	labels, err := encodeStrings(pr.Metadata.Labels)
+	if err != nil {
+		return err
+	}
+	paths, err := encodeStrings(pr.Metadata.Paths)
+	if err != nil {
+		return err
+	}
+	requiredSkills, err := encodeStrings(pr.Metadata.RequiredSkills)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, prQuery,
+		pr.PullRequestID,
+		pr.PullRequestName,
+		pr.AuthorID,
+		string(pr.Status),
+		pr.CreatedAt.UTC(),
+		mergedAt,
+		pr.Version,
+		pr.Metadata.Additions,
+		pr.Metadata.Deletions,
+		pr.Metadata.ChangedFiles,
+		pr.Metadata.Repository,
+		pr.Metadata.BaseBranch,
+		labels,
+		paths,
+		requiredSkills,
+		nullString(pr.ShadowReviewer),
+	)
+	if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY) {
+		return entity.ErrPRExists
+	}
+	if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+		return fmt.Errorf("author %s: %w", pr.AuthorID, entity.ErrNoUser)
+	}
+	if err != nil {
+		return fmt.Errorf("restore pull request: %w", err)
+	}
+
+	if err := insertReviewers(ctx, tx, pr.PullRequestID, pr.AssignedReviewers); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	repo.logger.Info("SQLITE_RESTORE_PR", "Pull request restored successfully",
+		"pr_id", pr.PullRequestID,
+		"reviewers_count", len(pr.AssignedReviewers))
+	return nil
+}
+
+func (repo *SQLiteRepository) FindPRByID(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	prQuery := `
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, version,
+			additions, deletions, changed_files, repository, base_branch, labels, paths, required_skills,
+			shadow_reviewer_id
+		FROM pull_requests
+		WHERE pull_request_id = ?
+	`
+
+	var pr entity.PullRequest
+	var status, labels, paths, requiredSkills string
+	var mergedAt sql.NullTime
+	var shadowReviewer sql.NullString
+
+	err := repo.conn().QueryRowContext(ctx, prQuery, prID).Scan(
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.AuthorID,
+		&status,
+		&pr.CreatedAt,
+		&mergedAt,
+		&pr.Version,
+		&pr.Metadata.Additions,
+		&pr.Metadata.Deletions,
+		&pr.Metadata.ChangedFiles,
+		&pr.Metadata.Repository,
+		&pr.Metadata.BaseBranch,
+		&labels,
+		&paths,
+		&requiredSkills,
+		&shadowReviewer,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entity.ErrNoPR
+		}
+		repo.logger.Error("SQLITE_FIND_PR_BY_ID", "Failed to find pull request",
+			"pr_id", prID,
+			"error", err)
+		return nil, fmt.Errorf("find PR by ID: %w", err)
+	}
+
+	pr.Status = entity.PullRequestStatus(status)
+	if mergedAt.Valid {
+		pr.MergedAt = mergedAt.Time
+	}
+	pr.ShadowReviewer = shadowReviewer.String
+	if pr.Metadata.Labels, err = decodeStrings(labels); err != nil {
+		return nil, err
+	}
+	if pr.Metadata.Paths, err = decodeStrings(paths); err != nil {
+		return nil, err
+	}
+	if pr.Metadata.RequiredSkills, err = decodeStrings(requiredSkills); err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.conn().QueryContext(ctx,
+		`SELECT reviewer_id FROM pull_request_reviewers WHERE pull_request_id = ? ORDER BY reviewer_id`, prID)
+	if err != nil {
+		return nil, fmt.Errorf("query PR reviewers: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_FIND_PR_BY_ID", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var reviewerID string
+		if err := rows.Scan(&reviewerID); err != nil {
+			return nil, fmt.Errorf("scan reviewer row: %w", err)
+		}
+		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewer rows: %w", err)
+	}
+	return &pr, nil
+}
+
+// FindPRByIDForUpdate совпадает с FindPRByID: единственное соединение
+// сериализует транзакции WithTx, отдельная блокировка строки не нужна
+func (repo *SQLiteRepository) FindPRByIDForUpdate(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	return repo.FindPRByID(ctx, prID)
+}
+
+func (repo *SQLiteRepository) UpdatePR(ctx context.Context, pr *entity.PullRequest) error {
+	start := time.Now()
+
+	repo.logger.Debug("SQLITE_UPDATE_PR", "Updating pull request",
+		"pr_id", pr.PullRequestID,
+		"status", pr.Status,
+		"version", pr.Version)
+
+	tx, err := repo.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			repo.logger.Error("SQLITE_UPDATE_PR", "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	var mergedAt sql.NullTime
+	if !pr.MergedAt.IsZero() {
+		mergedAt = sql.NullTime{Time: pr.MergedAt.UTC(), Valid: true}
+	}
+
+	prQuery := `
+		UPDATE pull_requests
+		SET pull_request_name = ?, status = ?, merged_at = ?, shadow_reviewer_id = ?, version = version + 1
+		WHERE pull_request_id = ? AND version = ?
+		RETURNING version
+	`
+
+	var newVersion int
+	err = tx.QueryRowContext(ctx, prQuery, pr.PullRequestName, string(pr.Status), mergedAt, nullString(pr.ShadowReviewer),
+		pr.PullRequestID, pr.Version).Scan(&newVersion)
+	if err == sql.ErrNoRows {
+		// Отличаем отсутствующий PR от параллельного изменения
+		var exists bool
+		existsQuery := `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = ?)`
+		if err := tx.QueryRowContext(ctx, existsQuery, pr.PullRequestID).Scan(&exists); err != nil {
+			return fmt.Errorf("check PR existence: %w", err)
+		}
+		if !exists {
+			return entity.ErrNoPR
+		}
+		repo.logger.Warn("SQLITE_UPDATE_PR", "Pull request version conflict",
+			"pr_id", pr.PullRequestID,
+			"version", pr.Version,
+			"duration_ms", time.Since(start).Milliseconds())
+		return entity.ErrVersionConflict
+	}
+	if err != nil {
+		return fmt.Errorf("update PR: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM pull_request_reviewers WHERE pull_request_id = ?`, pr.PullRequestID); err != nil {
+		return fmt.Errorf("delete old reviewers: %w", err)
+	}
+	if err := insertReviewers(ctx, tx, pr.PullRequestID, pr.AssignedReviewers); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	pr.Version = newVersion
+
+	repo.logger.Info("SQLITE_UPDATE_PR", "Pull request updated successfully",
+		"pr_id", pr.PullRequestID,
+		"version", pr.Version,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (repo *SQLiteRepository) FindPRsByReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+	// Один запрос: все ревьюверы PR, в которых участвует пользователь,
+	// строки одного PR идут подряд
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status,
+			pr.created_at, pr.merged_at, pr.version, pr.additions, pr.deletions, pr.changed_files,
+			pr.repository, pr.base_branch, pr.labels, pr.paths, pr.required_skills, pr.shadow_reviewer_id,
+			prr.reviewer_id
+		FROM pull_requests pr
+		JOIN pull_request_reviewers prr ON pr.pull_request_id = prr.pull_request_id
+		WHERE pr.pull_request_id IN (
+			SELECT pull_request_id FROM pull_request_reviewers WHERE reviewer_id = ?
+		)
+		ORDER BY pr.created_at DESC, pr.pull_request_id, prr.reviewer_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query, userID)
+	if err != nil {
+		repo.logger.Error("SQLITE_FIND_PRS_BY_REVIEWER", "Failed to query PRs by reviewer",
+			"user_id", userID,
+			"error", err)
+		return nil, fmt.Errorf("find PRs by reviewer: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_FIND_PRS_BY_REVIEWER", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var prs []*entity.PullRequest
+	var current *entity.PullRequest
+	for rows.Next() {
+		var pr entity.PullRequest
+		var status, labels, paths, requiredSkills string
+		var mergedAt sql.NullTime
+		var shadowReviewer sql.NullString
+		var reviewerID string
+
+		if err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&status,
+			&pr.CreatedAt,
+			&mergedAt,
+			&pr.Version,
+			&pr.Metadata.Additions,
+			&pr.Metadata.Deletions,
+			&pr.Metadata.ChangedFiles,
+			&pr.Metadata.Repository,
+			&pr.Metadata.BaseBranch,
+			&labels,
+			&paths,
+			&requiredSkills,
+			&shadowReviewer,
+			&reviewerID,
+		); err != nil {
+			return nil, fmt.Errorf("scan PR row: %w", err)
+		}
+
+		if current == nil || current.PullRequestID != pr.PullRequestID {
+			pr.Status = entity.PullRequestStatus(status)
+			if mergedAt.Valid {
+				pr.MergedAt = mergedAt.Time
+			}
+			pr.ShadowReviewer = shadowReviewer.String
+			var err error
+			if pr.Metadata.Labels, err = decodeStrings(labels); err != nil {
+				return nil, err
+			}
+			if pr.Metadata.Paths, err = decodeStrings(paths); err != nil {
+				return nil, err
+			}
+			if pr.Metadata.RequiredSkills, err = decodeStrings(requiredSkills); err != nil {
+				return nil, err
+			}
+			current = &pr
+			prs = append(prs, current)
+		}
+		current.AssignedReviewers = append(current.AssignedReviewers, reviewerID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate PR rows: %w", err)
+	}
+	return prs, nil
+}
+
+func (repo *SQLiteRepository) ListPRs(ctx context.Context) ([]*entity.PullRequest, error) {
+	// LEFT JOIN оставляет PR без ревьюверов (reviewer_id = NULL), строки одного PR идут подряд
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status,
+			pr.created_at, pr.merged_at, pr.version, pr.additions, pr.deletions, pr.changed_files,
+			pr.repository, pr.base_branch, pr.labels, pr.paths, pr.required_skills, pr.shadow_reviewer_id,
+			prr.reviewer_id
+		FROM pull_requests pr
+		LEFT JOIN pull_request_reviewers prr ON pr.pull_request_id = prr.pull_request_id
+		ORDER BY pr.created_at, pr.pull_request_id, prr.reviewer_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query)
+	if err != nil {
+		repo.logger.Error("SQLITE_LIST_PRS", "Failed to query PRs", "error", err)
+		return nil, fmt.Errorf("list PRs: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_LIST_PRS", "failed to close sql rows", "error", err)
+		}
+	}()
+	return scanPRsWithReviewers(rows)
+}
+
+func (repo *SQLiteRepository) FindPRsByShadowReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status,
+			pr.created_at, pr.merged_at, pr.version, pr.additions, pr.deletions, pr.changed_files,
+			pr.repository, pr.base_branch, pr.labels, pr.paths, pr.required_skills, pr.shadow_reviewer_id,
+			prr.reviewer_id
+		FROM pull_requests pr
+		LEFT JOIN pull_request_reviewers prr ON pr.pull_request_id = prr.pull_request_id
+		WHERE pr.shadow_reviewer_id = ?
+		ORDER BY pr.created_at DESC, pr.pull_request_id, prr.reviewer_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query, userID)
+	if err != nil {
+		repo.logger.Error("SQLITE_FIND_PRS_BY_SHADOW_REVIEWER", "Failed to query PRs by shadow reviewer",
+			"user_id", userID,
+			"error", err)
+		return nil, fmt.Errorf("find PRs by shadow reviewer: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_FIND_PRS_BY_SHADOW_REVIEWER", "failed to close sql rows", "error", err)
+		}
+	}()
+	return scanPRsWithReviewers(rows)
+}
+
+// scanPRsWithReviewers собирает PR из строк LEFT JOIN с ревьюверами, где строки одного PR идут подряд
+func scanPRsWithReviewers(rows *sql.Rows) ([]*entity.PullRequest, error) {
+	var prs []*entity.PullRequest
+	var current *entity.PullRequest
+	for rows.Next() {
+		var pr entity.PullRequest
+		var status, labels, paths, requiredSkills string
+		var mergedAt sql.NullTime
+		var shadowReviewer, reviewerID sql.NullString
+
+		if err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&status,
+			&pr.CreatedAt,
+			&mergedAt,
+			&pr.Version,
+			&pr.Metadata.Additions,
+			&pr.Metadata.Deletions,
+			&pr.Metadata.ChangedFiles,
+			&pr.Metadata.Repository,
+			&pr.Metadata.BaseBranch,
+			&labels,
+			&paths,
+			&requiredSkills,
+			&shadowReviewer,
+			&reviewerID,
+		); err != nil {
+			return nil, fmt.Errorf("scan PR row: %w", err)
+		}
+
+		if current == nil || current.PullRequestID != pr.PullRequestID {
+			pr.Status = entity.PullRequestStatus(status)
+			if mergedAt.Valid {
+				pr.MergedAt = mergedAt.Time
+			}
+			pr.ShadowReviewer = shadowReviewer.String
+			var err error
+			if pr.Metadata.Labels, err = decodeStrings(labels); err != nil {
+				return nil, err
+			}
+			if pr.Metadata.Paths, err = decodeStrings(paths); err != nil {
+				return nil, err
+			}
+			if pr.Metadata.RequiredSkills, err = decodeStrings(requiredSkills); err != nil {
+				return nil, err
+			}
+			pr.AssignedReviewers = []string{}
+			current = &pr
+			prs = append(prs, current)
+		}
+		if reviewerID.Valid {
+			current.AssignedReviewers = append(current.AssignedReviewers, reviewerID.String)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate PR rows: %w", err)
+	}
+	return prs, nil
+}
+
+// Unavailability
+
+const unavailabilityColumns = `
+	unavailability_id, user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at, external_id
+`
+
+func (repo *SQLiteRepository) CreateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	repo.logger.Debug("SQLITE_CREATE_UNAVAILABILITY", "Creating unavailability period",
+		"user_id", period.UserID,
+		"starts_at", period.StartsAt,
+		"ends_at", period.EndsAt)
+
+	var reassignedAt sql.NullTime
+	if !period.ReassignedAt.IsZero() {
+		reassignedAt = sql.NullTime{Time: period.ReassignedAt.UTC(), Valid: true}
+	}
+
+	query := `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at, external_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING unavailability_id
+	`
+	err := repo.conn().QueryRowContext(ctx, query,
+		period.UserID,
+		period.StartsAt.UTC(),
+		period.EndsAt.UTC(),
+		period.Reason,
+		period.ReassignReviews,
+		reassignedAt,
+		period.ExternalID,
+	).Scan(&period.ID)
+	if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+		return fmt.Errorf("user %s: %w", period.UserID, entity.ErrNoUser)
+	}
+	if err != nil {
+		repo.logger.Error("SQLITE_CREATE_UNAVAILABILITY", "Failed to create unavailability period",
+			"user_id", period.UserID, "error", err)
+		return fmt.Errorf("create unavailability: %w", err)
+	}
+
+	repo.logger.Info("SQLITE_CREATE_UNAVAILABILITY", "Unavailability period created successfully",
+		"user_id", period.UserID, "unavailability_id", period.ID)
+	return nil
+}
+
+func (repo *SQLiteRepository) FindUnavailabilitiesByUser(ctx context.Context, userID string) ([]*entity.Unavailability, error) {
+	query := `SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		WHERE user_id = ?
+		ORDER BY starts_at, unavailability_id
+	`
+	return repo.queryUnavailabilities(ctx, "SQLITE_FIND_UNAVAILABILITIES_BY_USER", query, userID)
+}
+
+func (repo *SQLiteRepository) ListUnavailabilities(ctx context.Context) ([]*entity.Unavailability, error) {
+	query := `SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		ORDER BY user_id, starts_at, unavailability_id
+	`
+	return repo.queryUnavailabilities(ctx, "SQLITE_LIST_UNAVAILABILITIES", query)
+}
+
+func (repo *SQLiteRepository) FindPendingReassignments(ctx context.Context, at time.Time) ([]*entity.Unavailability, error) {
+	query := `SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		WHERE reassign_reviews AND reassigned_at IS NULL AND starts_at <= ? AND ends_at > ?
+		ORDER BY starts_at, unavailability_id
+	`
+	return repo.queryUnavailabilities(ctx, "SQLITE_FIND_PENDING_REASSIGNMENTS", query, at.UTC(), at.UTC())
+}
+
+func (repo *SQLiteRepository) queryUnavailabilities(ctx context.Context, operation, query string, args ...any) ([]*entity.Unavailability, error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		repo.logger.Error(operation, "Failed to query unavailability periods", "error", err)
+		return nil, fmt.Errorf("query unavailability: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error(operation, "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var periods []*entity.Unavailability
+	for rows.Next() {
+		var period entity.Unavailability
+		var reassignedAt sql.NullTime
+		if err := rows.Scan(
+			&period.ID,
+			&period.UserID,
+			&period.StartsAt,
+			&period.EndsAt,
+			&period.Reason,
+			&period.ReassignReviews,
+			&reassignedAt,
+			&period.ExternalID,
+		); err != nil {
+			return nil, fmt.Errorf("scan unavailability row: %w", err)
+		}
+		if reassignedAt.Valid {
+			period.ReassignedAt = reassignedAt.Time
+		}
+		periods = append(periods, &period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate unavailability rows: %w", err)
+	}
+	return periods, nil
+}
+
+func (repo *SQLiteRepository) DeleteUnavailability(ctx context.Context, id int64) error {
+	result, err := repo.conn().ExecContext(ctx, `DELETE FROM user_unavailability WHERE unavailability_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete unavailability: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return entity.ErrNoUnavailability
+	}
+	return nil
+}
+
+func (repo *SQLiteRepository) FindUnavailableUserIDs(ctx context.Context, teamName string, at time.Time) ([]string, error) {
+	query := `
+		SELECT DISTINCT uu.user_id
+		FROM user_unavailability uu
+		JOIN users u ON u.user_id = uu.user_id
+		JOIN teams t ON t.team_id = u.team_id
+		WHERE t.team_name = ? AND uu.starts_at <= ? AND uu.ends_at > ?
+		ORDER BY uu.user_id
+	`
+
+	rows, err := repo.conn().QueryContext(ctx, query, teamName, at.UTC(), at.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("query unavailable users: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_FIND_UNAVAILABLE_USER_IDS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("scan unavailable user row: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate unavailable user rows: %w", err)
+	}
+	return userIDs, nil
+}
+
+func (repo *SQLiteRepository) MarkUnavailabilityReassigned(ctx context.Context, id int64, at time.Time) error {
+	result, err := repo.conn().ExecContext(ctx,
+		`UPDATE user_unavailability SET reassigned_at = ? WHERE unavailability_id = ?`, at.UTC(), id)
+	if err != nil {
+		return fmt.Errorf("mark unavailability reassigned: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return entity.ErrNoUnavailability
+	}
+	return nil
+}
+
+func (repo *SQLiteRepository) UpdateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	repo.logger.Debug("SQLITE_UPDATE_UNAVAILABILITY", "Updating unavailability period",
+		"unavailability_id", period.ID,
+		"starts_at", period.StartsAt,
+		"ends_at", period.EndsAt)
+
+	var reassignedAt sql.NullTime
+	if !period.ReassignedAt.IsZero() {
+		reassignedAt = sql.NullTime{Time: period.ReassignedAt.UTC(), Valid: true}
+	}
+
+	query := `
+		UPDATE user_unavailability
+		SET starts_at = ?, ends_at = ?, reason = ?, reassign_reviews = ?, reassigned_at = ?, external_id = ?
+		WHERE unavailability_id = ?
+	`
+	result, err := repo.conn().ExecContext(ctx, query,
+		period.StartsAt.UTC(),
+		period.EndsAt.UTC(),
+		period.Reason,
+		period.ReassignReviews,
+		reassignedAt,
+		period.ExternalID,
+		period.ID,
+	)
+	if err != nil {
+		repo.logger.Error("SQLITE_UPDATE_UNAVAILABILITY", "Failed to update unavailability period",
+			"unavailability_id", period.ID, "error", err)
+		return fmt.Errorf("update unavailability: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return entity.ErrNoUnavailability
+	}
+	return nil
+}
+
+// Aliases
+
+func (repo *SQLiteRepository) SetUserAliases(ctx context.Context, userID string, aliases []string) error {
+	repo.logger.Debug("SQLITE_SET_USER_ALIASES", "Setting user aliases",
+		"user_id", userID,
+		"aliases_count", len(aliases))
+
+	tx, err := repo.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			repo.logger.Error("SQLITE_SET_USER_ALIASES", "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE user_id = ?)`, userID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("check user: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("user %s: %w", userID, entity.ErrNoUser)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_aliases WHERE user_id = ?`, userID); err != nil {
+		return fmt.Errorf("delete user aliases: %w", err)
+	}
+	for _, alias := range aliases {
+		_, err := tx.ExecContext(ctx, `INSERT INTO user_aliases (alias, user_id) VALUES (?, ?)`, alias, userID)
+		if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY) {
+			return fmt.Errorf("alias %s: %w", alias, entity.ErrAliasTaken)
+		}
+		if err != nil {
+			return fmt.Errorf("insert user alias: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	repo.logger.Info("SQLITE_SET_USER_ALIASES", "User aliases set successfully",
+		"user_id", userID, "aliases_count", len(aliases))
+	return nil
+}
+
+func (repo *SQLiteRepository) FindUserAliases(ctx context.Context, userID string) ([]string, error) {
+	aliases, err := repo.queryUserAliases(ctx,
+		`SELECT alias, user_id FROM user_aliases WHERE user_id = ? ORDER BY alias`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(aliases))
+	for i, alias := range aliases {
+		result[i] = alias.Alias
+	}
+	return result, nil
+}
+
+func (repo *SQLiteRepository) ListUserAliases(ctx context.Context) ([]entity.UserAlias, error) {
+	return repo.queryUserAliases(ctx, `SELECT alias, user_id FROM user_aliases ORDER BY user_id, alias`)
+}
+
+func (repo *SQLiteRepository) queryUserAliases(ctx context.Context, query string, args ...any) ([]entity.UserAlias, error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query user aliases: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_QUERY_USER_ALIASES", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var aliases []entity.UserAlias
+	for rows.Next() {
+		var alias entity.UserAlias
+		if err := rows.Scan(&alias.Alias, &alias.UserID); err != nil {
+			return nil, fmt.Errorf("scan user alias row: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user alias rows: %w", err)
+	}
+	return aliases, nil
+}
+
+func (repo *SQLiteRepository) FindUserIDByAlias(ctx context.Context, alias string) (string, error) {
+	var userID string
+	err := repo.conn().QueryRowContext(ctx, `SELECT user_id FROM user_aliases WHERE alias = ?`, alias).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("alias %s: %w", alias, entity.ErrNoUser)
+	}
+	if err != nil {
+		return "", fmt.Errorf("find user by alias: %w", err)
+	}
+	return userID, nil
+}
+
+// Work schedules
+
+func (repo *SQLiteRepository) SetWorkSchedule(ctx context.Context, schedule *entity.WorkSchedule) error {
+	repo.logger.Debug("SQLITE_SET_WORK_SCHEDULE", "Setting work schedule",
+		"user_id", schedule.UserID,
+		"time_zone", schedule.TimeZone)
+
+	query := `
+		INSERT INTO user_schedules (user_id, time_zone, start_minute, end_minute, work_days, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE
+		SET time_zone = excluded.time_zone,
+			start_minute = excluded.start_minute,
+			end_minute = excluded.end_minute,
+			work_days = excluded.work_days,
+			updated_at = excluded.updated_at
+	`
+	_, err := repo.conn().ExecContext(ctx, query,
+		schedule.UserID,
+		schedule.TimeZone,
+		schedule.StartMinute,
+		schedule.EndMinute,
+		entity.WorkDaysMask(schedule.Days),
+		time.Now().UTC(),
+	)
+	if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+		return fmt.Errorf("user %s: %w", schedule.UserID, entity.ErrNoUser)
+	}
+	if err != nil {
+		repo.logger.Error("SQLITE_SET_WORK_SCHEDULE", "Failed to set work schedule",
+			"user_id", schedule.UserID, "error", err)
+		return fmt.Errorf("set work schedule: %w", err)
+	}
+
+	repo.logger.Info("SQLITE_SET_WORK_SCHEDULE", "Work schedule set successfully",
+		"user_id", schedule.UserID)
+	return nil
+}
+
+const workScheduleColumns = `user_id, time_zone, start_minute, end_minute, work_days`
+
+func (repo *SQLiteRepository) FindWorkSchedule(ctx context.Context, userID string) (*entity.WorkSchedule, error) {
+	schedules, err := repo.queryWorkSchedules(ctx,
+		`SELECT `+workScheduleColumns+` FROM user_schedules WHERE user_id = ?`, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		return nil, fmt.Errorf("user %s: %w", userID, entity.ErrNoWorkSchedule)
+	}
+	return schedules[0], nil
+}
+
+func (repo *SQLiteRepository) FindWorkSchedulesByTeam(ctx context.Context, teamName string) ([]*entity.WorkSchedule, error) {
+	return repo.queryWorkSchedules(ctx, `
+		SELECT us.user_id, us.time_zone, us.start_minute, us.end_minute, us.work_days
+		FROM user_schedules us
+		JOIN users u ON u.user_id = us.user_id
+		JOIN teams t ON t.team_id = u.team_id
+		WHERE t.team_name = ?
+		ORDER BY us.user_id
+	`, teamName)
+}
+
+func (repo *SQLiteRepository) ListWorkSchedules(ctx context.Context) ([]*entity.WorkSchedule, error) {
+	return repo.queryWorkSchedules(ctx, `SELECT `+workScheduleColumns+` FROM user_schedules ORDER BY user_id`)
+}
+
+func (repo *SQLiteRepository) queryWorkSchedules(ctx context.Context, query string, args ...any) ([]*entity.WorkSchedule, error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query work schedules: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_QUERY_WORK_SCHEDULES", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var schedules []*entity.WorkSchedule
+	for rows.Next() {
+		var schedule entity.WorkSchedule
+		var days int
+		if err := rows.Scan(
+			&schedule.UserID,
+			&schedule.TimeZone,
+			&schedule.StartMinute,
+			&schedule.EndMinute,
+			&days,
+		); err != nil {
+			return nil, fmt.Errorf("scan work schedule row: %w", err)
+		}
+		schedule.Days = entity.WorkDaysFromMask(days)
+		schedules = append(schedules, &schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate work schedule rows: %w", err)
+	}
+	return schedules, nil
+}
+
+func (repo *SQLiteRepository) DeleteWorkSchedule(ctx context.Context, userID string) error {
+	result, err := repo.conn().ExecContext(ctx, `DELETE FROM user_schedules WHERE user_id = ?`, userID)
+	if err != nil {
+		return fmt.Errorf("delete work schedule: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("user %s: %w", userID, entity.ErrNoWorkSchedule)
+	}
+	return nil
+}
+
+// Skills
+
+func (repo *SQLiteRepository) SetUserSkills(ctx context.Context, userID string, skills []entity.UserSkill) error {
+	repo.logger.Debug("SQLITE_SET_USER_SKILLS", "Setting user skills",
+		"user_id", userID,
+		"skills_count", len(skills))
+
+	tx, err := repo.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			repo.logger.Error("SQLITE_SET_USER_SKILLS", "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE user_id = ?)`, userID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("check user: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("user %s: %w", userID, entity.ErrNoUser)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_skills WHERE user_id = ?`, userID); err != nil {
+		return fmt.Errorf("delete user skills: %w", err)
+	}
+	for _, skill := range skills {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO user_skills (user_id, tag, level) VALUES (?, ?, ?)`, userID, skill.Tag, skill.Level)
+		if err != nil {
+			return fmt.Errorf("insert user skill: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	repo.logger.Info("SQLITE_SET_USER_SKILLS", "User skills set successfully",
+		"user_id", userID, "skills_count", len(skills))
+	return nil
+}
+
+func (repo *SQLiteRepository) FindUserSkills(ctx context.Context, userID string) ([]entity.UserSkill, error) {
+	return repo.queryUserSkills(ctx, `
+		SELECT user_id, tag, level FROM user_skills WHERE user_id = ? ORDER BY tag
+	`, userID)
+}
+
+func (repo *SQLiteRepository) FindSkillsByTeam(ctx context.Context, teamName string) ([]entity.UserSkill, error) {
+	return repo.queryUserSkills(ctx, `
+		SELECT us.user_id, us.tag, us.level
+		FROM user_skills us
+		JOIN users u ON u.user_id = us.user_id
+		JOIN teams t ON t.team_id = u.team_id
+		WHERE t.team_name = ?
+		ORDER BY us.user_id, us.tag
+	`, teamName)
+}
+
+func (repo *SQLiteRepository) ListUserSkills(ctx context.Context) ([]entity.UserSkill, error) {
+	return repo.queryUserSkills(ctx, `
+		SELECT user_id, tag, level FROM user_skills ORDER BY user_id, tag
+	`)
+}
+
+func (repo *SQLiteRepository) queryUserSkills(ctx context.Context, query string, args ...any) ([]entity.UserSkill, error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query user skills: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_QUERY_USER_SKILLS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var skills []entity.UserSkill
+	for rows.Next() {
+		var skill entity.UserSkill
+		if err := rows.Scan(&skill.UserID, &skill.Tag, &skill.Level); err != nil {
+			return nil, fmt.Errorf("scan user skill row: %w", err)
+		}
+		skills = append(skills, skill)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user skill rows: %w", err)
+	}
+	return skills, nil
+}
+
+// Code owners
+
+func (repo *SQLiteRepository) SetCodeOwners(ctx context.Context, owners *entity.CodeOwners) error {
+	repo.logger.Debug("SQLITE_SET_CODE_OWNERS", "Setting code owners",
+		"repository", owners.Repository,
+		"rules_count", len(owners.Rules))
+
+	tx, err := repo.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			repo.logger.Error("SQLITE_SET_CODE_OWNERS", "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM code_owner_rules WHERE repository = ?`, owners.Repository); err != nil {
+		return fmt.Errorf("delete code owner rules: %w", err)
+	}
+	for i, rule := range owners.Rules {
+		users, err := encodeStrings(rule.Users)
+		if err != nil {
+			return err
+		}
+		teams, err := encodeStrings(rule.Teams)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO code_owner_rules (repository, position, line, pattern, user_owners, team_owners)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, owners.Repository, i, rule.Line, rule.Pattern, users, teams)
+		if err != nil {
+			repo.logger.Error("SQLITE_SET_CODE_OWNERS", "Failed to insert code owner rule",
+				"repository", owners.Repository,
+				"line", rule.Line,
+				"error", err)
+			return fmt.Errorf("insert code owner rule: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	repo.logger.Info("SQLITE_SET_CODE_OWNERS", "Code owners set successfully",
+		"repository", owners.Repository, "rules_count", len(owners.Rules))
+	return nil
+}
+
+const codeOwnerRuleColumns = `repository, line, pattern, user_owners, team_owners`
+
+func (repo *SQLiteRepository) FindCodeOwners(ctx context.Context, repositoryName string) (*entity.CodeOwners, error) {
+	owners, err := repo.queryCodeOwners(ctx, `
+		SELECT `+codeOwnerRuleColumns+` FROM code_owner_rules WHERE repository = ? ORDER BY position
+	`, repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	if len(owners) == 0 {
+		return nil, fmt.Errorf("repository %s: %w", repositoryName, entity.ErrNoCodeOwners)
+	}
+	return owners[0], nil
+}
+
+func (repo *SQLiteRepository) ListCodeOwners(ctx context.Context) ([]*entity.CodeOwners, error) {
+	return repo.queryCodeOwners(ctx, `
+		SELECT `+codeOwnerRuleColumns+` FROM code_owner_rules ORDER BY repository, position
+	`)
+}
+
+// queryCodeOwners собирает правила, упорядоченные по репозиторию, в CodeOwners
+func (repo *SQLiteRepository) queryCodeOwners(ctx context.Context, query string, args ...any) ([]*entity.CodeOwners, error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query code owners: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_QUERY_CODE_OWNERS", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var owners []*entity.CodeOwners
+	for rows.Next() {
+		var repositoryName, users, teams string
+		var rule entity.CodeOwnerRule
+		if err := rows.Scan(&repositoryName, &rule.Line, &rule.Pattern, &users, &teams); err != nil {
+			return nil, fmt.Errorf("scan code owner rule row: %w", err)
+		}
+		if rule.Users, err = decodeStrings(users); err != nil {
+			return nil, err
+		}
+		if rule.Teams, err = decodeStrings(teams); err != nil {
+			return nil, err
+		}
+
+		if len(owners) == 0 || owners[len(owners)-1].Repository != repositoryName {
+			owners = append(owners, &entity.CodeOwners{Repository: repositoryName})
+		}
+		current := owners[len(owners)-1]
+		current.Rules = append(current.Rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate code owner rule rows: %w", err)
+	}
+	return owners, nil
+}
+
+func (repo *SQLiteRepository) DeleteCodeOwners(ctx context.Context, repositoryName string) error {
+	result, err := repo.conn().ExecContext(ctx, `DELETE FROM code_owner_rules WHERE repository = ?`, repositoryName)
+	if err != nil {
+		return fmt.Errorf("delete code owners: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("repository %s: %w", repositoryName, entity.ErrNoCodeOwners)
+	}
+	return nil
+}
+
+// Reviewer rules
+
+func (repo *SQLiteRepository) SetReviewerRule(ctx context.Context, rule *entity.ReviewerRule) error {
+	repo.logger.Debug("SQLITE_SET_REVIEWER_RULE", "Setting reviewer rule",
+		"author_id", rule.AuthorID,
+		"reviewer_id", rule.ReviewerID,
+		"kind", rule.Kind)
+
+	query := `
+		INSERT INTO reviewer_rules (author_id, reviewer_id, kind, reason)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (author_id, reviewer_id) DO UPDATE
+		SET kind = excluded.kind,
+			reason = excluded.reason
+	`
+	_, err := repo.conn().ExecContext(ctx, query, rule.AuthorID, rule.ReviewerID, string(rule.Kind), rule.Reason)
+	if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+		return fmt.Errorf("author %s or reviewer %s: %w", rule.AuthorID, rule.ReviewerID, entity.ErrNoUser)
+	}
+	if err != nil {
+		repo.logger.Error("SQLITE_SET_REVIEWER_RULE", "Failed to set reviewer rule",
+			"author_id", rule.AuthorID, "reviewer_id", rule.ReviewerID, "error", err)
+		return fmt.Errorf("set reviewer rule: %w", err)
+	}
+
+	repo.logger.Info("SQLITE_SET_REVIEWER_RULE", "Reviewer rule set successfully",
+		"author_id", rule.AuthorID,
+		"reviewer_id", rule.ReviewerID)
+	return nil
+}
+
+func (repo *SQLiteRepository) FindReviewerRulesByAuthor(ctx context.Context, authorID string) ([]entity.ReviewerRule, error) {
+	return repo.queryReviewerRules(ctx, `
+		SELECT author_id, reviewer_id, kind, reason FROM reviewer_rules WHERE author_id = ? ORDER BY reviewer_id
+	`, authorID)
+}
+
+func (repo *SQLiteRepository) ListReviewerRules(ctx context.Context) ([]entity.ReviewerRule, error) {
+	return repo.queryReviewerRules(ctx, `
+		SELECT author_id, reviewer_id, kind, reason FROM reviewer_rules ORDER BY author_id, reviewer_id
+	`)
+}
+
+func (repo *SQLiteRepository) queryReviewerRules(ctx context.Context, query string, args ...any) ([]entity.ReviewerRule, error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query reviewer rules: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			repo.logger.Error("SQLITE_QUERY_REVIEWER_RULES", "failed to close sql rows", "error", err)
+		}
+	}()
+
+	var rules []entity.ReviewerRule
+	for rows.Next() {
+		var rule entity.ReviewerRule
+		var kind string
+		if err := rows.Scan(&rule.AuthorID, &rule.ReviewerID, &kind, &rule.Reason); err != nil {
+			return nil, fmt.Errorf("scan reviewer rule row: %w", err)
+		}
+		rule.Kind = entity.ReviewerRuleKind(kind)
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewer rule rows: %w", err)
+	}
+	return rules, nil
+}
+
+func (repo *SQLiteRepository) DeleteReviewerRule(ctx context.Context, authorID, reviewerID string) error {
+	result, err := repo.conn().ExecContext(ctx,
+		`DELETE FROM reviewer_rules WHERE author_id = ? AND reviewer_id = ?`, authorID, reviewerID)
+	if err != nil {
+		return fmt.Errorf("delete reviewer rule: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("author %s, reviewer %s: %w", authorID, reviewerID, entity.ErrNoReviewerRule)
+	}
+	return nil
+}
+
+// Idempotency keys
+
+func (repo *SQLiteRepository) FindIdempotencyRecord(ctx context.Context, key, endpoint string) (*entity.IdempotencyRecord, error) {
+	query := `
+		SELECT idempotency_key, endpoint, request_hash, status_code, response_headers,
+			response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE idempotency_key = ? AND endpoint = ? AND expires_at > ?
+	`
+
+	var record entity.IdempotencyRecord
+	var headers string
+	err := repo.conn().QueryRowContext(ctx, query, key, endpoint, time.Now().UTC()).Scan(
+		&record.Key,
+		&record.Endpoint,
+		&record.RequestHash,
+		&record.StatusCode,
+		&headers,
+		&record.ResponseBody,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find idempotency record: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(headers), &record.ResponseHeaders); err != nil {
+		return nil, fmt.Errorf("decode idempotency record headers: %w", err)
+	}
+	return &record, nil
+}
+
+func (repo *SQLiteRepository) ReserveIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (idempotency_key, endpoint, request_hash, status_code,
+			response_headers, response_body, created_at, expires_at)
+		VALUES (?, ?, ?, ?, '{}', ?, ?, ?)
+		ON CONFLICT (idempotency_key, endpoint) DO NOTHING
+	`
+
+	result, err := repo.conn().ExecContext(ctx, query,
+		record.Key,
+		record.Endpoint,
+		record.RequestHash,
+		record.StatusCode,
+		[]byte{},
+		record.CreatedAt.UTC(),
+		record.ExpiresAt.UTC(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("reserve idempotency key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+func (repo *SQLiteRepository) CompleteIdempotencyRecord(ctx context.Context, record *entity.IdempotencyRecord) error {
+	headers, err := json.Marshal(record.ResponseHeaders)
+	if err != nil {
+		return fmt.Errorf("encode idempotency record headers: %w", err)
+	}
+
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = ?, response_headers = ?, response_body = ?, created_at = ?, expires_at = ?
+		WHERE idempotency_key = ? AND endpoint = ? AND request_hash = ?
+	`
+
+	_, err = repo.conn().ExecContext(ctx, query,
+		record.StatusCode,
+		string(headers),
+		record.ResponseBody,
+		record.CreatedAt.UTC(),
+		record.ExpiresAt.UTC(),
+		record.Key,
+		record.Endpoint,
+		record.RequestHash,
+	)
+	if err != nil {
+		return fmt.Errorf("complete idempotency record: %w", err)
+	}
+	return nil
+}
+
+func (repo *SQLiteRepository) ReleaseIdempotencyKey(ctx context.Context, key, endpoint string) error {
+	_, err := repo.conn().ExecContext(ctx,
+		`DELETE FROM idempotency_keys WHERE idempotency_key = ? AND endpoint = ? AND status_code = 0`,
+		key, endpoint)
+	if err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (repo *SQLiteRepository) DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error) {
+	result, err := repo.conn().ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= ?`, now.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency records: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get rows affected: %w", err)
+	}
+	return deleted, nil
+}
+
+// Вспомогательные функции
+
+func (repo *SQLiteRepository) getTeamIDByName(ctx context.Context, teamName string) (int64, error) {
+	var teamID int64
+	err := repo.conn().QueryRowContext(ctx, `SELECT team_id FROM teams WHERE team_name = ?`, teamName).Scan(&teamID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, entity.ErrNoTeam
+		}
+		return 0, fmt.Errorf("get team ID by name: %w", err)
+	}
+	return teamID, nil
+}
+
+func insertReviewers(ctx context.Context, tx *txScope, prID string, reviewers []string) error {
+	query := `INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id) VALUES (?, ?)`
+	for _, reviewerID := range reviewers {
+		_, err := tx.ExecContext(ctx, query, prID, reviewerID)
+		if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+			return fmt.Errorf("reviewer %s: %w", reviewerID, entity.ErrNoUser)
+		}
+		if err != nil {
+			return fmt.Errorf("add reviewer %s to PR: %w", reviewerID, err)
+		}
+	}
+	return nil
+}
+
+// encodeStrings упаковывает список строк в JSON-массив: так хранятся метки, пути и навыки PR
+// и владельцы правил CODEOWNERS
+func encodeStrings(values []string) (string, error) {
+	if len(values) == 0 {
+		return "[]", nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("encode strings: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeStrings распаковывает JSON-массив строк, пустой массив - nil
+func decodeStrings(data string) ([]string, error) {
+	var values []string
+	if err := json.Unmarshal([]byte(data), &values); err != nil {
+		return nil, fmt.Errorf("decode strings: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values, nil
+}
+
+// nullString - пустая строка хранится как NULL, например у PR без теневого ревьювера
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func isConstraintError(err error, code int) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == code
+}
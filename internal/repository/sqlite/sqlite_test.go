@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+	"github.com/pozedorum/set_pr_reviers_service/internal/repository/repositorytest"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCtx = context.Background()
+
+func newTestLogger(t *testing.T) interfaces.Logger {
+	t.Helper()
+	testLogger, err := logger.NewLogger("pr-service-test", "logger_for_tests")
+	require.NoError(t, err)
+	return testLogger
+}
+
+// newTestRepo создаёт отдельную базу в файле во временном каталоге теста
+func newTestRepo(t *testing.T) interfaces.Repository {
+	t.Helper()
+	repo, err := NewSQLiteRepository(filepath.Join(t.TempDir(), "test.db"), newTestLogger(t))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+func TestSQLiteRepository_Contract(t *testing.T) {
+	repositorytest.Run(t, newTestRepo)
+}
+
+func TestSQLiteRepository_InMemoryContract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) interfaces.Repository {
+		repo, err := NewSQLiteRepository(":memory:", newTestLogger(t))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = repo.Close() })
+		return repo
+	})
+}
+
+func TestNewSQLiteRepository_ReopenKeepsData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	repo, err := NewSQLiteRepository(path, newTestLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateTeam(testCtx, &entity.Team{
+		TeamName: "backend",
+		Members:  []entity.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}))
+	require.NoError(t, repo.Close())
+
+	// Повторное открытие не применяет схему заново
+	repo, err = NewSQLiteRepository(path, newTestLogger(t))
+	require.NoError(t, err)
+	defer func() { _ = repo.Close() }()
+
+	assert.True(t, repo.TeamExists(testCtx, "backend"))
+}
@@ -13,7 +13,8 @@ func NewAPIAdapter(server *PRServer) generated.ServerInterface {
 	return &APIAdapter{server: server}
 }
 
-func (a *APIAdapter) PostTeamAdd(c *gin.Context) {
+// PostTeamAdd: Idempotency-Key из params уже обработан idempotencyMiddleware
+func (a *APIAdapter) PostTeamAdd(c *gin.Context, _ generated.PostTeamAddParams) {
 	a.server.handleCreateTeam(c)
 }
 
@@ -22,23 +23,164 @@ func (a *APIAdapter) GetTeamGet(c *gin.Context, params generated.GetTeamGetParam
 	a.server.handleGetTeam(c)
 }
 
+func (a *APIAdapter) PostTeamSync(c *gin.Context, params generated.PostTeamSyncParams) {
+	c.Set("dry_run", params.DryRun != nil && *params.DryRun)
+	a.server.handleSyncTeams(c)
+}
+
 func (a *APIAdapter) PostUsersSetIsActive(c *gin.Context) {
 	a.server.handleSetUserActive(c)
 }
 
+func (a *APIAdapter) PostUsersSetMaxOpenReviews(c *gin.Context) {
+	a.server.handleSetUserMaxOpenReviews(c)
+}
+
+func (a *APIAdapter) PostTeamSetMaxOpenReviews(c *gin.Context) {
+	a.server.handleSetTeamMaxOpenReviews(c)
+}
+
+func (a *APIAdapter) PostUsersSetLevel(c *gin.Context) {
+	a.server.handleSetUserLevel(c)
+}
+
+func (a *APIAdapter) PostUsersSetLearner(c *gin.Context) {
+	a.server.handleSetUserLearner(c)
+}
+
+func (a *APIAdapter) PostTeamSetReviewPolicy(c *gin.Context) {
+	a.server.handleSetTeamReviewPolicy(c)
+}
+
 func (a *APIAdapter) GetUsersGetReview(c *gin.Context, params generated.GetUsersGetReviewParams) {
 	c.Set("user_id", params.UserId)
 	a.server.handleGetUserReviews(c)
 }
 
-func (a *APIAdapter) PostPullRequestCreate(c *gin.Context) {
+func (a *APIAdapter) GetUsersUnavailability(c *gin.Context, params generated.GetUsersUnavailabilityParams) {
+	c.Set("user_id", params.UserId)
+	a.server.handleGetUnavailability(c)
+}
+
+func (a *APIAdapter) PostUsersUnavailability(c *gin.Context) {
+	a.server.handleAddUnavailability(c)
+}
+
+func (a *APIAdapter) DeleteUsersUnavailability(c *gin.Context, params generated.DeleteUsersUnavailabilityParams) {
+	c.Set("unavailability_id", params.Id)
+	a.server.handleDeleteUnavailability(c)
+}
+
+func (a *APIAdapter) PostUsersUnavailabilityImport(c *gin.Context, params generated.PostUsersUnavailabilityImportParams) {
+	if params.Category != nil {
+		c.Set("category", *params.Category)
+	}
+	c.Set("reassign_reviews", params.ReassignReviews != nil && *params.ReassignReviews)
+	a.server.handleImportCalendar(c)
+}
+
+func (a *APIAdapter) GetUsersAliases(c *gin.Context, params generated.GetUsersAliasesParams) {
+	c.Set("user_id", params.UserId)
+	a.server.handleGetUserAliases(c)
+}
+
+func (a *APIAdapter) PutUsersAliases(c *gin.Context) {
+	a.server.handleSetUserAliases(c)
+}
+
+func (a *APIAdapter) GetUsersSkills(c *gin.Context, params generated.GetUsersSkillsParams) {
+	c.Set("user_id", params.UserId)
+	a.server.handleGetUserSkills(c)
+}
+
+func (a *APIAdapter) PutUsersSkills(c *gin.Context) {
+	a.server.handleSetUserSkills(c)
+}
+
+func (a *APIAdapter) GetUsersSchedule(c *gin.Context, params generated.GetUsersScheduleParams) {
+	c.Set("user_id", params.UserId)
+	a.server.handleGetWorkSchedule(c)
+}
+
+func (a *APIAdapter) PutUsersSchedule(c *gin.Context) {
+	a.server.handleSetWorkSchedule(c)
+}
+
+func (a *APIAdapter) DeleteUsersSchedule(c *gin.Context, params generated.DeleteUsersScheduleParams) {
+	c.Set("user_id", params.UserId)
+	a.server.handleDeleteWorkSchedule(c)
+}
+
+func (a *APIAdapter) GetCodeOwners(c *gin.Context, params generated.GetCodeOwnersParams) {
+	c.Set("repository", params.Repository)
+	a.server.handleGetCodeOwners(c)
+}
+
+func (a *APIAdapter) PutCodeOwners(c *gin.Context, params generated.PutCodeOwnersParams) {
+	c.Set("repository", params.Repository)
+	a.server.handleSetCodeOwners(c)
+}
+
+func (a *APIAdapter) DeleteCodeOwners(c *gin.Context, params generated.DeleteCodeOwnersParams) {
+	c.Set("repository", params.Repository)
+	a.server.handleDeleteCodeOwners(c)
+}
+
+func (a *APIAdapter) GetReviewerRules(c *gin.Context, params generated.GetReviewerRulesParams) {
+	if params.AuthorId != nil {
+		c.Set("author_id", *params.AuthorId)
+	}
+	a.server.handleGetReviewerRules(c)
+}
+
+func (a *APIAdapter) PutReviewerRules(c *gin.Context) {
+	a.server.handleSetReviewerRule(c)
+}
+
+func (a *APIAdapter) DeleteReviewerRules(c *gin.Context, params generated.DeleteReviewerRulesParams) {
+	c.Set("author_id", params.AuthorId)
+	c.Set("reviewer_id", params.ReviewerId)
+	a.server.handleDeleteReviewerRule(c)
+}
+
+func (a *APIAdapter) PostPullRequestCreate(c *gin.Context, _ generated.PostPullRequestCreateParams) {
 	a.server.handleCreatePR(c)
 }
 
-func (a *APIAdapter) PostPullRequestMerge(c *gin.Context) {
+func (a *APIAdapter) PostPullRequestPreview(c *gin.Context) {
+	a.server.handlePreviewPR(c)
+}
+
+func (a *APIAdapter) PostPullRequestMerge(c *gin.Context, params generated.PostPullRequestMergeParams) {
+	if params.IfMatch != nil {
+		c.Set("if_match", *params.IfMatch)
+	}
 	a.server.handleMergePR(c)
 }
 
-func (a *APIAdapter) PostPullRequestReassign(c *gin.Context) {
+func (a *APIAdapter) PostPullRequestReassign(c *gin.Context, params generated.PostPullRequestReassignParams) {
+	if params.IfMatch != nil {
+		c.Set("if_match", *params.IfMatch)
+	}
 	a.server.handleReassignReviewer(c)
 }
+
+func (a *APIAdapter) PostImportUsersCsv(c *gin.Context) {
+	a.server.handleImportUsers(c)
+}
+
+func (a *APIAdapter) GetExportUsersCsv(c *gin.Context) {
+	a.server.handleExportUsers(c)
+}
+
+func (a *APIAdapter) GetExportPullRequestsCsv(c *gin.Context) {
+	a.server.handleExportPRs(c)
+}
+
+func (a *APIAdapter) GetAdminSnapshot(c *gin.Context) {
+	a.server.handleExportSnapshot(c)
+}
+
+func (a *APIAdapter) PostAdminRestore(c *gin.Context) {
+	a.server.handleRestoreSnapshot(c)
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+)
+
+func (s *PRServer) handleSetUserMaxOpenReviews(c *gin.Context) {
+	var request struct {
+		UserID         string `json:"user_id"`
+		MaxOpenReviews int    `json:"max_open_reviews"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	user, err := s.serv.SetUserMaxOpenReviews(c.Request.Context(), request.UserID, request.MaxOpenReviews)
+	if err != nil {
+		s.logger.Error("SET_MAX_OPEN_REVIEWS_ERROR", "Failed to set user review limit",
+			"error", err, "user_id", request.UserID)
+
+		switch err {
+		case service.ErrEmptyUserID, service.ErrInvalidReviewLimit:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_REVIEW_LIMIT",
+				"message": err.Error(),
+			}})
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": entityUserToGenerated(*user)})
+}
+
+func (s *PRServer) handleSetTeamMaxOpenReviews(c *gin.Context) {
+	var request struct {
+		TeamName       string `json:"team_name"`
+		MaxOpenReviews int    `json:"max_open_reviews"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	team, err := s.serv.SetTeamMaxOpenReviews(c.Request.Context(), request.TeamName, request.MaxOpenReviews)
+	if err != nil {
+		s.logger.Error("SET_TEAM_MAX_OPEN_REVIEWS_ERROR", "Failed to set team review limit",
+			"error", err, "team_name", request.TeamName)
+
+		switch err {
+		case service.ErrEmptyTeamName, service.ErrInvalidReviewLimit:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_REVIEW_LIMIT",
+				"message": err.Error(),
+			}})
+		case service.ErrNoTeam:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team": entityTeamToGenerated(*team)})
+}
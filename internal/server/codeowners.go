@@ -0,0 +1,114 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/codeowners"
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+)
+
+func (s *PRServer) handleGetCodeOwners(c *gin.Context) {
+	repository := getRepositoryFromContext(c)
+	if repository == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "repository parameter is required"})
+		return
+	}
+
+	owners, err := s.serv.GetCodeOwners(c.Request.Context(), repository)
+	if err != nil {
+		s.logger.Error("GET_CODE_OWNERS_ERROR", "Failed to get code owners",
+			"error", err, "repository", repository)
+
+		switch err {
+		case service.ErrNoCodeOwners:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entityCodeOwnersToGenerated(*owners))
+}
+
+func (s *PRServer) handleSetCodeOwners(c *gin.Context) {
+	repository := getRepositoryFromContext(c)
+	if repository == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "repository parameter is required"})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxImportSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if len(body) > maxImportSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+			"code":    "INVALID_CODEOWNERS",
+			"message": fmt.Sprintf("file is larger than %d bytes", maxImportSize),
+		}})
+		return
+	}
+
+	rules, err := codeowners.Parse(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+			"code":    "INVALID_CODEOWNERS",
+			"message": err.Error(),
+		}})
+		return
+	}
+
+	owners := entity.CodeOwners{Repository: repository, Rules: rules}
+	if err := s.serv.SetCodeOwners(c.Request.Context(), &owners); err != nil {
+		s.logger.Error("SET_CODE_OWNERS_ERROR", "Failed to set code owners",
+			"error", err, "repository", repository)
+
+		switch err {
+		case service.ErrEmptyRepository, service.ErrInvalidCodeOwners:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_CODEOWNERS",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entityCodeOwnersToGenerated(owners))
+}
+
+func (s *PRServer) handleDeleteCodeOwners(c *gin.Context) {
+	repository := getRepositoryFromContext(c)
+	if repository == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "repository parameter is required"})
+		return
+	}
+
+	if err := s.serv.DeleteCodeOwners(c.Request.Context(), repository); err != nil {
+		s.logger.Error("DELETE_CODE_OWNERS_ERROR", "Failed to delete code owners",
+			"error", err, "repository", repository)
+
+		switch err {
+		case service.ErrNoCodeOwners:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,6 +1,9 @@
 package server
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
 	"github.com/pozedorum/set_pr_reviers_service/internal/generated"
 )
@@ -10,29 +13,229 @@ func generatedTeamToEntity(gTeam generated.Team) entity.Team {
 	members := make([]entity.TeamMember, len(gTeam.Members))
 	for i, m := range gTeam.Members {
 		members[i] = entity.TeamMember{
-			UserID:   m.UserId,
-			Username: m.Username,
-			IsActive: m.IsActive,
+			UserID:         m.UserId,
+			Username:       m.Username,
+			IsActive:       m.IsActive,
+			MaxOpenReviews: valueOrZero(m.MaxOpenReviews),
+			Level:          generatedUserLevelToEntity(m.Level),
+			IsLearner:      m.IsLearner != nil && *m.IsLearner,
 		}
 	}
 
-	return entity.Team{
-		TeamName: gTeam.TeamName,
-		Members:  members,
+	team := entity.Team{
+		TeamName:       gTeam.TeamName,
+		Members:        members,
+		MaxOpenReviews: valueOrZero(gTeam.MaxOpenReviews),
+	}
+	if gTeam.ReviewPolicy != nil {
+		team.ReviewPolicy = entity.ReviewPolicy{
+			RequireSenior:    gTeam.ReviewPolicy.RequireSenior != nil && *gTeam.ReviewPolicy.RequireSenior,
+			SeniorForJuniors: gTeam.ReviewPolicy.SeniorForJuniors != nil && *gTeam.ReviewPolicy.SeniorForJuniors,
+		}
 	}
+	return team
 }
 
-// func generatedPRToEntity(gPR generated.PullRequest) entity.PullRequest {
-// 	return entity.PullRequest{
-// 		PullRequestID:     gPR.PullRequestId,
-// 		PullRequestName:   gPR.PullRequestName,
-// 		AuthorID:          gPR.AuthorId,
-// 		Status:            entity.PullRequestStatus(gPR.Status),
-// 		AssignedReviewers: gPR.AssignedReviewers,
-// 		CreatedAt:         *gPR.CreatedAt,
-// 		MergedAt:          *gPR.MergedAt,
-// 	}
-// }
+func generatedUserLevelToEntity(level *generated.UserLevel) entity.UserLevel {
+	if level == nil {
+		return ""
+	}
+	return entity.UserLevel(*level)
+}
+
+func generatedPRToEntity(gPR generated.PullRequest) entity.PullRequest {
+	pr := entity.PullRequest{
+		PullRequestID:     gPR.PullRequestId,
+		PullRequestName:   gPR.PullRequestName,
+		AuthorID:          gPR.AuthorId,
+		Status:            entity.PullRequestStatus(gPR.Status),
+		AssignedReviewers: gPR.AssignedReviewers,
+	}
+	if gPR.ShadowReviewer != nil {
+		pr.ShadowReviewer = *gPR.ShadowReviewer
+	}
+	if gPR.CreatedAt != nil {
+		pr.CreatedAt = *gPR.CreatedAt
+	}
+	if gPR.MergedAt != nil {
+		pr.MergedAt = *gPR.MergedAt
+	}
+	if gPR.Version != nil {
+		pr.Version = *gPR.Version
+	}
+	pr.Metadata = generatedPRMetadataToEntity(gPR.Metadata)
+	return pr
+}
+
+func generatedPRMetadataToEntity(gMetadata *generated.PRMetadata) entity.PRMetadata {
+	if gMetadata == nil {
+		return entity.PRMetadata{}
+	}
+	metadata := entity.PRMetadata{
+		Additions:    valueOrZero(gMetadata.Additions),
+		Deletions:    valueOrZero(gMetadata.Deletions),
+		ChangedFiles: valueOrZero(gMetadata.ChangedFiles),
+	}
+	if gMetadata.Repository != nil {
+		metadata.Repository = *gMetadata.Repository
+	}
+	if gMetadata.BaseBranch != nil {
+		metadata.BaseBranch = *gMetadata.BaseBranch
+	}
+	if gMetadata.Labels != nil {
+		metadata.Labels = *gMetadata.Labels
+	}
+	if gMetadata.Paths != nil {
+		metadata.Paths = *gMetadata.Paths
+	}
+	if gMetadata.RequiredSkills != nil {
+		metadata.RequiredSkills = *gMetadata.RequiredSkills
+	}
+	return metadata
+}
+
+func generatedSnapshotToEntity(gSnap generated.Snapshot) entity.Snapshot {
+	snap := entity.Snapshot{
+		FormatVersion: gSnap.FormatVersion,
+		CreatedAt:     gSnap.CreatedAt,
+		Teams:         make([]entity.Team, len(gSnap.Teams)),
+		PullRequests:  make([]entity.PullRequest, len(gSnap.PullRequests)),
+	}
+	for i, team := range gSnap.Teams {
+		snap.Teams[i] = generatedTeamToEntity(team)
+	}
+	for i, pr := range gSnap.PullRequests {
+		snap.PullRequests[i] = generatedPRToEntity(pr)
+	}
+	// Снапшоты до появления периодов недоступности не содержат поля unavailability
+	if gSnap.Unavailability != nil {
+		for _, period := range *gSnap.Unavailability {
+			snap.Unavailability = append(snap.Unavailability, generatedUnavailabilityToEntity(period))
+		}
+	}
+	if gSnap.Aliases != nil {
+		for _, alias := range *gSnap.Aliases {
+			snap.Aliases = append(snap.Aliases, entity.UserAlias{Alias: alias.Alias, UserID: alias.UserId})
+		}
+	}
+	if gSnap.WorkSchedules != nil {
+		for _, schedule := range *gSnap.WorkSchedules {
+			snap.WorkSchedules = append(snap.WorkSchedules, generatedWorkScheduleToEntity(schedule))
+		}
+	}
+	if gSnap.CodeOwners != nil {
+		for _, owners := range *gSnap.CodeOwners {
+			snap.CodeOwners = append(snap.CodeOwners, generatedCodeOwnersToEntity(owners))
+		}
+	}
+	if gSnap.Skills != nil {
+		for _, userSkills := range *gSnap.Skills {
+			snap.Skills = append(snap.Skills, generatedUserSkillsToEntity(userSkills.UserId, userSkills.Skills)...)
+		}
+	}
+	if gSnap.ReviewerRules != nil {
+		for _, rule := range *gSnap.ReviewerRules {
+			snap.ReviewerRules = append(snap.ReviewerRules, generatedReviewerRuleToEntity(rule))
+		}
+	}
+	return snap
+}
+
+func generatedReviewerRuleToEntity(gRule generated.ReviewerRule) entity.ReviewerRule {
+	rule := entity.ReviewerRule{
+		AuthorID:   gRule.AuthorId,
+		ReviewerID: gRule.ReviewerId,
+		Kind:       entity.ReviewerRuleKind(gRule.Kind),
+	}
+	if gRule.Reason != nil {
+		rule.Reason = *gRule.Reason
+	}
+	return rule
+}
+
+func generatedUserSkillsToEntity(userID string, gSkills []generated.UserSkill) []entity.UserSkill {
+	skills := make([]entity.UserSkill, len(gSkills))
+	for i, skill := range gSkills {
+		skills[i] = entity.UserSkill{UserID: userID, Tag: skill.Tag, Level: skill.Level}
+	}
+	return skills
+}
+
+func generatedCodeOwnersToEntity(gOwners generated.CodeOwners) entity.CodeOwners {
+	owners := entity.CodeOwners{Repository: gOwners.Repository, Rules: make([]entity.CodeOwnerRule, len(gOwners.Rules))}
+	for i, gRule := range gOwners.Rules {
+		owners.Rules[i] = generatedCodeOwnerRuleToEntity(gRule)
+	}
+	return owners
+}
+
+func generatedCodeOwnerRuleToEntity(gRule generated.CodeOwnerRule) entity.CodeOwnerRule {
+	rule := entity.CodeOwnerRule{Line: gRule.Line, Pattern: gRule.Pattern}
+	if gRule.Users != nil {
+		rule.Users = *gRule.Users
+	}
+	if gRule.Teams != nil {
+		rule.Teams = *gRule.Teams
+	}
+	return rule
+}
+
+// workDays - дни недели API в порядке time.Weekday
+var workDays = []generated.WorkScheduleWorkDays{
+	generated.SUN, generated.MON, generated.TUE, generated.WED, generated.THU, generated.FRI, generated.SAT,
+}
+
+// generatedWorkScheduleToEntity не возвращает ошибок: неразобранное время и неизвестные
+// дни становятся значениями вне допустимых, и их отклоняет проверка в сервисе
+func generatedWorkScheduleToEntity(gSchedule generated.WorkSchedule) entity.WorkSchedule {
+	schedule := entity.WorkSchedule{
+		UserID:      gSchedule.UserId,
+		TimeZone:    gSchedule.TimeZone,
+		StartMinute: parseClock(gSchedule.WorkStart),
+		EndMinute:   parseClock(gSchedule.WorkEnd),
+	}
+	if gSchedule.WorkDays == nil {
+		schedule.Days = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+		return schedule
+	}
+	for _, gDay := range *gSchedule.WorkDays {
+		day := time.Weekday(-1)
+		for i, workDay := range workDays {
+			if gDay == workDay {
+				day = time.Weekday(i)
+			}
+		}
+		schedule.Days = append(schedule.Days, day)
+	}
+	return schedule
+}
+
+// parseClock переводит HH:MM в минуты от полуночи, -1 - время не разобрано
+func parseClock(value string) int {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return -1
+	}
+	return clock.Hour()*60 + clock.Minute()
+}
+
+func generatedUnavailabilityToEntity(gPeriod generated.Unavailability) entity.Unavailability {
+	period := entity.Unavailability{
+		ID:              gPeriod.Id,
+		UserID:          gPeriod.UserId,
+		StartsAt:        gPeriod.StartsAt,
+		EndsAt:          gPeriod.EndsAt,
+		Reason:          gPeriod.Reason,
+		ReassignReviews: gPeriod.ReassignReviews,
+	}
+	if gPeriod.ReassignedAt != nil {
+		period.ReassignedAt = *gPeriod.ReassignedAt
+	}
+	if gPeriod.ExternalId != nil {
+		period.ExternalID = *gPeriod.ExternalId
+	}
+	return period
+}
 
 // func generatedUserToEntity(gUser generated.User) entity.User {
 // 	return entity.User{
@@ -48,25 +251,114 @@ func entityTeamToGenerated(eTeam entity.Team) generated.Team {
 	members := make([]generated.TeamMember, len(eTeam.Members))
 	for i, m := range eTeam.Members {
 		members[i] = generated.TeamMember{
-			UserId:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+			UserId:         m.UserID,
+			Username:       m.Username,
+			IsActive:       m.IsActive,
+			MaxOpenReviews: optionalInt(m.MaxOpenReviews),
+			Level:          optionalUserLevel(m.Level),
+			IsLearner:      optionalBool(m.IsLearner),
 		}
 	}
 
-	return generated.Team{
-		TeamName: eTeam.TeamName,
-		Members:  members,
+	team := generated.Team{
+		TeamName:       eTeam.TeamName,
+		Members:        members,
+		MaxOpenReviews: optionalInt(eTeam.MaxOpenReviews),
 	}
+	// Политика без требований в ответ не попадает, как и нулевой предел
+	if policy := eTeam.ReviewPolicy; policy.RequireSenior || policy.SeniorForJuniors {
+		team.ReviewPolicy = &generated.ReviewPolicy{
+			RequireSenior:    &policy.RequireSenior,
+			SeniorForJuniors: &policy.SeniorForJuniors,
+		}
+	}
+	return team
 }
 
 func entityUserToGenerated(eUser entity.User) generated.User {
 	return generated.User{
-		UserId:   eUser.UserID,
-		Username: eUser.Username,
-		TeamName: eUser.TeamName,
-		IsActive: eUser.IsActive,
+		UserId:         eUser.UserID,
+		Username:       eUser.Username,
+		TeamName:       eUser.TeamName,
+		IsActive:       eUser.IsActive,
+		MaxOpenReviews: optionalInt(eUser.MaxOpenReviews),
+		Level:          optionalUserLevel(eUser.Level),
+		IsLearner:      optionalBool(eUser.IsLearner),
+	}
+}
+
+func entityReviewAssignmentToGenerated(assignment entity.ReviewAssignment) generated.ReviewAssignment {
+	result := generated.ReviewAssignment{UnfilledSlots: assignment.UnfilledSlots}
+	if len(assignment.AtCapacity) > 0 {
+		result.AtCapacity = &assignment.AtCapacity
+	}
+	if len(assignment.CodeOwners) > 0 {
+		owners := make([]generated.CodeOwnerAssignment, len(assignment.CodeOwners))
+		for i, owner := range assignment.CodeOwners {
+			owners[i] = generated.CodeOwnerAssignment{ReviewerId: owner.ReviewerID, Rule: entityCodeOwnerRuleToGenerated(owner.Rule)}
+		}
+		result.CodeOwners = &owners
+	}
+	if len(assignment.UncoveredRules) > 0 {
+		rules := make([]generated.CodeOwnerRule, len(assignment.UncoveredRules))
+		for i, rule := range assignment.UncoveredRules {
+			rules[i] = entityCodeOwnerRuleToGenerated(rule)
+		}
+		result.UncoveredRules = &rules
 	}
+	if assignment.SeniorMissing {
+		result.SeniorMissing = &assignment.SeniorMissing
+	}
+	if len(assignment.Candidates) > 0 {
+		candidates := entityCandidatesToGenerated(assignment.Candidates)
+		result.Candidates = &candidates
+	}
+	return result
+}
+
+func entityReviewPreviewToGenerated(preview entity.ReviewPreview) generated.ReviewPreview {
+	result := generated.ReviewPreview{
+		Reviewers:    preview.Reviewers,
+		Alternatives: preview.Alternatives,
+		Assignment:   entityReviewAssignmentToGenerated(preview.Assignment),
+	}
+	if result.Reviewers == nil {
+		result.Reviewers = []string{}
+	}
+	if result.Alternatives == nil {
+		result.Alternatives = []string{}
+	}
+	if preview.ShadowReviewer != "" {
+		result.ShadowReviewer = &preview.ShadowReviewer
+	}
+	return result
+}
+
+func entityCandidatesToGenerated(eCandidates []entity.CandidateExplanation) []generated.CandidateExplanation {
+	candidates := make([]generated.CandidateExplanation, len(eCandidates))
+	for i, eCandidate := range eCandidates {
+		candidate := generated.CandidateExplanation{
+			UserId:   eCandidate.UserID,
+			Selected: optionalBool(eCandidate.Selected),
+			Shadow:   optionalBool(eCandidate.Shadow),
+		}
+		// У исключённых участников оценки нет
+		if eCandidate.ExcludedBy != "" {
+			excludedBy := generated.CandidateExplanationExcludedBy(eCandidate.ExcludedBy)
+			candidate.ExcludedBy = &excludedBy
+		} else {
+			candidate.Score = &generated.CandidateScore{
+				Weight:       eCandidate.Score.Weight(),
+				Skills:       eCandidate.Score.Skills,
+				WorkingHours: eCandidate.Score.WorkingHours,
+				Load:         eCandidate.Score.Load,
+				PairHistory:  eCandidate.Score.PairHistory,
+				Rule:         eCandidate.Score.Rule,
+			}
+		}
+		candidates[i] = candidate
+	}
+	return candidates
 }
 
 func entityPRToGenerated(ePR entity.PullRequest) generated.PullRequest {
@@ -76,9 +368,36 @@ func entityPRToGenerated(ePR entity.PullRequest) generated.PullRequest {
 		AuthorId:          ePR.AuthorID,
 		Status:            generated.PullRequestStatus(ePR.Status),
 		AssignedReviewers: ePR.AssignedReviewers,
+		ShadowReviewer:    optionalString(ePR.ShadowReviewer),
 		CreatedAt:         &ePR.CreatedAt,
 		MergedAt:          &ePR.MergedAt,
+		Version:           &ePR.Version,
+		Metadata:          entityPRMetadataToGenerated(ePR.Metadata),
+	}
+}
+
+// entityPRMetadataToGenerated опускает metadata целиком, если при создании PR её не передали
+func entityPRMetadataToGenerated(eMetadata entity.PRMetadata) *generated.PRMetadata {
+	metadata := &generated.PRMetadata{
+		Additions:    optionalInt(eMetadata.Additions),
+		Deletions:    optionalInt(eMetadata.Deletions),
+		ChangedFiles: optionalInt(eMetadata.ChangedFiles),
+		Repository:   optionalString(eMetadata.Repository),
+		BaseBranch:   optionalString(eMetadata.BaseBranch),
+	}
+	if len(eMetadata.Labels) > 0 {
+		metadata.Labels = &eMetadata.Labels
+	}
+	if len(eMetadata.Paths) > 0 {
+		metadata.Paths = &eMetadata.Paths
+	}
+	if len(eMetadata.RequiredSkills) > 0 {
+		metadata.RequiredSkills = &eMetadata.RequiredSkills
+	}
+	if *metadata == (generated.PRMetadata{}) {
+		return nil
 	}
+	return metadata
 }
 
 func entityPRToShortGenerated(ePR entity.PullRequest) generated.PullRequestShort {
@@ -89,3 +408,192 @@ func entityPRToShortGenerated(ePR entity.PullRequest) generated.PullRequestShort
 		Status:          generated.PullRequestShortStatus(ePR.Status),
 	}
 }
+
+func entitySnapshotToGenerated(eSnap entity.Snapshot) generated.Snapshot {
+	snap := generated.Snapshot{
+		FormatVersion: eSnap.FormatVersion,
+		CreatedAt:     eSnap.CreatedAt,
+		Teams:         make([]generated.Team, len(eSnap.Teams)),
+		PullRequests:  make([]generated.PullRequest, len(eSnap.PullRequests)),
+	}
+	for i, team := range eSnap.Teams {
+		snap.Teams[i] = entityTeamToGenerated(team)
+	}
+	for i, pr := range eSnap.PullRequests {
+		snap.PullRequests[i] = entityPRToGenerated(pr)
+	}
+	periods := make([]generated.Unavailability, len(eSnap.Unavailability))
+	for i, period := range eSnap.Unavailability {
+		periods[i] = entityUnavailabilityToGenerated(period)
+	}
+	snap.Unavailability = &periods
+	aliases := make([]generated.UserAlias, len(eSnap.Aliases))
+	for i, alias := range eSnap.Aliases {
+		aliases[i] = generated.UserAlias{Alias: alias.Alias, UserId: alias.UserID}
+	}
+	snap.Aliases = &aliases
+	schedules := make([]generated.WorkSchedule, len(eSnap.WorkSchedules))
+	for i, schedule := range eSnap.WorkSchedules {
+		schedules[i] = entityWorkScheduleToGenerated(schedule)
+	}
+	snap.WorkSchedules = &schedules
+	owners := make([]generated.CodeOwners, len(eSnap.CodeOwners))
+	for i, repoOwners := range eSnap.CodeOwners {
+		owners[i] = entityCodeOwnersToGenerated(repoOwners)
+	}
+	snap.CodeOwners = &owners
+	// Навыки сгруппированы по пользователю, хранилище отдаёт их по user_id
+	skills := []generated.UserSkills{}
+	for _, skill := range eSnap.Skills {
+		if len(skills) == 0 || skills[len(skills)-1].UserId != skill.UserID {
+			skills = append(skills, generated.UserSkills{UserId: skill.UserID, Skills: []generated.UserSkill{}})
+		}
+		last := &skills[len(skills)-1]
+		last.Skills = append(last.Skills, generated.UserSkill{Tag: skill.Tag, Level: skill.Level})
+	}
+	snap.Skills = &skills
+	rules := make([]generated.ReviewerRule, len(eSnap.ReviewerRules))
+	for i, rule := range eSnap.ReviewerRules {
+		rules[i] = entityReviewerRuleToGenerated(rule)
+	}
+	snap.ReviewerRules = &rules
+	return snap
+}
+
+func entityUserSkillsToGenerated(userID string, eSkills []entity.UserSkill) generated.UserSkills {
+	skills := make([]generated.UserSkill, len(eSkills))
+	for i, skill := range eSkills {
+		skills[i] = generated.UserSkill{Tag: skill.Tag, Level: skill.Level}
+	}
+	return generated.UserSkills{UserId: userID, Skills: skills}
+}
+
+func entityReviewerRuleToGenerated(eRule entity.ReviewerRule) generated.ReviewerRule {
+	return generated.ReviewerRule{
+		AuthorId:   eRule.AuthorID,
+		ReviewerId: eRule.ReviewerID,
+		Kind:       generated.ReviewerRuleKind(eRule.Kind),
+		Reason:     optionalString(eRule.Reason),
+	}
+}
+
+func entityCodeOwnersToGenerated(eOwners entity.CodeOwners) generated.CodeOwners {
+	rules := make([]generated.CodeOwnerRule, len(eOwners.Rules))
+	for i, rule := range eOwners.Rules {
+		rules[i] = entityCodeOwnerRuleToGenerated(rule)
+	}
+	return generated.CodeOwners{Repository: eOwners.Repository, Rules: rules}
+}
+
+func entityCodeOwnerRuleToGenerated(eRule entity.CodeOwnerRule) generated.CodeOwnerRule {
+	rule := generated.CodeOwnerRule{Line: eRule.Line, Pattern: eRule.Pattern}
+	if len(eRule.Users) > 0 {
+		rule.Users = &eRule.Users
+	}
+	if len(eRule.Teams) > 0 {
+		rule.Teams = &eRule.Teams
+	}
+	return rule
+}
+
+func entityUnavailabilityToGenerated(ePeriod entity.Unavailability) generated.Unavailability {
+	period := generated.Unavailability{
+		Id:              ePeriod.ID,
+		UserId:          ePeriod.UserID,
+		StartsAt:        ePeriod.StartsAt,
+		EndsAt:          ePeriod.EndsAt,
+		Reason:          ePeriod.Reason,
+		ReassignReviews: ePeriod.ReassignReviews,
+	}
+	if !ePeriod.ReassignedAt.IsZero() {
+		period.ReassignedAt = &ePeriod.ReassignedAt
+	}
+	if ePeriod.ExternalID != "" {
+		period.ExternalId = &ePeriod.ExternalID
+	}
+	return period
+}
+
+func entityWorkScheduleToGenerated(eSchedule entity.WorkSchedule) generated.WorkSchedule {
+	days := make([]generated.WorkScheduleWorkDays, len(eSchedule.Days))
+	for i, day := range eSchedule.Days {
+		days[i] = workDays[day]
+	}
+	return generated.WorkSchedule{
+		UserId:    eSchedule.UserID,
+		TimeZone:  eSchedule.TimeZone,
+		WorkStart: formatClock(eSchedule.StartMinute),
+		WorkEnd:   formatClock(eSchedule.EndMinute),
+		WorkDays:  &days,
+	}
+}
+
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func entityCalendarImportResultToGenerated(result *entity.CalendarImportResult) generated.CalendarImportResponse {
+	skipped := make([]generated.CalendarImportSkip, len(result.Skipped))
+	for i, skip := range result.Skipped {
+		skipped[i] = generated.CalendarImportSkip{Uid: skip.UID, Summary: skip.Summary, Reason: skip.Reason}
+	}
+	return generated.CalendarImportResponse{
+		Created:   result.Created,
+		Updated:   result.Updated,
+		Unchanged: result.Unchanged,
+		Deleted:   result.Deleted,
+		Skipped:   skipped,
+	}
+}
+
+func entityTeamSyncChangesToGenerated(changes []entity.TeamSyncChange) []generated.TeamSyncChange {
+	result := make([]generated.TeamSyncChange, len(changes))
+	for i, change := range changes {
+		result[i] = generated.TeamSyncChange{
+			Action:   generated.TeamSyncChangeAction(change.Action),
+			TeamName: change.TeamName,
+			UserId:   optionalString(change.UserID),
+			Username: optionalString(change.Username),
+			FromTeam: optionalString(change.FromTeam),
+		}
+	}
+	return result
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// optionalInt - как optionalString: нулевое значение в ответ не попадает
+func optionalInt(value int) *int {
+	if value == 0 {
+		return nil
+	}
+	return &value
+}
+
+// optionalBool - как optionalString: false в ответ не попадает
+func optionalBool(value bool) *bool {
+	if !value {
+		return nil
+	}
+	return &value
+}
+
+func optionalUserLevel(level entity.UserLevel) *generated.UserLevel {
+	if level == "" {
+		return nil
+	}
+	gLevel := generated.UserLevel(level)
+	return &gLevel
+}
+
+func valueOrZero(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
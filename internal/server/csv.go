@@ -0,0 +1,243 @@
+package server
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/generated"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+)
+
+// maxImportSize ограничивает размер загружаемого CSV
+const maxImportSize = 10 << 20
+
+var (
+	userCSVHeader = []string{"team_name", "user_id", "username", "is_active"}
+	prCSVHeader   = []string{"pull_request_id", "pull_request_name", "author_id", "status",
+		"assigned_reviewers", "created_at", "merged_at", "version",
+		"additions", "deletions", "changed_files", "repository", "base_branch", "labels", "shadow_reviewer"}
+)
+
+func (s *PRServer) handleImportUsers(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxImportSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if len(body) > maxImportSize {
+		importError(c, fmt.Sprintf("file is larger than %d bytes", maxImportSize), nil)
+		return
+	}
+
+	users, lines, rowErrors, err := parseUsersCSV(body)
+	if err != nil {
+		importError(c, err.Error(), nil)
+		return
+	}
+	if len(rowErrors) > 0 {
+		importError(c, fmt.Sprintf("%d invalid row(s)", len(rowErrors)), rowErrors)
+		return
+	}
+
+	changes, err := s.serv.ImportUsers(c.Request.Context(), users)
+	if err != nil {
+		s.logger.Error("IMPORT_USERS_ERROR", "Failed to import users", "error", err, "rows", len(users))
+
+		var importErr *service.ImportError
+		switch {
+		case errors.As(err, &importErr):
+			rowErrors := make([]generated.ImportRowError, len(importErr.Rows))
+			for i, row := range importErr.Rows {
+				rowErrors[i] = generated.ImportRowError{Line: lines[row.Index], Message: row.Err.Error()}
+			}
+			importError(c, err.Error(), rowErrors)
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, generated.UserImportResponse{
+		Rows:    len(users),
+		Changes: entityTeamSyncChangesToGenerated(changes),
+	})
+}
+
+func (s *PRServer) handleExportUsers(c *gin.Context) {
+	users, err := s.serv.ListUsers(c.Request.Context())
+	if err != nil {
+		s.logger.Error("EXPORT_USERS_ERROR", "Failed to list users", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	records := [][]string{userCSVHeader}
+	for _, user := range users {
+		records = append(records, []string{user.TeamName, user.UserID, user.Username, strconv.FormatBool(user.IsActive)})
+	}
+	writeCSV(c, "users.csv", records)
+}
+
+func (s *PRServer) handleExportPRs(c *gin.Context) {
+	prs, err := s.serv.ListPRs(c.Request.Context())
+	if err != nil {
+		s.logger.Error("EXPORT_PRS_ERROR", "Failed to list PRs", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	records := [][]string{prCSVHeader}
+	for _, pr := range prs {
+		records = append(records, []string{
+			pr.PullRequestID,
+			pr.PullRequestName,
+			pr.AuthorID,
+			string(pr.Status),
+			strings.Join(pr.AssignedReviewers, ";"),
+			formatCSVTime(pr.CreatedAt),
+			formatCSVTime(pr.MergedAt),
+			strconv.Itoa(pr.Version),
+			strconv.Itoa(pr.Metadata.Additions),
+			strconv.Itoa(pr.Metadata.Deletions),
+			strconv.Itoa(pr.Metadata.ChangedFiles),
+			pr.Metadata.Repository,
+			pr.Metadata.BaseBranch,
+			strings.Join(pr.Metadata.Labels, ";"),
+			pr.ShadowReviewer,
+		})
+	}
+	writeCSV(c, "pullRequests.csv", records)
+}
+
+// parseUsersCSV разбирает CSV пользователей. Ошибка возвращается для файла в целом
+// (пустой файл, неверный заголовок), ошибки отдельных строк - в rowErrors.
+// lines[i] - номер строки файла, из которой получен users[i]
+func parseUsersCSV(data []byte) ([]entity.User, []int, []generated.ImportRowError, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("read header: %w", err)
+	}
+	columns, err := csvColumns(header, userCSVHeader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var (
+		users     []entity.User
+		lines     []int
+		rowErrors []generated.ImportRowError
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				line = parseErr.StartLine
+			}
+			rowErrors = append(rowErrors, generated.ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		if len(record) != len(header) {
+			rowErrors = append(rowErrors, generated.ImportRowError{
+				Line:    line,
+				Message: fmt.Sprintf("expected %d fields, got %d", len(header), len(record)),
+			})
+			continue
+		}
+
+		isActive, err := strconv.ParseBool(strings.TrimSpace(record[columns["is_active"]]))
+		if err != nil {
+			rowErrors = append(rowErrors, generated.ImportRowError{
+				Line:    line,
+				Message: fmt.Sprintf("is_active: invalid boolean %q", record[columns["is_active"]]),
+			})
+			continue
+		}
+
+		users = append(users, entity.User{
+			TeamName: strings.TrimSpace(record[columns["team_name"]]),
+			UserID:   strings.TrimSpace(record[columns["user_id"]]),
+			Username: strings.TrimSpace(record[columns["username"]]),
+			IsActive: isActive,
+		})
+		lines = append(lines, line)
+	}
+	return users, lines, rowErrors, nil
+}
+
+// csvColumns проверяет, что заголовок состоит ровно из ожидаемых столбцов
+// (в любом порядке), и возвращает позицию каждого из них
+func csvColumns(header, expected []string) (map[string]int, error) {
+	known := make(map[string]bool, len(expected))
+	for _, name := range expected {
+		known[name] = true
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")) // BOM из Excel
+		if !known[name] {
+			return nil, fmt.Errorf("unknown column %q, expected %s", name, strings.Join(expected, ","))
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("duplicate column %q", name)
+		}
+		columns[name] = i
+	}
+	for _, name := range expected {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+	return columns, nil
+}
+
+func importError(c *gin.Context, message string, rows []generated.ImportRowError) {
+	if rows == nil {
+		rows = []generated.ImportRowError{}
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": gin.H{
+			"code":    "INVALID_CSV",
+			"message": message,
+		},
+		"rows": rows,
+	})
+}
+
+func writeCSV(c *gin.Context, filename string, records [][]string) {
+	var buf bytes.Buffer
+	if err := csv.NewWriter(&buf).WriteAll(records); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
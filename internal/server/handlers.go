@@ -3,6 +3,8 @@ package server
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
@@ -19,7 +21,7 @@ func (s *PRServer) handleCreateTeam(c *gin.Context) {
 
 	team := generatedTeamToEntity(request)
 
-	if err := s.serv.CreateTeam(&team); err != nil {
+	if err := s.serv.CreateTeam(c.Request.Context(), &team); err != nil {
 		s.logger.Error("CREATE_TEAM_ERROR", "Failed to create team", "error", err, "team_name", team.TeamName)
 
 		switch err {
@@ -28,6 +30,16 @@ func (s *PRServer) handleCreateTeam(c *gin.Context) {
 				"code":    "TEAM_EXISTS",
 				"message": err.Error(),
 			}})
+		case service.ErrInvalidReviewLimit:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_REVIEW_LIMIT",
+				"message": err.Error(),
+			}})
+		case service.ErrInvalidUserLevel:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_USER_LEVEL",
+				"message": err.Error(),
+			}})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -45,7 +57,7 @@ func (s *PRServer) handleGetTeam(c *gin.Context) {
 		return
 	}
 
-	team, err := s.serv.GetTeam(teamName)
+	team, err := s.serv.GetTeam(c.Request.Context(), teamName)
 	if err != nil {
 		s.logger.Error("GET_TEAM_ERROR", "Failed to get team", "error", err, "team_name", teamName)
 
@@ -65,6 +77,43 @@ func (s *PRServer) handleGetTeam(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (s *PRServer) handleSyncTeams(c *gin.Context) {
+	var request generated.TeamSyncRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	roster := make([]entity.Team, len(request.Teams))
+	for i, team := range request.Teams {
+		roster[i] = generatedTeamToEntity(team)
+	}
+	dryRun := getDryRunFromContext(c)
+
+	changes, err := s.serv.SyncTeams(c.Request.Context(), roster, dryRun)
+	if err != nil {
+		s.logger.Error("SYNC_TEAMS_ERROR", "Failed to sync teams", "error", err, "dry_run", dryRun)
+
+		switch err {
+		case service.ErrEmptyTeamName, service.ErrEmptyUserID, service.ErrEmptyUserUsername,
+			service.ErrDuplicateRosterTeam, service.ErrDuplicateRosterUser, service.ErrInvalidReviewLimit,
+			service.ErrInvalidUserLevel:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_ROSTER",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, generated.TeamSyncResponse{
+		DryRun:  dryRun,
+		Changes: entityTeamSyncChangesToGenerated(changes),
+	})
+}
+
 func (s *PRServer) handleSetUserActive(c *gin.Context) {
 	var request struct {
 		UserID   string `json:"user_id"`
@@ -76,7 +125,7 @@ func (s *PRServer) handleSetUserActive(c *gin.Context) {
 		return
 	}
 
-	usr, err := s.serv.SetUserActive(request.UserID, request.IsActive)
+	usr, err := s.serv.SetUserActive(c.Request.Context(), request.UserID, request.IsActive)
 	if err != nil {
 		s.logger.Error("SET_USER_ACTIVE_ERROR", "Failed to set user active",
 			"error", err, "user_id", request.UserID, "is_active", request.IsActive)
@@ -104,7 +153,7 @@ func (s *PRServer) handleGetUserReviews(c *gin.Context) {
 		return
 	}
 
-	prs, err := s.serv.GetUserReviews(userID)
+	prs, err := s.serv.GetUserReviews(c.Request.Context(), userID)
 	if err != nil {
 		s.logger.Error("GET_USER_REVIEWS_ERROR", "Failed to get user reviews",
 			"error", err, "user_id", userID)
@@ -121,22 +170,36 @@ func (s *PRServer) handleGetUserReviews(c *gin.Context) {
 		return
 	}
 
+	shadowPRs, err := s.serv.GetUserShadowReviews(c.Request.Context(), userID)
+	if err != nil {
+		s.logger.Error("GET_USER_REVIEWS_ERROR", "Failed to get user shadow reviews",
+			"error", err, "user_id", userID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	response := make([]generated.PullRequestShort, len(prs))
 	for i, pr := range prs {
 		response[i] = entityPRToShortGenerated(*pr)
 	}
+	shadowResponse := make([]generated.PullRequestShort, len(shadowPRs))
+	for i, pr := range shadowPRs {
+		shadowResponse[i] = entityPRToShortGenerated(*pr)
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"user_id":       userID,
-		"pull_requests": response,
+		"user_id":              userID,
+		"pull_requests":        response,
+		"shadow_pull_requests": shadowResponse,
 	})
 }
 
 func (s *PRServer) handleCreatePR(c *gin.Context) {
 	var request struct {
-		PullRequestID   string `json:"pull_request_id"`
-		PullRequestName string `json:"pull_request_name"`
-		AuthorID        string `json:"author_id"`
+		PullRequestID   string                `json:"pull_request_id"`
+		PullRequestName string                `json:"pull_request_name"`
+		AuthorID        string                `json:"author_id"`
+		Metadata        *generated.PRMetadata `json:"metadata"`
 	}
 
 	if err := c.BindJSON(&request); err != nil {
@@ -148,14 +211,20 @@ func (s *PRServer) handleCreatePR(c *gin.Context) {
 		PullRequestID:   request.PullRequestID,
 		PullRequestName: request.PullRequestName,
 		AuthorID:        request.AuthorID,
+		Metadata:        generatedPRMetadataToEntity(request.Metadata),
 	}
 
-	err := s.serv.CreatePR(&pr)
+	assignment, err := s.serv.CreatePR(c.Request.Context(), &pr)
 	if err != nil {
 		s.logger.Error("CREATE_PR_ERROR", "Failed to create PR",
 			"error", err, "pr_id", pr.PullRequestID, "author_id", pr.AuthorID)
 
 		switch err {
+		case service.ErrInvalidPRMetadata:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_PR_METADATA",
+				"message": err.Error(),
+			}})
 		case service.ErrPRAlreadyExists:
 			c.JSON(http.StatusConflict, gin.H{"error": gin.H{
 				"code":    "PR_EXISTS",
@@ -173,7 +242,56 @@ func (s *PRServer) handleCreatePR(c *gin.Context) {
 	}
 
 	response := entityPRToGenerated(pr)
-	c.JSON(http.StatusCreated, gin.H{"pr": response})
+	setETag(c, pr.Version)
+	c.JSON(http.StatusCreated, gin.H{
+		"pr":         response,
+		"assignment": entityReviewAssignmentToGenerated(*assignment),
+	})
+}
+
+func (s *PRServer) handlePreviewPR(c *gin.Context) {
+	var request struct {
+		PullRequestName string                `json:"pull_request_name"`
+		AuthorID        string                `json:"author_id"`
+		Metadata        *generated.PRMetadata `json:"metadata"`
+	}
+
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	pr := entity.PullRequest{
+		PullRequestName: request.PullRequestName,
+		AuthorID:        request.AuthorID,
+		Metadata:        generatedPRMetadataToEntity(request.Metadata),
+	}
+
+	preview, err := s.serv.PreviewPR(c.Request.Context(), &pr)
+	if err != nil {
+		s.logger.Error("PREVIEW_PR_ERROR", "Failed to preview PR reviewers",
+			"error", err, "author_id", pr.AuthorID)
+
+		switch err {
+		case service.ErrEmptyPRAuthorID:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "author_id is required"})
+		case service.ErrInvalidPRMetadata:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_PR_METADATA",
+				"message": err.Error(),
+			}})
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entityReviewPreviewToGenerated(*preview))
 }
 
 func (s *PRServer) handleMergePR(c *gin.Context) {
@@ -186,7 +304,13 @@ func (s *PRServer) handleMergePR(c *gin.Context) {
 		return
 	}
 
-	pr, err := s.serv.MergePR(request.PullRequestID)
+	expectedVersion, err := getIfMatchFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid If-Match header"})
+		return
+	}
+
+	pr, err := s.serv.MergePR(c.Request.Context(), request.PullRequestID, expectedVersion)
 	if err != nil {
 		s.logger.Error("MERGE_PR_ERROR", "Failed to merge PR",
 			"error", err, "pr_id", request.PullRequestID)
@@ -197,6 +321,11 @@ func (s *PRServer) handleMergePR(c *gin.Context) {
 				"code":    "NOT_FOUND",
 				"message": err.Error(),
 			}})
+		case service.ErrPRVersionConflict:
+			c.JSON(http.StatusConflict, gin.H{"error": gin.H{
+				"code":    "PR_VERSION_CONFLICT",
+				"message": err.Error(),
+			}})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -204,12 +333,15 @@ func (s *PRServer) handleMergePR(c *gin.Context) {
 	}
 
 	response := entityPRToGenerated(*pr)
+	setETag(c, pr.Version)
 	c.JSON(http.StatusOK, gin.H{"pr": response})
 }
 
 func (s *PRServer) handleReassignReviewer(c *gin.Context) {
 	var request struct {
 		PullRequestID string `json:"pull_request_id"`
+		OldUserID     string `json:"old_user_id"`
+		// OldReviewerID - прежнее имя поля, его ещё присылают старые клиенты
 		OldReviewerID string `json:"old_reviewer_id"`
 	}
 
@@ -217,8 +349,17 @@ func (s *PRServer) handleReassignReviewer(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
 		return
 	}
+	if request.OldUserID != "" {
+		request.OldReviewerID = request.OldUserID
+	}
+
+	expectedVersion, err := getIfMatchFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid If-Match header"})
+		return
+	}
 
-	updatedPR, newReviewerID, err := s.serv.ReassignReviewer(request.PullRequestID, request.OldReviewerID)
+	updatedPR, reassignment, err := s.serv.ReassignReviewer(c.Request.Context(), request.PullRequestID, request.OldReviewerID, expectedVersion)
 	if err != nil {
 		s.logger.Error("REASSIGN_REVIEWER_ERROR", "Failed to reassign reviewer",
 			"error", err, "pr_id", request.PullRequestID, "old_reviewer", request.OldReviewerID)
@@ -239,6 +380,16 @@ func (s *PRServer) handleReassignReviewer(c *gin.Context) {
 				"code":    "NO_CANDIDATE",
 				"message": err.Error(),
 			}})
+		case service.ErrSeniorReviewerRequired:
+			c.JSON(http.StatusConflict, gin.H{"error": gin.H{
+				"code":    "SENIOR_REQUIRED",
+				"message": err.Error(),
+			}})
+		case service.ErrPRVersionConflict:
+			c.JSON(http.StatusConflict, gin.H{"error": gin.H{
+				"code":    "PR_VERSION_CONFLICT",
+				"message": err.Error(),
+			}})
 		default:
 			// Проверяем текст ошибки для более специфичных случаев
 			if err.Error() == fmt.Sprintf("reviewer %s not assigned to this PR", request.OldReviewerID) {
@@ -254,9 +405,11 @@ func (s *PRServer) handleReassignReviewer(c *gin.Context) {
 	}
 
 	response := entityPRToGenerated(*updatedPR)
+	setETag(c, updatedPR.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"pr":          response,
-		"replaced_by": newReviewerID,
+		"replaced_by": reassignment.NewReviewerID,
+		"candidates":  entityCandidatesToGenerated(reassignment.Candidates),
 	})
 }
 
@@ -268,9 +421,42 @@ func getTeamNameFromContext(c *gin.Context) string {
 	return ""
 }
 
+func getDryRunFromContext(c *gin.Context) bool {
+	return c.GetBool("dry_run")
+}
+
 func getUserIDFromContext(c *gin.Context) string {
 	if userID, exists := c.Get("user_id"); exists {
 		return userID.(string)
 	}
 	return ""
 }
+
+func getRepositoryFromContext(c *gin.Context) string {
+	if repository, exists := c.Get("repository"); exists {
+		return repository.(string)
+	}
+	return ""
+}
+
+// getIfMatchFromContext возвращает ожидаемую версию PR из If-Match, 0 - без условия
+func getIfMatchFromContext(c *gin.Context) (int, error) {
+	value, exists := c.Get("if_match")
+	if !exists {
+		return 0, nil
+	}
+	etag := strings.TrimSpace(value.(string))
+	if etag == "" || etag == "*" {
+		return 0, nil
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	version, err := strconv.Atoi(strings.Trim(etag, `"`))
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid If-Match value %q", value)
+	}
+	return version, nil
+}
+
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/repository/memory"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleReassignReviewer_OldUserIDFieldNames(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testLogger, err := logger.NewLogger("pr-service-test", "logger_for_tests")
+	require.NoError(t, err)
+
+	s := &PRServer{
+		serv:   service.NewPRService(memory.NewMemoryRepository(testLogger), testLogger),
+		logger: testLogger,
+	}
+	router := gin.New()
+	router.POST("/team/add", s.handleCreateTeam)
+	router.POST("/pullRequest/create", s.handleCreatePR)
+	router.POST("/pullRequest/reassign", s.handleReassignReviewer)
+
+	do := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	team := do("/team/add", `{"team_name": "backend", "members": [
+		{"user_id": "u1", "username": "Alice", "is_active": true},
+		{"user_id": "u2", "username": "Bob", "is_active": true},
+		{"user_id": "u3", "username": "Carol", "is_active": true},
+		{"user_id": "u4", "username": "Dave", "is_active": true}
+	]}`)
+	require.Equal(t, http.StatusCreated, team.Code, team.Body.String())
+
+	// old_user_id - имя поля из спецификации, old_reviewer_id присылают старые клиенты
+	for i, field := range []string{"old_user_id", "old_reviewer_id"} {
+		t.Run(field, func(t *testing.T) {
+			prID := []string{"pr-1", "pr-2"}[i]
+			created := do("/pullRequest/create",
+				`{"pull_request_id": "`+prID+`", "pull_request_name": "Add search", "author_id": "u1"}`)
+			require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+
+			var createResponse struct {
+				PR struct {
+					AssignedReviewers []string `json:"assigned_reviewers"`
+				} `json:"pr"`
+			}
+			require.NoError(t, json.Unmarshal(created.Body.Bytes(), &createResponse))
+			require.NotEmpty(t, createResponse.PR.AssignedReviewers)
+			oldReviewer := createResponse.PR.AssignedReviewers[0]
+
+			reassigned := do("/pullRequest/reassign",
+				`{"pull_request_id": "`+prID+`", "`+field+`": "`+oldReviewer+`"}`)
+			require.Equal(t, http.StatusOK, reassigned.Code, reassigned.Body.String())
+
+			var reassignResponse struct {
+				PR struct {
+					AssignedReviewers []string `json:"assigned_reviewers"`
+				} `json:"pr"`
+				ReplacedBy string `json:"replaced_by"`
+			}
+			require.NoError(t, json.Unmarshal(reassigned.Body.Bytes(), &reassignResponse))
+			assert.NotEqual(t, oldReviewer, reassignResponse.ReplacedBy)
+			assert.NotContains(t, reassignResponse.PR.AssignedReviewers, oldReviewer)
+			assert.Contains(t, reassignResponse.PR.AssignedReviewers, reassignResponse.ReplacedBy)
+		})
+	}
+}
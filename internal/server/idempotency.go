@@ -0,0 +1,146 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencySaveTimeout - дедлайн сохранения ответа. Сохранение не зависит от дедлайна
+// запроса: иначе ответ, полученный под конец дедлайна, не сохранился бы и повтор выполнил
+// запрос ещё раз
+const idempotencySaveTimeout = 2 * time.Second
+
+// Заголовки ответа, которые сохраняются вместе с телом и отдаются при повторе
+var replayedHeaders = []string{"Content-Type", "ETag"}
+
+// responseRecorder дублирует тело ответа в буфер, чтобы его можно было сохранить
+type responseRecorder struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+// idempotencyMiddleware обрабатывает Idempotency-Key для перечисленных путей:
+// повтор с тем же телом отдаёт сохранённый ответ, с другим телом - 409. Ключ резервируется
+// до выполнения запроса, поэтому повтор, пришедший пока первый запрос ещё выполняется,
+// тоже получает 409 и не выполняет запрос второй раз
+func (s *PRServer) idempotencyMiddleware(paths ...string) gin.HandlerFunc {
+	enabled := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		enabled[path] = true
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		endpoint := c.FullPath()
+		if key == "" || !enabled[endpoint] {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.Sum256(body)
+		requestHash := hex.EncodeToString(hash[:])
+
+		record, err := s.serv.BeginIdempotentRequest(c.Request.Context(), key, endpoint, requestHash)
+		if err != nil {
+			s.logger.Error("IDEMPOTENCY_ERROR", "Failed to check idempotency key",
+				"error", err, "key", key, "endpoint", endpoint)
+
+			switch err {
+			case service.ErrIdempotencyKeyReused:
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": gin.H{
+					"code":    "IDEMPOTENCY_KEY_REUSED",
+					"message": err.Error(),
+				}})
+			case service.ErrIdempotencyKeyInProgress:
+				c.Header("Retry-After", "1")
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": gin.H{
+					"code":    "IDEMPOTENCY_KEY_IN_PROGRESS",
+					"message": err.Error(),
+				}})
+			default:
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			return
+		}
+
+		if record != nil {
+			for name, value := range record.ResponseHeaders {
+				c.Header(name, value)
+			}
+			c.Header("Idempotent-Replayed", "true")
+			c.Status(record.StatusCode)
+			if _, err := c.Writer.Write(record.ResponseBody); err != nil {
+				s.logger.Error("IDEMPOTENCY_ERROR", "Failed to write replayed response",
+					"error", err, "key", key, "endpoint", endpoint)
+			}
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = recorder
+
+		c.Next()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), idempotencySaveTimeout)
+		defer cancel()
+
+		// Сохраняем только успешные ответы: после ошибки клиент может исправить
+		// данные и повторить запрос с тем же ключом, поэтому резерв снимаем
+		status := recorder.Status()
+		if status < http.StatusOK || status >= http.StatusMultipleChoices {
+			if err := s.serv.ReleaseIdempotencyKey(ctx, key, endpoint); err != nil {
+				s.logger.Error("IDEMPOTENCY_ERROR", "Failed to release idempotency key",
+					"error", err, "key", key, "endpoint", endpoint)
+			}
+			return
+		}
+
+		headers := make(map[string]string, len(replayedHeaders))
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+
+		if err := s.serv.SaveIdempotentResponse(ctx, &entity.IdempotencyRecord{
+			Key:             key,
+			Endpoint:        endpoint,
+			RequestHash:     requestHash,
+			StatusCode:      status,
+			ResponseHeaders: headers,
+			ResponseBody:    recorder.body.Bytes(),
+		}); err != nil {
+			s.logger.Error("IDEMPOTENCY_ERROR", "Failed to save idempotent response",
+				"error", err, "key", key, "endpoint", endpoint)
+		}
+	}
+}
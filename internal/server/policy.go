@@ -0,0 +1,78 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+)
+
+func (s *PRServer) handleSetUserLevel(c *gin.Context) {
+	var request struct {
+		UserID string `json:"user_id"`
+		Level  string `json:"level"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	user, err := s.serv.SetUserLevel(c.Request.Context(), request.UserID, entity.UserLevel(request.Level))
+	if err != nil {
+		s.logger.Error("SET_USER_LEVEL_ERROR", "Failed to set user level",
+			"error", err, "user_id", request.UserID)
+
+		switch err {
+		case service.ErrEmptyUserID, service.ErrInvalidUserLevel:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_USER_LEVEL",
+				"message": err.Error(),
+			}})
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": entityUserToGenerated(*user)})
+}
+
+func (s *PRServer) handleSetTeamReviewPolicy(c *gin.Context) {
+	var request struct {
+		TeamName         string `json:"team_name"`
+		RequireSenior    bool   `json:"require_senior"`
+		SeniorForJuniors bool   `json:"senior_for_juniors"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	policy := entity.ReviewPolicy{RequireSenior: request.RequireSenior, SeniorForJuniors: request.SeniorForJuniors}
+	team, err := s.serv.SetTeamReviewPolicy(c.Request.Context(), request.TeamName, policy)
+	if err != nil {
+		s.logger.Error("SET_TEAM_REVIEW_POLICY_ERROR", "Failed to set team review policy",
+			"error", err, "team_name", request.TeamName)
+
+		switch err {
+		case service.ErrEmptyTeamName:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "team_name is required"})
+		case service.ErrNoTeam:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team": entityTeamToGenerated(*team)})
+}
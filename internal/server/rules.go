@@ -0,0 +1,94 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/generated"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+)
+
+func (s *PRServer) handleGetReviewerRules(c *gin.Context) {
+	authorID := c.GetString("author_id")
+
+	rules, err := s.serv.GetReviewerRules(c.Request.Context(), authorID)
+	if err != nil {
+		s.logger.Error("GET_REVIEWER_RULES_ERROR", "Failed to get reviewer rules",
+			"error", err, "author_id", authorID)
+
+		switch err {
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	response := generated.ReviewerRules{Rules: make([]generated.ReviewerRule, len(rules))}
+	for i, rule := range rules {
+		response.Rules[i] = entityReviewerRuleToGenerated(rule)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (s *PRServer) handleSetReviewerRule(c *gin.Context) {
+	var request generated.ReviewerRule
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	rule := generatedReviewerRuleToEntity(request)
+	if err := s.serv.SetReviewerRule(c.Request.Context(), &rule); err != nil {
+		s.logger.Error("SET_REVIEWER_RULE_ERROR", "Failed to set reviewer rule",
+			"error", err, "author_id", rule.AuthorID, "reviewer_id", rule.ReviewerID)
+
+		switch err {
+		case service.ErrEmptyUserID, service.ErrInvalidReviewerRule:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_REVIEWER_RULE",
+				"message": err.Error(),
+			}})
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entityReviewerRuleToGenerated(rule))
+}
+
+func (s *PRServer) handleDeleteReviewerRule(c *gin.Context) {
+	authorID, reviewerID := c.GetString("author_id"), c.GetString("reviewer_id")
+	if authorID == "" || reviewerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "author_id and reviewer_id parameters are required"})
+		return
+	}
+
+	if err := s.serv.DeleteReviewerRule(c.Request.Context(), authorID, reviewerID); err != nil {
+		s.logger.Error("DELETE_REVIEWER_RULE_ERROR", "Failed to delete reviewer rule",
+			"error", err, "author_id", authorID, "reviewer_id", reviewerID)
+
+		switch err {
+		case service.ErrNoReviewerRule:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
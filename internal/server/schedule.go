@@ -0,0 +1,94 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/generated"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+)
+
+func (s *PRServer) handleGetWorkSchedule(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+		return
+	}
+
+	schedule, err := s.serv.GetWorkSchedule(c.Request.Context(), userID)
+	if err != nil {
+		s.logger.Error("GET_WORK_SCHEDULE_ERROR", "Failed to get work schedule",
+			"error", err, "user_id", userID)
+
+		switch err {
+		case service.ErrNoWorkSchedule:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entityWorkScheduleToGenerated(*schedule))
+}
+
+func (s *PRServer) handleSetWorkSchedule(c *gin.Context) {
+	var request generated.WorkSchedule
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	schedule := generatedWorkScheduleToEntity(request)
+	if err := s.serv.SetWorkSchedule(c.Request.Context(), &schedule); err != nil {
+		s.logger.Error("SET_WORK_SCHEDULE_ERROR", "Failed to set work schedule",
+			"error", err, "user_id", request.UserId)
+
+		switch err {
+		case service.ErrEmptyUserID, service.ErrInvalidWorkSchedule:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_SCHEDULE",
+				"message": err.Error(),
+			}})
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entityWorkScheduleToGenerated(schedule))
+}
+
+func (s *PRServer) handleDeleteWorkSchedule(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+		return
+	}
+
+	if err := s.serv.DeleteWorkSchedule(c.Request.Context(), userID); err != nil {
+		s.logger.Error("DELETE_WORK_SCHEDULE_ERROR", "Failed to delete work schedule",
+			"error", err, "user_id", userID)
+
+		switch err {
+		case service.ErrNoWorkSchedule:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
 	"time"
 
@@ -11,14 +13,34 @@ import (
 )
 
 type PRServer struct {
-	server *http.Server
-	router *gin.Engine
-	serv   interfaces.Service
-	logger interfaces.Logger
+	server         *http.Server
+	router         *gin.Engine
+	serv           interfaces.Service
+	logger         interfaces.Logger
+	requestTimeout time.Duration
+	// bulkRequestTimeout - дедлайн запросов из bulkRoutes
+	bulkRequestTimeout time.Duration
+	// calendarCategory - категория событий отсутствия при импорте календаря по умолчанию
+	calendarCategory string
+	// cancelRequests отменяет контексты всех незавершённых запросов при остановке
+	cancelRequests context.CancelFunc
 }
 
-func NewPRServer(port string, service interfaces.Service, logger interfaces.Logger) *PRServer {
+// bulkRoutes - административные и массовые запросы, которые выполняются в одной транзакции
+// и не укладываются в SLI: вместо requestTimeout им даётся bulkRequestTimeout
+var bulkRoutes = map[string]bool{
+	"/admin/snapshot":              true,
+	"/admin/restore":               true,
+	"/import/users.csv":            true,
+	"/export/users.csv":            true,
+	"/export/pullRequests.csv":     true,
+	"/users/unavailability/import": true,
+	"/team/sync":                   true,
+}
+
+func NewPRServer(port string, requestTimeout, bulkRequestTimeout time.Duration, calendarCategory string, service interfaces.Service, logger interfaces.Logger) *PRServer {
 	router := gin.Default()
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
 
 	s := &PRServer{
 		serv:   service,
@@ -26,8 +48,16 @@ func NewPRServer(port string, service interfaces.Service, logger interfaces.Logg
 		server: &http.Server{
 			Addr:    ":" + port,
 			Handler: router,
+			BaseContext: func(net.Listener) context.Context {
+				return baseCtx
+			},
 		},
-		router: router,
+		router:             router,
+		requestTimeout:     requestTimeout,
+		bulkRequestTimeout: bulkRequestTimeout,
+		cancelRequests:     cancelRequests,
+
+		calendarCategory: calendarCategory,
 	}
 
 	s.setupRoutes()
@@ -37,6 +67,10 @@ func NewPRServer(port string, service interfaces.Service, logger interfaces.Logg
 func (s *PRServer) setupRoutes() {
 	// Логирование запросов
 	s.router.Use(s.loggingMiddleware())
+	// Дедлайн запроса, который доходит до запросов в БД
+	s.router.Use(s.timeoutMiddleware())
+	// Повторы запросов с Idempotency-Key
+	s.router.Use(s.idempotencyMiddleware("/team/add", "/pullRequest/create", "/pullRequest/reassign"))
 
 	s.router.GET("/health", s.handleHealthCheck)
 
@@ -71,13 +105,47 @@ func (s *PRServer) Start() error {
 
 func (s *PRServer) Shutdown(ctx context.Context) error {
 	s.logger.Info("SERVER_SHUTDOWN", "Initiating server shutdown")
-	return s.server.Shutdown(ctx)
+
+	// Незавершённые к дедлайну остановки запросы отменяются вместе с их запросами в БД
+	stop := context.AfterFunc(ctx, s.cancelRequests)
+	defer stop()
+
+	err := s.server.Shutdown(ctx)
+	s.cancelRequests()
+	return err
 }
 
 func (s *PRServer) handleHealthCheck(c *gin.Context) {
 	c.JSON(200, gin.H{"status": "ok"})
 }
 
+func (s *PRServer) timeoutMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := s.requestTimeout
+		if bulkRoutes[c.FullPath()] {
+			timeout = s.bulkRequestTimeout
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			s.logger.Warn("HTTP_REQUEST", "Request deadline exceeded",
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"timeout_ms", timeout.Milliseconds(),
+			)
+		}
+	}
+}
+
 func (s *PRServer) loggingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/repository/memory"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutMiddleware_BulkRoutesUseOwnDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testLogger, err := logger.NewLogger("pr-service-test", "logger_for_tests")
+	require.NoError(t, err)
+
+	// SLI-дедлайн истекает ещё до обработчика, дедлайна снапшотов хватает с запасом
+	serv := service.NewPRService(memory.NewMemoryRepository(testLogger), testLogger)
+	s := NewPRServer("0", time.Nanosecond, time.Minute, "OOO", serv, testLogger)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		s.router.ServeHTTP(w, req)
+		return w
+	}
+
+	restore := do(http.MethodPost, "/admin/restore", `{
+		"format_version": 1,
+		"teams": [{"team_name": "backend", "members": [
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true}
+		]}]
+	}`)
+	assert.Equal(t, http.StatusOK, restore.Code, restore.Body.String())
+
+	export := do(http.MethodGet, "/admin/snapshot", "")
+	assert.Equal(t, http.StatusOK, export.Code, export.Body.String())
+	assert.Contains(t, export.Body.String(), `"backend"`)
+
+	imported := do(http.MethodPost, "/import/users.csv", "team_name,user_id,username,is_active\nbackend,u3,Carol,true\n")
+	assert.Equal(t, http.StatusOK, imported.Code, imported.Body.String())
+
+	exported := do(http.MethodGet, "/export/users.csv", "")
+	assert.Equal(t, http.StatusOK, exported.Code, exported.Body.String())
+	assert.Contains(t, exported.Body.String(), "backend,u3,Carol,true")
+
+	calendar := do(http.MethodPost, "/users/unavailability/import", "BEGIN:VCALENDAR\nVERSION:2.0\n"+
+		"BEGIN:VEVENT\nUID:vacation@cal\nSUMMARY:Vacation\nCATEGORIES:OOO\n"+
+		"DTSTART;VALUE=DATE:20990701\nDTEND;VALUE=DATE:20990715\n"+
+		"ORGANIZER;CN=Bob:mailto:bob@example.com\nEND:VEVENT\nEND:VCALENDAR\n")
+	assert.Equal(t, http.StatusOK, calendar.Code, calendar.Body.String())
+
+	synced := do(http.MethodPost, "/team/sync", `{"teams": [{"team_name": "backend", "members": [
+		{"user_id": "u1", "username": "Alice", "is_active": true},
+		{"user_id": "u4", "username": "Dave", "is_active": true}
+	]}]}`)
+	assert.Equal(t, http.StatusOK, synced.Code, synced.Body.String())
+	assert.Contains(t, synced.Body.String(), `"u4"`)
+
+	// Обычные запросы по-прежнему ограничены SLI-дедлайном
+	team := do(http.MethodGet, "/team/get?team_name=backend", "")
+	assert.Equal(t, http.StatusInternalServerError, team.Code)
+	assert.Contains(t, team.Body.String(), "deadline exceeded")
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+)
+
+func (s *PRServer) handleSetUserLearner(c *gin.Context) {
+	var request struct {
+		UserID    string `json:"user_id"`
+		IsLearner bool   `json:"is_learner"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	user, err := s.serv.SetUserLearner(c.Request.Context(), request.UserID, request.IsLearner)
+	if err != nil {
+		s.logger.Error("SET_USER_LEARNER_ERROR", "Failed to set user learner flag",
+			"error", err, "user_id", request.UserID)
+
+		switch err {
+		case service.ErrEmptyUserID:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": entityUserToGenerated(*user)})
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/generated"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+)
+
+func (s *PRServer) handleGetUserSkills(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+		return
+	}
+
+	skills, err := s.serv.GetUserSkills(c.Request.Context(), userID)
+	if err != nil {
+		s.logger.Error("GET_USER_SKILLS_ERROR", "Failed to get user skills",
+			"error", err, "user_id", userID)
+
+		switch err {
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entityUserSkillsToGenerated(userID, skills))
+}
+
+func (s *PRServer) handleSetUserSkills(c *gin.Context) {
+	var request generated.UserSkills
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	skills, err := s.serv.SetUserSkills(c.Request.Context(), request.UserId,
+		generatedUserSkillsToEntity(request.UserId, request.Skills))
+	if err != nil {
+		s.logger.Error("SET_USER_SKILLS_ERROR", "Failed to set user skills",
+			"error", err, "user_id", request.UserId)
+
+		switch err {
+		case service.ErrEmptyUserID, service.ErrInvalidSkill, service.ErrDuplicateSkill:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_SKILL",
+				"message": err.Error(),
+			}})
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entityUserSkillsToGenerated(request.UserId, skills))
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/generated"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+)
+
+func (s *PRServer) handleExportSnapshot(c *gin.Context) {
+	snap, err := s.serv.ExportSnapshot(c.Request.Context())
+	if err != nil {
+		s.logger.Error("EXPORT_SNAPSHOT_ERROR", "Failed to export snapshot", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entitySnapshotToGenerated(*snap))
+}
+
+func (s *PRServer) handleRestoreSnapshot(c *gin.Context) {
+	var request generated.Snapshot
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	snap := generatedSnapshotToEntity(request)
+	if err := s.serv.RestoreSnapshot(c.Request.Context(), &snap); err != nil {
+		s.logger.Error("RESTORE_SNAPSHOT_ERROR", "Failed to restore snapshot",
+			"error", err, "format_version", snap.FormatVersion)
+
+		switch err {
+		case service.ErrUnsupportedSnapshotVersion, service.ErrDuplicateSnapshotPR,
+			service.ErrSnapshotUnknownUser, service.ErrInvalidSnapshotPR,
+			service.ErrEmptyTeamName, service.ErrEmptyUserID, service.ErrEmptyUserUsername,
+			service.ErrDuplicateRosterTeam, service.ErrDuplicateRosterUser,
+			service.ErrEmptyPRID, service.ErrEmptyPRName, service.ErrEmptyPRAuthorID,
+			service.ErrInvalidUnavailabilityPeriod, service.ErrEmptyAlias, service.ErrAliasTaken,
+			service.ErrInvalidWorkSchedule, service.ErrDuplicateSnapshotSchedule, service.ErrInvalidReviewLimit,
+			service.ErrInvalidPRMetadata, service.ErrEmptyRepository, service.ErrInvalidCodeOwners,
+			service.ErrDuplicateSnapshotOwners, service.ErrInvalidSkill, service.ErrDuplicateSkill,
+			service.ErrInvalidUserLevel, service.ErrInvalidReviewerRule, service.ErrDuplicateSnapshotRule:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_SNAPSHOT",
+				"message": err.Error(),
+			}})
+		case service.ErrStorageNotEmpty:
+			c.JSON(http.StatusConflict, gin.H{"error": gin.H{
+				"code":    "STORAGE_NOT_EMPTY",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	users := 0
+	for _, team := range snap.Teams {
+		users += len(team.Members)
+	}
+	c.JSON(http.StatusOK, generated.SnapshotRestoreResponse{
+		Teams:        len(snap.Teams),
+		Users:        users,
+		PullRequests: len(snap.PullRequests),
+	})
+}
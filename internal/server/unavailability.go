@@ -0,0 +1,226 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/generated"
+	"github.com/pozedorum/set_pr_reviers_service/internal/ical"
+	"github.com/pozedorum/set_pr_reviers_service/internal/service"
+)
+
+func (s *PRServer) handleGetUnavailability(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+		return
+	}
+
+	periods, err := s.serv.GetUnavailability(c.Request.Context(), userID)
+	if err != nil {
+		s.logger.Error("GET_UNAVAILABILITY_ERROR", "Failed to get unavailability periods",
+			"error", err, "user_id", userID)
+
+		switch err {
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	response := make([]generated.Unavailability, len(periods))
+	for i, period := range periods {
+		response[i] = entityUnavailabilityToGenerated(*period)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id": userID,
+		"periods": response,
+	})
+}
+
+func (s *PRServer) handleAddUnavailability(c *gin.Context) {
+	var request generated.UnavailabilityCreateRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	period := entity.Unavailability{
+		UserID:          request.UserId,
+		StartsAt:        request.StartsAt,
+		EndsAt:          request.EndsAt,
+		ReassignReviews: request.ReassignReviews != nil && *request.ReassignReviews,
+	}
+	if request.Reason != nil {
+		period.Reason = *request.Reason
+	}
+
+	if err := s.serv.AddUnavailability(c.Request.Context(), &period); err != nil {
+		s.logger.Error("ADD_UNAVAILABILITY_ERROR", "Failed to add unavailability period",
+			"error", err, "user_id", request.UserId)
+
+		switch err {
+		case service.ErrEmptyUserID, service.ErrInvalidUnavailabilityPeriod:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_PERIOD",
+				"message": err.Error(),
+			}})
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"period": entityUnavailabilityToGenerated(period)})
+}
+
+func (s *PRServer) handleDeleteUnavailability(c *gin.Context) {
+	id := c.GetInt64("unavailability_id")
+
+	if err := s.serv.DeleteUnavailability(c.Request.Context(), id); err != nil {
+		s.logger.Error("DELETE_UNAVAILABILITY_ERROR", "Failed to delete unavailability period",
+			"error", err, "unavailability_id", id)
+
+		switch err {
+		case service.ErrNoUnavailability:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *PRServer) handleImportCalendar(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxImportSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if len(body) > maxImportSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+			"code":    "INVALID_CALENDAR",
+			"message": fmt.Sprintf("file is larger than %d bytes", maxImportSize),
+		}})
+		return
+	}
+
+	events, err := ical.Parse(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+			"code":    "INVALID_CALENDAR",
+			"message": err.Error(),
+		}})
+		return
+	}
+
+	opts := entity.CalendarImportOptions{
+		Category:        s.calendarCategory,
+		ReassignReviews: c.GetBool("reassign_reviews"),
+	}
+	if category, ok := c.Get("category"); ok {
+		opts.Category = category.(string)
+	}
+
+	result, err := s.serv.ImportCalendar(c.Request.Context(), events, opts)
+	if err != nil {
+		s.logger.Error("IMPORT_CALENDAR_ERROR", "Failed to import calendar",
+			"error", err, "events", len(events))
+
+		switch err {
+		case service.ErrEmptyCalendarCategory:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_CALENDAR",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entityCalendarImportResultToGenerated(result))
+}
+
+func (s *PRServer) handleGetUserAliases(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+		return
+	}
+
+	aliases, err := s.serv.GetUserAliases(c.Request.Context(), userID)
+	if err != nil {
+		s.logger.Error("GET_USER_ALIASES_ERROR", "Failed to get user aliases",
+			"error", err, "user_id", userID)
+
+		switch err {
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, generated.UserAliases{UserId: userID, Aliases: aliases})
+}
+
+func (s *PRServer) handleSetUserAliases(c *gin.Context) {
+	var request generated.UserAliases
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	aliases, err := s.serv.SetUserAliases(c.Request.Context(), request.UserId, request.Aliases)
+	if err != nil {
+		s.logger.Error("SET_USER_ALIASES_ERROR", "Failed to set user aliases",
+			"error", err, "user_id", request.UserId)
+
+		switch err {
+		case service.ErrEmptyUserID, service.ErrEmptyAlias:
+			c.JSON(http.StatusBadRequest, gin.H{"error": gin.H{
+				"code":    "INVALID_ALIAS",
+				"message": err.Error(),
+			}})
+		case service.ErrNoUser:
+			c.JSON(http.StatusNotFound, gin.H{"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": err.Error(),
+			}})
+		case service.ErrAliasTaken:
+			c.JSON(http.StatusConflict, gin.H{"error": gin.H{
+				"code":    "ALIAS_TAKEN",
+				"message": err.Error(),
+			}})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, generated.UserAliases{UserId: request.UserId, Aliases: aliases})
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+)
+
+// ImportCalendar превращает события календаря отсутствий с категорией opts.Category
+// в периоды недоступности. Отсутствующие находятся по email или имени среди псевдонимов.
+// Период связан с событием через его UID, поэтому повторный импорт того же календаря
+// обновляет периоды, а не создаёт новые, и удаляет ещё не закончившиеся периоды отменённых,
+// пропавших из календаря событий и участников, которых в событии больше нет.
+// Все изменения применяются одной транзакцией
+func (servs *PrService) ImportCalendar(ctx context.Context, events []entity.CalendarEvent, opts entity.CalendarImportOptions) (*entity.CalendarImportResult, error) {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_IMPORT_CALENDAR", "Starting calendar import",
+		"events_count", len(events),
+		"category", opts.Category)
+
+	category := strings.TrimSpace(opts.Category)
+	if category == "" {
+		return nil, ErrEmptyCalendarCategory
+	}
+
+	var (
+		result  *entity.CalendarImportResult
+		changed []*entity.Unavailability
+	)
+	err := servs.repo.WithTx(ctx, func(repo interfaces.Repository) error {
+		result = &entity.CalendarImportResult{}
+		changed = nil
+		stored, err := findImportedPeriods(ctx, repo)
+		if err != nil {
+			return err
+		}
+		// Импортированные периоды пользователей по UID события
+		imported := make(map[string]map[string]*entity.Unavailability)
+		for _, period := range stored {
+			if imported[period.UserID] == nil {
+				imported[period.UserID] = make(map[string]*entity.Unavailability)
+			}
+			imported[period.UserID][period.ExternalID] = period
+		}
+		// ID сохранённых периодов, событие и участник которых остались в календаре
+		kept := make(map[int64]struct{})
+
+		for _, event := range events {
+			if event.Cancelled || !hasCategory(event, category) || !event.EndsAt.After(start) {
+				continue
+			}
+			skip := func(reason string) {
+				result.Skipped = append(result.Skipped, entity.CalendarImportSkip{
+					UID:     event.UID,
+					Summary: event.Summary,
+					Reason:  reason,
+				})
+			}
+			switch {
+			case event.UID == "":
+				skip("event has no UID")
+				continue
+			case !event.EndsAt.After(event.StartsAt):
+				skip("event must end after it starts")
+				continue
+			case len(event.People) == 0:
+				skip("event has no attendees or organizer")
+				continue
+			}
+
+			for _, person := range event.People {
+				userID, err := resolveCalendarPerson(ctx, repo, person)
+				if errors.Is(err, entity.ErrNoUser) {
+					skip(fmt.Sprintf("no user with email or alias %q", calendarPersonLabel(person)))
+					continue
+				}
+				if err != nil {
+					return err
+				}
+
+				periods, ok := imported[userID]
+				if !ok {
+					periods = make(map[string]*entity.Unavailability)
+					imported[userID] = periods
+				}
+
+				period, ok := periods[event.UID]
+				if ok {
+					kept[period.ID] = struct{}{}
+				}
+				switch {
+				case !ok:
+					period = &entity.Unavailability{
+						UserID:          userID,
+						StartsAt:        event.StartsAt,
+						EndsAt:          event.EndsAt,
+						Reason:          event.Summary,
+						ReassignReviews: opts.ReassignReviews,
+						ExternalID:      event.UID,
+					}
+					if err := repo.CreateUnavailability(ctx, period); err != nil {
+						return err
+					}
+					periods[event.UID] = period
+					result.Created++
+				case period.StartsAt.Equal(event.StartsAt) && period.EndsAt.Equal(event.EndsAt) &&
+					period.Reason == event.Summary:
+					result.Unchanged++
+					continue
+				default:
+					// Перенесённый на другие даты отпуск переназначается заново
+					if !period.StartsAt.Equal(event.StartsAt) {
+						period.ReassignedAt = time.Time{}
+					}
+					period.StartsAt = event.StartsAt
+					period.EndsAt = event.EndsAt
+					period.Reason = event.Summary
+					if err := repo.UpdateUnavailability(ctx, period); err != nil {
+						return err
+					}
+					result.Updated++
+				}
+				changed = append(changed, period)
+			}
+		}
+
+		// Закончившиеся периоды остаются историей, даже если событие уже удалили из календаря
+		for _, period := range stored {
+			if _, ok := kept[period.ID]; ok || !period.EndsAt.After(start) {
+				continue
+			}
+			if err := repo.DeleteUnavailability(ctx, period.ID); err != nil {
+				return err
+			}
+			result.Deleted++
+		}
+		return nil
+	})
+	if err != nil {
+		servs.logger.Error("SERVICE_IMPORT_CALENDAR", "Failed to import calendar",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	// Как и в AddUnavailability, уже идущие периоды не ждут фоновой проверки
+	for _, period := range changed {
+		if !period.ReassignReviews || !period.ReassignedAt.IsZero() || !period.Covers(start) {
+			continue
+		}
+		if _, err := servs.reassignPeriodReviews(ctx, period, start); err != nil {
+			servs.logger.Warn("SERVICE_IMPORT_CALENDAR", "Failed to reassign reviews, will retry",
+				"user_id", period.UserID,
+				"unavailability_id", period.ID,
+				"error", err)
+		}
+	}
+
+	servs.logger.Info("SERVICE_IMPORT_CALENDAR", "Calendar imported successfully",
+		"created_count", result.Created,
+		"updated_count", result.Updated,
+		"unchanged_count", result.Unchanged,
+		"deleted_count", result.Deleted,
+		"skipped_count", len(result.Skipped),
+		"duration_ms", time.Since(start).Milliseconds())
+	return result, nil
+}
+
+func hasCategory(event entity.CalendarEvent, category string) bool {
+	for _, eventCategory := range event.Categories {
+		if strings.EqualFold(eventCategory, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveCalendarPerson ищет пользователя сначала по email, затем по имени
+func resolveCalendarPerson(ctx context.Context, repo interfaces.Repository, person entity.CalendarPerson) (string, error) {
+	for _, candidate := range []string{person.Email, person.Name} {
+		alias := normalizeAlias(candidate)
+		if alias == "" {
+			continue
+		}
+		userID, err := repo.FindUserIDByAlias(ctx, alias)
+		if !errors.Is(err, entity.ErrNoUser) {
+			return userID, err
+		}
+	}
+	return "", entity.ErrNoUser
+}
+
+func calendarPersonLabel(person entity.CalendarPerson) string {
+	if person.Email != "" {
+		return person.Email
+	}
+	return person.Name
+}
+
+// findImportedPeriods возвращает периоды всех пользователей, импортированные из календаря
+func findImportedPeriods(ctx context.Context, repo interfaces.Repository) ([]*entity.Unavailability, error) {
+	periods, err := repo.ListUnavailabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	imported := make([]*entity.Unavailability, 0, len(periods))
+	for _, period := range periods {
+		if period.ExternalID != "" {
+			imported = append(imported, period)
+		}
+	}
+	return imported, nil
+}
+
+// SetUserAliases заменяет псевдонимы пользователя. Псевдонимы сравниваются без учёта
+// регистра и пробелов по краям, поэтому хранятся в нижнем регистре
+func (servs *PrService) SetUserAliases(ctx context.Context, userID string, aliases []string) ([]string, error) {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_SET_USER_ALIASES", "Setting user aliases",
+		"user_id", userID,
+		"aliases_count", len(aliases))
+
+	if userID == "" {
+		return nil, ErrEmptyUserID
+	}
+
+	normalized := make([]string, 0, len(aliases))
+	seen := make(map[string]struct{}, len(aliases))
+	for _, alias := range aliases {
+		alias = normalizeAlias(alias)
+		if alias == "" {
+			return nil, ErrEmptyAlias
+		}
+		if _, ok := seen[alias]; ok {
+			continue
+		}
+		seen[alias] = struct{}{}
+		normalized = append(normalized, alias)
+	}
+	sort.Strings(normalized)
+
+	if err := servs.repo.SetUserAliases(ctx, userID, normalized); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNoUser):
+			return nil, ErrNoUser
+		case errors.Is(err, entity.ErrAliasTaken):
+			return nil, ErrAliasTaken
+		}
+		servs.logger.Error("SERVICE_SET_USER_ALIASES", "Failed to set user aliases",
+			"user_id", userID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	servs.logger.Info("SERVICE_SET_USER_ALIASES", "User aliases set successfully",
+		"user_id", userID,
+		"aliases_count", len(normalized),
+		"duration_ms", time.Since(start).Milliseconds())
+	return normalized, nil
+}
+
+func (servs *PrService) GetUserAliases(ctx context.Context, userID string) ([]string, error) {
+	if userID == "" {
+		return nil, ErrEmptyUserID
+	}
+
+	if _, err := servs.repo.FindUserByID(ctx, userID); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return nil, ErrNoUser
+		}
+		return nil, err
+	}
+	return servs.repo.FindUserAliases(ctx, userID)
+}
+
+func normalizeAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
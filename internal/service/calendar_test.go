@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func expectAliases(mockRepo *mocks.Repository, aliases map[string]string) {
+	mockRepo.On("FindUserIDByAlias", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, alias string) string { return aliases[alias] },
+		func(_ context.Context, alias string) error {
+			if _, ok := aliases[alias]; !ok {
+				return fmt.Errorf("alias %s: %w", alias, entity.ErrNoUser)
+			}
+			return nil
+		})
+}
+
+func TestImportCalendar(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	end := start.Add(72 * time.Hour)
+	events := []entity.CalendarEvent{
+		{
+			UID: "new@cal", Summary: "Vacation", StartsAt: start, EndsAt: end,
+			Categories: []string{"Team", "ooo"},
+			People:     []entity.CalendarPerson{{Email: "Alice@Example.com"}},
+		},
+		{
+			UID: "moved@cal", Summary: "Trip", StartsAt: start.Add(time.Hour), EndsAt: end,
+			Categories: []string{"OOO"},
+			People:     []entity.CalendarPerson{{Email: "bob@example.com", Name: "Bob"}},
+		},
+		{
+			UID: "same@cal", Summary: "Sick", StartsAt: start, EndsAt: end,
+			Categories: []string{"OOO"},
+			People:     []entity.CalendarPerson{{Email: "unknown@example.com", Name: "Bob"}},
+		},
+		{
+			UID: "stranger@cal", Summary: "Away", StartsAt: start, EndsAt: end,
+			Categories: []string{"OOO"},
+			People:     []entity.CalendarPerson{{Email: "carol@example.com", Name: "Carol"}},
+		},
+		{UID: "", StartsAt: start, EndsAt: end, Categories: []string{"OOO"}},
+		{UID: "nobody@cal", StartsAt: start, EndsAt: end, Categories: []string{"OOO"}},
+		// Не импортируются и в итог не попадают
+		{UID: "meeting@cal", StartsAt: start, EndsAt: end, Categories: []string{"Meeting"},
+			People: []entity.CalendarPerson{{Email: "alice@example.com"}}},
+		{UID: "cancelled@cal", StartsAt: start, EndsAt: end, Categories: []string{"OOO"}, Cancelled: true,
+			People: []entity.CalendarPerson{{Email: "alice@example.com"}}},
+		{UID: "past@cal", StartsAt: start.AddDate(-1, 0, 0), EndsAt: end.AddDate(-1, 0, 0), Categories: []string{"OOO"},
+			People: []entity.CalendarPerson{{Email: "alice@example.com"}}},
+	}
+
+	expectTx(mockRepo)
+	expectAliases(mockRepo, map[string]string{"alice@example.com": "u1", "bob@example.com": "u2", "bob": "u2"})
+	mockRepo.On("ListUnavailabilities", mock.Anything).Return([]*entity.Unavailability{
+		{ID: 8, UserID: "u1", StartsAt: start, EndsAt: end, ExternalID: "cancelled@cal"},
+		{ID: 9, UserID: "u1", StartsAt: start.AddDate(-1, 0, 0), EndsAt: end.AddDate(-1, 0, 0), ExternalID: "deleted@cal"},
+		{ID: 5, UserID: "u2", StartsAt: start, EndsAt: end, Reason: "Trip", ExternalID: "moved@cal",
+			ReassignReviews: true, ReassignedAt: start},
+		{ID: 6, UserID: "u2", StartsAt: start, EndsAt: end, Reason: "Sick", ExternalID: "same@cal"},
+		{ID: 7, UserID: "u2", StartsAt: start, EndsAt: end, Reason: "Manual"},
+	}, nil)
+	mockRepo.On("CreateUnavailability", mock.Anything, &entity.Unavailability{
+		UserID: "u1", StartsAt: start, EndsAt: end, Reason: "Vacation", ReassignReviews: true, ExternalID: "new@cal",
+	}).Return(nil)
+	mockRepo.On("UpdateUnavailability", mock.Anything, &entity.Unavailability{
+		ID: 5, UserID: "u2", StartsAt: start.Add(time.Hour), EndsAt: end, Reason: "Trip", ExternalID: "moved@cal",
+		ReassignReviews: true,
+	}).Return(nil)
+	// Период отменённого события удаляется, закончившийся и добавленный вручную остаются
+	mockRepo.On("DeleteUnavailability", mock.Anything, int64(8)).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	result, err := service.ImportCalendar(context.Background(), events,
+		entity.CalendarImportOptions{Category: "OOO", ReassignReviews: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Equal(t, []entity.CalendarImportSkip{
+		{UID: "stranger@cal", Summary: "Away", Reason: `no user with email or alias "carol@example.com"`},
+		{UID: "", Reason: "event has no UID"},
+		{UID: "nobody@cal", Reason: "event has no attendees or organizer"},
+	}, result.Skipped)
+	// Периоды начинаются завтра - переназначать нечего
+	mockRepo.AssertNotCalled(t, "FindPRsByReviewer", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportCalendar_ReassignsStartedPeriod(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	events := []entity.CalendarEvent{{
+		UID: "now@cal", StartsAt: time.Now().Add(-time.Hour), EndsAt: time.Now().Add(time.Hour),
+		Categories: []string{"OOO"},
+		People:     []entity.CalendarPerson{{Email: "alice@example.com"}},
+	}}
+
+	expectTx(mockRepo)
+	expectAliases(mockRepo, map[string]string{"alice@example.com": "u1"})
+	mockRepo.On("ListUnavailabilities", mock.Anything).Return(nil, nil)
+	mockRepo.On("CreateUnavailability", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*entity.Unavailability).ID = 3
+	}).Return(nil)
+	mockRepo.On("FindPRsByReviewer", mock.Anything, "u1").Return(nil, nil)
+	mockRepo.On("MarkUnavailabilityReassigned", mock.Anything, int64(3), mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	result, err := service.ImportCalendar(context.Background(), events,
+		entity.CalendarImportOptions{Category: "ooo", ReassignReviews: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportCalendar_ReimportDeletesRemovedPeriods(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	// Хранилище периодов между импортами
+	var stored []*entity.Unavailability
+	expectTx(mockRepo)
+	expectAliases(mockRepo, map[string]string{"alice@example.com": "u1", "bob@example.com": "u2"})
+	mockRepo.On("ListUnavailabilities", mock.Anything).Return(
+		func(context.Context) []*entity.Unavailability {
+			periods := make([]*entity.Unavailability, len(stored))
+			for i, period := range stored {
+				cp := *period
+				periods[i] = &cp
+			}
+			return periods
+		}, nil)
+	mockRepo.On("CreateUnavailability", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		period := *args.Get(1).(*entity.Unavailability)
+		period.ID = int64(len(stored) + 1)
+		args.Get(1).(*entity.Unavailability).ID = period.ID
+		stored = append(stored, &period)
+	}).Return(nil)
+	mockRepo.On("DeleteUnavailability", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		id := args.Get(1).(int64)
+		for i, period := range stored {
+			if period.ID == id {
+				stored = append(stored[:i], stored[i+1:]...)
+				break
+			}
+		}
+	}).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	opts := entity.CalendarImportOptions{Category: "OOO"}
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	event := entity.CalendarEvent{
+		UID: "offsite@cal", Summary: "Offsite", StartsAt: start, EndsAt: start.Add(48 * time.Hour),
+		Categories: []string{"OOO"},
+		People:     []entity.CalendarPerson{{Email: "alice@example.com"}, {Email: "bob@example.com"}},
+	}
+
+	result, err := service.ImportCalendar(context.Background(), []entity.CalendarEvent{event}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	require.Len(t, stored, 2)
+
+	// Боб больше не участвует в событии
+	event.People = event.People[:1]
+	result, err = service.ImportCalendar(context.Background(), []entity.CalendarEvent{event}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, 1, result.Deleted)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "u1", stored[0].UserID)
+
+	event.Cancelled = true
+	result, err = service.ImportCalendar(context.Background(), []entity.CalendarEvent{event}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Unchanged)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Empty(t, stored)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportCalendar_EmptyCategory(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	service := NewPRService(mockRepo, logger)
+
+	_, err = service.ImportCalendar(context.Background(), nil, entity.CalendarImportOptions{Category: " "})
+
+	assert.Equal(t, ErrEmptyCalendarCategory, err)
+	mockRepo.AssertNotCalled(t, "WithTx", mock.Anything, mock.Anything)
+}
+
+func TestSetUserAliases(t *testing.T) {
+	tests := []struct {
+		name      string
+		aliases   []string
+		repoErr   error
+		want      []string
+		wantErr   error
+		repoCalls bool
+	}{
+		{
+			name:      "normalized and deduplicated",
+			aliases:   []string{" Alice@Example.com", "alice", "ALICE@example.com"},
+			want:      []string{"alice", "alice@example.com"},
+			repoCalls: true,
+		},
+		{
+			name:    "empty alias",
+			aliases: []string{"alice", "  "},
+			wantErr: ErrEmptyAlias,
+		},
+		{
+			name:      "alias of another user",
+			aliases:   []string{"bob"},
+			repoErr:   fmt.Errorf("alias bob: %w", entity.ErrAliasTaken),
+			wantErr:   ErrAliasTaken,
+			repoCalls: true,
+		},
+		{
+			name:      "unknown user",
+			aliases:   []string{"ghost"},
+			repoErr:   fmt.Errorf("user u1: %w", entity.ErrNoUser),
+			wantErr:   ErrNoUser,
+			repoCalls: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.Repository{}
+			logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+			require.NoError(t, err)
+
+			mockRepo.On("SetUserAliases", mock.Anything, "u1", mock.Anything).Return(tt.repoErr)
+			service := NewPRService(mockRepo, logger)
+
+			aliases, err := service.SetUserAliases(context.Background(), "u1", tt.aliases)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, aliases)
+				mockRepo.AssertCalled(t, "SetUserAliases", mock.Anything, "u1", tt.want)
+			}
+			if !tt.repoCalls {
+				mockRepo.AssertNotCalled(t, "SetUserAliases", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
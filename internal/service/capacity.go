@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+)
+
+// SetUserMaxOpenReviews задаёт предел открытых ревью пользователя, 0 - как у команды
+func (servs *PrService) SetUserMaxOpenReviews(ctx context.Context, userID string, limit int) (*entity.User, error) {
+	start := time.Now()
+
+	if userID == "" {
+		return nil, ErrEmptyUserID
+	}
+	if limit < 0 {
+		return nil, ErrInvalidReviewLimit
+	}
+
+	if err := servs.repo.SetMaxOpenReviews(ctx, userID, limit); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return nil, ErrNoUser
+		}
+		servs.logger.Error("SERVICE_SET_MAX_OPEN_REVIEWS", "Failed to set user review limit",
+			"user_id", userID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	user, err := servs.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	servs.logger.Info("SERVICE_SET_MAX_OPEN_REVIEWS", "User review limit updated",
+		"user_id", userID,
+		"max_open_reviews", limit,
+		"duration_ms", time.Since(start).Milliseconds())
+	return user, nil
+}
+
+// SetTeamMaxOpenReviews задаёт предел открытых ревью по умолчанию для участников
+// команды, 0 - без ограничения
+func (servs *PrService) SetTeamMaxOpenReviews(ctx context.Context, teamName string, limit int) (*entity.Team, error) {
+	start := time.Now()
+
+	if teamName == "" {
+		return nil, ErrEmptyTeamName
+	}
+	if limit < 0 {
+		return nil, ErrInvalidReviewLimit
+	}
+
+	if err := servs.repo.SetTeamMaxOpenReviews(ctx, teamName, limit); err != nil {
+		if errors.Is(err, entity.ErrNoTeam) {
+			return nil, ErrNoTeam
+		}
+		servs.logger.Error("SERVICE_SET_TEAM_MAX_OPEN_REVIEWS", "Failed to set team review limit",
+			"team_name", teamName,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	team, err := servs.repo.FindTeamByName(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("find team: %w", err)
+	}
+
+	servs.logger.Info("SERVICE_SET_TEAM_MAX_OPEN_REVIEWS", "Team review limit updated",
+		"team_name", teamName,
+		"max_open_reviews", limit,
+		"duration_ms", time.Since(start).Milliseconds())
+	return team, nil
+}
+
+// dropAtCapacity убирает из candidates тех, у кого открытых ревью не меньше предела:
+// собственного или, если он 0, командного. Возвращает оставшихся и user_id убранных
+func dropAtCapacity(ctx context.Context, repo interfaces.Repository, teamName string, candidates []*entity.User) ([]*entity.User, []string, error) {
+	team, err := repo.FindTeamByName(ctx, teamName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find team: %w", err)
+	}
+
+	limits := make(map[string]int, len(candidates))
+	for _, candidate := range candidates {
+		limit := candidate.MaxOpenReviews
+		if limit == 0 {
+			limit = team.MaxOpenReviews
+		}
+		if limit > 0 {
+			limits[candidate.UserID] = limit
+		}
+	}
+	// Без ограничений открытые ревью можно не считать
+	if len(limits) == 0 {
+		return candidates, nil, nil
+	}
+
+	loads, err := repo.FindReviewLoads(ctx, teamName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find review loads: %w", err)
+	}
+
+	var (
+		remaining  []*entity.User
+		atCapacity []string
+	)
+	for _, candidate := range candidates {
+		if limit, ok := limits[candidate.UserID]; ok && loads[candidate.UserID].OpenReviews >= limit {
+			atCapacity = append(atCapacity, candidate.UserID)
+			continue
+		}
+		remaining = append(remaining, candidate)
+	}
+	return remaining, atCapacity, nil
+}
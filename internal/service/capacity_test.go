@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePR_SkipsUsersAtCapacity(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	author := &entity.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(author, nil)
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return([]*entity.User{
+		author,
+		// Собственный предел важнее командного
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true, MaxOpenReviews: 1},
+		{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true, MaxOpenReviews: 5},
+	}, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return(nil, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend", MaxOpenReviews: 2}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("FindReviewLoads", mock.Anything, "backend").Return(map[string]entity.ReviewLoad{
+		"u2": {OpenReviews: 1}, "u3": {OpenReviews: 2}, "u4": {OpenReviews: 4},
+	}, nil)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRServiceWithSeed(mockRepo, logger, 42)
+	pr := &entity.PullRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"}
+	assignment, err := service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4"}, pr.AssignedReviewers)
+	assert.Equal(t, &entity.ReviewAssignment{
+		UnfilledSlots: 1,
+		AtCapacity:    []string{"u2", "u3"},
+		Candidates: []entity.CandidateExplanation{
+			{UserID: "u1", ExcludedBy: entity.ExcludedAuthor},
+			{UserID: "u2", ExcludedBy: entity.ExcludedAtCapacity},
+			{UserID: "u3", ExcludedBy: entity.ExcludedAtCapacity},
+			{UserID: "u4", Score: entity.NewCandidateScore(), Selected: true},
+		},
+	}, assignment)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFindReviewCandidates_NoLimits(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	users := []*entity.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+	}
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return(users, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return(nil, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend"}, nil)
+
+	service := NewPRService(mockRepo, logger).(*PrService)
+	candidates, excluded, err := service.findReviewCandidates(context.Background(), mockRepo, "backend", "u1")
+
+	require.NoError(t, err)
+	assert.Equal(t, users[1:], candidates)
+	assert.Equal(t, []entity.CandidateExplanation{{UserID: "u1", ExcludedBy: entity.ExcludedAuthor}}, excluded)
+	// Без пределов открытые ревью не считаются
+	mockRepo.AssertNotCalled(t, "FindReviewLoads", mock.Anything, mock.Anything)
+}
+
+func TestSetUserMaxOpenReviews(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    string
+		limit     int
+		repoErr   error
+		wantErr   error
+		repoCalls bool
+	}{
+		{name: "valid", userID: "u1", limit: 3, repoCalls: true},
+		{name: "inherit team limit", userID: "u1", limit: 0, repoCalls: true},
+		{name: "negative", userID: "u1", limit: -1, wantErr: ErrInvalidReviewLimit},
+		{name: "empty user", userID: "", limit: 1, wantErr: ErrEmptyUserID},
+		{
+			name:      "unknown user",
+			userID:    "u1",
+			limit:     1,
+			repoErr:   fmt.Errorf("user u1: %w", entity.ErrNoUser),
+			wantErr:   ErrNoUser,
+			repoCalls: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.Repository{}
+			logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+			require.NoError(t, err)
+
+			mockRepo.On("SetMaxOpenReviews", mock.Anything, tt.userID, tt.limit).Return(tt.repoErr)
+			mockRepo.On("FindUserByID", mock.Anything, tt.userID).
+				Return(&entity.User{UserID: tt.userID, MaxOpenReviews: tt.limit}, nil)
+			service := NewPRService(mockRepo, logger)
+
+			user, err := service.SetUserMaxOpenReviews(context.Background(), tt.userID, tt.limit)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.limit, user.MaxOpenReviews)
+			}
+			if !tt.repoCalls {
+				mockRepo.AssertNotCalled(t, "SetMaxOpenReviews", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestSetTeamMaxOpenReviews_Errors(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("SetTeamMaxOpenReviews", mock.Anything, "ghost", 2).Return(entity.ErrNoTeam)
+	service := NewPRService(mockRepo, logger)
+
+	_, err = service.SetTeamMaxOpenReviews(context.Background(), "ghost", 2)
+	assert.ErrorIs(t, err, ErrNoTeam)
+	_, err = service.SetTeamMaxOpenReviews(context.Background(), "backend", -1)
+	assert.ErrorIs(t, err, ErrInvalidReviewLimit)
+	_, err = service.SetTeamMaxOpenReviews(context.Background(), "", 2)
+	assert.ErrorIs(t, err, ErrEmptyTeamName)
+	mockRepo.AssertNumberOfCalls(t, "SetTeamMaxOpenReviews", 1)
+}
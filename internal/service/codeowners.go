@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/codeowners"
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+)
+
+// SetCodeOwners заменяет правила CODEOWNERS репозитория. Владельцы не проверяются:
+// неизвестные пользователи и команды просто не назначаются ревьюверами
+func (servs *PrService) SetCodeOwners(ctx context.Context, owners *entity.CodeOwners) error {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_SET_CODE_OWNERS", "Setting code owners",
+		"repository", owners.Repository,
+		"rules_count", len(owners.Rules))
+
+	if err := checkCodeOwnersCorrectness(owners); err != nil {
+		servs.logger.Warn("SERVICE_SET_CODE_OWNERS", "Code owners validation failed",
+			"repository", owners.Repository,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	if err := servs.repo.SetCodeOwners(ctx, owners); err != nil {
+		servs.logger.Error("SERVICE_SET_CODE_OWNERS", "Failed to set code owners",
+			"repository", owners.Repository,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	servs.logger.Info("SERVICE_SET_CODE_OWNERS", "Code owners set successfully",
+		"repository", owners.Repository,
+		"rules_count", len(owners.Rules),
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (servs *PrService) GetCodeOwners(ctx context.Context, repository string) (*entity.CodeOwners, error) {
+	owners, err := servs.repo.FindCodeOwners(ctx, repository)
+	if errors.Is(err, entity.ErrNoCodeOwners) {
+		return nil, ErrNoCodeOwners
+	}
+	if err != nil {
+		servs.logger.Error("SERVICE_GET_CODE_OWNERS", "Failed to get code owners",
+			"repository", repository,
+			"error", err)
+		return nil, err
+	}
+	return owners, nil
+}
+
+func (servs *PrService) DeleteCodeOwners(ctx context.Context, repository string) error {
+	err := servs.repo.DeleteCodeOwners(ctx, repository)
+	if errors.Is(err, entity.ErrNoCodeOwners) {
+		return ErrNoCodeOwners
+	}
+	if err != nil {
+		servs.logger.Error("SERVICE_DELETE_CODE_OWNERS", "Failed to delete code owners",
+			"repository", repository,
+			"error", err)
+		return err
+	}
+
+	servs.logger.Info("SERVICE_DELETE_CODE_OWNERS", "Code owners deleted",
+		"repository", repository)
+	return nil
+}
+
+func checkCodeOwnersCorrectness(owners *entity.CodeOwners) error {
+	if strings.TrimSpace(owners.Repository) == "" {
+		return ErrEmptyRepository
+	}
+	if _, err := codeowners.Compile(owners.Rules); err != nil {
+		return ErrInvalidCodeOwners
+	}
+	return nil
+}
+
+// assignCodeOwners назначает по одному владельцу кода на каждое правило CODEOWNERS
+// репозитория PR, совпавшее с его изменёнными файлами. Если один из уже выбранных
+// владельцев подходит и следующему правилу, новый ревьювер для него не нужен.
+// Правила, ни одного владельца которых назначить нельзя, попадают в UncoveredRules,
+// пропущенные из-за предела открытых ревью - в AtCapacity. Владельцы, исключённые
+// правилами пар автора, не назначаются, предпочтённые выбираются чаще
+func (servs *PrService) assignCodeOwners(ctx context.Context, repo interfaces.Repository, pr *entity.PullRequest, rules reviewerRules, assignment *entity.ReviewAssignment) ([]string, error) {
+	if pr.Metadata.Repository == "" || len(pr.Metadata.Paths) == 0 {
+		return nil, nil
+	}
+	owners, err := repo.FindCodeOwners(ctx, pr.Metadata.Repository)
+	if errors.Is(err, entity.ErrNoCodeOwners) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find code owners: %w", err)
+	}
+	ruleset, err := codeowners.Compile(owners.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("compile code owners: %w", err)
+	}
+
+	resolver := &ownerResolver{servs: servs, repo: repo, authorID: pr.AuthorID, teams: make(map[string]teamCandidates)}
+	var reviewers []string
+	for _, rule := range ruleset.MatchAll(pr.Metadata.Paths) {
+		if !rule.HasOwners() {
+			continue
+		}
+		candidates, err := resolver.candidates(ctx, rule)
+		if err != nil {
+			return nil, err
+		}
+		weights := make([]float64, len(candidates))
+		for i := range weights {
+			weights[i] = 1
+		}
+		candidates, weights = rules.apply(candidates, weights)
+		if len(candidates) == 0 {
+			assignment.UncoveredRules = append(assignment.UncoveredRules, rule)
+			continue
+		}
+
+		reviewerID := ""
+		for _, candidate := range candidates {
+			if servs.containsReviewer(reviewers, candidate.UserID) {
+				reviewerID = candidate.UserID
+				break
+			}
+		}
+		if reviewerID == "" {
+			reviewerID = servs.selectReviewers(candidates, weights, 1)[0]
+			reviewers = append(reviewers, reviewerID)
+		}
+		assignment.CodeOwners = append(assignment.CodeOwners, entity.CodeOwnerAssignment{ReviewerID: reviewerID, Rule: rule})
+	}
+	assignment.AtCapacity = resolver.atCapacity
+	return reviewers, nil
+}
+
+// ownerResolver находит владельцев правил среди пользователей, которых можно назначить
+// ревьюверами. Кандидаты каждой команды запрашиваются один раз на PR
+type ownerResolver struct {
+	servs      *PrService
+	repo       interfaces.Repository
+	authorID   string
+	teams      map[string]teamCandidates
+	atCapacity []string
+}
+
+type teamCandidates struct {
+	members    []*entity.User
+	atCapacity []string
+}
+
+func (r *ownerResolver) candidates(ctx context.Context, rule entity.CodeOwnerRule) ([]*entity.User, error) {
+	var candidates []*entity.User
+	add := func(user *entity.User) {
+		for _, candidate := range candidates {
+			if candidate.UserID == user.UserID {
+				return
+			}
+		}
+		candidates = append(candidates, user)
+	}
+
+	for _, teamName := range rule.Teams {
+		team, err := r.team(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range team.members {
+			add(member)
+		}
+		r.markAtCapacity(team.atCapacity...)
+	}
+	for _, name := range rule.Users {
+		user, err := r.findUser(ctx, name)
+		if errors.Is(err, entity.ErrNoUser) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		team, err := r.team(ctx, user.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range team.members {
+			if member.UserID == user.UserID {
+				add(member)
+			}
+		}
+		if r.servs.containsReviewer(team.atCapacity, user.UserID) {
+			r.markAtCapacity(user.UserID)
+		}
+	}
+	return candidates, nil
+}
+
+// findUser ищет владельца по user_id, а затем по псевдониму
+func (r *ownerResolver) findUser(ctx context.Context, name string) (*entity.User, error) {
+	user, err := r.repo.FindUserByID(ctx, name)
+	if !errors.Is(err, entity.ErrNoUser) {
+		return user, err
+	}
+	userID, err := r.repo.FindUserIDByAlias(ctx, normalizeAlias(name))
+	if err != nil {
+		return nil, err
+	}
+	return r.repo.FindUserByID(ctx, userID)
+}
+
+// team возвращает кандидатов команды; неизвестная команда считается пустой
+func (r *ownerResolver) team(ctx context.Context, teamName string) (teamCandidates, error) {
+	if team, ok := r.teams[teamName]; ok {
+		return team, nil
+	}
+	members, excluded, err := r.servs.findReviewCandidates(ctx, r.repo, teamName, r.authorID)
+	if err != nil && !errors.Is(err, entity.ErrNoTeam) {
+		return teamCandidates{}, fmt.Errorf("find code owner candidates: %w", err)
+	}
+	team := teamCandidates{members: members, atCapacity: atCapacityIDs(excluded)}
+	r.teams[teamName] = team
+	return team, nil
+}
+
+func (r *ownerResolver) markAtCapacity(userIDs ...string) {
+	for _, userID := range userIDs {
+		if !r.servs.containsReviewer(r.atCapacity, userID) {
+			r.atCapacity = append(r.atCapacity, userID)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func expectTeam(mockRepo *mocks.Repository, teamName string, users ...*entity.User) {
+	mockRepo.On("FindUsersByTeam", mock.Anything, teamName).Return(users, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, teamName, mock.Anything).Return(nil, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, teamName).Return(&entity.Team{TeamName: teamName}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, mock.Anything).Return(nil, nil)
+}
+
+func TestCreatePR_CodeOwners(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	author := &entity.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	bob := &entity.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	dora := &entity.User{UserID: "d1", Username: "Dora", TeamName: "docs", IsActive: true}
+	rules := []entity.CodeOwnerRule{
+		{Line: 1, Pattern: "*.go", Users: []string{"u2"}},
+		{Line: 2, Pattern: "/docs/", Users: []string{"dora@example.com"}},
+		{Line: 3, Pattern: "/infra/", Users: []string{"ghost"}},
+	}
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(author, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u2").Return(bob, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "d1").Return(dora, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "dora@example.com").Return(nil, entity.ErrNoUser)
+	mockRepo.On("FindUserByID", mock.Anything, "ghost").Return(nil, entity.ErrNoUser)
+	mockRepo.On("FindUserIDByAlias", mock.Anything, "dora@example.com").Return("d1", nil)
+	mockRepo.On("FindUserIDByAlias", mock.Anything, "ghost").Return("", entity.ErrNoUser)
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("FindCodeOwners", mock.Anything, "acme/search").Return(&entity.CodeOwners{Repository: "acme/search", Rules: rules}, nil)
+	expectTeam(mockRepo, "backend", author, bob, &entity.User{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true})
+	expectTeam(mockRepo, "docs", dora)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRServiceWithSeed(mockRepo, logger, 42)
+	pr := &entity.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorID:        "u1",
+		Metadata: entity.PRMetadata{
+			Repository: "acme/search",
+			Paths:      []string{"cmd/main.go", "docs/search.md", "infra/search.tf"},
+		},
+	}
+	assignment, err := service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	// Оба места заняли владельцы, владелец из другой команды найден по псевдониму
+	assert.Equal(t, []string{"u2", "d1"}, pr.AssignedReviewers)
+	assert.Equal(t, []entity.CodeOwnerAssignment{
+		{ReviewerID: "u2", Rule: rules[0]},
+		{ReviewerID: "d1", Rule: rules[1]},
+	}, assignment.CodeOwners)
+	assert.Equal(t, []entity.CodeOwnerRule{rules[2]}, assignment.UncoveredRules)
+	assert.Zero(t, assignment.UnfilledSlots)
+}
+
+func TestCreatePR_CodeOwnerCoversSeveralRules(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	author := &entity.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	bob := &entity.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}
+	carol := &entity.User{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true}
+	rules := []entity.CodeOwnerRule{
+		{Line: 1, Pattern: "*.go", Users: []string{"u2"}},
+		{Line: 2, Pattern: "/api/", Teams: []string{"backend"}},
+	}
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(author, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u2").Return(bob, nil)
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("FindCodeOwners", mock.Anything, "acme/search").Return(&entity.CodeOwners{Repository: "acme/search", Rules: rules}, nil)
+	expectTeam(mockRepo, "backend", author, bob, carol)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRServiceWithSeed(mockRepo, logger, 42)
+	pr := &entity.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorID:        "u1",
+		Metadata:        entity.PRMetadata{Repository: "acme/search", Paths: []string{"main.go", "api/search.proto"}},
+	}
+	assignment, err := service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	// u2 уже назначен по первому правилу и входит в команду второго, второе место - обычный выбор
+	assert.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+	assert.Equal(t, []entity.CodeOwnerAssignment{
+		{ReviewerID: "u2", Rule: rules[0]},
+		{ReviewerID: "u2", Rule: rules[1]},
+	}, assignment.CodeOwners)
+	assert.Empty(t, assignment.UncoveredRules)
+}
+
+func TestCreatePR_NoCodeOwners(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	author := &entity.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(author, nil)
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("FindCodeOwners", mock.Anything, "acme/search").Return(nil, entity.ErrNoCodeOwners)
+	expectTeam(mockRepo, "backend", author, &entity.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true})
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	pr := &entity.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorID:        "u1",
+		Metadata:        entity.PRMetadata{Repository: "acme/search", Paths: []string{"main.go", " main.go", ""}},
+	}
+	assignment, err := service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+	assert.Equal(t, []string{"main.go"}, pr.Metadata.Paths)
+	assert.Empty(t, assignment.CodeOwners)
+}
+
+func TestSetCodeOwners_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		owners  *entity.CodeOwners
+		wantErr error
+	}{
+		{
+			name:    "empty repository",
+			owners:  &entity.CodeOwners{Repository: " ", Rules: []entity.CodeOwnerRule{{Line: 1, Pattern: "*", Users: []string{"u1"}}}},
+			wantErr: ErrEmptyRepository,
+		},
+		{
+			name:    "unsupported pattern",
+			owners:  &entity.CodeOwners{Repository: "acme/search", Rules: []entity.CodeOwnerRule{{Line: 1, Pattern: "*.[ch]", Users: []string{"u1"}}}},
+			wantErr: ErrInvalidCodeOwners,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.Repository{}
+			logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+			require.NoError(t, err)
+
+			service := NewPRService(mockRepo, logger)
+			err = service.SetCodeOwners(context.Background(), tt.owners)
+
+			assert.Equal(t, tt.wantErr, err)
+			mockRepo.AssertNotCalled(t, "SetCodeOwners", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetCodeOwners_NotFound(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("FindCodeOwners", mock.Anything, "acme/search").Return(nil, entity.ErrNoCodeOwners)
+	mockRepo.On("DeleteCodeOwners", mock.Anything, "acme/search").Return(entity.ErrNoCodeOwners)
+
+	service := NewPRService(mockRepo, logger)
+
+	_, err = service.GetCodeOwners(context.Background(), "acme/search")
+	assert.Equal(t, ErrNoCodeOwners, err)
+	err = service.DeleteCodeOwners(context.Background(), "acme/search")
+	assert.Equal(t, ErrNoCodeOwners, err)
+}
@@ -13,22 +13,81 @@ var (
 	ErrEmptyTeam         = errors.New("team has no members")
 	ErrTeamAlreadyExists = errors.New("team already exists")
 
+	ErrDuplicateRosterTeam = errors.New("team is listed more than once in roster")
+	ErrDuplicateRosterUser = errors.New("user is listed more than once in roster")
+	ErrDuplicateImportUser = errors.New("user is listed more than once in import")
+
 	ErrNoUser            = errors.New("no such user")
 	ErrEmptyUserID       = errors.New("empty team member user ID")
 	ErrEmptyUserUsername = errors.New("empty team member username")
 
-	ErrNoPR            = errors.New("no such pull request")
-	ErrNilPR           = errors.New("empty pull request")
-	ErrEmptyPRID       = errors.New("empty pull request ID")
-	ErrEmptyPRName     = errors.New("empty pull request name")
-	ErrEmptyPRAuthorID = errors.New("empty author ID of pull request")
-	ErrPRAlreadyExists = errors.New("pull request already exists")
+	ErrNoPR              = errors.New("no such pull request")
+	ErrNilPR             = errors.New("empty pull request")
+	ErrEmptyPRID         = errors.New("empty pull request ID")
+	ErrEmptyPRName       = errors.New("empty pull request name")
+	ErrEmptyPRAuthorID   = errors.New("empty author ID of pull request")
+	ErrPRAlreadyExists   = errors.New("pull request already exists")
+	ErrPRVersionConflict = errors.New("pull request was modified concurrently")
 
 	ErrCannotReassingOnMergedPR = errors.New("cannot reasing reviewer on closed pull request")
 	ErrWrongReassignReviewer    = errors.New("reassigned reviewer not in a team")
 	ErrNoReplacementCandidate   = errors.New("no available candidates for replacement")
+	ErrSeniorReviewerRequired   = errors.New("team policy requires a senior reviewer and no senior candidate is available")
+
+	ErrNoUnavailability            = errors.New("no such unavailability period")
+	ErrInvalidUnavailabilityPeriod = errors.New("unavailability period must end after it starts")
+	ErrEmptyAlias                  = errors.New("empty alias")
+	ErrAliasTaken                  = errors.New("alias belongs to another user")
+	ErrEmptyCalendarCategory       = errors.New("empty calendar category")
+
+	ErrNoWorkSchedule      = errors.New("user has no work schedule")
+	ErrInvalidWorkSchedule = errors.New("work schedule needs a known time zone, different start and end and at least one day")
+
+	ErrInvalidReviewLimit = errors.New("max open reviews must not be negative")
+	ErrInvalidUserLevel   = errors.New("user level must be junior, middle, senior or lead")
+
+	ErrInvalidPRMetadata = errors.New("pull request line and file counts must not be negative")
+
+	ErrInvalidSkill   = errors.New("skill needs a tag of up to 64 characters and a level from 1 to 3")
+	ErrDuplicateSkill = errors.New("skill tag is listed more than once")
+
+	ErrEmptyRepository   = errors.New("empty repository name")
+	ErrNoCodeOwners      = errors.New("repository has no code owners")
+	ErrInvalidCodeOwners = errors.New("code owners rule has an invalid pattern")
+
+	ErrInvalidReviewerRule = errors.New("reviewer rule needs two different users and kind exclude or prefer")
+	ErrNoReviewerRule      = errors.New("no reviewer rule for this author and reviewer")
+
+	ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot format version")
+	ErrDuplicateSnapshotPR        = errors.New("pull request is listed more than once in snapshot")
+	ErrSnapshotUnknownUser        = errors.New("snapshot pull request references user missing from snapshot")
+	ErrInvalidSnapshotPR          = errors.New("snapshot pull request has invalid status, version or timestamps")
+	ErrDuplicateSnapshotSchedule  = errors.New("user has more than one work schedule in snapshot")
+	ErrDuplicateSnapshotOwners    = errors.New("repository has more than one code owners set in snapshot")
+	ErrDuplicateSnapshotRule      = errors.New("author and reviewer pair has more than one rule in snapshot")
+	ErrStorageNotEmpty            = errors.New("storage is not empty")
+
+	ErrEmptyIdempotencyKey  = errors.New("empty idempotency key")
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+	// ErrIdempotencyKeyInProgress - первый запрос с этим ключом ещё выполняется
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is still in progress")
 )
 
 func ErrUserAlreadyExists(UserID string) error {
 	return fmt.Errorf("user %s already exists", UserID)
 }
+
+// RowError - ошибка в одной строке импорта. Index - позиция строки в переданном списке
+type RowError struct {
+	Index int
+	Err   error
+}
+
+// ImportError - импорт отклонён целиком из-за ошибок в строках
+type ImportError struct {
+	Rows []RowError
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("%d invalid row(s)", len(e.Rows))
+}
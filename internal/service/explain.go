@@ -0,0 +1,91 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+)
+
+// explainCandidates собирает объяснение выбора ревьюверов: участников, исключённых
+// findReviewCandidates, и кандидатов с оценками scores. Кандидаты, исключённые правилами
+// пар автора, тоже попадают в исключённые, а предпочтения правил - в оценку.
+// Участники идут по user_id
+func explainCandidates(candidates []*entity.User, scores []entity.CandidateScore, excluded []entity.CandidateExplanation, rules reviewerRules) []entity.CandidateExplanation {
+	explanation := make([]entity.CandidateExplanation, 0, len(candidates)+len(excluded))
+	explanation = append(explanation, excluded...)
+	for i, candidate := range candidates {
+		score := scores[i]
+		switch rules[candidate.UserID] {
+		case entity.ReviewerRuleExclude:
+			explanation = append(explanation, entity.CandidateExplanation{UserID: candidate.UserID, ExcludedBy: entity.ExcludedByRule})
+			continue
+		case entity.ReviewerRulePrefer:
+			score.Rule = preferredReviewerWeight
+		}
+		explanation = append(explanation, entity.CandidateExplanation{UserID: candidate.UserID, Score: score})
+	}
+	sort.Slice(explanation, func(i, j int) bool {
+		return explanation[i].UserID < explanation[j].UserID
+	})
+	return explanation
+}
+
+// markSelected отмечает в объяснении назначенных ревьюверов и теневого ревьювера
+func markSelected(explanation []entity.CandidateExplanation, reviewers []string, shadowReviewer string) {
+	for i := range explanation {
+		if explanation[i].ExcludedBy != "" {
+			continue
+		}
+		for _, reviewerID := range reviewers {
+			if explanation[i].UserID == reviewerID {
+				explanation[i].Selected = true
+			}
+		}
+		explanation[i].Shadow = shadowReviewer != "" && explanation[i].UserID == shadowReviewer
+	}
+}
+
+// excludeNonSeniors отмечает кандидатов, которые не senior и не lead, исключёнными
+// политикой команды, когда выбор идёт только среди seniors
+func excludeNonSeniors(explanation []entity.CandidateExplanation, seniors []*entity.User) {
+	isSenior := make(map[string]bool, len(seniors))
+	for _, senior := range seniors {
+		isSenior[senior.UserID] = true
+	}
+	for i := range explanation {
+		if explanation[i].ExcludedBy == "" && !isSenior[explanation[i].UserID] {
+			explanation[i] = entity.CandidateExplanation{UserID: explanation[i].UserID, ExcludedBy: entity.ExcludedSeniorPolicy}
+		}
+	}
+}
+
+// atCapacityIDs возвращает участников, исключённых из-за предела открытых ревью
+func atCapacityIDs(excluded []entity.CandidateExplanation) []string {
+	var userIDs []string
+	for _, candidate := range excluded {
+		if candidate.ExcludedBy == entity.ExcludedAtCapacity {
+			userIDs = append(userIDs, candidate.UserID)
+		}
+	}
+	return userIDs
+}
+
+// alternativeReviewers - кандидаты из объяснения, которых можно было бы назначить
+// вместо выбранных, по убыванию веса
+func alternativeReviewers(explanation []entity.CandidateExplanation) []string {
+	alternatives := make([]entity.CandidateExplanation, 0, len(explanation))
+	for _, candidate := range explanation {
+		if candidate.ExcludedBy == "" && !candidate.Selected && !candidate.Shadow {
+			alternatives = append(alternatives, candidate)
+		}
+	}
+	sort.SliceStable(alternatives, func(i, j int) bool {
+		return alternatives[i].Score.Weight() > alternatives[j].Score.Weight()
+	})
+
+	userIDs := make([]string, 0, len(alternatives))
+	for _, candidate := range alternatives {
+		userIDs = append(userIDs, candidate.UserID)
+	}
+	return userIDs
+}
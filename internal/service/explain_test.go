@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePR_ExplainsCandidates(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	author := &entity.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(author, nil)
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return([]*entity.User{
+		author,
+		{UserID: "u2", TeamName: "backend"},
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+		{UserID: "u4", TeamName: "backend", IsActive: true},
+		{UserID: "u5", TeamName: "backend", IsActive: true},
+		{UserID: "u6", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return([]string{"u3"}, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend"}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, "u1").Return([]entity.ReviewerRule{
+		{AuthorID: "u1", ReviewerID: "u4", Kind: entity.ReviewerRuleExclude},
+		{AuthorID: "u1", ReviewerID: "u5", Kind: entity.ReviewerRulePrefer},
+	}, nil)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	pr := &entity.PullRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"}
+	assignment, err := service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	preferred := entity.NewCandidateScore()
+	preferred.Rule = preferredReviewerWeight
+	assert.Equal(t, []entity.CandidateExplanation{
+		{UserID: "u1", ExcludedBy: entity.ExcludedAuthor},
+		{UserID: "u2", ExcludedBy: entity.ExcludedInactive},
+		{UserID: "u3", ExcludedBy: entity.ExcludedUnavailable},
+		{UserID: "u4", ExcludedBy: entity.ExcludedByRule},
+		{UserID: "u5", Score: preferred, Selected: true},
+		{UserID: "u6", Score: entity.NewCandidateScore(), Selected: true},
+	}, assignment.Candidates)
+}
+
+func TestReassignReviewer_ExplainsCandidates(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-1").Return(pr, nil)
+	expectPolicyTeam(mockRepo, "backend", entity.ReviewPolicy{RequireSenior: true},
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true, Level: entity.UserLevelSenior},
+		&entity.User{UserID: "u3", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u4", TeamName: "backend", IsActive: true, Level: entity.UserLevelMiddle},
+		&entity.User{UserID: "u5", TeamName: "backend", IsActive: true, Level: entity.UserLevelLead})
+	mockRepo.On("UpdatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	_, reassignment, err := service.ReassignReviewer(context.Background(), "pr-1", "u2", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, &entity.Reassignment{
+		NewReviewerID: "u5",
+		Candidates: []entity.CandidateExplanation{
+			{UserID: "u1", ExcludedBy: entity.ExcludedAuthor},
+			{UserID: "u2", ExcludedBy: entity.ExcludedAssigned},
+			{UserID: "u3", ExcludedBy: entity.ExcludedAssigned},
+			{UserID: "u4", ExcludedBy: entity.ExcludedSeniorPolicy},
+			{UserID: "u5", Score: entity.NewCandidateScore(), Selected: true},
+		},
+	}, reassignment)
+}
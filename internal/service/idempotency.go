@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+)
+
+// IdempotencyKeyTTL - сколько хранится ответ для повторов с тем же Idempotency-Key
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// IdempotencyPendingTTL - сколько держится резерв ключа, если запрос так и не завершился
+// (например, процесс упал): после этого ключ снова считается новым
+const IdempotencyPendingTTL = time.Minute
+
+// BeginIdempotentRequest резервирует ключ за новым запросом и возвращает nil, nil - тогда
+// запрос нужно выполнить и сохранить ответ через SaveIdempotentResponse или снять резерв
+// через ReleaseIdempotencyKey. Для повтора возвращает сохранённый ответ. Повтор ключа
+// с другим телом - ErrIdempotencyKeyReused, пока первый запрос выполняется - ErrIdempotencyKeyInProgress
+func (servs *PrService) BeginIdempotentRequest(ctx context.Context, key, endpoint, requestHash string) (*entity.IdempotencyRecord, error) {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_BEGIN_IDEMPOTENT_REQUEST", "Reserving idempotency key",
+		"key", key,
+		"endpoint", endpoint)
+
+	if key == "" {
+		servs.logger.Warn("SERVICE_BEGIN_IDEMPOTENT_REQUEST", "Empty idempotency key provided",
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, ErrEmptyIdempotencyKey
+	}
+
+	// Истёкшие ключи удаляем до резерва, иначе они заняли бы место нового.
+	// Ошибка очистки не критична - только логируем
+	if deleted, err := servs.repo.DeleteExpiredIdempotencyRecords(ctx, start); err != nil {
+		servs.logger.Warn("SERVICE_BEGIN_IDEMPOTENT_REQUEST", "Failed to delete expired idempotency keys",
+			"error", err)
+	} else if deleted > 0 {
+		servs.logger.Debug("SERVICE_BEGIN_IDEMPOTENT_REQUEST", "Expired idempotency keys deleted",
+			"deleted", deleted)
+	}
+
+	reserved, err := servs.repo.ReserveIdempotencyKey(ctx, &entity.IdempotencyRecord{
+		Key:         key,
+		Endpoint:    endpoint,
+		RequestHash: requestHash,
+		CreatedAt:   start,
+		ExpiresAt:   start.Add(IdempotencyPendingTTL),
+	})
+	if err != nil {
+		servs.logger.Error("SERVICE_BEGIN_IDEMPOTENT_REQUEST", "Failed to reserve idempotency key",
+			"key", key,
+			"endpoint", endpoint,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+	if reserved {
+		servs.logger.Debug("SERVICE_BEGIN_IDEMPOTENT_REQUEST", "Idempotency key is new",
+			"key", key,
+			"endpoint", endpoint,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, nil
+	}
+
+	record, err := servs.repo.FindIdempotencyRecord(ctx, key, endpoint)
+	if err != nil {
+		servs.logger.Error("SERVICE_BEGIN_IDEMPOTENT_REQUEST", "Failed to find idempotency record",
+			"key", key,
+			"endpoint", endpoint,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	// Запись могла истечь или быть снята первым запросом между резервом и чтением -
+	// клиенту достаточно повторить запрос
+	if record == nil || (record.Pending() && record.RequestHash == requestHash) {
+		servs.logger.Warn("SERVICE_BEGIN_IDEMPOTENT_REQUEST", "Idempotency key is in progress",
+			"key", key,
+			"endpoint", endpoint,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, ErrIdempotencyKeyInProgress
+	}
+
+	if record.RequestHash != requestHash {
+		servs.logger.Warn("SERVICE_BEGIN_IDEMPOTENT_REQUEST", "Idempotency key reused with different request",
+			"key", key,
+			"endpoint", endpoint,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, ErrIdempotencyKeyReused
+	}
+
+	servs.logger.Info("SERVICE_BEGIN_IDEMPOTENT_REQUEST", "Replaying stored response",
+		"key", key,
+		"endpoint", endpoint,
+		"status_code", record.StatusCode,
+		"duration_ms", time.Since(start).Milliseconds())
+	return record, nil
+}
+
+// SaveIdempotentResponse записывает ответ в зарезервированный ключ и хранит его IdempotencyKeyTTL
+func (servs *PrService) SaveIdempotentResponse(ctx context.Context, record *entity.IdempotencyRecord) error {
+	start := time.Now()
+
+	if record == nil || record.Key == "" {
+		servs.logger.Warn("SERVICE_SAVE_IDEMPOTENT_RESPONSE", "Empty idempotency key provided",
+			"duration_ms", time.Since(start).Milliseconds())
+		return ErrEmptyIdempotencyKey
+	}
+
+	servs.logger.Debug("SERVICE_SAVE_IDEMPOTENT_RESPONSE", "Saving idempotent response",
+		"key", record.Key,
+		"endpoint", record.Endpoint,
+		"status_code", record.StatusCode)
+
+	record.CreatedAt = start
+	record.ExpiresAt = start.Add(IdempotencyKeyTTL)
+
+	if err := servs.repo.CompleteIdempotencyRecord(ctx, record); err != nil {
+		servs.logger.Error("SERVICE_SAVE_IDEMPOTENT_RESPONSE", "Failed to save idempotency record",
+			"key", record.Key,
+			"endpoint", record.Endpoint,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	servs.logger.Info("SERVICE_SAVE_IDEMPOTENT_RESPONSE", "Idempotent response saved",
+		"key", record.Key,
+		"endpoint", record.Endpoint,
+		"expires_at", record.ExpiresAt,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (servs *PrService) ReleaseIdempotencyKey(ctx context.Context, key, endpoint string) error {
+	start := time.Now()
+
+	if key == "" {
+		return ErrEmptyIdempotencyKey
+	}
+
+	if err := servs.repo.ReleaseIdempotencyKey(ctx, key, endpoint); err != nil {
+		servs.logger.Error("SERVICE_RELEASE_IDEMPOTENCY_KEY", "Failed to release idempotency key",
+			"key", key,
+			"endpoint", endpoint,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	servs.logger.Debug("SERVICE_RELEASE_IDEMPOTENCY_KEY", "Idempotency key released",
+		"key", key,
+		"endpoint", endpoint,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBeginIdempotentRequest_NewKey(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	assert.NoError(t, err)
+
+	// Ошибка очистки истёкших ключей не должна мешать резерву
+	mockRepo.On("DeleteExpiredIdempotencyRecords", mock.Anything, mock.Anything).Return(int64(0), errors.New("db is busy"))
+	mockRepo.On("ReserveIdempotencyKey", mock.Anything, mock.MatchedBy(func(r *entity.IdempotencyRecord) bool {
+		return r.Key == "key-1" && r.RequestHash == "hash" && r.Pending() &&
+			r.ExpiresAt.Sub(r.CreatedAt) == IdempotencyPendingTTL
+	})).Return(true, nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	record, err := service.BeginIdempotentRequest(context.Background(), "key-1", "/pullRequest/create", "hash")
+
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "FindIdempotencyRecord", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBeginIdempotentRequest_Replay(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	assert.NoError(t, err)
+
+	stored := &entity.IdempotencyRecord{
+		Key:          "key-1",
+		Endpoint:     "/pullRequest/create",
+		RequestHash:  "hash",
+		StatusCode:   201,
+		ResponseBody: []byte(`{"pr":{}}`),
+	}
+	mockRepo.On("DeleteExpiredIdempotencyRecords", mock.Anything, mock.Anything).Return(int64(0), nil)
+	mockRepo.On("ReserveIdempotencyKey", mock.Anything, mock.Anything).Return(false, nil)
+	mockRepo.On("FindIdempotencyRecord", mock.Anything, "key-1", "/pullRequest/create").Return(stored, nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	record, err := service.BeginIdempotentRequest(context.Background(), "key-1", "/pullRequest/create", "hash")
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored, record)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBeginIdempotentRequest_DifferentBody(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	assert.NoError(t, err)
+
+	stored := &entity.IdempotencyRecord{
+		Key:         "key-1",
+		Endpoint:    "/pullRequest/create",
+		RequestHash: "hash",
+		StatusCode:  201,
+	}
+	mockRepo.On("DeleteExpiredIdempotencyRecords", mock.Anything, mock.Anything).Return(int64(0), nil)
+	mockRepo.On("ReserveIdempotencyKey", mock.Anything, mock.Anything).Return(false, nil)
+	mockRepo.On("FindIdempotencyRecord", mock.Anything, "key-1", "/pullRequest/create").Return(stored, nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	record, err := service.BeginIdempotentRequest(context.Background(), "key-1", "/pullRequest/create", "other-hash")
+
+	assert.Nil(t, record)
+	assert.Equal(t, ErrIdempotencyKeyReused, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBeginIdempotentRequest_InProgress(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored *entity.IdempotencyRecord
+	}{
+		{"first request still running", &entity.IdempotencyRecord{Key: "key-1", Endpoint: "/pullRequest/reassign", RequestHash: "hash"}},
+		{"reservation released meanwhile", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.Repository{}
+			logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+			assert.NoError(t, err)
+
+			mockRepo.On("DeleteExpiredIdempotencyRecords", mock.Anything, mock.Anything).Return(int64(0), nil)
+			mockRepo.On("ReserveIdempotencyKey", mock.Anything, mock.Anything).Return(false, nil)
+			mockRepo.On("FindIdempotencyRecord", mock.Anything, "key-1", "/pullRequest/reassign").Return(tt.stored, nil)
+
+			service := NewPRService(mockRepo, logger)
+
+			record, err := service.BeginIdempotentRequest(context.Background(), "key-1", "/pullRequest/reassign", "hash")
+
+			assert.Nil(t, record)
+			assert.Equal(t, ErrIdempotencyKeyInProgress, err)
+		})
+	}
+}
+
+func TestSaveIdempotentResponse_SetsExpiration(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	assert.NoError(t, err)
+
+	record := &entity.IdempotencyRecord{
+		Key:          "key-1",
+		Endpoint:     "/team/add",
+		RequestHash:  "hash",
+		StatusCode:   201,
+		ResponseBody: []byte(`{"team":{}}`),
+	}
+
+	mockRepo.On("CompleteIdempotencyRecord", mock.Anything, mock.MatchedBy(func(r *entity.IdempotencyRecord) bool {
+		return r.Key == "key-1" && r.ExpiresAt.Sub(r.CreatedAt) == IdempotencyKeyTTL
+	})).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	err = service.SaveIdempotentResponse(context.Background(), record)
+
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(IdempotencyKeyTTL), record.ExpiresAt, time.Minute)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReleaseIdempotencyKey(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	assert.NoError(t, err)
+
+	mockRepo.On("ReleaseIdempotencyKey", mock.Anything, "key-1", "/team/add").Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	assert.NoError(t, service.ReleaseIdempotencyKey(context.Background(), "key-1", "/team/add"))
+	assert.Equal(t, ErrEmptyIdempotencyKey, service.ReleaseIdempotencyKey(context.Background(), "", "/team/add"))
+	mockRepo.AssertExpectations(t)
+}
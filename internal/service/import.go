@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+)
+
+// ImportUsers создаёт недостающие команды и пользователей из users, существующим
+// пользователям выставляет команду, имя и активность из users. В отличие от SyncTeams
+// никого не деактивирует. Строки с ошибками возвращаются в *ImportError, и тогда
+// не применяется ни одна строка
+func (servs *PrService) ImportUsers(ctx context.Context, users []entity.User) ([]entity.TeamSyncChange, error) {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_IMPORT_USERS", "Starting users import",
+		"rows_count", len(users))
+
+	if err := checkImportCorrectness(users); err != nil {
+		servs.logger.Warn("SERVICE_IMPORT_USERS", "Import validation failed",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	// Строки группируются по командам в порядке первого появления команды
+	var roster []entity.Team
+	teamIndex := make(map[string]int)
+	for _, user := range users {
+		i, ok := teamIndex[user.TeamName]
+		if !ok {
+			i = len(roster)
+			teamIndex[user.TeamName] = i
+			roster = append(roster, entity.Team{TeamName: user.TeamName})
+		}
+		roster[i].Members = append(roster[i].Members, entity.TeamMember{
+			UserID:   user.UserID,
+			Username: user.Username,
+			IsActive: user.IsActive,
+		})
+	}
+
+	var changes []entity.TeamSyncChange
+	err := servs.repo.WithTx(ctx, func(repo interfaces.Repository) error {
+		var (
+			desired map[string]entity.User
+			err     error
+		)
+		changes, desired, err = planTeamSync(ctx, repo, roster, false)
+		if err != nil {
+			return err
+		}
+		return applyTeamSync(ctx, repo, changes, desired)
+	})
+	if err != nil {
+		servs.logger.Error("SERVICE_IMPORT_USERS", "Failed to import users",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	servs.logger.Info("SERVICE_IMPORT_USERS", "Users imported successfully",
+		"rows_count", len(users),
+		"changes_count", len(changes),
+		"duration_ms", time.Since(start).Milliseconds())
+	return changes, nil
+}
+
+// checkImportCorrectness проверяет все строки и собирает ошибки каждой из них
+func checkImportCorrectness(users []entity.User) error {
+	var rowErrors []RowError
+	seen := make(map[string]struct{}, len(users))
+	for i, user := range users {
+		err := checkTeamMemberCorrectness(entity.TeamMember{
+			UserID:   user.UserID,
+			Username: user.Username,
+			IsActive: user.IsActive,
+		})
+		if err == nil && user.TeamName == "" {
+			err = ErrEmptyTeamName
+		}
+		if err == nil {
+			if _, ok := seen[user.UserID]; ok {
+				err = ErrDuplicateImportUser
+			}
+			seen[user.UserID] = struct{}{}
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Index: i, Err: err})
+		}
+	}
+
+	if len(rowErrors) > 0 {
+		return &ImportError{Rows: rowErrors}
+	}
+	return nil
+}
+
+func (servs *PrService) ListUsers(ctx context.Context) ([]*entity.User, error) {
+	start := time.Now()
+
+	users, err := servs.repo.ListUsers(ctx)
+	if err != nil {
+		servs.logger.Error("SERVICE_LIST_USERS", "Failed to list users",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	servs.logger.Info("SERVICE_LIST_USERS", "Users listed successfully",
+		"users_count", len(users),
+		"duration_ms", time.Since(start).Milliseconds())
+	return users, nil
+}
+
+func (servs *PrService) ListPRs(ctx context.Context) ([]*entity.PullRequest, error) {
+	start := time.Now()
+
+	prs, err := servs.repo.ListPRs(ctx)
+	if err != nil {
+		servs.logger.Error("SERVICE_LIST_PRS", "Failed to list PRs",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	servs.logger.Info("SERVICE_LIST_PRS", "PRs listed successfully",
+		"prs_count", len(prs),
+		"duration_ms", time.Since(start).Milliseconds())
+	return prs, nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportUsers_CreatesAndUpdates(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	expectTx(mockRepo)
+	mockRepo.On("TeamExists", mock.Anything, "backend").Return(true)
+	mockRepo.On("TeamExists", mock.Anything, "platform").Return(false)
+	// В CSV нет предела ревью, уровня и признака обучения - у существующих они не меняются
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(
+		&entity.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true,
+			MaxOpenReviews: 2, Level: entity.UserLevelSenior}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u2").Return(
+		&entity.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true, IsLearner: true}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u5").Return(nil, entity.ErrNoUser)
+
+	mockRepo.On("UpdateUser", mock.Anything,
+		&entity.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: false, IsLearner: true}).Return(nil)
+	mockRepo.On("CreateTeam", mock.Anything, &entity.Team{TeamName: "platform"}).Return(nil)
+	mockRepo.On("CreateUser", mock.Anything,
+		&entity.User{UserID: "u5", Username: "Eve", TeamName: "platform", IsActive: true}).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	changes, err := service.ImportUsers(context.Background(), []entity.User{
+		{TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: true},
+		{TeamName: "platform", UserID: "u5", Username: "Eve", IsActive: true},
+		{TeamName: "backend", UserID: "u2", Username: "Bob", IsActive: false},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []entity.TeamSyncChange{
+		{Action: entity.TeamSyncDeactivateUser, TeamName: "backend", UserID: "u2", Username: "Bob"},
+		{Action: entity.TeamSyncCreateTeam, TeamName: "platform"},
+		{Action: entity.TeamSyncCreateUser, TeamName: "platform", UserID: "u5", Username: "Eve"},
+	}, changes)
+	// Импорт не деактивирует участников, которых нет в файле
+	mockRepo.AssertNotCalled(t, "FindUsersByTeam", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportUsers_ReportsAllInvalidRows(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	service := NewPRService(mockRepo, logger)
+
+	changes, err := service.ImportUsers(context.Background(), []entity.User{
+		{TeamName: "backend", UserID: "u1", Username: "Alice"},
+		{TeamName: "backend", UserID: "u2"},
+		{TeamName: "", UserID: "u3", Username: "Charlie"},
+		{TeamName: "frontend", UserID: "u1", Username: "Alice"},
+	})
+
+	assert.Nil(t, changes)
+	var importErr *ImportError
+	require.True(t, errors.As(err, &importErr))
+	assert.Equal(t, []RowError{
+		{Index: 1, Err: ErrEmptyUserUsername},
+		{Index: 2, Err: ErrEmptyTeamName},
+		{Index: 3, Err: ErrDuplicateImportUser},
+	}, importErr.Rows)
+	// Ни одна строка не применяется
+	mockRepo.AssertNotCalled(t, "WithTx", mock.Anything, mock.Anything)
+}
+
+func TestImportUsers_RollsBackOnRepositoryError(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	repoErr := errors.New("connection lost")
+	expectTx(mockRepo)
+	mockRepo.On("TeamExists", mock.Anything, "backend").Return(false)
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("CreateTeam", mock.Anything, &entity.Team{TeamName: "backend"}).Return(repoErr)
+
+	service := NewPRService(mockRepo, logger)
+
+	changes, err := service.ImportUsers(context.Background(), []entity.User{
+		{TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: true},
+	})
+
+	assert.ErrorIs(t, err, repoErr)
+	assert.Nil(t, changes)
+	mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
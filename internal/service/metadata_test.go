@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreCandidates_BalanceLoad(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	candidates := []*entity.User{{UserID: "idle"}, {UserID: "small"}, {UserID: "big"}}
+	mockRepo.On("FindReviewLoads", mock.Anything, "backend").Return(map[string]entity.ReviewLoad{
+		"small": {OpenReviews: 1, ChangedLines: 40},
+		"big":   {OpenReviews: 1, ChangedLines: 1200},
+	}, nil)
+
+	service := NewPRServiceWithOptions(mockRepo, logger, Options{BalanceLoad: true, LinesPerReview: 400}).(*PrService)
+
+	scores, err := service.scoreCandidates(context.Background(), mockRepo, "backend", "u1", candidates, nil, time.Now())
+
+	require.NoError(t, err)
+	// Одно ревью на 1200 строк весит как четыре небольших
+	assert.Equal(t, []float64{1, 1 / 2.1, 0.2}, scoreWeights(scores))
+	assert.Equal(t, 0.2, scores[2].Load)
+}
+
+func TestScoreCandidates_PairHistory(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	candidates := []*entity.User{{UserID: "u2"}, {UserID: "u3"}, {UserID: "u4"}}
+	mockRepo.On("FindPairReviewCounts", mock.Anything, "u1", now.Add(-30*24*time.Hour)).Return(map[string]int{
+		"u2": 3,
+		"u3": 1,
+	}, nil)
+
+	service := NewPRServiceWithOptions(mockRepo, logger, Options{PairHistoryWindow: 30 * 24 * time.Hour}).(*PrService)
+
+	scores, err := service.scoreCandidates(context.Background(), mockRepo, "backend", "u1", candidates, nil, now)
+
+	require.NoError(t, err)
+	// Кто чаще ревьюил автора за окно, тот реже выбирается снова
+	assert.Equal(t, []float64{0.25, 0.5, 1}, scoreWeights(scores))
+	assert.Equal(t, 0.25, scores[0].PairHistory)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreatePR_Metadata(t *testing.T) {
+	tests := []struct {
+		name       string
+		metadata   entity.PRMetadata
+		wantErr    error
+		wantLabels []string
+	}{
+		{
+			name:       "labels normalized",
+			metadata:   entity.PRMetadata{Additions: 10, Labels: []string{" backend", "", "security", "backend "}},
+			wantLabels: []string{"backend", "security"},
+		},
+		{name: "negative additions", metadata: entity.PRMetadata{Additions: -1}, wantErr: ErrInvalidPRMetadata},
+		{name: "negative deletions", metadata: entity.PRMetadata{Deletions: -1}, wantErr: ErrInvalidPRMetadata},
+		{name: "negative files", metadata: entity.PRMetadata{ChangedFiles: -1}, wantErr: ErrInvalidPRMetadata},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.Repository{}
+			logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+			require.NoError(t, err)
+
+			author := &entity.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+			mockRepo.On("FindUserByID", mock.Anything, "u1").Return(author, nil)
+			mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+			mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return([]*entity.User{author}, nil)
+			mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return(nil, nil)
+			mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend"}, nil)
+			mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, mock.Anything).Return(nil, nil)
+			mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+			service := NewPRService(mockRepo, logger)
+			pr := &entity.PullRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1", Metadata: tt.metadata}
+			_, err = service.CreatePR(context.Background(), pr)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				mockRepo.AssertNotCalled(t, "CreatePR", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLabels, pr.Metadata.Labels)
+		})
+	}
+}
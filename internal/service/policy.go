@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+)
+
+// SetUserLevel задаёт уровень пользователя, пустой - не задан
+func (servs *PrService) SetUserLevel(ctx context.Context, userID string, level entity.UserLevel) (*entity.User, error) {
+	start := time.Now()
+
+	if userID == "" {
+		return nil, ErrEmptyUserID
+	}
+	if !validUserLevel(level) {
+		return nil, ErrInvalidUserLevel
+	}
+
+	if err := servs.repo.SetUserLevel(ctx, userID, level); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return nil, ErrNoUser
+		}
+		servs.logger.Error("SERVICE_SET_USER_LEVEL", "Failed to set user level",
+			"user_id", userID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	user, err := servs.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	servs.logger.Info("SERVICE_SET_USER_LEVEL", "User level updated",
+		"user_id", userID,
+		"level", level,
+		"duration_ms", time.Since(start).Milliseconds())
+	return user, nil
+}
+
+// SetTeamReviewPolicy задаёт требования к уровню ревьюверов PR участников команды
+func (servs *PrService) SetTeamReviewPolicy(ctx context.Context, teamName string, policy entity.ReviewPolicy) (*entity.Team, error) {
+	start := time.Now()
+
+	if teamName == "" {
+		return nil, ErrEmptyTeamName
+	}
+
+	if err := servs.repo.SetTeamReviewPolicy(ctx, teamName, policy); err != nil {
+		if errors.Is(err, entity.ErrNoTeam) {
+			return nil, ErrNoTeam
+		}
+		servs.logger.Error("SERVICE_SET_TEAM_REVIEW_POLICY", "Failed to set team review policy",
+			"team_name", teamName,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	team, err := servs.repo.FindTeamByName(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("find team: %w", err)
+	}
+
+	servs.logger.Info("SERVICE_SET_TEAM_REVIEW_POLICY", "Team review policy updated",
+		"team_name", teamName,
+		"require_senior", policy.RequireSenior,
+		"senior_for_juniors", policy.SeniorForJuniors,
+		"duration_ms", time.Since(start).Milliseconds())
+	return team, nil
+}
+
+func validUserLevel(level entity.UserLevel) bool {
+	return level == "" || level.Rank() > 0
+}
+
+// seniorRequired сообщает, что политика команды автора требует ревьювера senior или lead,
+// а среди уже назначенных reviewers такого нет
+func seniorRequired(ctx context.Context, repo interfaces.Repository, author *entity.User, reviewers []string) (bool, error) {
+	team, err := repo.FindTeamByName(ctx, author.TeamName)
+	if err != nil {
+		return false, fmt.Errorf("find author team: %w", err)
+	}
+	if !team.ReviewPolicy.NeedsSenior(author.Level) {
+		return false, nil
+	}
+	for _, reviewerID := range reviewers {
+		reviewer, err := repo.FindUserByID(ctx, reviewerID)
+		if err != nil {
+			return false, fmt.Errorf("find reviewer: %w", err)
+		}
+		if reviewer.Level.IsSenior() {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// seniorCandidates оставляет из кандидатов senior и lead вместе с их весами
+func seniorCandidates(candidates []*entity.User, weights []float64) ([]*entity.User, []float64) {
+	var (
+		seniors       []*entity.User
+		seniorWeights []float64
+	)
+	for i, candidate := range candidates {
+		if candidate.Level.IsSenior() {
+			seniors = append(seniors, candidate)
+			seniorWeights = append(seniorWeights, weights[i])
+		}
+	}
+	return seniors, seniorWeights
+}
+
+// withoutCandidate убирает выбранного кандидата из кандидатов и весов
+func withoutCandidate(candidates []*entity.User, weights []float64, userID string) ([]*entity.User, []float64) {
+	var (
+		rest        []*entity.User
+		restWeights []float64
+	)
+	for i, candidate := range candidates {
+		if candidate.UserID != userID {
+			rest = append(rest, candidate)
+			restWeights = append(restWeights, weights[i])
+		}
+	}
+	return rest, restWeights
+}
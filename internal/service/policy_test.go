@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// expectPolicyTeam - как expectTeam, но команда возвращается с политикой ревью
+func expectPolicyTeam(mockRepo *mocks.Repository, teamName string, policy entity.ReviewPolicy, users ...*entity.User) {
+	mockRepo.On("FindUsersByTeam", mock.Anything, teamName).Return(users, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, teamName, mock.Anything).Return(nil, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, teamName).Return(&entity.Team{TeamName: teamName, ReviewPolicy: policy}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, mock.Anything).Return(nil, nil)
+	for _, user := range users {
+		mockRepo.On("FindUserByID", mock.Anything, user.UserID).Return(user, nil)
+	}
+}
+
+func TestSetUserLevel(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("SetUserLevel", mock.Anything, "u1", entity.UserLevelSenior).Return(nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(
+		&entity.User{UserID: "u1", TeamName: "backend", Level: entity.UserLevelSenior}, nil)
+
+	service := NewPRService(mockRepo, logger)
+	user, err := service.SetUserLevel(context.Background(), "u1", entity.UserLevelSenior)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserLevelSenior, user.Level)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetUserLevel_Errors(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("SetUserLevel", mock.Anything, "ghost", entity.UserLevelLead).Return(entity.ErrNoUser)
+
+	service := NewPRService(mockRepo, logger)
+
+	_, err = service.SetUserLevel(context.Background(), "", entity.UserLevelLead)
+	assert.Equal(t, ErrEmptyUserID, err)
+	_, err = service.SetUserLevel(context.Background(), "u1", "principal")
+	assert.Equal(t, ErrInvalidUserLevel, err)
+	_, err = service.SetUserLevel(context.Background(), "ghost", entity.UserLevelLead)
+	assert.Equal(t, ErrNoUser, err)
+}
+
+func TestSetTeamReviewPolicy_UnknownTeam(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("SetTeamReviewPolicy", mock.Anything, "ghost", mock.Anything).Return(entity.ErrNoTeam)
+
+	service := NewPRService(mockRepo, logger)
+	_, err = service.SetTeamReviewPolicy(context.Background(), "ghost", entity.ReviewPolicy{RequireSenior: true})
+
+	assert.Equal(t, ErrNoTeam, err)
+}
+
+func TestCreatePR_RequireSenior(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	author := &entity.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	expectPolicyTeam(mockRepo, "backend", entity.ReviewPolicy{RequireSenior: true}, author,
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true, Level: entity.UserLevelJunior},
+		&entity.User{UserID: "u3", TeamName: "backend", IsActive: true, Level: entity.UserLevelMiddle},
+		&entity.User{UserID: "u4", TeamName: "backend", IsActive: true, Level: entity.UserLevelLead})
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	pr := &entity.PullRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"}
+	assignment, err := service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	assert.Len(t, pr.AssignedReviewers, 2)
+	// Единственный lead назначается всегда
+	assert.Contains(t, pr.AssignedReviewers, "u4")
+	assert.False(t, assignment.SeniorMissing)
+}
+
+func TestCreatePR_SeniorForJuniorsMissing(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	author := &entity.User{UserID: "u1", TeamName: "backend", IsActive: true, Level: entity.UserLevelJunior}
+	expectPolicyTeam(mockRepo, "backend", entity.ReviewPolicy{SeniorForJuniors: true}, author,
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true, Level: entity.UserLevelMiddle},
+		&entity.User{UserID: "u3", TeamName: "backend", IsActive: true})
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	pr := &entity.PullRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"}
+	assignment, err := service.CreatePR(context.Background(), pr)
+
+	// PR создаётся, но без senior, о чём сообщает SeniorMissing
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+	assert.True(t, assignment.SeniorMissing)
+}
+
+func TestReassignReviewer_KeepsSenior(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-1").Return(pr, nil)
+	expectPolicyTeam(mockRepo, "backend", entity.ReviewPolicy{RequireSenior: true},
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true, Level: entity.UserLevelSenior},
+		&entity.User{UserID: "u3", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u4", TeamName: "backend", IsActive: true, Level: entity.UserLevelMiddle},
+		&entity.User{UserID: "u5", TeamName: "backend", IsActive: true, Level: entity.UserLevelLead})
+	mockRepo.On("UpdatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	_, reassignment, err := service.ReassignReviewer(context.Background(), "pr-1", "u2", 0)
+
+	require.NoError(t, err)
+	// Senior заменяется только на senior или lead
+	assert.Equal(t, "u5", reassignment.NewReviewerID)
+}
+
+func TestReassignReviewer_SeniorRequired(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-1").Return(pr, nil)
+	expectPolicyTeam(mockRepo, "backend", entity.ReviewPolicy{RequireSenior: true},
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true, Level: entity.UserLevelSenior},
+		&entity.User{UserID: "u3", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u4", TeamName: "backend", IsActive: true, Level: entity.UserLevelMiddle})
+
+	service := NewPRService(mockRepo, logger)
+	updated, reassignment, err := service.ReassignReviewer(context.Background(), "pr-1", "u2", 0)
+
+	assert.Equal(t, ErrSeniorReviewerRequired, err)
+	assert.Nil(t, updated)
+	assert.Nil(t, reassignment)
+	mockRepo.AssertNotCalled(t, "UpdatePR", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewPR(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	author := &entity.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(author, nil)
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return([]*entity.User{
+		author,
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+		{UserID: "u4", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return([]string{}, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend"}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, "u1").Return([]entity.ReviewerRule{}, nil)
+
+	service := NewPRService(mockRepo, logger)
+	pr := &entity.PullRequest{AuthorID: "u1", Metadata: entity.PRMetadata{Labels: []string{" backend "}}}
+	preview, err := service.PreviewPR(context.Background(), pr)
+
+	require.NoError(t, err)
+	assert.Len(t, preview.Reviewers, reviewersPerPR)
+	assert.Len(t, preview.Alternatives, 1)
+	assert.ElementsMatch(t, []string{"u2", "u3", "u4"}, append(preview.Reviewers, preview.Alternatives...))
+	assert.Len(t, preview.Assignment.Candidates, 4)
+	assert.Equal(t, []string{"backend"}, pr.Metadata.Labels)
+	// Предпросмотр не проверяет и не сохраняет PR
+	mockRepo.AssertNotCalled(t, "FindPRByID", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreatePR", mock.Anything, mock.Anything)
+}
+
+func TestPreviewPR_Errors(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("FindUserByID", mock.Anything, "ghost").Return(nil, entity.ErrNoUser)
+	service := NewPRService(mockRepo, logger)
+
+	tests := []struct {
+		name string
+		pr   *entity.PullRequest
+		want error
+	}{
+		{"empty author", &entity.PullRequest{}, ErrEmptyPRAuthorID},
+		{"negative metadata", &entity.PullRequest{AuthorID: "u1", Metadata: entity.PRMetadata{Additions: -1}}, ErrInvalidPRMetadata},
+		{"unknown author", &entity.PullRequest{AuthorID: "ghost"}, ErrNoUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.PreviewPR(context.Background(), tt.pr)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestAlternativeReviewers(t *testing.T) {
+	light := entity.NewCandidateScore()
+	light.Load = 0.25
+	heavy := entity.NewCandidateScore()
+	heavy.Rule = preferredReviewerWeight
+
+	alternatives := alternativeReviewers([]entity.CandidateExplanation{
+		{UserID: "u1", ExcludedBy: entity.ExcludedAuthor},
+		{UserID: "u2", Score: light},
+		{UserID: "u3", Score: entity.NewCandidateScore(), Selected: true},
+		{UserID: "u4", Score: entity.NewCandidateScore()},
+		{UserID: "u5", Score: heavy},
+		{UserID: "u6", Score: entity.NewCandidateScore(), Shadow: true},
+	})
+
+	assert.Equal(t, []string{"u5", "u4", "u2"}, alternatives)
+}
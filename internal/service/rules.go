@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+)
+
+// preferredReviewerWeight - во сколько раз правило prefer увеличивает вес ревьювера на PR автора
+const preferredReviewerWeight = 4
+
+// SetReviewerRule создаёт или заменяет правило пары автор-ревьювер
+func (servs *PrService) SetReviewerRule(ctx context.Context, rule *entity.ReviewerRule) error {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_SET_REVIEWER_RULE", "Setting reviewer rule",
+		"author_id", rule.AuthorID,
+		"reviewer_id", rule.ReviewerID,
+		"kind", rule.Kind)
+
+	if err := checkReviewerRuleCorrectness(rule); err != nil {
+		servs.logger.Warn("SERVICE_SET_REVIEWER_RULE", "Reviewer rule validation failed",
+			"author_id", rule.AuthorID,
+			"reviewer_id", rule.ReviewerID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	if err := servs.repo.SetReviewerRule(ctx, rule); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return ErrNoUser
+		}
+		servs.logger.Error("SERVICE_SET_REVIEWER_RULE", "Failed to set reviewer rule",
+			"author_id", rule.AuthorID,
+			"reviewer_id", rule.ReviewerID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	servs.logger.Info("SERVICE_SET_REVIEWER_RULE", "Reviewer rule set successfully",
+		"author_id", rule.AuthorID,
+		"reviewer_id", rule.ReviewerID,
+		"kind", rule.Kind,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (servs *PrService) GetReviewerRules(ctx context.Context, authorID string) ([]entity.ReviewerRule, error) {
+	if authorID == "" {
+		return servs.repo.ListReviewerRules(ctx)
+	}
+
+	if _, err := servs.repo.FindUserByID(ctx, authorID); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return nil, ErrNoUser
+		}
+		return nil, err
+	}
+	return servs.repo.FindReviewerRulesByAuthor(ctx, authorID)
+}
+
+func (servs *PrService) DeleteReviewerRule(ctx context.Context, authorID, reviewerID string) error {
+	if authorID == "" || reviewerID == "" {
+		return ErrEmptyUserID
+	}
+
+	err := servs.repo.DeleteReviewerRule(ctx, authorID, reviewerID)
+	if errors.Is(err, entity.ErrNoReviewerRule) {
+		return ErrNoReviewerRule
+	}
+	if err != nil {
+		servs.logger.Error("SERVICE_DELETE_REVIEWER_RULE", "Failed to delete reviewer rule",
+			"author_id", authorID,
+			"reviewer_id", reviewerID,
+			"error", err)
+		return err
+	}
+
+	servs.logger.Info("SERVICE_DELETE_REVIEWER_RULE", "Reviewer rule deleted",
+		"author_id", authorID,
+		"reviewer_id", reviewerID)
+	return nil
+}
+
+func checkReviewerRuleCorrectness(rule *entity.ReviewerRule) error {
+	if rule.AuthorID == "" || rule.ReviewerID == "" {
+		return ErrEmptyUserID
+	}
+	if rule.AuthorID == rule.ReviewerID {
+		return ErrInvalidReviewerRule
+	}
+	if rule.Kind != entity.ReviewerRuleExclude && rule.Kind != entity.ReviewerRulePrefer {
+		return ErrInvalidReviewerRule
+	}
+	return nil
+}
+
+// reviewerRules - правила для PR одного автора по reviewer_id
+type reviewerRules map[string]entity.ReviewerRuleKind
+
+// loadReviewerRules принимает repo явно, чтобы работать и внутри транзакции WithTx
+func loadReviewerRules(ctx context.Context, repo interfaces.Repository, authorID string) (reviewerRules, error) {
+	rules, err := repo.FindReviewerRulesByAuthor(ctx, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("find reviewer rules: %w", err)
+	}
+	byReviewer := make(reviewerRules, len(rules))
+	for _, rule := range rules {
+		byReviewer[rule.ReviewerID] = rule.Kind
+	}
+	return byReviewer, nil
+}
+
+// apply убирает из кандидатов исключённых правилами exclude и увеличивает вес
+// предпочтённых правилами prefer. Исходные срезы не меняются
+func (rules reviewerRules) apply(candidates []*entity.User, weights []float64) ([]*entity.User, []float64) {
+	if len(rules) == 0 {
+		return candidates, weights
+	}
+
+	var (
+		kept        []*entity.User
+		keptWeights []float64
+	)
+	for i, candidate := range candidates {
+		switch rules[candidate.UserID] {
+		case entity.ReviewerRuleExclude:
+			continue
+		case entity.ReviewerRulePrefer:
+			keptWeights = append(keptWeights, weights[i]*preferredReviewerWeight)
+		default:
+			keptWeights = append(keptWeights, weights[i])
+		}
+		kept = append(kept, candidate)
+	}
+	return kept, keptWeights
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetReviewerRule_Errors(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("SetReviewerRule", mock.Anything, mock.Anything).Return(entity.ErrNoUser)
+
+	service := NewPRService(mockRepo, logger)
+	tests := []struct {
+		name    string
+		rule    entity.ReviewerRule
+		wantErr error
+	}{
+		{"empty author", entity.ReviewerRule{ReviewerID: "u2", Kind: entity.ReviewerRuleExclude}, ErrEmptyUserID},
+		{"same user", entity.ReviewerRule{AuthorID: "u1", ReviewerID: "u1", Kind: entity.ReviewerRuleExclude}, ErrInvalidReviewerRule},
+		{"unknown kind", entity.ReviewerRule{AuthorID: "u1", ReviewerID: "u2", Kind: "avoid"}, ErrInvalidReviewerRule},
+		{"unknown user", entity.ReviewerRule{AuthorID: "u1", ReviewerID: "ghost", Kind: entity.ReviewerRulePrefer}, ErrNoUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, service.SetReviewerRule(context.Background(), &tt.rule))
+		})
+	}
+}
+
+func TestDeleteReviewerRule_NotFound(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("DeleteReviewerRule", mock.Anything, "u1", "u2").Return(entity.ErrNoReviewerRule)
+
+	service := NewPRService(mockRepo, logger)
+
+	assert.Equal(t, ErrNoReviewerRule, service.DeleteReviewerRule(context.Background(), "u1", "u2"))
+}
+
+func TestReviewerRulesApply(t *testing.T) {
+	candidates := []*entity.User{{UserID: "u2"}, {UserID: "u3"}, {UserID: "u4"}}
+	rules := reviewerRules{"u2": entity.ReviewerRuleExclude, "u4": entity.ReviewerRulePrefer}
+
+	kept, weights := rules.apply(candidates, []float64{1, 1, 0.5})
+
+	assert.Equal(t, []*entity.User{candidates[1], candidates[2]}, kept)
+	assert.Equal(t, []float64{1, 2}, weights)
+}
+
+func TestCreatePR_ExcludedReviewer(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, "u1").Return([]entity.ReviewerRule{
+		{AuthorID: "u1", ReviewerID: "u2", Kind: entity.ReviewerRuleExclude},
+	}, nil)
+	expectPolicyTeam(mockRepo, "backend", entity.ReviewPolicy{},
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u3", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u4", TeamName: "backend", IsActive: true})
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	pr := &entity.PullRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"}
+	_, err = service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u3", "u4"}, pr.AssignedReviewers)
+}
+
+func TestCreatePR_ExcludedCodeOwner(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	author := &entity.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	bob := &entity.User{UserID: "u2", TeamName: "backend", IsActive: true}
+	owners := []entity.CodeOwnerRule{{Line: 1, Pattern: "*.go", Users: []string{"u2"}}}
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, "u1").Return([]entity.ReviewerRule{
+		{AuthorID: "u1", ReviewerID: "u2", Kind: entity.ReviewerRuleExclude, Reason: "co-author"},
+	}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(author, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u2").Return(bob, nil)
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("FindCodeOwners", mock.Anything, "acme/search").Return(&entity.CodeOwners{Repository: "acme/search", Rules: owners}, nil)
+	expectTeam(mockRepo, "backend", author, bob, &entity.User{UserID: "u3", TeamName: "backend", IsActive: true})
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	pr := &entity.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorID:        "u1",
+		Metadata:        entity.PRMetadata{Repository: "acme/search", Paths: []string{"cmd/main.go"}},
+	}
+	assignment, err := service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	// Единственный владелец исключён правилом, поэтому правило CODEOWNERS не покрыто
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+	assert.Equal(t, owners, assignment.UncoveredRules)
+	assert.Equal(t, 1, assignment.UnfilledSlots)
+}
+
+func TestReassignReviewer_SkipsExcludedReviewer(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-1").Return(pr, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, "u1").Return([]entity.ReviewerRule{
+		{AuthorID: "u1", ReviewerID: "u4", Kind: entity.ReviewerRuleExclude},
+	}, nil)
+	expectPolicyTeam(mockRepo, "backend", entity.ReviewPolicy{},
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u3", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u4", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u5", TeamName: "backend", IsActive: true})
+	mockRepo.On("UpdatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	_, reassignment, err := service.ReassignReviewer(context.Background(), "pr-1", "u2", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, "u5", reassignment.NewReviewerID)
+}
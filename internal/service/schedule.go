@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+)
+
+// SetWorkSchedule создаёт или заменяет рабочее время пользователя
+func (servs *PrService) SetWorkSchedule(ctx context.Context, schedule *entity.WorkSchedule) error {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_SET_WORK_SCHEDULE", "Setting work schedule",
+		"user_id", schedule.UserID,
+		"time_zone", schedule.TimeZone,
+		"start_minute", schedule.StartMinute,
+		"end_minute", schedule.EndMinute)
+
+	if err := checkWorkScheduleCorrectness(schedule); err != nil {
+		servs.logger.Warn("SERVICE_SET_WORK_SCHEDULE", "Work schedule validation failed",
+			"user_id", schedule.UserID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	// Дни без повторов по порядку недели, как их возвращает хранилище
+	days := make(map[time.Weekday]bool, len(schedule.Days))
+	for _, day := range schedule.Days {
+		days[day] = true
+	}
+	schedule.Days = make([]time.Weekday, 0, len(days))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if days[day] {
+			schedule.Days = append(schedule.Days, day)
+		}
+	}
+
+	if err := servs.repo.SetWorkSchedule(ctx, schedule); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return ErrNoUser
+		}
+		servs.logger.Error("SERVICE_SET_WORK_SCHEDULE", "Failed to set work schedule",
+			"user_id", schedule.UserID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	servs.logger.Info("SERVICE_SET_WORK_SCHEDULE", "Work schedule set successfully",
+		"user_id", schedule.UserID,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (servs *PrService) GetWorkSchedule(ctx context.Context, userID string) (*entity.WorkSchedule, error) {
+	if userID == "" {
+		return nil, ErrEmptyUserID
+	}
+
+	schedule, err := servs.repo.FindWorkSchedule(ctx, userID)
+	if errors.Is(err, entity.ErrNoWorkSchedule) {
+		return nil, ErrNoWorkSchedule
+	}
+	return schedule, err
+}
+
+func (servs *PrService) DeleteWorkSchedule(ctx context.Context, userID string) error {
+	if userID == "" {
+		return ErrEmptyUserID
+	}
+
+	err := servs.repo.DeleteWorkSchedule(ctx, userID)
+	if errors.Is(err, entity.ErrNoWorkSchedule) {
+		return ErrNoWorkSchedule
+	}
+	if err != nil {
+		servs.logger.Error("SERVICE_DELETE_WORK_SCHEDULE", "Failed to delete work schedule",
+			"user_id", userID,
+			"error", err)
+		return err
+	}
+
+	servs.logger.Info("SERVICE_DELETE_WORK_SCHEDULE", "Work schedule deleted successfully",
+		"user_id", userID)
+	return nil
+}
+
+func checkWorkScheduleCorrectness(schedule *entity.WorkSchedule) error {
+	if schedule.UserID == "" {
+		return ErrEmptyUserID
+	}
+	// Пустое имя и Local LoadLocation принимает, но это пояс сервера, а не пользователя
+	if schedule.TimeZone == "" || schedule.TimeZone == "Local" {
+		return ErrInvalidWorkSchedule
+	}
+	if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+		return ErrInvalidWorkSchedule
+	}
+	const minutesPerDay = 24 * 60
+	if schedule.StartMinute < 0 || schedule.StartMinute >= minutesPerDay ||
+		schedule.EndMinute < 0 || schedule.EndMinute >= minutesPerDay ||
+		schedule.StartMinute == schedule.EndMinute {
+		return ErrInvalidWorkSchedule
+	}
+	if len(schedule.Days) == 0 {
+		return ErrInvalidWorkSchedule
+	}
+	for _, day := range schedule.Days {
+		if day < time.Sunday || day > time.Saturday {
+			return ErrInvalidWorkSchedule
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+func TestSetWorkSchedule(t *testing.T) {
+	valid := func() *entity.WorkSchedule {
+		return &entity.WorkSchedule{
+			UserID: "u1", TimeZone: "America/Los_Angeles", StartMinute: 9 * 60, EndMinute: 17 * 60, Days: weekdays,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(schedule *entity.WorkSchedule)
+		repoErr   error
+		wantErr   error
+		repoCalls bool
+	}{
+		{name: "valid", modify: func(*entity.WorkSchedule) {}, repoCalls: true},
+		{name: "night shift", modify: func(s *entity.WorkSchedule) { s.StartMinute, s.EndMinute = 22*60, 6*60 }, repoCalls: true},
+		{name: "empty user", modify: func(s *entity.WorkSchedule) { s.UserID = "" }, wantErr: ErrEmptyUserID},
+		{name: "unknown time zone", modify: func(s *entity.WorkSchedule) { s.TimeZone = "UTC-8" }, wantErr: ErrInvalidWorkSchedule},
+		{name: "server time zone", modify: func(s *entity.WorkSchedule) { s.TimeZone = "Local" }, wantErr: ErrInvalidWorkSchedule},
+		{name: "empty day", modify: func(s *entity.WorkSchedule) { s.EndMinute = s.StartMinute }, wantErr: ErrInvalidWorkSchedule},
+		{name: "minute out of day", modify: func(s *entity.WorkSchedule) { s.EndMinute = 24 * 60 }, wantErr: ErrInvalidWorkSchedule},
+		{name: "no days", modify: func(s *entity.WorkSchedule) { s.Days = nil }, wantErr: ErrInvalidWorkSchedule},
+		{name: "unknown day", modify: func(s *entity.WorkSchedule) { s.Days = []time.Weekday{7} }, wantErr: ErrInvalidWorkSchedule},
+		{
+			name:      "unknown user",
+			modify:    func(*entity.WorkSchedule) {},
+			repoErr:   fmt.Errorf("user u1: %w", entity.ErrNoUser),
+			wantErr:   ErrNoUser,
+			repoCalls: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.Repository{}
+			logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+			require.NoError(t, err)
+
+			schedule := valid()
+			tt.modify(schedule)
+			mockRepo.On("SetWorkSchedule", mock.Anything, schedule).Return(tt.repoErr)
+			service := NewPRService(mockRepo, logger)
+
+			err = service.SetWorkSchedule(context.Background(), schedule)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if !tt.repoCalls {
+				mockRepo.AssertNotCalled(t, "SetWorkSchedule", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestSetWorkSchedule_SortsDays(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	schedule := &entity.WorkSchedule{
+		UserID: "u1", TimeZone: "UTC", StartMinute: 0, EndMinute: 8 * 60,
+		Days: []time.Weekday{time.Saturday, time.Monday, time.Sunday, time.Monday},
+	}
+	mockRepo.On("SetWorkSchedule", mock.Anything, schedule).Return(nil)
+	service := NewPRService(mockRepo, logger)
+
+	require.NoError(t, service.SetWorkSchedule(context.Background(), schedule))
+	assert.Equal(t, []time.Weekday{time.Sunday, time.Monday, time.Saturday}, schedule.Days)
+}
+
+func TestScoreCandidates_WorkingHours(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	// Среда, 14:00 в Берлине и 05:00 в Лос-Анджелесе
+	now := time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)
+	candidates := []*entity.User{{UserID: "berlin"}, {UserID: "la"}, {UserID: "unknown"}}
+	mockRepo.On("FindWorkSchedulesByTeam", mock.Anything, "backend").Return([]*entity.WorkSchedule{
+		{UserID: "berlin", TimeZone: "Europe/Berlin", StartMinute: 9 * 60, EndMinute: 18 * 60, Days: weekdays},
+		{UserID: "la", TimeZone: "America/Los_Angeles", StartMinute: 9 * 60, EndMinute: 17 * 60, Days: weekdays},
+	}, nil)
+
+	service := NewPRServiceWithOptions(mockRepo, logger, Options{PreferWorkingHours: true}).(*PrService)
+
+	scores, err := service.scoreCandidates(context.Background(), mockRepo, "backend", "u1", candidates, nil, now)
+
+	require.NoError(t, err)
+	// До начала рабочего дня в Лос-Анджелесе 4 часа
+	assert.Equal(t, []float64{1, 0.2, 1}, scoreWeights(scores))
+	assert.Equal(t, 0.2, scores[1].WorkingHours)
+}
+
+func TestScoreCandidates_Disabled(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	service := NewPRService(mockRepo, logger).(*PrService)
+
+	scores, err := service.scoreCandidates(context.Background(), mockRepo, "backend", "u1",
+		[]*entity.User{{UserID: "u1"}, {UserID: "u2"}}, nil, time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, []entity.CandidateScore{entity.NewCandidateScore(), entity.NewCandidateScore()}, scores)
+	assert.Equal(t, []float64{1, 1}, scoreWeights(scores))
+	mockRepo.AssertNotCalled(t, "FindWorkSchedulesByTeam", mock.Anything, mock.Anything)
+}
+
+func TestSelectReviewers_Weighted(t *testing.T) {
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+	service := NewPRServiceWithSeed(&mocks.Repository{}, logger, 42).(*PrService)
+
+	candidates := []*entity.User{{UserID: "asleep"}, {UserID: "u1"}, {UserID: "u2"}}
+	for i := 0; i < 100; i++ {
+		selected := service.selectReviewers(candidates, []float64{0, 1, 0.5}, 2)
+		assert.ElementsMatch(t, []string{"u1", "u2"}, selected)
+	}
+
+	// Кандидатов не больше мест - выбираются все, вес не важен
+	assert.Equal(t, []string{"asleep"}, service.selectReviewers(candidates[:1], []float64{0}, 2))
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+)
+
+// scoreCandidates оценивает каждого кандидата из findReviewCandidates на PR автора
+// authorID: множитель каждого фактора отдельно, чтобы объяснить выбор, а итоговый вес
+// (scoreWeights) - чем он больше, тем вероятнее кандидат будет выбран. Без нужных PR
+// навыков и включённых в Options факторов веса равны и выбор равновероятен
+func (servs *PrService) scoreCandidates(ctx context.Context, repo interfaces.Repository, teamName, authorID string, candidates []*entity.User, requiredSkills []string, now time.Time) ([]entity.CandidateScore, error) {
+	scores := make([]entity.CandidateScore, len(candidates))
+	for i := range scores {
+		scores[i] = entity.NewCandidateScore()
+	}
+
+	if len(requiredSkills) > 0 && len(candidates) > 0 {
+		skills, err := repo.FindSkillsByTeam(ctx, teamName)
+		if err != nil {
+			return nil, fmt.Errorf("find skills: %w", err)
+		}
+		byUser := make(map[string]map[string]int)
+		for _, skill := range skills {
+			if byUser[skill.UserID] == nil {
+				byUser[skill.UserID] = make(map[string]int)
+			}
+			byUser[skill.UserID][skill.Tag] = skill.Level
+		}
+		for i, candidate := range candidates {
+			scores[i].Skills = skillWeight(byUser[candidate.UserID], requiredSkills)
+		}
+	}
+
+	if servs.options.PreferWorkingHours && len(candidates) > 0 {
+		schedules, err := repo.FindWorkSchedulesByTeam(ctx, teamName)
+		if err != nil {
+			return nil, fmt.Errorf("find work schedules: %w", err)
+		}
+		byUser := make(map[string]*entity.WorkSchedule, len(schedules))
+		for _, schedule := range schedules {
+			byUser[schedule.UserID] = schedule
+		}
+		// Кандидаты без рабочего времени считаются доступными всегда
+		for i, candidate := range candidates {
+			if schedule, ok := byUser[candidate.UserID]; ok {
+				scores[i].WorkingHours = workingHoursWeight(schedule.UntilWorkingHours(now))
+			}
+		}
+	}
+
+	if servs.options.BalanceLoad && len(candidates) > 0 {
+		loads, err := repo.FindReviewLoads(ctx, teamName)
+		if err != nil {
+			return nil, fmt.Errorf("find review loads: %w", err)
+		}
+		linesPerReview := servs.options.LinesPerReview
+		if linesPerReview <= 0 {
+			linesPerReview = defaultLinesPerReview
+		}
+		for i, candidate := range candidates {
+			scores[i].Load = loadWeight(loads[candidate.UserID], linesPerReview)
+		}
+	}
+
+	if servs.options.PairHistoryWindow > 0 && len(candidates) > 0 {
+		counts, err := repo.FindPairReviewCounts(ctx, authorID, now.Add(-servs.options.PairHistoryWindow))
+		if err != nil {
+			return nil, fmt.Errorf("find pair review counts: %w", err)
+		}
+		for i, candidate := range candidates {
+			scores[i].PairHistory = pairHistoryWeight(counts[candidate.UserID])
+		}
+	}
+	return scores, nil
+}
+
+func scoreWeights(scores []entity.CandidateScore) []float64 {
+	weights := make([]float64, len(scores))
+	for i, score := range scores {
+		weights[i] = score.Weight()
+	}
+	return weights
+}
+
+// workingHoursWeight - 1 в рабочее время и меньше с каждым часом ожидания его начала:
+// через час 1/2, через ночь в 15 часов 1/16
+func workingHoursWeight(wait time.Duration) float64 {
+	return 1 / (1 + wait.Hours())
+}
+
+// defaultLinesPerReview - сколько изменённых строк открытых PR по умолчанию весят
+// как одно дополнительное ревью
+const defaultLinesPerReview = 500
+
+// loadWeight - 1 без открытых ревью и меньше с каждым ревью и каждыми linesPerReview
+// строками в них: одно небольшое ревью даёт 1/2, одно ревью на 1000 строк при 500 - 1/4
+func loadWeight(load entity.ReviewLoad, linesPerReview int) float64 {
+	return 1 / (1 + float64(load.OpenReviews) + float64(load.ChangedLines)/float64(linesPerReview))
+}
+
+// pairHistoryWeight - 1, если кандидат не ревьюил PR автора за окно истории, и меньше
+// с каждым таким ревью: после одного 1/2, после трёх 1/4
+func pairHistoryWeight(reviews int) float64 {
+	return 1 / (1 + float64(reviews))
+}
+
+// selectReviewers выбирает до maxCount кандидатов без повторов, каждого с вероятностью,
+// пропорциональной его весу среди ещё не выбранных
+func (servs *PrService) selectReviewers(candidates []*entity.User, weights []float64, maxCount int) []string {
+	if len(candidates) == 0 {
+		return []string{}
+	}
+
+	// Если кандидатов меньше или равно maxCount - возвращаем всех
+	if len(candidates) <= maxCount {
+		userIDs := make([]string, len(candidates))
+		for i, user := range candidates {
+			userIDs[i] = user.UserID
+		}
+		return userIDs
+	}
+
+	remaining := make([]int, len(candidates))
+	for i := range remaining {
+		remaining[i] = i
+	}
+
+	// Используем random сервиса
+	selected := make([]string, 0, maxCount)
+	for len(selected) < maxCount {
+		total := 0.0
+		for _, idx := range remaining {
+			total += weights[idx]
+		}
+
+		point := servs.random.Float64() * total
+		pick := len(remaining) - 1
+		for i, idx := range remaining {
+			point -= weights[idx]
+			if point < 0 {
+				pick = i
+				break
+			}
+		}
+
+		selected = append(selected, candidates[remaining[pick]].UserID)
+		remaining = append(remaining[:pick], remaining[pick+1:]...)
+	}
+
+	return selected
+}
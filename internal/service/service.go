@@ -1,8 +1,11 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
@@ -10,9 +13,24 @@ import (
 )
 
 type PrService struct {
-	repo   interfaces.Repository
-	logger interfaces.Logger
-	random *rand.Rand
+	repo    interfaces.Repository
+	logger  interfaces.Logger
+	random  *rand.Rand
+	options Options
+}
+
+// Options включает необязательные факторы выбора ревьюверов
+type Options struct {
+	// PreferWorkingHours - чаще выбирать кандидатов, у которых идёт или скоро начнётся рабочее время
+	PreferWorkingHours bool
+	// BalanceLoad - реже выбирать кандидатов с большим числом и объёмом открытых ревью
+	BalanceLoad bool
+	// LinesPerReview - сколько изменённых строк открытых PR считаются за одно ревью
+	// при BalanceLoad, 0 - значение по умолчанию defaultLinesPerReview
+	LinesPerReview int
+	// PairHistoryWindow - за какой срок учитываются прошлые ревью PR автора: чем чаще кандидат
+	// ревьюил автора, тем реже он выбирается снова. 0 отключает фактор
+	PairHistoryWindow time.Duration
 }
 
 func NewPRService(repo interfaces.Repository, logger interfaces.Logger) interfaces.Service {
@@ -32,11 +50,21 @@ func NewPRServiceWithSeed(repo interfaces.Repository, logger interfaces.Logger,
 	}
 }
 
+// NewPRServiceWithOptions создает сервис с дополнительными факторами выбора ревьюверов
+func NewPRServiceWithOptions(repo interfaces.Repository, logger interfaces.Logger, options Options) interfaces.Service {
+	return &PrService{
+		repo:    repo,
+		logger:  logger,
+		random:  rand.New(rand.NewSource(time.Now().UnixNano())),
+		options: options,
+	}
+}
+
 func (servs *PrService) SetSeed(seed int64) {
 	servs.random = rand.New(rand.NewSource(seed))
 }
 
-func (servs *PrService) CreateTeam(team *entity.Team) error {
+func (servs *PrService) CreateTeam(ctx context.Context, team *entity.Team) error {
 	start := time.Now()
 
 	if team == nil {
@@ -59,7 +87,7 @@ func (servs *PrService) CreateTeam(team *entity.Team) error {
 	}
 
 	// Проверка валидности создания
-	if err := servs.validateTeamCreation(team); err != nil {
+	if err := servs.validateTeamCreation(ctx, team); err != nil {
 		servs.logger.Warn("SERVICE_CREATE_TEAM", "Team creation validation failed",
 			"team_name", team.TeamName,
 			"error", err,
@@ -67,7 +95,7 @@ func (servs *PrService) CreateTeam(team *entity.Team) error {
 		return err
 	}
 
-	if err := servs.repo.CreateTeam(team); err != nil {
+	if err := servs.repo.CreateTeam(ctx, team); err != nil {
 		servs.logger.Error("SERVICE_CREATE_TEAM", "Failed to create team in repository",
 			"team_name", team.TeamName,
 			"error", err,
@@ -82,7 +110,7 @@ func (servs *PrService) CreateTeam(team *entity.Team) error {
 	return nil
 }
 
-func (servs *PrService) GetTeam(teamName string) (*entity.Team, error) {
+func (servs *PrService) GetTeam(ctx context.Context, teamName string) (*entity.Team, error) {
 	start := time.Now()
 
 	servs.logger.Debug("SERVICE_GET_TEAM", "Getting team",
@@ -94,7 +122,7 @@ func (servs *PrService) GetTeam(teamName string) (*entity.Team, error) {
 		return nil, ErrEmptyTeamName
 	}
 
-	team, err := servs.repo.FindTeamByName(teamName)
+	team, err := servs.repo.FindTeamByName(ctx, teamName)
 	if err != nil {
 		servs.logger.Error("SERVICE_GET_TEAM", "Failed to find team",
 			"team_name", teamName,
@@ -110,7 +138,7 @@ func (servs *PrService) GetTeam(teamName string) (*entity.Team, error) {
 	return team, nil
 }
 
-func (servs *PrService) SetUserActive(userID string, isActive bool) (*entity.User, error) {
+func (servs *PrService) SetUserActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
 	start := time.Now()
 
 	servs.logger.Debug("SERVICE_SET_USER_ACTIVE", "Setting user active status",
@@ -123,7 +151,7 @@ func (servs *PrService) SetUserActive(userID string, isActive bool) (*entity.Use
 		return nil, ErrEmptyUserID
 	}
 
-	user, err := servs.repo.FindUserByID(userID)
+	user, err := servs.repo.FindUserByID(ctx, userID)
 	if err != nil {
 		servs.logger.Error("SERVICE_SET_USER_ACTIVE", "Failed to find user",
 			"user_id", userID,
@@ -140,7 +168,7 @@ func (servs *PrService) SetUserActive(userID string, isActive bool) (*entity.Use
 		return user, nil
 	}
 
-	err = servs.repo.SetActive(userID, isActive)
+	err = servs.repo.SetActive(ctx, userID, isActive)
 	if err != nil {
 		servs.logger.Error("SERVICE_SET_USER_ACTIVE", "Failed to set user active status in repository",
 			"user_id", userID,
@@ -160,7 +188,7 @@ func (servs *PrService) SetUserActive(userID string, isActive bool) (*entity.Use
 	return user, nil
 }
 
-func (servs *PrService) GetUserReviews(userID string) ([]*entity.PullRequest, error) {
+func (servs *PrService) GetUserReviews(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
 	start := time.Now()
 
 	servs.logger.Debug("SERVICE_GET_USER_REVIEWS", "Getting user reviews",
@@ -172,7 +200,7 @@ func (servs *PrService) GetUserReviews(userID string) ([]*entity.PullRequest, er
 		return nil, ErrEmptyUserID
 	}
 
-	if _, err := servs.repo.FindUserByID(userID); err != nil {
+	if _, err := servs.repo.FindUserByID(ctx, userID); err != nil {
 		servs.logger.Error("SERVICE_GET_USER_REVIEWS", "Failed to find user",
 			"user_id", userID,
 			"error", err,
@@ -180,7 +208,7 @@ func (servs *PrService) GetUserReviews(userID string) ([]*entity.PullRequest, er
 		return nil, err
 	}
 
-	prs, err := servs.repo.FindPRsByReviewer(userID)
+	prs, err := servs.repo.FindPRsByReviewer(ctx, userID)
 	if err != nil {
 		servs.logger.Error("SERVICE_GET_USER_REVIEWS", "Failed to get user reviews from repository",
 			"user_id", userID,
@@ -196,7 +224,10 @@ func (servs *PrService) GetUserReviews(userID string) ([]*entity.PullRequest, er
 	return prs, nil
 }
 
-func (servs *PrService) CreatePR(pr *entity.PullRequest) error {
+// reviewersPerPR - сколько ревьюверов назначается на новый PR
+const reviewersPerPR = 2
+
+func (servs *PrService) CreatePR(ctx context.Context, pr *entity.PullRequest) (*entity.ReviewAssignment, error) {
 	start := time.Now()
 
 	if err := checkPRCorrectness(pr); err != nil {
@@ -206,60 +237,52 @@ func (servs *PrService) CreatePR(pr *entity.PullRequest) error {
 			"author_id", pr.AuthorID,
 			"error", err,
 			"duration_ms", time.Since(start).Milliseconds())
-		return err
+		return nil, err
 	}
 
+	pr.Metadata.Labels = normalizeStrings(pr.Metadata.Labels)
+	pr.Metadata.Paths = normalizeStrings(pr.Metadata.Paths)
+	pr.Metadata.RequiredSkills = normalizeSkillTags(pr.Metadata.RequiredSkills)
+
 	servs.logger.Debug("SERVICE_CREATE_PR", "Starting PR creation",
 		"pr_id", pr.PullRequestID,
 		"pr_name", pr.PullRequestName,
 		"author_id", pr.AuthorID)
 
 	// Проверяем что автор существует
-	author, err := servs.repo.FindUserByID(pr.AuthorID)
+	author, err := servs.repo.FindUserByID(ctx, pr.AuthorID)
 	if err != nil {
 		servs.logger.Error("SERVICE_CREATE_PR", "Author not found",
 			"author_id", pr.AuthorID,
 			"error", err,
 			"duration_ms", time.Since(start).Milliseconds())
-		return fmt.Errorf("author not found: %w", err)
+		return nil, fmt.Errorf("author not found: %w", err)
 	}
 
 	// Проверяем что PR не существует
-	if existingPR, err := servs.repo.FindPRByID(pr.PullRequestID); err == nil && existingPR != nil {
+	if existingPR, err := servs.repo.FindPRByID(ctx, pr.PullRequestID); err == nil && existingPR != nil {
 		servs.logger.Warn("SERVICE_CREATE_PR", "PR already exists",
 			"pr_id", pr.PullRequestID,
 			"duration_ms", time.Since(start).Milliseconds())
-		return ErrPRAlreadyExists
+		return nil, ErrPRAlreadyExists
 	}
 
-	// Назначаем ревьюверов
-	candidates, err := servs.findReviewCandidates(author.TeamName, pr.AuthorID)
+	assignment, reviewers, shadowReviewer, err := servs.planReviewers(ctx, "SERVICE_CREATE_PR", pr, author, start)
 	if err != nil {
-		servs.logger.Error("SERVICE_CREATE_PR", "Failed to find review candidates",
-			"team_name", author.TeamName,
-			"author_id", pr.AuthorID,
-			"error", err,
-			"duration_ms", time.Since(start).Milliseconds())
-		return fmt.Errorf("find review candidates: %w", err)
+		return nil, err
 	}
 
-	reviewers := servs.selectReviewers(candidates, 2)
-
-	servs.logger.Debug("SERVICE_CREATE_PR", "Reviewers selected",
-		"pr_id", pr.PullRequestID,
-		"candidates_count", len(candidates),
-		"reviewers_selected", reviewers)
-
 	pr.Status = entity.PullRequestStatusOpen
 	pr.AssignedReviewers = reviewers
+	pr.ShadowReviewer = shadowReviewer
 	pr.CreatedAt = time.Now() // Добавляем timestamp
 
-	if err := servs.repo.CreatePR(pr); err != nil {
+	if err := servs.repo.CreatePR(ctx, pr); err != nil {
 		servs.logger.Error("SERVICE_CREATE_PR", "Failed to create PR in repository",
 			"pr_id", pr.PullRequestID,
 			"error", err,
 			"duration_ms", time.Since(start).Milliseconds())
-		return err
+		return nil, err
 	}
 
 	servs.logger.Info("SERVICE_CREATE_PR", "PR created successfully",
@@ -268,173 +291,443 @@ func (servs *PrService) CreatePR(pr *entity.PullRequest) error {
 		"author_id", pr.AuthorID,
 		"reviewers_count", len(reviewers),
 		"team_name", author.TeamName,
+		"candidates", assignment.Candidates,
 		"duration_ms", time.Since(start).Milliseconds())
-	return nil
+	return assignment, nil
 }
 
-func (servs *PrService) MergePR(prID string) (*entity.PullRequest, error) {
+func (servs *PrService) PreviewPR(ctx context.Context, pr *entity.PullRequest) (*entity.ReviewPreview, error) {
 	start := time.Now()
 
-	servs.logger.Debug("SERVICE_MERGE_PR", "Starting PR merge",
-		"pr_id", prID)
-
-	if prID == "" {
-		servs.logger.Warn("SERVICE_MERGE_PR", "Empty PR ID provided",
+	if err := checkPreviewCorrectness(pr); err != nil {
+		servs.logger.Warn("SERVICE_PREVIEW_PR", "PR data validation failed",
+			"author_id", pr.AuthorID,
+			"error", err,
 			"duration_ms", time.Since(start).Milliseconds())
-		return nil, ErrEmptyPRID
+		return nil, err
 	}
 
-	pr, err := servs.repo.FindPRByID(prID)
+	pr.Metadata.Labels = normalizeStrings(pr.Metadata.Labels)
+	pr.Metadata.Paths = normalizeStrings(pr.Metadata.Paths)
+	pr.Metadata.RequiredSkills = normalizeSkillTags(pr.Metadata.RequiredSkills)
+
+	author, err := servs.repo.FindUserByID(ctx, pr.AuthorID)
 	if err != nil {
-		servs.logger.Error("SERVICE_MERGE_PR", "Failed to find PR",
-			"pr_id", prID,
+		if errors.Is(err, entity.ErrNoUser) {
+			return nil, ErrNoUser
+		}
+		servs.logger.Error("SERVICE_PREVIEW_PR", "Failed to find author",
+			"author_id", pr.AuthorID,
 			"error", err,
 			"duration_ms", time.Since(start).Milliseconds())
-		return nil, fmt.Errorf("find PR: %w", err)
+		return nil, fmt.Errorf("find author: %w", err)
 	}
 
-	if pr.Status == entity.PullRequestStatusMerged {
-		servs.logger.Debug("SERVICE_MERGE_PR", "PR already merged",
-			"pr_id", prID,
-			"duration_ms", time.Since(start).Milliseconds())
-		return pr, nil
+	assignment, reviewers, shadowReviewer, err := servs.planReviewers(ctx, "SERVICE_PREVIEW_PR", pr, author, start)
+	if err != nil {
+		return nil, err
 	}
 
-	pr.Status = entity.PullRequestStatusMerged
-	pr.MergedAt = start
-	if err := servs.repo.UpdatePR(pr); err != nil {
-		servs.logger.Error("SERVICE_MERGE_PR", "Failed to update PR status in repository",
-			"pr_id", prID,
-			"error", err,
-			"duration_ms", time.Since(start).Milliseconds())
-		return nil, fmt.Errorf("update PR: %w", err)
+	preview := &entity.ReviewPreview{
+		Reviewers:      reviewers,
+		ShadowReviewer: shadowReviewer,
+		Alternatives:   alternativeReviewers(assignment.Candidates),
+		Assignment:     *assignment,
 	}
 
-	servs.logger.Info("SERVICE_MERGE_PR", "PR merged successfully",
-		"pr_id", prID,
-		"pr_name", pr.PullRequestName,
+	servs.logger.Info("SERVICE_PREVIEW_PR", "PR reviewers previewed",
 		"author_id", pr.AuthorID,
-		"reviewers_count", len(pr.AssignedReviewers),
+		"team_name", author.TeamName,
+		"reviewers", reviewers,
+		"alternatives", preview.Alternatives,
+		"candidates", assignment.Candidates,
 		"duration_ms", time.Since(start).Milliseconds())
-	return pr, nil
+	return preview, nil
 }
 
-func (servs *PrService) ReassignReviewer(prID, oldUserID string) (*entity.PullRequest, string, error) {
-	start := time.Now()
-
-	servs.logger.Debug("SERVICE_REASSIGN_REVIEWER", "Starting reviewer reassignment",
-		"pr_id", prID,
-		"old_user_id", oldUserID)
-
-	if prID == "" {
-		servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "Empty PR ID provided",
-			"duration_ms", time.Since(start).Milliseconds())
-		return nil, "", ErrEmptyPRID
-	}
-	if oldUserID == "" {
-		servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "Empty user ID provided",
+// planReviewers подбирает ревьюверов на PR автора author: владельцы кода, кандидаты из
+// команды с учётом правил пар, политики senior и теневого ревьювера. Ничего не сохраняет,
+// op - операция для логов вызывающего метода
+func (servs *PrService) planReviewers(ctx context.Context, op string, pr *entity.PullRequest, author *entity.User, start time.Time) (*entity.ReviewAssignment, []string, string, error) {
+	// Правила пар автора действуют и на владельцев кода, и на кандидатов из команды
+	rules, err := loadReviewerRules(ctx, servs.repo, pr.AuthorID)
+	if err != nil {
+		servs.logger.Error(op, "Failed to load reviewer rules",
+			"author_id", pr.AuthorID,
+			"error", err,
 			"duration_ms", time.Since(start).Milliseconds())
-		return nil, "", ErrEmptyUserID
+		return nil, nil, "", err
 	}
 
-	// Находим PR
-	pr, err := servs.repo.FindPRByID(prID)
+	// Сначала владельцы кода изменённых файлов, они могут быть и из других команд
+	assignment := &entity.ReviewAssignment{}
+	owners, err := servs.assignCodeOwners(ctx, servs.repo, pr, rules, assignment)
 	if err != nil {
-		servs.logger.Error("SERVICE_REASSIGN_REVIEWER", "Failed to find PR",
-			"pr_id", prID,
+		servs.logger.Error(op, "Failed to assign code owners",
+			"pr_id", pr.PullRequestID,
+			"repository", pr.Metadata.Repository,
 			"error", err,
 			"duration_ms", time.Since(start).Milliseconds())
-		return nil, "", fmt.Errorf("find PR: %w", err)
+		return nil, nil, "", fmt.Errorf("assign code owners: %w", err)
+	}
+	if len(assignment.UncoveredRules) > 0 {
+		servs.logger.Warn(op, "Code owners rules left uncovered",
+			"pr_id", pr.PullRequestID,
+			"repository", pr.Metadata.Repository,
+			"uncovered_rules", len(assignment.UncoveredRules))
 	}
 
-	// Проверяем что PR не мерджен
-	if pr.Status == entity.PullRequestStatusMerged {
-		servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "Attempt to reassign on merged PR",
-			"pr_id", prID,
+	// Остальные места заполняем из команды автора
+	candidates, excluded, err := servs.findReviewCandidates(ctx, servs.repo, author.TeamName, pr.AuthorID, owners...)
+	if err != nil {
+		servs.logger.Error(op, "Failed to find review candidates",
+			"team_name", author.TeamName,
+			"author_id", pr.AuthorID,
+			"error", err,
 			"duration_ms", time.Since(start).Milliseconds())
-		return nil, "", ErrCannotReassingOnMergedPR
+		return nil, nil, "", fmt.Errorf("find review candidates: %w", err)
 	}
 
-	// Проверяем что старый ревьювер существует и получаем его команду
-	oldUser, err := servs.repo.FindUserByID(oldUserID)
+	scores, err := servs.scoreCandidates(ctx, servs.repo, author.TeamName, pr.AuthorID, candidates, pr.Metadata.RequiredSkills, start)
 	if err != nil {
-		servs.logger.Error("SERVICE_REASSIGN_REVIEWER", "Failed to find old user",
-			"old_user_id", oldUserID,
+		servs.logger.Error(op, "Failed to weigh review candidates",
+			"team_name", author.TeamName,
 			"error", err,
 			"duration_ms", time.Since(start).Milliseconds())
-		return nil, "", fmt.Errorf("find old user: %w", err)
+		return nil, nil, "", fmt.Errorf("weigh review candidates: %w", err)
 	}
+	assignment.Candidates = explainCandidates(candidates, scores, excluded, rules)
+	candidates, weights := rules.apply(candidates, scoreWeights(scores))
 
-	// Проверяем что старый ревьювер действительно назначен на этот PR
-	if !servs.containsReviewer(pr.AssignedReviewers, oldUserID) {
-		servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "Reviewer not assigned to this PR",
-			"pr_id", prID,
-			"old_user_id", oldUserID,
-			"assigned_reviewers", pr.AssignedReviewers,
+	// Если политика команды требует senior, а среди владельцев кода его нет, первым
+	// выбирается senior: на одно из оставшихся мест или сверх них, если владельцы заняли все
+	slots := max(reviewersPerPR-len(owners), 0)
+	needSenior, err := seniorRequired(ctx, servs.repo, author, owners)
+	if err != nil {
+		servs.logger.Error(op, "Failed to check team review policy",
+			"team_name", author.TeamName,
+			"error", err,
 			"duration_ms", time.Since(start).Milliseconds())
-		return nil, "", fmt.Errorf("reviewer %s not assigned to this PR", oldUserID)
+		return nil, nil, "", fmt.Errorf("check review policy: %w", err)
+	}
+	if needSenior {
+		seniors, seniorWeights := seniorCandidates(candidates, weights)
+		if picked := servs.selectReviewers(seniors, seniorWeights, 1); len(picked) > 0 {
+			owners = append(owners, picked[0])
+			candidates, weights = withoutCandidate(candidates, weights, picked[0])
+			slots = max(slots-1, 0)
+		} else {
+			assignment.SeniorMissing = true
+			servs.logger.Warn(op, "Team policy requires a senior reviewer, none available",
+				"pr_id", pr.PullRequestID,
+				"team_name", author.TeamName)
+		}
 	}
 
-	// Ищем кандидатов для замены из команды старого ревьювера
-	excludeUsers := []string{pr.AuthorID}
-	excludeUsers = append(excludeUsers, pr.AssignedReviewers...) // исключаем уже назначенных
-	flag := false
-	for _, usersId := range excludeUsers {
-		if usersId == oldUserID {
-			flag = true
-			break
+	// Владельцев кода может оказаться больше reviewersPerPR, тогда они назначаются все
+	reviewers := append(owners, servs.selectReviewers(candidates, weights, slots)...)
+	assignment.UnfilledSlots = max(reviewersPerPR-len(reviewers), 0)
+	for _, userID := range atCapacityIDs(excluded) {
+		if !servs.containsReviewer(assignment.AtCapacity, userID) {
+			assignment.AtCapacity = append(assignment.AtCapacity, userID)
 		}
 	}
-	if !flag {
-		excludeUsers = append(excludeUsers, oldUserID)
+	if assignment.UnfilledSlots > 0 && len(assignment.AtCapacity) > 0 {
+		servs.logger.Warn(op, "Reviewer slots left unfilled, team members at capacity",
+			"pr_id", pr.PullRequestID,
+			"unfilled_slots", assignment.UnfilledSlots,
+			"at_capacity", assignment.AtCapacity)
 	}
 
-	candidates, err := servs.findReviewCandidates(oldUser.TeamName, excludeUsers...)
-	if err != nil {
-		servs.logger.Error("SERVICE_REASSIGN_REVIEWER", "Failed to find replacement candidates",
-			"team_name", oldUser.TeamName,
-			"excluded_users", excludeUsers,
-			"error", err,
-			"duration_ms", time.Since(start).Milliseconds())
-		return nil, "", fmt.Errorf("find replacement candidates: %w", err)
-	}
+	// Теневой ревьювер из учеников занимает отдельное место и в reviewers не входит
+	shadowReviewer := servs.selectShadowReviewer(candidates, weights, reviewers)
+	markSelected(assignment.Candidates, reviewers, shadowReviewer)
+
+	servs.logger.Debug(op, "Reviewers selected",
+		"pr_id", pr.PullRequestID,
+		"candidates_count", len(candidates),
+		"reviewers_selected", reviewers,
+		"shadow_reviewer", shadowReviewer)
 
-	// Выбираем одного случайного кандидата
-	newReviewers := servs.selectReviewers(candidates, 1)
-	if len(newReviewers) == 0 {
-		servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "No replacement candidates available",
-			"team_name", oldUser.TeamName,
-			"candidates_count", len(candidates),
+	return assignment, reviewers, shadowReviewer, nil
+}
+
+func (servs *PrService) MergePR(ctx context.Context, prID string, expectedVersion int) (*entity.PullRequest, error) {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_MERGE_PR", "Starting PR merge",
+		"pr_id", prID,
+		"expected_version", expectedVersion)
+
+	if prID == "" {
+		servs.logger.Warn("SERVICE_MERGE_PR", "Empty PR ID provided",
 			"duration_ms", time.Since(start).Milliseconds())
-		return nil, "", ErrNoReplacementCandidate
+		return nil, ErrEmptyPRID
 	}
 
-	newReviewerID := newReviewers[0]
+	var (
+		pr            *entity.PullRequest
+		alreadyMerged bool
+	)
+
+	// Чтение и запись PR в одной транзакции под блокировкой строки
+	err := servs.repo.WithTx(ctx, func(repo interfaces.Repository) error {
+		var err error
+		pr, err = repo.FindPRByIDForUpdate(ctx, prID)
+		if err != nil {
+			servs.logger.Error("SERVICE_MERGE_PR", "Failed to find PR",
+				"pr_id", prID,
+				"error", err,
+				"duration_ms", time.Since(start).Milliseconds())
+			return fmt.Errorf("find PR: %w", err)
+		}
+
+		if pr.Status == entity.PullRequestStatusMerged {
+			servs.logger.Debug("SERVICE_MERGE_PR", "PR already merged",
+				"pr_id", prID,
+				"duration_ms", time.Since(start).Milliseconds())
+			alreadyMerged = true
+			return nil
+		}
+
+		if err := checkPRVersion(pr, expectedVersion); err != nil {
+			servs.logger.Warn("SERVICE_MERGE_PR", "PR version mismatch",
+				"pr_id", prID,
+				"expected_version", expectedVersion,
+				"actual_version", pr.Version,
+				"duration_ms", time.Since(start).Milliseconds())
+			return err
+		}
 
-	// Заменяем ревьювера в списке
-	for i, reviewer := range pr.AssignedReviewers {
-		if reviewer == oldUserID {
-			pr.AssignedReviewers[i] = newReviewerID
-			break
+		pr.Status = entity.PullRequestStatusMerged
+		pr.MergedAt = start
+		if err := repo.UpdatePR(ctx, pr); err != nil {
+			if errors.Is(err, entity.ErrVersionConflict) {
+				servs.logger.Warn("SERVICE_MERGE_PR", "PR was modified concurrently",
+					"pr_id", prID,
+					"duration_ms", time.Since(start).Milliseconds())
+				return ErrPRVersionConflict
+			}
+			servs.logger.Error("SERVICE_MERGE_PR", "Failed to update PR status in repository",
+				"pr_id", prID,
+				"error", err,
+				"duration_ms", time.Since(start).Milliseconds())
+			return fmt.Errorf("update PR: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Сохраняем изменения
-	if err := servs.repo.UpdatePR(pr); err != nil {
-		servs.logger.Error("SERVICE_REASSIGN_REVIEWER", "Failed to update PR in repository",
+	if !alreadyMerged {
+		servs.logger.Info("SERVICE_MERGE_PR", "PR merged successfully",
 			"pr_id", prID,
-			"error", err,
+			"pr_name", pr.PullRequestName,
+			"author_id", pr.AuthorID,
+			"reviewers_count", len(pr.AssignedReviewers),
+			"duration_ms", time.Since(start).Milliseconds())
+	}
+	return pr, nil
+}
+
+func (servs *PrService) ReassignReviewer(ctx context.Context, prID, oldUserID string, expectedVersion int) (*entity.PullRequest, *entity.Reassignment, error) {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_REASSIGN_REVIEWER", "Starting reviewer reassignment",
+		"pr_id", prID,
+		"old_user_id", oldUserID,
+		"expected_version", expectedVersion)
+
+	if prID == "" {
+		servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "Empty PR ID provided",
 			"duration_ms", time.Since(start).Milliseconds())
-		return nil, "", fmt.Errorf("update PR: %w", err)
+		return nil, nil, ErrEmptyPRID
+	}
+	if oldUserID == "" {
+		servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "Empty user ID provided",
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, nil, ErrEmptyUserID
+	}
+
+	var (
+		pr           *entity.PullRequest
+		reassignment = &entity.Reassignment{}
+		teamName     string
+	)
+
+	// Весь read-modify-write выполняется в одной транзакции: PR блокируется
+	// на чтении, поэтому параллельные переназначения выполняются по очереди
+	err := servs.repo.WithTx(ctx, func(repo interfaces.Repository) error {
+		var err error
+		pr, err = repo.FindPRByIDForUpdate(ctx, prID)
+		if err != nil {
+			servs.logger.Error("SERVICE_REASSIGN_REVIEWER", "Failed to find PR",
+				"pr_id", prID,
+				"error", err,
+				"duration_ms", time.Since(start).Milliseconds())
+			return fmt.Errorf("find PR: %w", err)
+		}
+
+		// Проверяем что PR не мерджен
+		if pr.Status == entity.PullRequestStatusMerged {
+			servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "Attempt to reassign on merged PR",
+				"pr_id", prID,
+				"duration_ms", time.Since(start).Milliseconds())
+			return ErrCannotReassingOnMergedPR
+		}
+
+		if err := checkPRVersion(pr, expectedVersion); err != nil {
+			servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "PR version mismatch",
+				"pr_id", prID,
+				"expected_version", expectedVersion,
+				"actual_version", pr.Version,
+				"duration_ms", time.Since(start).Milliseconds())
+			return err
+		}
+
+		// Проверяем что старый ревьювер существует и получаем его команду
+		oldUser, err := repo.FindUserByID(ctx, oldUserID)
+		if err != nil {
+			servs.logger.Error("SERVICE_REASSIGN_REVIEWER", "Failed to find old user",
+				"old_user_id", oldUserID,
+				"error", err,
+				"duration_ms", time.Since(start).Milliseconds())
+			return fmt.Errorf("find old user: %w", err)
+		}
+		teamName = oldUser.TeamName
+
+		// Проверяем что старый ревьювер действительно назначен на этот PR
+		if !servs.containsReviewer(pr.AssignedReviewers, oldUserID) {
+			servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "Reviewer not assigned to this PR",
+				"pr_id", prID,
+				"old_user_id", oldUserID,
+				"assigned_reviewers", pr.AssignedReviewers,
+				"duration_ms", time.Since(start).Milliseconds())
+			return fmt.Errorf("reviewer %s not assigned to this PR", oldUserID)
+		}
+
+		// Политика команды автора: нужен ли senior на место старого ревьювера
+		author, err := repo.FindUserByID(ctx, pr.AuthorID)
+		if err != nil {
+			return fmt.Errorf("find author: %w", err)
+		}
+		var remaining []string
+		for _, reviewer := range pr.AssignedReviewers {
+			if reviewer != oldUserID {
+				remaining = append(remaining, reviewer)
+			}
+		}
+		needSenior, err := seniorRequired(ctx, repo, author, remaining)
+		if err != nil {
+			return fmt.Errorf("check review policy: %w", err)
+		}
+
+		// Ищем кандидатов для замены из команды старого ревьювера
+		excludeUsers := append([]string{}, pr.AssignedReviewers...) // исключаем уже назначенных
+		if pr.ShadowReviewer != "" {
+			excludeUsers = append(excludeUsers, pr.ShadowReviewer) // и теневого ревьювера
+		}
+		flag := false
+		for _, usersId := range excludeUsers {
+			if usersId == oldUserID {
+				flag = true
+				break
+			}
+		}
+		if !flag {
+			excludeUsers = append(excludeUsers, oldUserID)
+		}
+
+		candidates, excluded, err := servs.findReviewCandidates(ctx, repo, oldUser.TeamName, pr.AuthorID, excludeUsers...)
+		if err != nil {
+			servs.logger.Error("SERVICE_REASSIGN_REVIEWER", "Failed to find replacement candidates",
+				"team_name", oldUser.TeamName,
+				"excluded_users", excludeUsers,
+				"error", err,
+				"duration_ms", time.Since(start).Milliseconds())
+			return fmt.Errorf("find replacement candidates: %w", err)
+		}
+
+		scores, err := servs.scoreCandidates(ctx, repo, oldUser.TeamName, pr.AuthorID, candidates, pr.Metadata.RequiredSkills, start)
+		if err != nil {
+			return fmt.Errorf("weigh replacement candidates: %w", err)
+		}
+		rules, err := loadReviewerRules(ctx, repo, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+		reassignment.Candidates = explainCandidates(candidates, scores, excluded, rules)
+		candidates, weights := rules.apply(candidates, scoreWeights(scores))
+
+		// Замена senior на не-senior нарушила бы политику. Если же политика не выполнялась
+		// и до переназначения, а senior заменить некем, выбираем как обычно
+		if needSenior {
+			seniors, seniorWeights := seniorCandidates(candidates, weights)
+			switch {
+			case len(seniors) > 0:
+				candidates, weights = seniors, seniorWeights
+				excludeNonSeniors(reassignment.Candidates, seniors)
+			case oldUser.Level.IsSenior():
+				servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "Reassignment would break team review policy",
+					"pr_id", prID,
+					"old_user_id", oldUserID,
+					"team_name", author.TeamName,
+					"duration_ms", time.Since(start).Milliseconds())
+				return ErrSeniorReviewerRequired
+			}
+		}
+
+		// Выбираем одного случайного кандидата
+		newReviewers := servs.selectReviewers(candidates, weights, 1)
+		if len(newReviewers) == 0 {
+			servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "No replacement candidates available",
+				"team_name", oldUser.TeamName,
+				"candidates", reassignment.Candidates,
+				"duration_ms", time.Since(start).Milliseconds())
+			return ErrNoReplacementCandidate
+		}
+
+		newReviewerID := newReviewers[0]
+		reassignment.NewReviewerID = newReviewerID
+		markSelected(reassignment.Candidates, newReviewers, "")
+
+		// Заменяем ревьювера в списке
+		for i, reviewer := range pr.AssignedReviewers {
+			if reviewer == oldUserID {
+				pr.AssignedReviewers[i] = newReviewerID
+				break
+			}
+		}
+
+		// Сохраняем изменения
+		if err := repo.UpdatePR(ctx, pr); err != nil {
+			if errors.Is(err, entity.ErrVersionConflict) {
+				servs.logger.Warn("SERVICE_REASSIGN_REVIEWER", "PR was modified concurrently",
+					"pr_id", prID,
+					"duration_ms", time.Since(start).Milliseconds())
+				return ErrPRVersionConflict
+			}
+			servs.logger.Error("SERVICE_REASSIGN_REVIEWER", "Failed to update PR in repository",
+				"pr_id", prID,
+				"error", err,
+				"duration_ms", time.Since(start).Milliseconds())
+			return fmt.Errorf("update PR: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
 	servs.logger.Info("SERVICE_REASSIGN_REVIEWER", "Reviewer reassigned successfully",
 		"pr_id", prID,
 		"old_user_id", oldUserID,
-		"new_user_id", newReviewerID,
-		"team_name", oldUser.TeamName,
+		"new_user_id", reassignment.NewReviewerID,
+		"team_name", teamName,
+		"candidates", reassignment.Candidates,
 		"duration_ms", time.Since(start).Milliseconds())
-	return pr, newReviewerID, nil
+	return pr, reassignment, nil
 }
 
 // Вспомогательная функция для проверки наличия ревьювера
@@ -455,6 +748,9 @@ func checkTeamCorrectness(team *entity.Team) error {
 	if len(team.Members) == 0 {
 		return ErrEmptyTeam
 	}
+	if team.MaxOpenReviews < 0 {
+		return ErrInvalidReviewLimit
+	}
 	for _, member := range team.Members {
 		if err := checkTeamMemberCorrectness(member); err != nil {
 			return err
@@ -470,6 +766,25 @@ func checkTeamMemberCorrectness(member entity.TeamMember) error {
 	if member.Username == "" {
 		return ErrEmptyUserUsername
 	}
+	if member.MaxOpenReviews < 0 {
+		return ErrInvalidReviewLimit
+	}
+	if !validUserLevel(member.Level) {
+		return ErrInvalidUserLevel
+	}
+	return nil
+}
+
+// checkPreviewCorrectness - как checkPRCorrectness, но PR ещё нет, поэтому ID и название не нужны
+func checkPreviewCorrectness(pr *entity.PullRequest) error {
+	switch {
+	case pr == nil:
+		return ErrNilPR
+	case pr.AuthorID == "":
+		return ErrEmptyPRAuthorID
+	case pr.Metadata.Additions < 0, pr.Metadata.Deletions < 0, pr.Metadata.ChangedFiles < 0:
+		return ErrInvalidPRMetadata
+	}
 	return nil
 }
 
@@ -483,17 +798,42 @@ func checkPRCorrectness(pr *entity.PullRequest) error {
 		return ErrEmptyPRName
 	case pr.AuthorID == "":
 		return ErrEmptyPRAuthorID
+	case pr.Metadata.Additions < 0, pr.Metadata.Deletions < 0, pr.Metadata.ChangedFiles < 0:
+		return ErrInvalidPRMetadata
 	}
 	return nil
 }
 
-func (servs *PrService) validateTeamCreation(team *entity.Team) error {
-	if exists := servs.repo.TeamExists(team.TeamName); exists {
+// normalizeStrings обрезает пробелы вокруг меток, путей и навыков PR и убирает пустые и повторные
+func normalizeStrings(values []string) []string {
+	var normalized []string
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		normalized = append(normalized, value)
+	}
+	return normalized
+}
+
+// checkPRVersion сверяет версию PR с ожидаемой клиентом, 0 означает отсутствие условия
+func checkPRVersion(pr *entity.PullRequest, expectedVersion int) error {
+	if expectedVersion != 0 && pr.Version != expectedVersion {
+		return ErrPRVersionConflict
+	}
+	return nil
+}
+
+func (servs *PrService) validateTeamCreation(ctx context.Context, team *entity.Team) error {
+	if exists := servs.repo.TeamExists(ctx, team.TeamName); exists {
 		return ErrTeamAlreadyExists
 	}
 
 	for _, member := range team.Members {
-		if _, err := servs.repo.FindUserByID(member.UserID); err == nil {
+		if _, err := servs.repo.FindUserByID(ctx, member.UserID); err == nil {
 			return ErrUserAlreadyExists(member.UserID)
 		}
 	}
@@ -501,60 +841,66 @@ func (servs *PrService) validateTeamCreation(team *entity.Team) error {
 	return nil
 }
 
-func (servs *PrService) findReviewCandidates(teamName string, excludeUserIDs ...string) ([]*entity.User, error) {
+// findReviewCandidates принимает repo явно, чтобы работать и внутри транзакции WithTx.
+// Автор и уже назначенные на PR пользователи из assignedUserIDs не выбираются. Вторым
+// значением возвращает остальных участников команды с причиной, по которой их нельзя выбрать
+func (servs *PrService) findReviewCandidates(ctx context.Context, repo interfaces.Repository, teamName, authorID string, assignedUserIDs ...string) ([]*entity.User, []entity.CandidateExplanation, error) {
 	if teamName == "" {
-		return nil, ErrEmptyTeamName
+		return nil, nil, ErrEmptyTeamName
 	}
 
 	// Получаем всех пользователей команды
-	teamUsers, err := servs.repo.FindUsersByTeam(teamName)
+	teamUsers, err := repo.FindUsersByTeam(ctx, teamName)
 	if err != nil {
-		return nil, fmt.Errorf("find team users: %w", err)
+		return nil, nil, fmt.Errorf("find team users: %w", err)
 	}
 
-	// Создаём множество для быстрого исключения
-	excludeSet := make(map[string]bool)
-	for _, id := range excludeUserIDs {
-		excludeSet[id] = true
+	// Пользователи в периоде недоступности не назначаются
+	unavailable, err := repo.FindUnavailableUserIDs(ctx, teamName, time.Now())
+	if err != nil {
+		return nil, nil, fmt.Errorf("find unavailable users: %w", err)
+	}
+
+	// Создаём множества для быстрого исключения
+	assignedSet := make(map[string]bool, len(assignedUserIDs))
+	for _, id := range assignedUserIDs {
+		assignedSet[id] = true
+	}
+	unavailableSet := make(map[string]bool, len(unavailable))
+	for _, id := range unavailable {
+		unavailableSet[id] = true
 	}
 
 	// Фильтруем кандидатов
-	var candidates []*entity.User
+	var (
+		candidates []*entity.User
+		excluded   []entity.CandidateExplanation
+	)
 	for _, user := range teamUsers {
-		// Исключаем неактивных и пользователей из exclude списка
-		if user.IsActive && !excludeSet[user.UserID] {
+		var reason entity.ExclusionReason
+		switch {
+		case user.UserID == authorID:
+			reason = entity.ExcludedAuthor
+		case assignedSet[user.UserID]:
+			reason = entity.ExcludedAssigned
+		case !user.IsActive:
+			reason = entity.ExcludedInactive
+		case unavailableSet[user.UserID]:
+			reason = entity.ExcludedUnavailable
+		default:
 			candidates = append(candidates, user)
+			continue
 		}
+		excluded = append(excluded, entity.CandidateExplanation{UserID: user.UserID, ExcludedBy: reason})
 	}
 
-	return candidates, nil
-}
-
-func (servs *PrService) selectReviewers(candidates []*entity.User, maxCount int) []string {
-	if len(candidates) == 0 {
-		return []string{}
-	}
-
-	// Если кандидатов меньше или равно maxCount - возвращаем всех
-	if len(candidates) <= maxCount {
-		userIDs := make([]string, len(candidates))
-		for i, user := range candidates {
-			userIDs[i] = user.UserID
-		}
-		return userIDs
+	// Занятые до предела тоже не назначаются
+	candidates, atCapacity, err := dropAtCapacity(ctx, repo, teamName, candidates)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	// Используем random сервиса
-	selected := make([]string, 0, maxCount)
-	used := make(map[int]bool)
-
-	for len(selected) < maxCount && len(used) < len(candidates) {
-		idx := servs.random.Intn(len(candidates))
-		if !used[idx] {
-			selected = append(selected, candidates[idx].UserID)
-			used[idx] = true
-		}
+	for _, userID := range atCapacity {
+		excluded = append(excluded, entity.CandidateExplanation{UserID: userID, ExcludedBy: entity.ExcludedAtCapacity})
 	}
-
-	return selected
+	return candidates, excluded, nil
 }
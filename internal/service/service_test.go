@@ -1,11 +1,13 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
 	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
 	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
 	"github.com/stretchr/testify/assert"
@@ -27,13 +29,13 @@ func TestCreateTeam_Success(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("TeamExists", "backend").Return(false)
-	mockRepo.On("FindUserByID", "user1").Return(nil, errors.New("not found"))
-	mockRepo.On("CreateTeam", team).Return(nil)
+	mockRepo.On("TeamExists", mock.Anything, "backend").Return(false)
+	mockRepo.On("FindUserByID", mock.Anything, "user1").Return(nil, errors.New("not found"))
+	mockRepo.On("CreateTeam", mock.Anything, team).Return(nil)
 
 	service := NewPRServiceWithSeed(mockRepo, logger, 42)
 
-	err = service.CreateTeam(team)
+	err = service.CreateTeam(context.Background(), team)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
@@ -54,11 +56,11 @@ func TestCreateTeam_AlreadyExists(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("TeamExists", "backend").Return(true)
+	mockRepo.On("TeamExists", mock.Anything, "backend").Return(true)
 
 	service := NewPRService(mockRepo, logger)
 
-	err = service.CreateTeam(team)
+	err = service.CreateTeam(context.Background(), team)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrTeamAlreadyExists, err)
@@ -71,7 +73,7 @@ func TestCreateTeam_EmptyTeam(t *testing.T) {
 	assert.NoError(t, err)
 	service := NewPRService(mockRepo, logger)
 
-	err = service.CreateTeam(nil)
+	err = service.CreateTeam(context.Background(), nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrCreateEmptyTeam, err)
@@ -102,10 +104,13 @@ func TestCreatePR_Success(t *testing.T) {
 		Status:          entity.PullRequestStatusOpen,
 	}
 
-	mockRepo.On("FindUserByID", "author1").Return(author, nil)
-	mockRepo.On("FindPRByID", "pr-123").Return(nil, errors.New("not found"))
-	mockRepo.On("FindUsersByTeam", "backend").Return(candidates, nil)
-	mockRepo.On("CreatePR", mock.MatchedBy(func(pr *entity.PullRequest) bool {
+	mockRepo.On("FindUserByID", mock.Anything, "author1").Return(author, nil)
+	mockRepo.On("FindPRByID", mock.Anything, "pr-123").Return(nil, errors.New("not found"))
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return(candidates, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return(nil, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend"}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("CreatePR", mock.Anything, mock.MatchedBy(func(pr *entity.PullRequest) bool {
 		return pr.PullRequestID == "pr-123" &&
 			pr.AuthorID == "author1" &&
 			len(pr.AssignedReviewers) == 2 &&
@@ -114,7 +119,7 @@ func TestCreatePR_Success(t *testing.T) {
 
 	service := NewPRServiceWithSeed(mockRepo, logger, 42)
 
-	err = service.CreatePR(pr)
+	_, err = service.CreatePR(context.Background(), pr)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
@@ -131,11 +136,11 @@ func TestCreatePR_AuthorNotFound(t *testing.T) {
 		AuthorID:        "author1",
 	}
 
-	mockRepo.On("FindUserByID", "author1").Return(nil, errors.New("not found"))
+	mockRepo.On("FindUserByID", mock.Anything, "author1").Return(nil, errors.New("not found"))
 
 	service := NewPRService(mockRepo, logger)
 
-	err = service.CreatePR(pr)
+	_, err = service.CreatePR(context.Background(), pr)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "author not found")
@@ -164,12 +169,12 @@ func TestCreatePR_AlreadyExists(t *testing.T) {
 		AuthorID:        "author1",
 	}
 
-	mockRepo.On("FindUserByID", "author1").Return(author, nil)
-	mockRepo.On("FindPRByID", "pr-123").Return(existingPR, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "author1").Return(author, nil)
+	mockRepo.On("FindPRByID", mock.Anything, "pr-123").Return(existingPR, nil)
 
 	service := NewPRService(mockRepo, logger)
 
-	err = service.CreatePR(pr)
+	_, err = service.CreatePR(context.Background(), pr)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrPRAlreadyExists, err)
@@ -190,15 +195,16 @@ func TestMergePR_Success(t *testing.T) {
 		CreatedAt:         time.Now().Add(-time.Hour),
 	}
 
-	mockRepo.On("FindPRByID", "pr-123").Return(existingPR, nil)
-	mockRepo.On("UpdatePR", mock.MatchedBy(func(pr *entity.PullRequest) bool {
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-123").Return(existingPR, nil)
+	mockRepo.On("UpdatePR", mock.Anything, mock.MatchedBy(func(pr *entity.PullRequest) bool {
 		return pr.Status == entity.PullRequestStatusMerged &&
 			pr.MergedAt.After(pr.CreatedAt)
 	})).Return(nil)
 
 	service := NewPRService(mockRepo, logger)
 
-	mergedPR, err := service.MergePR("pr-123")
+	mergedPR, err := service.MergePR(context.Background(), "pr-123", 0)
 
 	assert.NoError(t, err)
 	assert.Equal(t, entity.PullRequestStatusMerged, mergedPR.Status)
@@ -221,11 +227,12 @@ func TestMergePR_AlreadyMerged(t *testing.T) {
 		MergedAt:          time.Now().Add(-30 * time.Minute),
 	}
 
-	mockRepo.On("FindPRByID", "pr-123").Return(existingPR, nil)
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-123").Return(existingPR, nil)
 
 	service := NewPRService(mockRepo, logger)
 
-	mergedPR, err := service.MergePR("pr-123")
+	mergedPR, err := service.MergePR(context.Background(), "pr-123", 0)
 
 	assert.NoError(t, err)
 	assert.Equal(t, entity.PullRequestStatusMerged, mergedPR.Status)
@@ -237,11 +244,12 @@ func TestMergePR_NotFound(t *testing.T) {
 	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
 	assert.NoError(t, err)
 
-	mockRepo.On("FindPRByID", "pr-123").Return(nil, errors.New("not found"))
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-123").Return(nil, errors.New("not found"))
 
 	service := NewPRService(mockRepo, logger)
 
-	mergedPR, err := service.MergePR("pr-123")
+	mergedPR, err := service.MergePR(context.Background(), "pr-123", 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, mergedPR)
@@ -249,6 +257,60 @@ func TestMergePR_NotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMergePR_VersionMismatch(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	assert.NoError(t, err)
+
+	existingPR := &entity.PullRequest{
+		PullRequestID:     "pr-123",
+		PullRequestName:   "Test PR",
+		AuthorID:          "author1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user1", "user2"},
+		Version:           3,
+	}
+
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-123").Return(existingPR, nil)
+	// UpdatePR не должен вызываться!
+
+	service := NewPRService(mockRepo, logger)
+
+	mergedPR, err := service.MergePR(context.Background(), "pr-123", 2)
+
+	assert.Nil(t, mergedPR)
+	assert.Equal(t, ErrPRVersionConflict, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMergePR_ConcurrentUpdate(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	assert.NoError(t, err)
+
+	existingPR := &entity.PullRequest{
+		PullRequestID:     "pr-123",
+		PullRequestName:   "Test PR",
+		AuthorID:          "author1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user1", "user2"},
+		Version:           1,
+	}
+
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-123").Return(existingPR, nil)
+	mockRepo.On("UpdatePR", mock.Anything, mock.Anything).Return(entity.ErrVersionConflict)
+
+	service := NewPRService(mockRepo, logger)
+
+	mergedPR, err := service.MergePR(context.Background(), "pr-123", 1)
+
+	assert.Nil(t, mergedPR)
+	assert.Equal(t, ErrPRVersionConflict, err)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestReassignReviewer_Success(t *testing.T) {
 	mockRepo := &mocks.Repository{}
 	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
@@ -274,23 +336,28 @@ func TestReassignReviewer_Success(t *testing.T) {
 		{UserID: "user4", Username: "David", TeamName: "backend", IsActive: true},
 	}
 
-	mockRepo.On("FindPRByID", "pr-123").Return(pr, nil)
-	mockRepo.On("FindUserByID", "user1").Return(oldUser, nil)
-	mockRepo.On("FindUsersByTeam", "backend").Return(candidates, nil)
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-123").Return(pr, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "user1").Return(oldUser, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "author1").Return(&entity.User{UserID: "author1", TeamName: "backend"}, nil)
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return(candidates, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return(nil, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend"}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, mock.Anything).Return(nil, nil)
 
 	// Исправляем матчер - проверяем что user1 заменён, но не проверяем конкретно на кого
-	mockRepo.On("UpdatePR", mock.MatchedBy(func(pr *entity.PullRequest) bool {
+	mockRepo.On("UpdatePR", mock.Anything, mock.MatchedBy(func(pr *entity.PullRequest) bool {
 		return !contains(pr.AssignedReviewers, "user1") &&
 			len(pr.AssignedReviewers) == 2 // Должно остаться 2 ревьювера
 	})).Return(nil)
 
 	service := NewPRServiceWithSeed(mockRepo, logger, 42)
 
-	updatedPR, newReviewer, err := service.ReassignReviewer("pr-123", "user1")
+	updatedPR, reassignment, err := service.ReassignReviewer(context.Background(), "pr-123", "user1", 0)
 
 	assert.NoError(t, err)
-	assert.NotEqual(t, "user1", newReviewer)
-	assert.True(t, contains([]string{"user3", "user4"}, newReviewer)) // Может быть user3 или user4
+	assert.NotEqual(t, "user1", reassignment.NewReviewerID)
+	assert.True(t, contains([]string{"user3", "user4"}, reassignment.NewReviewerID)) // Может быть user3 или user4
 	assert.NotNil(t, updatedPR)
 	assert.False(t, contains(updatedPR.AssignedReviewers, "user1"))
 	assert.Len(t, updatedPR.AssignedReviewers, 2)
@@ -310,19 +377,89 @@ func TestReassignReviewer_MergedPR(t *testing.T) {
 		AssignedReviewers: []string{"user1", "user2"},
 	}
 
-	mockRepo.On("FindPRByID", "pr-123").Return(pr, nil)
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-123").Return(pr, nil)
 
 	service := NewPRService(mockRepo, logger)
 
-	updatedPR, newReviewer, err := service.ReassignReviewer("pr-123", "user1")
+	updatedPR, reassignment, err := service.ReassignReviewer(context.Background(), "pr-123", "user1", 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, updatedPR)
-	assert.Empty(t, newReviewer)
+	assert.Nil(t, reassignment)
 	assert.Equal(t, ErrCannotReassingOnMergedPR, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestReassignReviewer_NoCandidates(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	assert.NoError(t, err)
+
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-123",
+		PullRequestName:   "Test PR",
+		AuthorID:          "author1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user1", "user2"},
+	}
+
+	oldUser := &entity.User{UserID: "user1", Username: "Alice", TeamName: "backend", IsActive: true}
+
+	// В команде только автор и уже назначенные ревьюверы
+	teamUsers := []*entity.User{
+		{UserID: "author1", Username: "Author", TeamName: "backend", IsActive: true},
+		oldUser,
+		{UserID: "user2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}
+
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-123").Return(pr, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "user1").Return(oldUser, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "author1").Return(teamUsers[0], nil)
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return(teamUsers, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return(nil, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend"}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, mock.Anything).Return(nil, nil)
+	// UpdatePR не должен вызываться!
+
+	service := NewPRService(mockRepo, logger)
+
+	updatedPR, reassignment, err := service.ReassignReviewer(context.Background(), "pr-123", "user1", 0)
+
+	assert.Nil(t, updatedPR)
+	assert.Nil(t, reassignment)
+	assert.Equal(t, ErrNoReplacementCandidate, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReassignReviewer_VersionMismatch(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	assert.NoError(t, err)
+
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-123",
+		PullRequestName:   "Test PR",
+		AuthorID:          "author1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"user1", "user2"},
+		Version:           2,
+	}
+
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-123").Return(pr, nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	updatedPR, reassignment, err := service.ReassignReviewer(context.Background(), "pr-123", "user1", 1)
+
+	assert.Nil(t, updatedPR)
+	assert.Nil(t, reassignment)
+	assert.Equal(t, ErrPRVersionConflict, err)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestReassignReviewer_ReviewerNotFound(t *testing.T) {
 	mockRepo := &mocks.Repository{}
 	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
@@ -343,16 +480,17 @@ func TestReassignReviewer_ReviewerNotFound(t *testing.T) {
 		IsActive: true,
 	}
 
-	mockRepo.On("FindPRByID", "pr-123").Return(pr, nil)
-	mockRepo.On("FindUserByID", "user1").Return(oldUser, nil)
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-123").Return(pr, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "user1").Return(oldUser, nil)
 
 	service := NewPRService(mockRepo, logger)
 
-	updatedPR, newReviewer, err := service.ReassignReviewer("pr-123", "user1")
+	updatedPR, reassignment, err := service.ReassignReviewer(context.Background(), "pr-123", "user1", 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, updatedPR)
-	assert.Empty(t, newReviewer)
+	assert.Nil(t, reassignment)
 	assert.Contains(t, err.Error(), "reviewer user1 not assigned to this PR")
 	mockRepo.AssertExpectations(t)
 }
@@ -369,12 +507,12 @@ func TestSetUserActive_Success(t *testing.T) {
 		IsActive: false,
 	}
 
-	mockRepo.On("FindUserByID", "user1").Return(user, nil)
-	mockRepo.On("SetActive", "user1", true).Return(nil)
+	mockRepo.On("FindUserByID", mock.Anything, "user1").Return(user, nil)
+	mockRepo.On("SetActive", mock.Anything, "user1", true).Return(nil)
 
 	service := NewPRService(mockRepo, logger)
 
-	updatedUser, err := service.SetUserActive("user1", true)
+	updatedUser, err := service.SetUserActive(context.Background(), "user1", true)
 
 	assert.NoError(t, err)
 	assert.True(t, updatedUser.IsActive)
@@ -393,18 +531,25 @@ func TestSetUserActive_AlreadyActive(t *testing.T) {
 		IsActive: true,
 	}
 
-	mockRepo.On("FindUserByID", "user1").Return(user, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "user1").Return(user, nil)
 	// SetActive не должен вызываться!
 
 	service := NewPRService(mockRepo, logger)
 
-	updatedUser, err := service.SetUserActive("user1", true)
+	updatedUser, err := service.SetUserActive(context.Background(), "user1", true)
 
 	assert.NoError(t, err)
 	assert.True(t, updatedUser.IsActive)
 	mockRepo.AssertExpectations(t)
 }
 
+// expectTx настраивает WithTx так, чтобы fn выполнялась на том же моке
+func expectTx(mockRepo *mocks.Repository) {
+	mockRepo.On("WithTx", mock.Anything, mock.Anything).Return(func(_ context.Context, fn func(interfaces.Repository) error) error {
+		return fn(mockRepo)
+	})
+}
+
 // Вспомогательная функция
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+)
+
+// SetUserLearner отмечает пользователя учеником: ученики назначаются теневыми ревьюверами
+func (servs *PrService) SetUserLearner(ctx context.Context, userID string, isLearner bool) (*entity.User, error) {
+	start := time.Now()
+
+	if userID == "" {
+		return nil, ErrEmptyUserID
+	}
+
+	if err := servs.repo.SetUserLearner(ctx, userID, isLearner); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return nil, ErrNoUser
+		}
+		servs.logger.Error("SERVICE_SET_USER_LEARNER", "Failed to set user learner flag",
+			"user_id", userID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	user, err := servs.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	servs.logger.Info("SERVICE_SET_USER_LEARNER", "User learner flag updated",
+		"user_id", userID,
+		"is_learner", isLearner,
+		"duration_ms", time.Since(start).Milliseconds())
+	return user, nil
+}
+
+// GetUserShadowReviews возвращает PR, где пользователь теневой ревьювер, от новых к старым
+func (servs *PrService) GetUserShadowReviews(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+	if userID == "" {
+		return nil, ErrEmptyUserID
+	}
+
+	if _, err := servs.repo.FindUserByID(ctx, userID); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return nil, ErrNoUser
+		}
+		return nil, err
+	}
+	return servs.repo.FindPRsByShadowReviewer(ctx, userID)
+}
+
+// selectShadowReviewer выбирает теневого ревьювера среди учеников, которые ещё
+// не назначены ревьюверами. Пустая строка - подходящего ученика нет
+func (servs *PrService) selectShadowReviewer(candidates []*entity.User, weights []float64, reviewers []string) string {
+	var (
+		learners       []*entity.User
+		learnerWeights []float64
+	)
+	for i, candidate := range candidates {
+		if candidate.IsLearner && !servs.containsReviewer(reviewers, candidate.UserID) {
+			learners = append(learners, candidate)
+			learnerWeights = append(learnerWeights, weights[i])
+		}
+	}
+	if picked := servs.selectReviewers(learners, learnerWeights, 1); len(picked) > 0 {
+		return picked[0]
+	}
+	return ""
+}
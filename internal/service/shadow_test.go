@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUserLearner(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("SetUserLearner", mock.Anything, "u1", true).Return(nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(
+		&entity.User{UserID: "u1", TeamName: "backend", IsLearner: true}, nil)
+	mockRepo.On("SetUserLearner", mock.Anything, "ghost", true).Return(entity.ErrNoUser)
+
+	service := NewPRService(mockRepo, logger)
+	user, err := service.SetUserLearner(context.Background(), "u1", true)
+	require.NoError(t, err)
+	assert.True(t, user.IsLearner)
+
+	_, err = service.SetUserLearner(context.Background(), "", true)
+	assert.Equal(t, ErrEmptyUserID, err)
+	_, err = service.SetUserLearner(context.Background(), "ghost", true)
+	assert.Equal(t, ErrNoUser, err)
+}
+
+func TestCreatePR_ShadowReviewer(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	// Учеников больше, чем мест, поэтому хотя бы один остаётся для теневого места
+	expectPolicyTeam(mockRepo, "backend", entity.ReviewPolicy{},
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u3", TeamName: "backend", IsActive: true, IsLearner: true},
+		&entity.User{UserID: "u4", TeamName: "backend", IsActive: true, IsLearner: true},
+		&entity.User{UserID: "u5", TeamName: "backend", IsActive: true, IsLearner: true})
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	pr := &entity.PullRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"}
+	_, err = service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	// Теневой ревьювер не занимает место обычного
+	assert.Len(t, pr.AssignedReviewers, 2)
+	assert.Contains(t, []string{"u3", "u4", "u5"}, pr.ShadowReviewer)
+	assert.NotContains(t, pr.AssignedReviewers, pr.ShadowReviewer)
+}
+
+func TestCreatePR_NoLearners(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	expectPolicyTeam(mockRepo, "backend", entity.ReviewPolicy{},
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u3", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u4", TeamName: "backend", IsActive: true})
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	pr := &entity.PullRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"}
+	_, err = service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	assert.Len(t, pr.AssignedReviewers, 2)
+	assert.Empty(t, pr.ShadowReviewer)
+}
+
+func TestReassignReviewer_SkipsShadowReviewer(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	pr := &entity.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+		ShadowReviewer:    "u4",
+	}
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-1").Return(pr, nil)
+	expectPolicyTeam(mockRepo, "backend", entity.ReviewPolicy{},
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u3", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u4", TeamName: "backend", IsActive: true, IsLearner: true},
+		&entity.User{UserID: "u5", TeamName: "backend", IsActive: true})
+	mockRepo.On("UpdatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	updated, reassignment, err := service.ReassignReviewer(context.Background(), "pr-1", "u2", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, "u5", reassignment.NewReviewerID)
+	assert.Equal(t, "u4", updated.ShadowReviewer)
+}
+
+func TestGetUserShadowReviews(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	shadows := []*entity.PullRequest{{PullRequestID: "pr-1", AuthorID: "u1", ShadowReviewer: "u3"}}
+	mockRepo.On("FindUserByID", mock.Anything, "u3").Return(&entity.User{UserID: "u3"}, nil)
+	mockRepo.On("FindPRsByShadowReviewer", mock.Anything, "u3").Return(shadows, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "ghost").Return(nil, entity.ErrNoUser)
+
+	service := NewPRService(mockRepo, logger)
+	prs, err := service.GetUserShadowReviews(context.Background(), "u3")
+	require.NoError(t, err)
+	assert.Equal(t, shadows, prs)
+
+	_, err = service.GetUserShadowReviews(context.Background(), "ghost")
+	assert.Equal(t, ErrNoUser, err)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+)
+
+// maxSkillTagLength - предел длины тега навыка, как в схеме хранилища
+const maxSkillTagLength = 64
+
+// skillMatchWeight - во сколько раз каждый уровень совпавшего навыка увеличивает вес
+// кандидата сверх базового: эксперт по одному нужному навыку весит 1 + 2*3 = 7
+const skillMatchWeight = 2
+
+// SetUserSkills заменяет навыки пользователя. Теги сравниваются без учёта регистра
+// и пробелов по краям, поэтому хранятся в нижнем регистре. Пустой список удаляет навыки
+func (servs *PrService) SetUserSkills(ctx context.Context, userID string, skills []entity.UserSkill) ([]entity.UserSkill, error) {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_SET_USER_SKILLS", "Setting user skills",
+		"user_id", userID,
+		"skills_count", len(skills))
+
+	if userID == "" {
+		return nil, ErrEmptyUserID
+	}
+
+	normalized, err := normalizeSkills(userID, skills)
+	if err != nil {
+		servs.logger.Warn("SERVICE_SET_USER_SKILLS", "Skills validation failed",
+			"user_id", userID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	if err := servs.repo.SetUserSkills(ctx, userID, normalized); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return nil, ErrNoUser
+		}
+		servs.logger.Error("SERVICE_SET_USER_SKILLS", "Failed to set user skills",
+			"user_id", userID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	servs.logger.Info("SERVICE_SET_USER_SKILLS", "User skills set successfully",
+		"user_id", userID,
+		"skills_count", len(normalized),
+		"duration_ms", time.Since(start).Milliseconds())
+	return normalized, nil
+}
+
+func (servs *PrService) GetUserSkills(ctx context.Context, userID string) ([]entity.UserSkill, error) {
+	if userID == "" {
+		return nil, ErrEmptyUserID
+	}
+
+	if _, err := servs.repo.FindUserByID(ctx, userID); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return nil, ErrNoUser
+		}
+		return nil, err
+	}
+	return servs.repo.FindUserSkills(ctx, userID)
+}
+
+// normalizeSkills приводит теги к нижнему регистру, проверяет уровни и повторы
+// и возвращает навыки по алфавиту тегов
+func normalizeSkills(userID string, skills []entity.UserSkill) ([]entity.UserSkill, error) {
+	normalized := make([]entity.UserSkill, 0, len(skills))
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		tag := normalizeSkillTag(skill.Tag)
+		if tag == "" || len(tag) > maxSkillTagLength ||
+			skill.Level < entity.SkillLevelBasic || skill.Level > entity.SkillLevelExpert {
+			return nil, ErrInvalidSkill
+		}
+		if seen[tag] {
+			return nil, ErrDuplicateSkill
+		}
+		seen[tag] = true
+		normalized = append(normalized, entity.UserSkill{UserID: userID, Tag: tag, Level: skill.Level})
+	}
+	sort.Slice(normalized, func(i, j int) bool {
+		return normalized[i].Tag < normalized[j].Tag
+	})
+	return normalized, nil
+}
+
+func normalizeSkillTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// normalizeSkillTags приводит нужные PR навыки к нижнему регистру и убирает пустые и повторные
+func normalizeSkillTags(tags []string) []string {
+	lowered := make([]string, len(tags))
+	for i, tag := range tags {
+		lowered[i] = normalizeSkillTag(tag)
+	}
+	return normalizeStrings(lowered)
+}
+
+// skillWeight - 1 без совпавших навыков и больше с каждым уровнем навыка, который
+// нужен PR. Кандидаты без нужных навыков остаются в выборе, просто реже выбираются
+func skillWeight(skills map[string]int, requiredSkills []string) float64 {
+	levels := 0
+	for _, tag := range requiredSkills {
+		levels += skills[tag]
+	}
+	return 1 + skillMatchWeight*float64(levels)
+}
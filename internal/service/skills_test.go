@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUserSkills(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	want := []entity.UserSkill{
+		{UserID: "u1", Tag: "go", Level: entity.SkillLevelExpert},
+		{UserID: "u1", Tag: "sql", Level: entity.SkillLevelBasic},
+	}
+	mockRepo.On("SetUserSkills", mock.Anything, "u1", want).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+	skills, err := service.SetUserSkills(context.Background(), "u1", []entity.UserSkill{
+		{Tag: " SQL ", Level: entity.SkillLevelBasic},
+		{Tag: "Go", Level: entity.SkillLevelExpert},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, want, skills)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetUserSkills_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		skills  []entity.UserSkill
+		wantErr error
+	}{
+		{name: "empty user id", userID: "", wantErr: ErrEmptyUserID},
+		{name: "empty tag", userID: "u1", skills: []entity.UserSkill{{Tag: " ", Level: 1}}, wantErr: ErrInvalidSkill},
+		{name: "level too low", userID: "u1", skills: []entity.UserSkill{{Tag: "go", Level: 0}}, wantErr: ErrInvalidSkill},
+		{name: "level too high", userID: "u1", skills: []entity.UserSkill{{Tag: "go", Level: 4}}, wantErr: ErrInvalidSkill},
+		{
+			name:    "duplicate tag",
+			userID:  "u1",
+			skills:  []entity.UserSkill{{Tag: "go", Level: 1}, {Tag: "GO", Level: 3}},
+			wantErr: ErrDuplicateSkill,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.Repository{}
+			logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+			require.NoError(t, err)
+
+			service := NewPRService(mockRepo, logger)
+			_, err = service.SetUserSkills(context.Background(), tt.userID, tt.skills)
+
+			assert.Equal(t, tt.wantErr, err)
+			mockRepo.AssertNotCalled(t, "SetUserSkills", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetUserSkills_UnknownUser(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("FindUserByID", mock.Anything, "ghost").Return(nil, entity.ErrNoUser)
+	mockRepo.On("SetUserSkills", mock.Anything, "ghost", mock.Anything).Return(entity.ErrNoUser)
+
+	service := NewPRService(mockRepo, logger)
+
+	_, err = service.GetUserSkills(context.Background(), "ghost")
+	assert.Equal(t, ErrNoUser, err)
+	_, err = service.SetUserSkills(context.Background(), "ghost", []entity.UserSkill{{Tag: "go", Level: 1}})
+	assert.Equal(t, ErrNoUser, err)
+}
+
+func TestScoreCandidates_RequiredSkills(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	candidates := []*entity.User{{UserID: "expert"}, {UserID: "both"}, {UserID: "other"}, {UserID: "none"}}
+	mockRepo.On("FindSkillsByTeam", mock.Anything, "backend").Return([]entity.UserSkill{
+		{UserID: "both", Tag: "go", Level: entity.SkillLevelBasic},
+		{UserID: "both", Tag: "sql", Level: entity.SkillLevelBasic},
+		{UserID: "expert", Tag: "go", Level: entity.SkillLevelExpert},
+		{UserID: "other", Tag: "react", Level: entity.SkillLevelExpert},
+	}, nil)
+
+	service := NewPRService(mockRepo, logger).(*PrService)
+
+	scores, err := service.scoreCandidates(context.Background(), mockRepo, "backend", "u1", candidates, []string{"go", "sql"}, time.Now())
+
+	require.NoError(t, err)
+	// Эксперт по одному навыку весит больше новичка в двух, без совпадений вес базовый
+	assert.Equal(t, []float64{7, 5, 1, 1}, scoreWeights(scores))
+	assert.Equal(t, 7.0, scores[0].Skills)
+}
+
+func TestCreatePR_RequiredSkills(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	author := &entity.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(author, nil)
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, entity.ErrNoUser)
+	expectTeam(mockRepo, "backend", author,
+		&entity.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+		&entity.User{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true})
+	mockRepo.On("FindSkillsByTeam", mock.Anything, "backend").Return([]entity.UserSkill{
+		{UserID: "u3", Tag: "go", Level: entity.SkillLevelExpert},
+		{UserID: "u4", Tag: "k8s", Level: entity.SkillLevelExpert},
+	}, nil)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRServiceWithSeed(mockRepo, logger, 42)
+	pr := &entity.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorID:        "u1",
+		Metadata:        entity.PRMetadata{RequiredSkills: []string{" Go", "go", "K8S", ""}},
+	}
+	_, err = service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "k8s"}, pr.Metadata.RequiredSkills)
+	assert.Len(t, pr.AssignedReviewers, 2)
+	// Кандидаты взвешены по нормализованным навыкам PR
+	mockRepo.AssertCalled(t, "FindSkillsByTeam", mock.Anything, "backend")
+}
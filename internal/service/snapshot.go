@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+)
+
+// ExportSnapshot читает всё состояние хранилища одной транзакцией,
+// чтобы команды и PR в снапшоте были согласованы между собой
+func (servs *PrService) ExportSnapshot(ctx context.Context) (*entity.Snapshot, error) {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_EXPORT_SNAPSHOT", "Starting snapshot export")
+
+	snap := &entity.Snapshot{
+		FormatVersion: entity.SnapshotFormatVersion,
+		CreatedAt:     time.Now().UTC(),
+	}
+	err := servs.repo.WithTx(ctx, func(repo interfaces.Repository) error {
+		teams, err := repo.ListTeams(ctx)
+		if err != nil {
+			return err
+		}
+		prs, err := repo.ListPRs(ctx)
+		if err != nil {
+			return err
+		}
+		periods, err := repo.ListUnavailabilities(ctx)
+		if err != nil {
+			return err
+		}
+		if snap.Aliases, err = repo.ListUserAliases(ctx); err != nil {
+			return err
+		}
+		schedules, err := repo.ListWorkSchedules(ctx)
+		if err != nil {
+			return err
+		}
+		owners, err := repo.ListCodeOwners(ctx)
+		if err != nil {
+			return err
+		}
+		if snap.Skills, err = repo.ListUserSkills(ctx); err != nil {
+			return err
+		}
+		if snap.ReviewerRules, err = repo.ListReviewerRules(ctx); err != nil {
+			return err
+		}
+
+		snap.Teams = make([]entity.Team, len(teams))
+		for i, team := range teams {
+			snap.Teams[i] = *team
+		}
+		snap.PullRequests = make([]entity.PullRequest, len(prs))
+		for i, pr := range prs {
+			snap.PullRequests[i] = *pr
+		}
+		snap.Unavailability = make([]entity.Unavailability, len(periods))
+		for i, period := range periods {
+			snap.Unavailability[i] = *period
+		}
+		snap.WorkSchedules = make([]entity.WorkSchedule, len(schedules))
+		for i, schedule := range schedules {
+			snap.WorkSchedules[i] = *schedule
+		}
+		snap.CodeOwners = make([]entity.CodeOwners, len(owners))
+		for i, repoOwners := range owners {
+			snap.CodeOwners[i] = *repoOwners
+		}
+		return nil
+	})
+	if err != nil {
+		servs.logger.Error("SERVICE_EXPORT_SNAPSHOT", "Failed to export snapshot",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	servs.logger.Info("SERVICE_EXPORT_SNAPSHOT", "Snapshot exported successfully",
+		"teams_count", len(snap.Teams),
+		"prs_count", len(snap.PullRequests),
+		"unavailability_count", len(snap.Unavailability),
+		"aliases_count", len(snap.Aliases),
+		"work_schedules_count", len(snap.WorkSchedules),
+		"code_owners_count", len(snap.CodeOwners),
+		"skills_count", len(snap.Skills),
+		"reviewer_rules_count", len(snap.ReviewerRules),
+		"duration_ms", time.Since(start).Milliseconds())
+	return snap, nil
+}
+
+// RestoreSnapshot загружает снапшот в пустое хранилище одной транзакцией:
+// при любой ошибке хранилище остаётся пустым
+func (servs *PrService) RestoreSnapshot(ctx context.Context, snap *entity.Snapshot) error {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_RESTORE_SNAPSHOT", "Starting snapshot restore",
+		"format_version", snap.FormatVersion,
+		"teams_count", len(snap.Teams),
+		"prs_count", len(snap.PullRequests))
+
+	if err := checkSnapshotCorrectness(snap); err != nil {
+		servs.logger.Warn("SERVICE_RESTORE_SNAPSHOT", "Snapshot validation failed",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	err := servs.repo.WithTx(ctx, func(repo interfaces.Repository) error {
+		teams, err := repo.ListTeams(ctx)
+		if err != nil {
+			return err
+		}
+		prs, err := repo.ListPRs(ctx)
+		if err != nil {
+			return err
+		}
+		if len(teams) > 0 || len(prs) > 0 {
+			return ErrStorageNotEmpty
+		}
+
+		for i := range snap.Teams {
+			if err := repo.CreateTeam(ctx, &snap.Teams[i]); err != nil {
+				return err
+			}
+		}
+		for i := range snap.PullRequests {
+			if err := repo.RestorePR(ctx, &snap.PullRequests[i]); err != nil {
+				return err
+			}
+		}
+		// ID периодов назначает хранилище, ссылок на них в снапшоте нет
+		for i := range snap.Unavailability {
+			if err := repo.CreateUnavailability(ctx, &snap.Unavailability[i]); err != nil {
+				return err
+			}
+		}
+		// Псевдонимы сгруппированы по пользователю в порядке первого появления
+		var owners []string
+		aliases := make(map[string][]string)
+		for _, alias := range snap.Aliases {
+			if _, ok := aliases[alias.UserID]; !ok {
+				owners = append(owners, alias.UserID)
+			}
+			aliases[alias.UserID] = append(aliases[alias.UserID], normalizeAlias(alias.Alias))
+		}
+		for _, userID := range owners {
+			if err := repo.SetUserAliases(ctx, userID, aliases[userID]); err != nil {
+				return err
+			}
+		}
+		for i := range snap.WorkSchedules {
+			if err := repo.SetWorkSchedule(ctx, &snap.WorkSchedules[i]); err != nil {
+				return err
+			}
+		}
+		for i := range snap.CodeOwners {
+			if err := repo.SetCodeOwners(ctx, &snap.CodeOwners[i]); err != nil {
+				return err
+			}
+		}
+		skillOwners, skills := groupSkillsByUser(snap.Skills)
+		for _, userID := range skillOwners {
+			normalized, err := normalizeSkills(userID, skills[userID])
+			if err != nil {
+				return err
+			}
+			if err := repo.SetUserSkills(ctx, userID, normalized); err != nil {
+				return err
+			}
+		}
+		for i := range snap.ReviewerRules {
+			if err := repo.SetReviewerRule(ctx, &snap.ReviewerRules[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		servs.logger.Error("SERVICE_RESTORE_SNAPSHOT", "Failed to restore snapshot",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	servs.logger.Info("SERVICE_RESTORE_SNAPSHOT", "Snapshot restored successfully",
+		"teams_count", len(snap.Teams),
+		"prs_count", len(snap.PullRequests),
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+// checkSnapshotCorrectness проверяет снапшот целиком до записи в хранилище:
+// авторы и ревьюверы PR, владельцы периодов недоступности, псевдонимов и рабочего времени должны быть
+// среди участников команд снапшота
+func checkSnapshotCorrectness(snap *entity.Snapshot) error {
+	if snap.FormatVersion != entity.SnapshotFormatVersion {
+		return ErrUnsupportedSnapshotVersion
+	}
+	if err := checkRosterCorrectness(snap.Teams); err != nil {
+		return err
+	}
+
+	users := make(map[string]struct{})
+	for _, team := range snap.Teams {
+		if team.MaxOpenReviews < 0 {
+			return ErrInvalidReviewLimit
+		}
+		for _, member := range team.Members {
+			users[member.UserID] = struct{}{}
+		}
+	}
+
+	prs := make(map[string]struct{}, len(snap.PullRequests))
+	for i := range snap.PullRequests {
+		pr := &snap.PullRequests[i]
+		if err := checkPRCorrectness(pr); err != nil {
+			return err
+		}
+		if _, ok := prs[pr.PullRequestID]; ok {
+			return ErrDuplicateSnapshotPR
+		}
+		prs[pr.PullRequestID] = struct{}{}
+
+		switch {
+		case pr.Version < 1, pr.CreatedAt.IsZero():
+			return ErrInvalidSnapshotPR
+		case pr.Status == entity.PullRequestStatusOpen && !pr.MergedAt.IsZero():
+			return ErrInvalidSnapshotPR
+		case pr.Status != entity.PullRequestStatusOpen && pr.Status != entity.PullRequestStatusMerged:
+			return ErrInvalidSnapshotPR
+		}
+
+		if _, ok := users[pr.AuthorID]; !ok {
+			return ErrSnapshotUnknownUser
+		}
+		for _, reviewerID := range pr.AssignedReviewers {
+			if _, ok := users[reviewerID]; !ok {
+				return ErrSnapshotUnknownUser
+			}
+		}
+		if pr.ShadowReviewer != "" {
+			if _, ok := users[pr.ShadowReviewer]; !ok {
+				return ErrSnapshotUnknownUser
+			}
+			// Теневой ревьювер не может быть автором или назначенным ревьювером
+			if pr.ShadowReviewer == pr.AuthorID {
+				return ErrInvalidSnapshotPR
+			}
+			for _, reviewerID := range pr.AssignedReviewers {
+				if reviewerID == pr.ShadowReviewer {
+					return ErrInvalidSnapshotPR
+				}
+			}
+		}
+	}
+
+	for i := range snap.Unavailability {
+		if err := checkUnavailabilityCorrectness(&snap.Unavailability[i]); err != nil {
+			return err
+		}
+		if _, ok := users[snap.Unavailability[i].UserID]; !ok {
+			return ErrSnapshotUnknownUser
+		}
+	}
+
+	aliases := make(map[string]struct{}, len(snap.Aliases))
+	for _, alias := range snap.Aliases {
+		normalized := normalizeAlias(alias.Alias)
+		if normalized == "" {
+			return ErrEmptyAlias
+		}
+		if _, ok := aliases[normalized]; ok {
+			return ErrAliasTaken
+		}
+		aliases[normalized] = struct{}{}
+		if _, ok := users[alias.UserID]; !ok {
+			return ErrSnapshotUnknownUser
+		}
+	}
+
+	schedules := make(map[string]struct{}, len(snap.WorkSchedules))
+	for i := range snap.WorkSchedules {
+		schedule := &snap.WorkSchedules[i]
+		if err := checkWorkScheduleCorrectness(schedule); err != nil {
+			return err
+		}
+		if _, ok := schedules[schedule.UserID]; ok {
+			return ErrDuplicateSnapshotSchedule
+		}
+		schedules[schedule.UserID] = struct{}{}
+		if _, ok := users[schedule.UserID]; !ok {
+			return ErrSnapshotUnknownUser
+		}
+	}
+
+	// Владельцы в правилах не сверяются с пользователями: CODEOWNERS может ссылаться
+	// на тех, кого в сервисе нет
+	repositories := make(map[string]struct{}, len(snap.CodeOwners))
+	for i := range snap.CodeOwners {
+		owners := &snap.CodeOwners[i]
+		if err := checkCodeOwnersCorrectness(owners); err != nil {
+			return err
+		}
+		if _, ok := repositories[owners.Repository]; ok {
+			return ErrDuplicateSnapshotOwners
+		}
+		repositories[owners.Repository] = struct{}{}
+	}
+
+	skillOwners, skills := groupSkillsByUser(snap.Skills)
+	for _, userID := range skillOwners {
+		if _, err := normalizeSkills(userID, skills[userID]); err != nil {
+			return err
+		}
+		if _, ok := users[userID]; !ok {
+			return ErrSnapshotUnknownUser
+		}
+	}
+
+	pairs := make(map[[2]string]struct{}, len(snap.ReviewerRules))
+	for i := range snap.ReviewerRules {
+		rule := &snap.ReviewerRules[i]
+		if err := checkReviewerRuleCorrectness(rule); err != nil {
+			return err
+		}
+		pair := [2]string{rule.AuthorID, rule.ReviewerID}
+		if _, ok := pairs[pair]; ok {
+			return ErrDuplicateSnapshotRule
+		}
+		pairs[pair] = struct{}{}
+		if _, ok := users[rule.AuthorID]; !ok {
+			return ErrSnapshotUnknownUser
+		}
+		if _, ok := users[rule.ReviewerID]; !ok {
+			return ErrSnapshotUnknownUser
+		}
+	}
+	return nil
+}
+
+// groupSkillsByUser группирует навыки снапшота по пользователю в порядке первого появления
+func groupSkillsByUser(skills []entity.UserSkill) ([]string, map[string][]entity.UserSkill) {
+	var userIDs []string
+	grouped := make(map[string][]entity.UserSkill)
+	for _, skill := range skills {
+		if _, ok := grouped[skill.UserID]; !ok {
+			userIDs = append(userIDs, skill.UserID)
+		}
+		grouped[skill.UserID] = append(grouped[skill.UserID], skill)
+	}
+	return userIDs, grouped
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testSnapshot() *entity.Snapshot {
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	return &entity.Snapshot{
+		FormatVersion: entity.SnapshotFormatVersion,
+		Teams: []entity.Team{
+			{TeamName: "backend", Members: []entity.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: false},
+			}},
+			{TeamName: "platform"},
+		},
+		PullRequests: []entity.PullRequest{
+			{
+				PullRequestID:     "pr-1",
+				PullRequestName:   "Add search",
+				AuthorID:          "u1",
+				Status:            entity.PullRequestStatusMerged,
+				AssignedReviewers: []string{"u2"},
+				CreatedAt:         createdAt,
+				MergedAt:          createdAt.Add(time.Hour),
+				Version:           3,
+			},
+		},
+		Unavailability: []entity.Unavailability{
+			{
+				UserID:          "u2",
+				StartsAt:        createdAt,
+				EndsAt:          createdAt.Add(72 * time.Hour),
+				Reason:          "vacation",
+				ReassignReviews: true,
+				ReassignedAt:    createdAt,
+			},
+		},
+		Aliases: []entity.UserAlias{
+			{Alias: "alice@example.com", UserID: "u1"},
+			{Alias: "bob@example.com", UserID: "u2"},
+			{Alias: "bobby", UserID: "u2"},
+		},
+		WorkSchedules: []entity.WorkSchedule{
+			{
+				UserID:      "u1",
+				TimeZone:    "Europe/Berlin",
+				StartMinute: 9 * 60,
+				EndMinute:   18 * 60,
+				Days:        []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+			},
+		},
+		CodeOwners: []entity.CodeOwners{
+			{Repository: "acme/search", Rules: []entity.CodeOwnerRule{
+				{Line: 1, Pattern: "*", Teams: []string{"backend"}},
+				{Line: 2, Pattern: "/docs/", Users: []string{"ghost"}},
+			}},
+		},
+		Skills: []entity.UserSkill{
+			{UserID: "u1", Tag: "go", Level: entity.SkillLevelExpert},
+			{UserID: "u1", Tag: "sql", Level: 2},
+			{UserID: "u2", Tag: "frontend", Level: entity.SkillLevelBasic},
+		},
+		ReviewerRules: []entity.ReviewerRule{
+			{AuthorID: "u1", ReviewerID: "u2", Kind: entity.ReviewerRuleExclude, Reason: "co-author"},
+		},
+	}
+}
+
+func TestExportSnapshot(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	want := testSnapshot()
+	expectTx(mockRepo)
+	mockRepo.On("ListTeams", mock.Anything).Return([]*entity.Team{&want.Teams[0], &want.Teams[1]}, nil)
+	mockRepo.On("ListPRs", mock.Anything).Return([]*entity.PullRequest{&want.PullRequests[0]}, nil)
+	mockRepo.On("ListUnavailabilities", mock.Anything).Return([]*entity.Unavailability{&want.Unavailability[0]}, nil)
+	mockRepo.On("ListUserAliases", mock.Anything).Return(want.Aliases, nil)
+	mockRepo.On("ListWorkSchedules", mock.Anything).Return([]*entity.WorkSchedule{&want.WorkSchedules[0]}, nil)
+	mockRepo.On("ListCodeOwners", mock.Anything).Return([]*entity.CodeOwners{&want.CodeOwners[0]}, nil)
+	mockRepo.On("ListUserSkills", mock.Anything).Return(want.Skills, nil)
+	mockRepo.On("ListReviewerRules", mock.Anything).Return(want.ReviewerRules, nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	snap, err := service.ExportSnapshot(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.SnapshotFormatVersion, snap.FormatVersion)
+	assert.False(t, snap.CreatedAt.IsZero())
+	assert.Equal(t, want.Teams, snap.Teams)
+	assert.Equal(t, want.PullRequests, snap.PullRequests)
+	assert.Equal(t, want.Unavailability, snap.Unavailability)
+	assert.Equal(t, want.Aliases, snap.Aliases)
+	assert.Equal(t, want.WorkSchedules, snap.WorkSchedules)
+	assert.Equal(t, want.CodeOwners, snap.CodeOwners)
+	assert.Equal(t, want.Skills, snap.Skills)
+	assert.Equal(t, want.ReviewerRules, snap.ReviewerRules)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRestoreSnapshot_IntoEmptyStorage(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	snap := testSnapshot()
+	expectTx(mockRepo)
+	mockRepo.On("ListTeams", mock.Anything).Return(nil, nil)
+	mockRepo.On("ListPRs", mock.Anything).Return(nil, nil)
+	mockRepo.On("CreateTeam", mock.Anything, &snap.Teams[0]).Return(nil).Once()
+	mockRepo.On("CreateTeam", mock.Anything, &snap.Teams[1]).Return(nil).Once()
+	mockRepo.On("RestorePR", mock.Anything, &snap.PullRequests[0]).Return(nil)
+	mockRepo.On("CreateUnavailability", mock.Anything, &snap.Unavailability[0]).Return(nil)
+	mockRepo.On("SetUserAliases", mock.Anything, "u1", []string{"alice@example.com"}).Return(nil).Once()
+	mockRepo.On("SetUserAliases", mock.Anything, "u2", []string{"bob@example.com", "bobby"}).Return(nil).Once()
+	mockRepo.On("SetWorkSchedule", mock.Anything, &snap.WorkSchedules[0]).Return(nil)
+	mockRepo.On("SetCodeOwners", mock.Anything, &snap.CodeOwners[0]).Return(nil)
+	mockRepo.On("SetUserSkills", mock.Anything, "u1", snap.Skills[:2]).Return(nil).Once()
+	mockRepo.On("SetUserSkills", mock.Anything, "u2", snap.Skills[2:]).Return(nil).Once()
+	mockRepo.On("SetReviewerRule", mock.Anything, &snap.ReviewerRules[0]).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	err = service.RestoreSnapshot(context.Background(), snap)
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRestoreSnapshot_StorageNotEmpty(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	expectTx(mockRepo)
+	mockRepo.On("ListTeams", mock.Anything).Return([]*entity.Team{{TeamName: "backend"}}, nil)
+	mockRepo.On("ListPRs", mock.Anything).Return(nil, nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	err = service.RestoreSnapshot(context.Background(), testSnapshot())
+
+	assert.ErrorIs(t, err, ErrStorageNotEmpty)
+	mockRepo.AssertNotCalled(t, "CreateTeam", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "RestorePR", mock.Anything, mock.Anything)
+}
+
+func TestRestoreSnapshot_InvalidSnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(snap *entity.Snapshot)
+		wantErr error
+	}{
+		{
+			name:    "unsupported format version",
+			modify:  func(snap *entity.Snapshot) { snap.FormatVersion = 99 },
+			wantErr: ErrUnsupportedSnapshotVersion,
+		},
+		{
+			name: "duplicate user",
+			modify: func(snap *entity.Snapshot) {
+				snap.Teams[1].Members = []entity.TeamMember{{UserID: "u1", Username: "Alice"}}
+			},
+			wantErr: ErrDuplicateRosterUser,
+		},
+		{
+			name: "duplicate pull request",
+			modify: func(snap *entity.Snapshot) {
+				snap.PullRequests = append(snap.PullRequests, snap.PullRequests[0])
+			},
+			wantErr: ErrDuplicateSnapshotPR,
+		},
+		{
+			name:    "unknown reviewer",
+			modify:  func(snap *entity.Snapshot) { snap.PullRequests[0].AssignedReviewers = []string{"ghost"} },
+			wantErr: ErrSnapshotUnknownUser,
+		},
+		{
+			name:    "unknown shadow reviewer",
+			modify:  func(snap *entity.Snapshot) { snap.PullRequests[0].ShadowReviewer = "ghost" },
+			wantErr: ErrSnapshotUnknownUser,
+		},
+		{
+			name:    "shadow reviewer is also assigned",
+			modify:  func(snap *entity.Snapshot) { snap.PullRequests[0].ShadowReviewer = "u2" },
+			wantErr: ErrInvalidSnapshotPR,
+		},
+		{
+			name:    "rule of unknown user",
+			modify:  func(snap *entity.Snapshot) { snap.ReviewerRules[0].ReviewerID = "ghost" },
+			wantErr: ErrSnapshotUnknownUser,
+		},
+		{
+			name:    "rule with unknown kind",
+			modify:  func(snap *entity.Snapshot) { snap.ReviewerRules[0].Kind = "avoid" },
+			wantErr: ErrInvalidReviewerRule,
+		},
+		{
+			name: "rule listed twice",
+			modify: func(snap *entity.Snapshot) {
+				snap.ReviewerRules = append(snap.ReviewerRules, snap.ReviewerRules[0])
+			},
+			wantErr: ErrDuplicateSnapshotRule,
+		},
+		{
+			name:    "unknown status",
+			modify:  func(snap *entity.Snapshot) { snap.PullRequests[0].Status = "DRAFT" },
+			wantErr: ErrInvalidSnapshotPR,
+		},
+		{
+			name:    "zero version",
+			modify:  func(snap *entity.Snapshot) { snap.PullRequests[0].Version = 0 },
+			wantErr: ErrInvalidSnapshotPR,
+		},
+		{
+			name:    "open pull request with merge time",
+			modify:  func(snap *entity.Snapshot) { snap.PullRequests[0].Status = entity.PullRequestStatusOpen },
+			wantErr: ErrInvalidSnapshotPR,
+		},
+		{
+			name:    "unavailability of unknown user",
+			modify:  func(snap *entity.Snapshot) { snap.Unavailability[0].UserID = "ghost" },
+			wantErr: ErrSnapshotUnknownUser,
+		},
+		{
+			name: "unavailability ends before it starts",
+			modify: func(snap *entity.Snapshot) {
+				snap.Unavailability[0].EndsAt = snap.Unavailability[0].StartsAt.Add(-time.Hour)
+			},
+			wantErr: ErrInvalidUnavailabilityPeriod,
+		},
+		{
+			name:    "alias of unknown user",
+			modify:  func(snap *entity.Snapshot) { snap.Aliases[0].UserID = "ghost" },
+			wantErr: ErrSnapshotUnknownUser,
+		},
+		{
+			name:    "alias listed twice",
+			modify:  func(snap *entity.Snapshot) { snap.Aliases[2].Alias = "Bob@Example.com" },
+			wantErr: ErrAliasTaken,
+		},
+		{
+			name:    "empty alias",
+			modify:  func(snap *entity.Snapshot) { snap.Aliases[0].Alias = " " },
+			wantErr: ErrEmptyAlias,
+		},
+		{
+			name:    "work schedule of unknown user",
+			modify:  func(snap *entity.Snapshot) { snap.WorkSchedules[0].UserID = "ghost" },
+			wantErr: ErrSnapshotUnknownUser,
+		},
+		{
+			name: "work schedule listed twice",
+			modify: func(snap *entity.Snapshot) {
+				snap.WorkSchedules = append(snap.WorkSchedules, snap.WorkSchedules[0])
+			},
+			wantErr: ErrDuplicateSnapshotSchedule,
+		},
+		{
+			name:    "work schedule in unknown time zone",
+			modify:  func(snap *entity.Snapshot) { snap.WorkSchedules[0].TimeZone = "Mars/Olympus" },
+			wantErr: ErrInvalidWorkSchedule,
+		},
+		{
+			name: "code owners listed twice",
+			modify: func(snap *entity.Snapshot) {
+				snap.CodeOwners = append(snap.CodeOwners, snap.CodeOwners[0])
+			},
+			wantErr: ErrDuplicateSnapshotOwners,
+		},
+		{
+			name:    "code owners rule with negation",
+			modify:  func(snap *entity.Snapshot) { snap.CodeOwners[0].Rules[1].Pattern = "!docs/" },
+			wantErr: ErrInvalidCodeOwners,
+		},
+		{
+			name:    "skill of unknown user",
+			modify:  func(snap *entity.Snapshot) { snap.Skills[2].UserID = "ghost" },
+			wantErr: ErrSnapshotUnknownUser,
+		},
+		{
+			name:    "skill with invalid level",
+			modify:  func(snap *entity.Snapshot) { snap.Skills[0].Level = 4 },
+			wantErr: ErrInvalidSkill,
+		},
+		{
+			name:    "skill listed twice",
+			modify:  func(snap *entity.Snapshot) { snap.Skills[1].Tag = " GO" },
+			wantErr: ErrDuplicateSkill,
+		},
+		{
+			name:    "empty pull request name",
+			modify:  func(snap *entity.Snapshot) { snap.PullRequests[0].PullRequestName = "" },
+			wantErr: ErrEmptyPRName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.Repository{}
+			logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+			require.NoError(t, err)
+
+			snap := testSnapshot()
+			tt.modify(snap)
+			service := NewPRService(mockRepo, logger)
+
+			err = service.RestoreSnapshot(context.Background(), snap)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertNotCalled(t, "WithTx", mock.Anything, mock.Anything)
+		})
+	}
+}
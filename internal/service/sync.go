@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/interfaces"
+)
+
+// SyncTeams приводит перечисленные в roster команды к описанному составу: создаёт
+// команды и пользователей, переводит пользователей между командами, обновляет имена,
+// активность, предел ревью, уровень и признак обучения. Участники этих команд,
+// которых нет в roster, деактивируются.
+// Команды, не упомянутые в roster, не меняются. При dryRun план только рассчитывается
+func (servs *PrService) SyncTeams(ctx context.Context, roster []entity.Team, dryRun bool) ([]entity.TeamSyncChange, error) {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_SYNC_TEAMS", "Starting team sync",
+		"teams_count", len(roster),
+		"dry_run", dryRun)
+
+	if err := checkRosterCorrectness(roster); err != nil {
+		servs.logger.Warn("SERVICE_SYNC_TEAMS", "Roster validation failed",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	var changes []entity.TeamSyncChange
+
+	// План считается и применяется в одной транзакции, чтобы между ними
+	// состав команд не изменился
+	err := servs.repo.WithTx(ctx, func(repo interfaces.Repository) error {
+		var (
+			desired map[string]entity.User
+			err     error
+		)
+		changes, desired, err = planTeamSync(ctx, repo, roster, true)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			return nil
+		}
+		return applyTeamSync(ctx, repo, changes, desired)
+	})
+	if err != nil {
+		servs.logger.Error("SERVICE_SYNC_TEAMS", "Failed to sync teams",
+			"dry_run", dryRun,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	servs.logger.Info("SERVICE_SYNC_TEAMS", "Teams synced successfully",
+		"teams_count", len(roster),
+		"changes_count", len(changes),
+		"dry_run", dryRun,
+		"duration_ms", time.Since(start).Milliseconds())
+	return changes, nil
+}
+
+func checkRosterCorrectness(roster []entity.Team) error {
+	teams := make(map[string]struct{}, len(roster))
+	users := make(map[string]struct{})
+	for _, team := range roster {
+		if team.TeamName == "" {
+			return ErrEmptyTeamName
+		}
+		if _, ok := teams[team.TeamName]; ok {
+			return ErrDuplicateRosterTeam
+		}
+		teams[team.TeamName] = struct{}{}
+
+		for _, member := range team.Members {
+			if err := checkTeamMemberCorrectness(member); err != nil {
+				return err
+			}
+			if _, ok := users[member.UserID]; ok {
+				return ErrDuplicateRosterUser
+			}
+			users[member.UserID] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// planTeamSync сравнивает roster с хранилищем и возвращает список изменений
+// и итоговое состояние каждого затронутого пользователя из roster.
+// full - roster описывает команды полностью: участники, которых нет в roster, деактивируются,
+// а предел ревью, уровень и признак обучения берутся из roster. Иначе они у существующих
+// пользователей не меняются
+func planTeamSync(ctx context.Context, repo interfaces.Repository, roster []entity.Team, full bool) ([]entity.TeamSyncChange, map[string]entity.User, error) {
+	inRoster := make(map[string]struct{})
+	for _, team := range roster {
+		for _, member := range team.Members {
+			inRoster[member.UserID] = struct{}{}
+		}
+	}
+
+	changes := []entity.TeamSyncChange{}
+	desired := make(map[string]entity.User)
+
+	for _, team := range roster {
+		var current []*entity.User
+		switch {
+		case !repo.TeamExists(ctx, team.TeamName):
+			changes = append(changes, entity.TeamSyncChange{
+				Action:   entity.TeamSyncCreateTeam,
+				TeamName: team.TeamName,
+			})
+		case full:
+			var err error
+			current, err = repo.FindUsersByTeam(ctx, team.TeamName)
+			if err != nil {
+				return nil, nil, fmt.Errorf("find users of team %s: %w", team.TeamName, err)
+			}
+		}
+
+		for _, member := range team.Members {
+			target := entity.User{
+				UserID:         member.UserID,
+				Username:       member.Username,
+				TeamName:       team.TeamName,
+				IsActive:       member.IsActive,
+				MaxOpenReviews: member.MaxOpenReviews,
+				Level:          member.Level,
+				IsLearner:      member.IsLearner,
+			}
+			desired[member.UserID] = target
+
+			user, err := repo.FindUserByID(ctx, member.UserID)
+			if errors.Is(err, entity.ErrNoUser) {
+				changes = append(changes, entity.TeamSyncChange{
+					Action:   entity.TeamSyncCreateUser,
+					TeamName: team.TeamName,
+					UserID:   member.UserID,
+					Username: member.Username,
+				})
+				continue
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("find user %s: %w", member.UserID, err)
+			}
+			if !full {
+				target.MaxOpenReviews = user.MaxOpenReviews
+				target.Level = user.Level
+				target.IsLearner = user.IsLearner
+				desired[member.UserID] = target
+			}
+			changes = append(changes, userSyncChanges(user, target)...)
+		}
+
+		for _, user := range current {
+			if _, ok := inRoster[user.UserID]; ok || !user.IsActive {
+				continue
+			}
+			changes = append(changes, entity.TeamSyncChange{
+				Action:   entity.TeamSyncDeactivateUser,
+				TeamName: team.TeamName,
+				UserID:   user.UserID,
+				Username: user.Username,
+			})
+		}
+	}
+	return changes, desired, nil
+}
+
+// userSyncChanges возвращает изменения, переводящие существующего пользователя в target
+func userSyncChanges(user *entity.User, target entity.User) []entity.TeamSyncChange {
+	var changes []entity.TeamSyncChange
+	change := func(action entity.TeamSyncAction) entity.TeamSyncChange {
+		return entity.TeamSyncChange{
+			Action:   action,
+			TeamName: target.TeamName,
+			UserID:   target.UserID,
+			Username: target.Username,
+		}
+	}
+
+	if user.TeamName != target.TeamName {
+		move := change(entity.TeamSyncMoveUser)
+		move.FromTeam = user.TeamName
+		changes = append(changes, move)
+	}
+	if user.Username != target.Username {
+		changes = append(changes, change(entity.TeamSyncRenameUser))
+	}
+	if user.IsActive != target.IsActive {
+		if target.IsActive {
+			changes = append(changes, change(entity.TeamSyncActivateUser))
+		} else {
+			changes = append(changes, change(entity.TeamSyncDeactivateUser))
+		}
+	}
+	if user.MaxOpenReviews != target.MaxOpenReviews {
+		changes = append(changes, change(entity.TeamSyncSetMaxOpenReviews))
+	}
+	if user.Level != target.Level {
+		changes = append(changes, change(entity.TeamSyncSetLevel))
+	}
+	if user.IsLearner != target.IsLearner {
+		changes = append(changes, change(entity.TeamSyncSetLearner))
+	}
+	return changes
+}
+
+func applyTeamSync(ctx context.Context, repo interfaces.Repository, changes []entity.TeamSyncChange, desired map[string]entity.User) error {
+	// Перевод, переименование и смена активности одного пользователя
+	// применяются одним UpdateUser, остальные поля UpdateUser не меняет
+	updated := make(map[string]struct{})
+
+	for _, change := range changes {
+		switch change.Action {
+		case entity.TeamSyncCreateTeam:
+			if err := repo.CreateTeam(ctx, &entity.Team{TeamName: change.TeamName}); err != nil {
+				return fmt.Errorf("create team %s: %w", change.TeamName, err)
+			}
+		case entity.TeamSyncCreateUser:
+			user := desired[change.UserID]
+			if err := repo.CreateUser(ctx, &user); err != nil {
+				return fmt.Errorf("create user %s: %w", change.UserID, err)
+			}
+		case entity.TeamSyncSetMaxOpenReviews:
+			if err := repo.SetMaxOpenReviews(ctx, change.UserID, desired[change.UserID].MaxOpenReviews); err != nil {
+				return fmt.Errorf("set max open reviews of user %s: %w", change.UserID, err)
+			}
+		case entity.TeamSyncSetLevel:
+			if err := repo.SetUserLevel(ctx, change.UserID, desired[change.UserID].Level); err != nil {
+				return fmt.Errorf("set level of user %s: %w", change.UserID, err)
+			}
+		case entity.TeamSyncSetLearner:
+			if err := repo.SetUserLearner(ctx, change.UserID, desired[change.UserID].IsLearner); err != nil {
+				return fmt.Errorf("set learner flag of user %s: %w", change.UserID, err)
+			}
+		default:
+			user, ok := desired[change.UserID]
+			if !ok {
+				// Пользователя нет в roster - он удалён из команды
+				if err := repo.SetActive(ctx, change.UserID, false); err != nil {
+					return fmt.Errorf("deactivate user %s: %w", change.UserID, err)
+				}
+				continue
+			}
+			if _, ok := updated[change.UserID]; ok {
+				continue
+			}
+			if err := repo.UpdateUser(ctx, &user); err != nil {
+				return fmt.Errorf("update user %s: %w", change.UserID, err)
+			}
+			updated[change.UserID] = struct{}{}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// syncRoster: u3 переходит из frontend в backend с новым именем, u2 убран из backend,
+// команда platform и пользователь u5 новые
+func syncRoster() []entity.Team {
+	return []entity.Team{
+		{
+			TeamName: "backend",
+			Members: []entity.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u3", Username: "Charles", IsActive: true},
+			},
+		},
+		{
+			TeamName: "platform",
+			Members:  []entity.TeamMember{{UserID: "u5", Username: "Eve", IsActive: true}},
+		},
+	}
+}
+
+func expectSyncReads(mockRepo *mocks.Repository) {
+	expectTx(mockRepo)
+	mockRepo.On("TeamExists", mock.Anything, "backend").Return(true)
+	mockRepo.On("TeamExists", mock.Anything, "platform").Return(false)
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return([]*entity.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(
+		&entity.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u3").Return(
+		&entity.User{UserID: "u3", Username: "Charlie", TeamName: "frontend", IsActive: true}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u5").Return(nil, entity.ErrNoUser)
+}
+
+func expectedSyncChanges() []entity.TeamSyncChange {
+	return []entity.TeamSyncChange{
+		{Action: entity.TeamSyncMoveUser, TeamName: "backend", UserID: "u3", Username: "Charles", FromTeam: "frontend"},
+		{Action: entity.TeamSyncRenameUser, TeamName: "backend", UserID: "u3", Username: "Charles"},
+		{Action: entity.TeamSyncDeactivateUser, TeamName: "backend", UserID: "u2", Username: "Bob"},
+		{Action: entity.TeamSyncCreateTeam, TeamName: "platform"},
+		{Action: entity.TeamSyncCreateUser, TeamName: "platform", UserID: "u5", Username: "Eve"},
+	}
+}
+
+func TestSyncTeams_DryRun(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	expectSyncReads(mockRepo)
+
+	service := NewPRService(mockRepo, logger)
+
+	changes, err := service.SyncTeams(context.Background(), syncRoster(), true)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedSyncChanges(), changes)
+	// В режиме dry-run хранилище не меняется
+	mockRepo.AssertNotCalled(t, "CreateTeam", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "SetActive", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncTeams_Apply(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	expectSyncReads(mockRepo)
+	mockRepo.On("CreateTeam", mock.Anything, &entity.Team{TeamName: "platform"}).Return(nil)
+	mockRepo.On("CreateUser", mock.Anything,
+		&entity.User{UserID: "u5", Username: "Eve", TeamName: "platform", IsActive: true}).Return(nil)
+	// Перевод и переименование применяются одним обновлением
+	mockRepo.On("UpdateUser", mock.Anything,
+		&entity.User{UserID: "u3", Username: "Charles", TeamName: "backend", IsActive: true}).Return(nil).Once()
+	mockRepo.On("SetActive", mock.Anything, "u2", false).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	changes, err := service.SyncTeams(context.Background(), syncRoster(), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedSyncChanges(), changes)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncTeams_NoChanges(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	expectTx(mockRepo)
+	mockRepo.On("TeamExists", mock.Anything, "backend").Return(true)
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return([]*entity.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: false},
+	}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(
+		&entity.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}, nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	// Неактивный u2 отсутствует в описании и остаётся как есть
+	changes, err := service.SyncTeams(context.Background(), []entity.Team{{
+		TeamName: "backend",
+		Members:  []entity.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}}, false)
+
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncTeams_ProfileFields(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	expectTx(mockRepo)
+	mockRepo.On("TeamExists", mock.Anything, "backend").Return(true)
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return([]*entity.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true, Level: entity.UserLevelJunior, IsLearner: true},
+	}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(
+		&entity.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u2").Return(
+		&entity.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true,
+			Level: entity.UserLevelJunior, IsLearner: true}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u3").Return(nil, entity.ErrNoUser)
+	mockRepo.On("SetMaxOpenReviews", mock.Anything, "u1", 3).Return(nil)
+	mockRepo.On("SetUserLevel", mock.Anything, "u1", entity.UserLevelSenior).Return(nil)
+	mockRepo.On("SetUserLevel", mock.Anything, "u2", entity.UserLevelMiddle).Return(nil)
+	mockRepo.On("SetUserLearner", mock.Anything, "u2", false).Return(nil)
+	mockRepo.On("CreateUser", mock.Anything, &entity.User{UserID: "u3", Username: "Carol", TeamName: "backend",
+		IsActive: true, MaxOpenReviews: 1, IsLearner: true}).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	changes, err := service.SyncTeams(context.Background(), []entity.Team{{
+		TeamName: "backend",
+		Members: []entity.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true, MaxOpenReviews: 3, Level: entity.UserLevelSenior},
+			{UserID: "u2", Username: "Bob", IsActive: true, Level: entity.UserLevelMiddle},
+			{UserID: "u3", Username: "Carol", IsActive: true, MaxOpenReviews: 1, IsLearner: true},
+		},
+	}}, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, []entity.TeamSyncChange{
+		{Action: entity.TeamSyncSetMaxOpenReviews, TeamName: "backend", UserID: "u1", Username: "Alice"},
+		{Action: entity.TeamSyncSetLevel, TeamName: "backend", UserID: "u1", Username: "Alice"},
+		{Action: entity.TeamSyncSetLevel, TeamName: "backend", UserID: "u2", Username: "Bob"},
+		{Action: entity.TeamSyncSetLearner, TeamName: "backend", UserID: "u2", Username: "Bob"},
+		{Action: entity.TeamSyncCreateUser, TeamName: "backend", UserID: "u3", Username: "Carol"},
+	}, changes)
+	// Имя, команда и активность не менялись
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncTeams_InvalidRoster(t *testing.T) {
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		roster []entity.Team
+		err    error
+	}{
+		{
+			name:   "empty team name",
+			roster: []entity.Team{{Members: []entity.TeamMember{{UserID: "u1", Username: "Alice"}}}},
+			err:    ErrEmptyTeamName,
+		},
+		{
+			name:   "duplicate team",
+			roster: []entity.Team{{TeamName: "backend"}, {TeamName: "backend"}},
+			err:    ErrDuplicateRosterTeam,
+		},
+		{
+			name: "user in two teams",
+			roster: []entity.Team{
+				{TeamName: "backend", Members: []entity.TeamMember{{UserID: "u1", Username: "Alice"}}},
+				{TeamName: "frontend", Members: []entity.TeamMember{{UserID: "u1", Username: "Alice"}}},
+			},
+			err: ErrDuplicateRosterUser,
+		},
+		{
+			name:   "empty username",
+			roster: []entity.Team{{TeamName: "backend", Members: []entity.TeamMember{{UserID: "u1"}}}},
+			err:    ErrEmptyUserUsername,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.Repository{}
+			service := NewPRService(mockRepo, logger)
+
+			changes, err := service.SyncTeams(context.Background(), tt.roster, false)
+
+			assert.Equal(t, tt.err, err)
+			assert.Nil(t, changes)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
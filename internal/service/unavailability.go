@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+)
+
+// maxReassignAttempts - сколько раз переназначение ревью повторяется при параллельном изменении PR
+const maxReassignAttempts = 3
+
+// AddUnavailability сохраняет период недоступности пользователя. Если период
+// с ReassignReviews уже идёт, открытые ревью пользователя переназначаются сразу,
+// не дожидаясь фоновой проверки
+func (servs *PrService) AddUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	start := time.Now()
+
+	servs.logger.Debug("SERVICE_ADD_UNAVAILABILITY", "Adding unavailability period",
+		"user_id", period.UserID,
+		"starts_at", period.StartsAt,
+		"ends_at", period.EndsAt,
+		"reassign_reviews", period.ReassignReviews)
+
+	if err := checkUnavailabilityCorrectness(period); err != nil {
+		servs.logger.Warn("SERVICE_ADD_UNAVAILABILITY", "Unavailability validation failed",
+			"user_id", period.UserID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	// Время переназначения выставляет только сервис
+	period.ReassignedAt = time.Time{}
+	if err := servs.repo.CreateUnavailability(ctx, period); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return ErrNoUser
+		}
+		servs.logger.Error("SERVICE_ADD_UNAVAILABILITY", "Failed to create unavailability period",
+			"user_id", period.UserID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	if period.ReassignReviews && period.Covers(start) {
+		reassigned, err := servs.reassignPeriodReviews(ctx, period, start)
+		if err != nil {
+			// Период сохранён, фоновая проверка повторит переназначение
+			servs.logger.Warn("SERVICE_ADD_UNAVAILABILITY", "Failed to reassign reviews, will retry",
+				"user_id", period.UserID,
+				"unavailability_id", period.ID,
+				"error", err)
+		} else {
+			servs.logger.Debug("SERVICE_ADD_UNAVAILABILITY", "Reviews reassigned",
+				"user_id", period.UserID,
+				"reassigned_count", reassigned)
+		}
+	}
+
+	servs.logger.Info("SERVICE_ADD_UNAVAILABILITY", "Unavailability period added successfully",
+		"user_id", period.UserID,
+		"unavailability_id", period.ID,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func (servs *PrService) GetUnavailability(ctx context.Context, userID string) ([]*entity.Unavailability, error) {
+	start := time.Now()
+
+	if userID == "" {
+		return nil, ErrEmptyUserID
+	}
+
+	if _, err := servs.repo.FindUserByID(ctx, userID); err != nil {
+		if errors.Is(err, entity.ErrNoUser) {
+			return nil, ErrNoUser
+		}
+		return nil, err
+	}
+
+	periods, err := servs.repo.FindUnavailabilitiesByUser(ctx, userID)
+	if err != nil {
+		servs.logger.Error("SERVICE_GET_UNAVAILABILITY", "Failed to find unavailability periods",
+			"user_id", userID,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	servs.logger.Info("SERVICE_GET_UNAVAILABILITY", "Unavailability periods retrieved successfully",
+		"user_id", userID,
+		"periods_count", len(periods),
+		"duration_ms", time.Since(start).Milliseconds())
+	return periods, nil
+}
+
+func (servs *PrService) DeleteUnavailability(ctx context.Context, id int64) error {
+	start := time.Now()
+
+	if err := servs.repo.DeleteUnavailability(ctx, id); err != nil {
+		if errors.Is(err, entity.ErrNoUnavailability) {
+			return ErrNoUnavailability
+		}
+		servs.logger.Error("SERVICE_DELETE_UNAVAILABILITY", "Failed to delete unavailability period",
+			"unavailability_id", id,
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return err
+	}
+
+	servs.logger.Info("SERVICE_DELETE_UNAVAILABILITY", "Unavailability period deleted successfully",
+		"unavailability_id", id,
+		"duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+// ReassignUnavailableReviews переназначает открытые ревью пользователей, у которых
+// к моменту now начался период с ReassignReviews. Вызывается периодически
+// и возвращает общее число переназначенных ревью
+func (servs *PrService) ReassignUnavailableReviews(ctx context.Context, now time.Time) (int, error) {
+	start := time.Now()
+
+	periods, err := servs.repo.FindPendingReassignments(ctx, now)
+	if err != nil {
+		servs.logger.Error("SERVICE_REASSIGN_UNAVAILABLE_REVIEWS", "Failed to find pending reassignments",
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds())
+		return 0, err
+	}
+
+	// Ошибка одного периода не мешает остальным: он остаётся необработанным
+	// и повторяется при следующей проверке
+	total := 0
+	var errs []error
+	for _, period := range periods {
+		reassigned, err := servs.reassignPeriodReviews(ctx, period, now)
+		total += reassigned
+		if err != nil {
+			servs.logger.Error("SERVICE_REASSIGN_UNAVAILABLE_REVIEWS", "Failed to reassign reviews, will retry",
+				"user_id", period.UserID,
+				"unavailability_id", period.ID,
+				"error", err,
+				"duration_ms", time.Since(start).Milliseconds())
+			errs = append(errs, fmt.Errorf("unavailability %d: %w", period.ID, err))
+		}
+	}
+
+	if len(periods) > 0 {
+		servs.logger.Info("SERVICE_REASSIGN_UNAVAILABLE_REVIEWS", "Reviews of unavailable users reassigned",
+			"periods_count", len(periods),
+			"failed_count", len(errs),
+			"reassigned_count", total,
+			"duration_ms", time.Since(start).Milliseconds())
+	}
+	return total, errors.Join(errs...)
+}
+
+// reassignPeriodReviews переназначает открытые ревью пользователя из period и отмечает
+// период обработанным. Ревью без подходящей замены остаются за пользователем, PR, который
+// не удалось переназначить и за maxReassignAttempts попыток, пропускается
+func (servs *PrService) reassignPeriodReviews(ctx context.Context, period *entity.Unavailability, now time.Time) (int, error) {
+	prs, err := servs.repo.FindPRsByReviewer(ctx, period.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	reassigned := 0
+	for _, pr := range prs {
+		if pr.Status != entity.PullRequestStatusOpen {
+			continue
+		}
+		// PR, изменённый параллельно, перечитывается и переназначается заново
+		var reassignment *entity.Reassignment
+		for attempt := 1; attempt <= maxReassignAttempts; attempt++ {
+			_, reassignment, err = servs.ReassignReviewer(ctx, pr.PullRequestID, period.UserID, 0)
+			if err != ErrPRVersionConflict {
+				break
+			}
+		}
+		switch err {
+		case nil:
+			reassigned++
+			servs.logger.Debug("SERVICE_REASSIGN_UNAVAILABLE_REVIEWS", "Review reassigned",
+				"pr_id", pr.PullRequestID,
+				"old_user_id", period.UserID,
+				"new_user_id", reassignment.NewReviewerID)
+		case ErrNoReplacementCandidate, ErrSeniorReviewerRequired, ErrCannotReassingOnMergedPR:
+			servs.logger.Warn("SERVICE_REASSIGN_UNAVAILABLE_REVIEWS", "Review left with unavailable reviewer",
+				"pr_id", pr.PullRequestID,
+				"user_id", period.UserID,
+				"reason", err)
+		default:
+			// Прерванная проверка повторится целиком, период не отмечается
+			if ctx.Err() != nil {
+				return reassigned, ctx.Err()
+			}
+			servs.logger.Error("SERVICE_REASSIGN_UNAVAILABLE_REVIEWS", "Failed to reassign review, skipping",
+				"pr_id", pr.PullRequestID,
+				"user_id", period.UserID,
+				"error", err)
+		}
+	}
+
+	if err := servs.repo.MarkUnavailabilityReassigned(ctx, period.ID, now); err != nil {
+		return reassigned, err
+	}
+	return reassigned, nil
+}
+
+func checkUnavailabilityCorrectness(period *entity.Unavailability) error {
+	switch {
+	case period.UserID == "":
+		return ErrEmptyUserID
+	case period.StartsAt.IsZero(), !period.EndsAt.After(period.StartsAt):
+		return ErrInvalidUnavailabilityPeriod
+	}
+	return nil
+}
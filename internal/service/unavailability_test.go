@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pozedorum/set_pr_reviers_service/internal/entity"
+	"github.com/pozedorum/set_pr_reviers_service/internal/mocks"
+	"github.com/pozedorum/set_pr_reviers_service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddUnavailability_Validation(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		period  entity.Unavailability
+		wantErr error
+	}{
+		{
+			name:    "empty user ID",
+			period:  entity.Unavailability{StartsAt: now, EndsAt: now.Add(time.Hour)},
+			wantErr: ErrEmptyUserID,
+		},
+		{
+			name:    "ends before start",
+			period:  entity.Unavailability{UserID: "u1", StartsAt: now, EndsAt: now.Add(-time.Hour)},
+			wantErr: ErrInvalidUnavailabilityPeriod,
+		},
+		{
+			name:    "empty period",
+			period:  entity.Unavailability{UserID: "u1", StartsAt: now, EndsAt: now},
+			wantErr: ErrInvalidUnavailabilityPeriod,
+		},
+		{
+			name:    "no start",
+			period:  entity.Unavailability{UserID: "u1", EndsAt: now},
+			wantErr: ErrInvalidUnavailabilityPeriod,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.Repository{}
+			logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+			require.NoError(t, err)
+
+			service := NewPRService(mockRepo, logger)
+
+			err = service.AddUnavailability(context.Background(), &tt.period)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertNotCalled(t, "CreateUnavailability", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAddUnavailability_UnknownUser(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("CreateUnavailability", mock.Anything, mock.Anything).
+		Return(fmt.Errorf("user ghost: %w", entity.ErrNoUser))
+
+	service := NewPRService(mockRepo, logger)
+
+	err = service.AddUnavailability(context.Background(), &entity.Unavailability{
+		UserID:   "ghost",
+		StartsAt: time.Now(),
+		EndsAt:   time.Now().Add(time.Hour),
+	})
+
+	assert.Equal(t, ErrNoUser, err)
+}
+
+func TestAddUnavailability_FuturePeriodDoesNotReassign(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("CreateUnavailability", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	err = service.AddUnavailability(context.Background(), &entity.Unavailability{
+		UserID:          "u2",
+		StartsAt:        time.Now().Add(24 * time.Hour),
+		EndsAt:          time.Now().Add(48 * time.Hour),
+		ReassignReviews: true,
+		ReassignedAt:    time.Now(),
+	})
+
+	require.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "FindPRsByReviewer", mock.Anything, mock.Anything)
+	// ReassignedAt из запроса не сохраняется
+	mockRepo.AssertCalled(t, "CreateUnavailability", mock.Anything, mock.MatchedBy(func(period *entity.Unavailability) bool {
+		return period.ReassignedAt.IsZero()
+	}))
+}
+
+func TestAddUnavailability_ReassignsStartedPeriod(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	openPR := &entity.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+		Version:           1,
+	}
+	mergedPR := &entity.PullRequest{
+		PullRequestID:     "pr-2",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusMerged,
+		AssignedReviewers: []string{"u2"},
+		Version:           2,
+	}
+
+	mockRepo.On("CreateUnavailability", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*entity.Unavailability).ID = 7
+	}).Return(nil)
+	mockRepo.On("FindPRsByReviewer", mock.Anything, "u2").Return([]*entity.PullRequest{openPR, mergedPR}, nil)
+
+	// Переназначение идёт через ReassignReviewer
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-1").Return(openPR, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u2").Return(
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return([]*entity.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+		{UserID: "u4", TeamName: "backend", IsActive: true},
+		{UserID: "u5", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return([]string{"u2", "u5"}, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend"}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("UpdatePR", mock.Anything, mock.MatchedBy(func(pr *entity.PullRequest) bool {
+		return pr.PullRequestID == "pr-1"
+	})).Return(nil)
+	mockRepo.On("MarkUnavailabilityReassigned", mock.Anything, int64(7), mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	err = service.AddUnavailability(context.Background(), &entity.Unavailability{
+		UserID:          "u2",
+		StartsAt:        time.Now().Add(-time.Hour),
+		EndsAt:          time.Now().Add(time.Hour),
+		ReassignReviews: true,
+	})
+
+	require.NoError(t, err)
+	// Единственный доступный кандидат - u4: u1 автор, u3 уже назначен, u2 и u5 недоступны
+	assert.Equal(t, []string{"u4", "u3"}, openPR.AssignedReviewers)
+	mockRepo.AssertNotCalled(t, "FindPRByIDForUpdate", mock.Anything, "pr-2")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReassignUnavailableReviews_NoCandidateKeepsReviewer(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	now := time.Now()
+	openPR := &entity.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2"},
+		Version:           1,
+	}
+
+	mockRepo.On("FindPendingReassignments", mock.Anything, now).Return([]*entity.Unavailability{
+		{ID: 3, UserID: "u2", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), ReassignReviews: true},
+	}, nil)
+	mockRepo.On("FindPRsByReviewer", mock.Anything, "u2").Return([]*entity.PullRequest{openPR}, nil)
+	expectTx(mockRepo)
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-1").Return(openPR, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u2").Return(
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return([]*entity.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return([]string{"u2"}, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend"}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("MarkUnavailabilityReassigned", mock.Anything, int64(3), now).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	reassigned, err := service.ReassignUnavailableReviews(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, reassigned)
+	assert.Equal(t, []string{"u2"}, openPR.AssignedReviewers)
+	mockRepo.AssertNotCalled(t, "UpdatePR", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReassignUnavailableReviews_FailedPeriodDoesNotStopOthers(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	now := time.Now()
+	mockRepo.On("FindPendingReassignments", mock.Anything, now).Return([]*entity.Unavailability{
+		{ID: 3, UserID: "u2", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), ReassignReviews: true},
+		{ID: 4, UserID: "u5", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), ReassignReviews: true},
+	}, nil)
+	mockRepo.On("FindPRsByReviewer", mock.Anything, "u2").Return(nil, errors.New("connection reset"))
+	mockRepo.On("FindPRsByReviewer", mock.Anything, "u5").Return(nil, nil)
+	mockRepo.On("MarkUnavailabilityReassigned", mock.Anything, int64(4), now).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	reassigned, err := service.ReassignUnavailableReviews(context.Background(), now)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unavailability 3")
+	assert.Equal(t, 0, reassigned)
+	// Первый период повторится при следующей проверке
+	mockRepo.AssertNotCalled(t, "MarkUnavailabilityReassigned", mock.Anything, int64(3), mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReassignUnavailableReviews_RetriesVersionConflict(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	now := time.Now()
+	openPR := entity.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            entity.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u2"},
+		Version:           1,
+	}
+
+	mockRepo.On("FindPendingReassignments", mock.Anything, now).Return([]*entity.Unavailability{
+		{ID: 3, UserID: "u2", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), ReassignReviews: true},
+	}, nil)
+	mockRepo.On("FindPRsByReviewer", mock.Anything, "u2").Return([]*entity.PullRequest{&openPR}, nil)
+	expectTx(mockRepo)
+	// Каждая попытка перечитывает PR заново
+	mockRepo.On("FindPRByIDForUpdate", mock.Anything, "pr-1").Return(
+		func(context.Context, string) *entity.PullRequest {
+			pr := openPR
+			pr.AssignedReviewers = append([]string{}, openPR.AssignedReviewers...)
+			return &pr
+		}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u2").Return(
+		&entity.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return([]*entity.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return([]string{"u2"}, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend"}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("UpdatePR", mock.Anything, mock.Anything).Return(entity.ErrVersionConflict).Once()
+	mockRepo.On("UpdatePR", mock.Anything, mock.MatchedBy(func(pr *entity.PullRequest) bool {
+		return assert.ObjectsAreEqual([]string{"u3"}, pr.AssignedReviewers)
+	})).Return(nil).Once()
+	mockRepo.On("MarkUnavailabilityReassigned", mock.Anything, int64(3), now).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	reassigned, err := service.ReassignUnavailableReviews(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, reassigned)
+	mockRepo.AssertNumberOfCalls(t, "UpdatePR", 2)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreatePR_SkipsUnavailableReviewers(t *testing.T) {
+	mockRepo := &mocks.Repository{}
+	logger, err := logger.NewLogger("pr-service", "logger_for_tests")
+	require.NoError(t, err)
+
+	mockRepo.On("FindUserByID", mock.Anything, "u1").Return(
+		&entity.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	mockRepo.On("FindPRByID", mock.Anything, "pr-1").Return(nil, fmt.Errorf("not found"))
+	mockRepo.On("FindUsersByTeam", mock.Anything, "backend").Return([]*entity.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+		{UserID: "u4", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockRepo.On("FindUnavailableUserIDs", mock.Anything, "backend", mock.Anything).Return([]string{"u3"}, nil)
+	mockRepo.On("FindTeamByName", mock.Anything, "backend").Return(&entity.Team{TeamName: "backend"}, nil)
+	mockRepo.On("FindReviewerRulesByAuthor", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("CreatePR", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPRService(mockRepo, logger)
+
+	pr := &entity.PullRequest{PullRequestID: "pr-1", PullRequestName: "PR", AuthorID: "u1"}
+	_, err = service.CreatePR(context.Background(), pr)
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u2", "u4"}, pr.AssignedReviewers)
+}
@@ -0,0 +1,16 @@
+// Package migrations содержит версионированные SQL-миграции, встроенные в бинарник.
+// Каждая миграция - пара файлов NNN_name.up.sql и NNN_name.down.sql; каталог postgres/
+// применяется к PostgreSQL, sqlite/ содержит эквиваленты для SQLite
+package migrations
+
+import "embed"
+
+// Postgres - миграции PostgreSQL
+//
+//go:embed postgres/*.sql
+var Postgres embed.FS
+
+// SQLite - миграции SQLite
+//
+//go:embed sqlite/*.sql
+var SQLite embed.FS
@@ -0,0 +1,2 @@
+-- Версия PR для оптимистичной блокировки: увеличивается при каждом UpdatePR
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,13 @@
+-- Рабочее время пользователей: при включённом REVIEW_PREFER_WORKING_HOURS
+-- ревьюверами чаще выбираются те, у кого рабочий день идёт или скоро начнётся
+CREATE TABLE IF NOT EXISTS user_schedules (
+    user_id VARCHAR(255) PRIMARY KEY,
+    time_zone VARCHAR(64) NOT NULL,   -- Часовой пояс IANA
+    start_minute INTEGER NOT NULL,    -- Начало рабочего дня, минуты от полуночи
+    end_minute INTEGER NOT NULL,      -- Конец рабочего дня, меньше начала - через полночь
+    work_days INTEGER NOT NULL,       -- Битовая маска дней недели, бит 0 - воскресенье
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CHECK (start_minute BETWEEN 0 AND 1439 AND end_minute BETWEEN 0 AND 1439 AND start_minute <> end_minute),
+    CHECK (work_days BETWEEN 1 AND 127)
+);
@@ -0,0 +1,6 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS labels;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS base_branch;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS repository;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS changed_files;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS deletions;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS additions;
@@ -0,0 +1,7 @@
+-- Необязательные сведения о размере PR, передаются при создании
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS additions INTEGER NOT NULL DEFAULT 0 CHECK (additions >= 0);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS deletions INTEGER NOT NULL DEFAULT 0 CHECK (deletions >= 0);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS changed_files INTEGER NOT NULL DEFAULT 0 CHECK (changed_files >= 0);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS repository VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS base_branch VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS labels TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS paths;
+DROP TABLE IF EXISTS code_owner_rules;
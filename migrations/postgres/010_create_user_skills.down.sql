@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS required_skills;
+DROP TABLE IF EXISTS user_skills;
@@ -0,0 +1,13 @@
+-- Навыки пользователей: при выборе ревьюверов кандидаты с навыками,
+-- которые нужны PR, выбираются чаще
+CREATE TABLE IF NOT EXISTS user_skills (
+    user_id VARCHAR(255) NOT NULL,
+    tag VARCHAR(64) NOT NULL,         -- В нижнем регистре
+    level INTEGER NOT NULL,           -- 1 - начальный, 3 - эксперт
+    PRIMARY KEY (user_id, tag),
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CHECK (level BETWEEN 1 AND 3)
+);
+
+-- Навыки, которые нужны для ревью PR
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS required_skills TEXT[] NOT NULL DEFAULT '{}';
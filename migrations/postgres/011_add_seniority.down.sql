@@ -0,0 +1,3 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS senior_for_juniors;
+ALTER TABLE teams DROP COLUMN IF EXISTS require_senior;
+ALTER TABLE users DROP COLUMN IF EXISTS level;
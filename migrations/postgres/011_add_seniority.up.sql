@@ -0,0 +1,7 @@
+-- Уровень пользователя и требования команды к уровню ревьюверов
+ALTER TABLE users ADD COLUMN IF NOT EXISTS level VARCHAR(16) NOT NULL DEFAULT ''
+    CHECK (level IN ('', 'junior', 'middle', 'senior', 'lead'));
+-- Среди ревьюверов каждого PR участника команды есть senior или lead
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS require_senior BOOLEAN NOT NULL DEFAULT FALSE;
+-- То же только для PR авторов уровня junior
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS senior_for_juniors BOOLEAN NOT NULL DEFAULT FALSE;
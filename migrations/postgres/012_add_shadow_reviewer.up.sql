@@ -0,0 +1,5 @@
+-- Ученики назначаются на PR теневыми ревьюверами сверх обычных
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_learner BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS shadow_reviewer_id VARCHAR(255) NULL
+    REFERENCES users(user_id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_prs_shadow_reviewer_id ON pull_requests(shadow_reviewer_id);
@@ -0,0 +1,13 @@
+-- Правила для пар автор PR и ревьювер: exclude - не назначать ревьювера на PR автора,
+-- prefer - выбирать его чаще
+CREATE TABLE IF NOT EXISTS reviewer_rules (
+    author_id VARCHAR(255) NOT NULL,
+    reviewer_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (author_id, reviewer_id),
+    FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewer_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CHECK (kind IN ('exclude', 'prefer')),
+    CHECK (author_id <> reviewer_id)
+);
@@ -1,5 +1,6 @@
+-- Схема SQLite, эквивалентная migrations/001_init_database.sql
 CREATE TABLE teams (
-    team_id SERIAL PRIMARY KEY,           -- Внутренний ID для эффективности БД
+    team_id INTEGER PRIMARY KEY AUTOINCREMENT, -- Внутренний ID для эффективности БД
     team_name VARCHAR(255) UNIQUE NOT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
@@ -39,4 +40,4 @@ CREATE INDEX idx_users_is_active ON users(is_active);
 CREATE INDEX idx_prs_status ON pull_requests(status);
 CREATE INDEX idx_prs_author_id ON pull_requests(author_id);
 CREATE INDEX idx_pr_reviewers_reviewer_id ON pull_request_reviewers(reviewer_id);
-CREATE INDEX idx_teams_name ON teams(team_name);
\ No newline at end of file
+CREATE INDEX idx_teams_name ON teams(team_name);
@@ -0,0 +1,17 @@
+-- Периоды недоступности пользователей (отпуск, больничный): в это время
+-- пользователь не назначается ревьювером
+CREATE TABLE user_unavailability (
+    unavailability_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id VARCHAR(255) NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    reassign_reviews BOOLEAN NOT NULL DEFAULT FALSE, -- Переназначить открытые ревью в начале периода
+    reassigned_at TIMESTAMP NULL,                    -- Когда ревью были переназначены
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user_id ON user_unavailability(user_id, starts_at);
+CREATE INDEX idx_user_unavailability_period ON user_unavailability(starts_at, ends_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_user_unavailability_external_id;
+ALTER TABLE user_unavailability DROP COLUMN external_id;
+DROP TABLE IF EXISTS user_aliases;
@@ -0,0 +1,17 @@
+-- Псевдонимы пользователей (email, имя в календаре) для сопоставления
+-- событий импортируемого календаря с пользователями
+CREATE TABLE user_aliases (
+    alias VARCHAR(255) PRIMARY KEY, -- В нижнем регистре
+    user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_aliases_user_id ON user_aliases(user_id);
+
+-- UID события календаря, из которого импортирован период: повторный импорт
+-- обновляет период вместо создания нового
+ALTER TABLE user_unavailability ADD COLUMN external_id VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_user_unavailability_external_id
+    ON user_unavailability(user_id, external_id) WHERE external_id <> '';
@@ -0,0 +1,4 @@
+-- Предел одновременно открытых ревью: 0 у пользователя - брать значение
+-- команды, 0 у команды - без ограничения
+ALTER TABLE users ADD COLUMN max_open_reviews INTEGER NOT NULL DEFAULT 0 CHECK (max_open_reviews >= 0);
+ALTER TABLE teams ADD COLUMN max_open_reviews INTEGER NOT NULL DEFAULT 0 CHECK (max_open_reviews >= 0);
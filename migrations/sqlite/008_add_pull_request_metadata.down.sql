@@ -0,0 +1,6 @@
+ALTER TABLE pull_requests DROP COLUMN labels;
+ALTER TABLE pull_requests DROP COLUMN base_branch;
+ALTER TABLE pull_requests DROP COLUMN repository;
+ALTER TABLE pull_requests DROP COLUMN changed_files;
+ALTER TABLE pull_requests DROP COLUMN deletions;
+ALTER TABLE pull_requests DROP COLUMN additions;
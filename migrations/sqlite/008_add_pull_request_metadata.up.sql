@@ -0,0 +1,8 @@
+-- Необязательные сведения о размере PR, передаются при создании
+ALTER TABLE pull_requests ADD COLUMN additions INTEGER NOT NULL DEFAULT 0 CHECK (additions >= 0);
+ALTER TABLE pull_requests ADD COLUMN deletions INTEGER NOT NULL DEFAULT 0 CHECK (deletions >= 0);
+ALTER TABLE pull_requests ADD COLUMN changed_files INTEGER NOT NULL DEFAULT 0 CHECK (changed_files >= 0);
+ALTER TABLE pull_requests ADD COLUMN repository VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN base_branch VARCHAR(255) NOT NULL DEFAULT '';
+-- Метки хранятся JSON-массивом строк
+ALTER TABLE pull_requests ADD COLUMN labels TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests DROP COLUMN paths;
+DROP TABLE code_owner_rules;
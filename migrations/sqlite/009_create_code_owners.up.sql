@@ -0,0 +1,14 @@
+-- Правила владения кодом (CODEOWNERS) по репозиториям, в порядке строк файла.
+-- Владельцы хранятся JSON-массивами строк
+CREATE TABLE code_owner_rules (
+    repository VARCHAR(255) NOT NULL,
+    position INTEGER NOT NULL,        -- Порядок правила, при совпадении побеждает последнее
+    line INTEGER NOT NULL,            -- Номер строки в загруженном файле
+    pattern TEXT NOT NULL,
+    user_owners TEXT NOT NULL DEFAULT '[]',  -- user_id или псевдонимы пользователей
+    team_owners TEXT NOT NULL DEFAULT '[]',  -- Имена команд
+    PRIMARY KEY (repository, position)
+);
+
+-- Пути изменённых файлов PR, по ним выбираются владельцы кода
+ALTER TABLE pull_requests ADD COLUMN paths TEXT NOT NULL DEFAULT '[]';
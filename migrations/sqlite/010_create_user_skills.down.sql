@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests DROP COLUMN required_skills;
+DROP TABLE user_skills;
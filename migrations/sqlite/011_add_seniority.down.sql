@@ -0,0 +1,3 @@
+ALTER TABLE teams DROP COLUMN senior_for_juniors;
+ALTER TABLE teams DROP COLUMN require_senior;
+ALTER TABLE users DROP COLUMN level;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_prs_shadow_reviewer_id;
+ALTER TABLE pull_requests DROP COLUMN shadow_reviewer_id;
+ALTER TABLE users DROP COLUMN is_learner;
@@ -0,0 +1,5 @@
+-- Ученики назначаются на PR теневыми ревьюверами сверх обычных
+ALTER TABLE users ADD COLUMN is_learner BOOLEAN NOT NULL DEFAULT FALSE;
+-- Без REFERENCES: SQLite не удаляет через DROP COLUMN столбец с внешним ключом
+ALTER TABLE pull_requests ADD COLUMN shadow_reviewer_id VARCHAR(255) NULL;
+CREATE INDEX IF NOT EXISTS idx_prs_shadow_reviewer_id ON pull_requests(shadow_reviewer_id);
@@ -4,17 +4,66 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	Server   ServerConfig
+	Storage  StorageConfig
 	Database DatabaseConfig
+	Review   ReviewConfig
+	Calendar CalendarConfig
 }
 
 type ServerConfig struct {
 	Port string
+	// RequestTimeout - дедлайн обработки одного запроса (SLI времени ответа - 300 мс)
+	RequestTimeout time.Duration
+	// BulkRequestTimeout - дедлайн административных и массовых запросов (снапшоты, CSV),
+	// на которые SLI не распространяется
+	BulkRequestTimeout time.Duration
+}
+
+// Поддерживаемые хранилища
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+	StorageSQLite   = "sqlite"
+)
+
+type StorageConfig struct {
+	// Type - тип хранилища: postgres, memory или sqlite
+	Type string
+	// SQLitePath - путь к файлу базы SQLite
+	SQLitePath string
+}
+
+type ReviewConfig struct {
+	// ReassignCheckInterval - период проверки начавшихся отпусков для переназначения ревью, 0 отключает проверку
+	ReassignCheckInterval time.Duration
+	// PreferWorkingHours - чаще выбирать ревьюверов, у которых идёт или скоро начнётся рабочее время
+	PreferWorkingHours bool
+	// BalanceLoad - реже выбирать ревьюверов с большим числом и объёмом открытых ревью
+	BalanceLoad bool
+	// LinesPerReview - сколько изменённых строк открытых PR считаются за одно ревью при BalanceLoad
+	LinesPerReview int
+	// PairHistoryWindow - за какой срок прошлые ревью PR автора снижают шанс снова выбрать того же
+	// ревьювера, 0 отключает фактор
+	PairHistoryWindow time.Duration
+}
+
+type CalendarConfig struct {
+	// Category - категория событий отсутствия в импортируемом календаре
+	Category string
+	// Path - локальный ICS файл, который периодически импортируется, пустой отключает импорт
+	Path string
+	// ImportInterval - период повторного импорта файла Path
+	ImportInterval time.Duration
+	// ReassignReviews - переназначать ревью на время периодов из файла Path
+	ReassignReviews bool
 }
 
 type DatabaseConfig struct {
@@ -24,6 +73,8 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	// AutoMigrate - применять миграции при старте сервиса
+	AutoMigrate bool
 }
 
 func (d DatabaseConfig) GetDSN() string {
@@ -39,7 +90,14 @@ func Load() *Config {
 
 	return &Config{
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "8080"),
+			Port:               getEnv("SERVER_PORT", "8080"),
+			RequestTimeout:     getEnvDuration("SERVER_REQUEST_TIMEOUT", 300*time.Millisecond),
+			BulkRequestTimeout: getEnvDuration("SERVER_BULK_REQUEST_TIMEOUT", 2*time.Minute),
+		},
+
+		Storage: StorageConfig{
+			Type:       getEnv("STORAGE", StoragePostgres),
+			SQLitePath: getEnv("SQLITE_PATH", "./data/pr-service.db"),
 		},
 
 		Database: DatabaseConfig{
@@ -49,6 +107,23 @@ func Load() *Config {
 			Password: getEnv("DB_PASSWORD", "postgres"),
 			Name:     getEnv("DB_NAME", "eventbooker"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			AutoMigrate: getEnvBool("DB_AUTO_MIGRATE", true),
+		},
+
+		Review: ReviewConfig{
+			ReassignCheckInterval: getEnvDuration("REASSIGN_CHECK_INTERVAL", time.Minute),
+			PreferWorkingHours:    getEnvBool("REVIEW_PREFER_WORKING_HOURS", false),
+			BalanceLoad:           getEnvBool("REVIEW_BALANCE_LOAD", false),
+			LinesPerReview:        getEnvInt("REVIEW_LINES_PER_REVIEW", 500),
+			PairHistoryWindow:     getEnvDuration("REVIEW_PAIR_HISTORY_WINDOW", 0),
+		},
+
+		Calendar: CalendarConfig{
+			Category:        getEnv("ICS_CATEGORY", "OOO"),
+			Path:            getEnv("ICS_PATH", ""),
+			ImportInterval:  getEnvDuration("ICS_IMPORT_INTERVAL", 15*time.Minute),
+			ReassignReviews: getEnvBool("ICS_REASSIGN_REVIEWS", false),
 		},
 	}
 }
@@ -60,3 +135,42 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		fmt.Printf("invalid bool in %s=%q, using default %t\n", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		fmt.Printf("invalid int in %s=%q, using default %d\n", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		fmt.Printf("invalid duration in %s=%q, using default %s\n", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
@@ -1,60 +1,33 @@
 #!/bin/bash
 
-BASE_URL="http://localhost:8080"
+# Адрес сервиса берётся из PRCTL_SERVER (по умолчанию http://localhost:8080)
+PRCTL=${PRCTL:-"go run ./cmd/prctl"}
 GREEN='\033[0;32m'
 RED='\033[0;31m'
 NC='\033[0m'
 
-test_endpoint() {
+test_command() {
     local name=$1
-    local method=$2
-    local url=$3
-    local data=$4
-    
+    shift
+
     echo -n "Testing $name... "
-    
-    if [ "$method" = "GET" ]; then
-        response=$(curl -s -w "%{http_code}" "$BASE_URL$url")
-    else
-        response=$(curl -s -w "%{http_code}" -X $method -H "Content-Type: application/json" -d "$data" "$BASE_URL$url")
-    fi
-    
-    http_code=${response: -3}
-    body=${response%???}
-    
-    if [ $http_code -ge 200 ] && [ $http_code -lt 300 ]; then
-        echo -e "${GREEN}✓ ($http_code)${NC}"
+
+    if output=$($PRCTL "$@" 2>&1); then
+        echo -e "${GREEN}✓${NC}"
     else
-        echo -e "${RED}✗ ($http_code)${NC}"
-        echo "Response: $body"
+        echo -e "${RED}✗${NC}"
+        echo "Output: $output"
     fi
 }
 
 echo "🚀 Starting API tests..."
 
-# Test all endpoints
-test_endpoint "Health Check" "GET" "/health" ""
-
-test_endpoint "Create Team" "POST" "/team/add" '{
-    "team_name": "test-team",
-    "members": [
-        {"user_id": "test1", "username": "Test User 1", "is_active": true},
-        {"user_id": "test2", "username": "Test User 2", "is_active": true}
-    ]
-}'
-
-test_endpoint "Get Team" "GET" "/team/get?team_name=test-team" ""
-
-test_endpoint "Create PR" "POST" "/pullRequest/create" '{
-    "pull_request_id": "test-pr-001",
-    "pull_request_name": "Test PR",
-    "author_id": "test1"
-}'
-
-test_endpoint "Get User Reviews" "GET" "/users/getReview?user_id=test2" ""
-
-test_endpoint "Merge PR" "POST" "/pullRequest/merge" '{
-    "pull_request_id": "test-pr-001"
-}'
+test_command "Create Team" team add -f tests/data/test_team.json
+test_command "Get Team" team get test-team
+test_command "Create PR" pr create -id test-pr-001 -name "Test PR" -author test1
+test_command "Get User Reviews" reviews test2
+test_command "Deactivate User" user deactivate test2
+test_command "Activate User" user activate test2
+test_command "Merge PR" pr merge test-pr-001
 
-echo "🎉 All tests completed!"
\ No newline at end of file
+echo "🎉 All tests completed!"